@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// AffineElement is an immutable element for read-mostly points, e.g. public keys that are only multiplied or
+// compared. Its encoding is computed once, so that encoding and comparing it doesn't convert from the internal
+// coordinates each time, which benefits all groups. Only in Pallas is its internal representation also normalized to
+// affine coordinates, so that adding it to an Element uses the cheaper mixed addition of the default backend: the
+// dependencies of the other groups don't expose one, and AddAffine is a regular addition there. Its methods never modify it, so it can be shared
+// across goroutines without synchronization.
+type AffineElement struct {
+	_        disallowEqual
+	element  internal.Element
+	encoding []byte
+}
+
+// Affine returns an AffineElement set to the receiver. The receiver is not modified.
+func (e *Element) Affine() *AffineElement {
+	element := e.Group().get().NewElement().Set(e.Element)
+	if n, ok := element.(internal.Normalizer); ok {
+		n.Normalize()
+	}
+
+	return &AffineElement{
+		element:  element,
+		encoding: element.Encode(),
+	}
+}
+
+// NewAffineElement returns the AffineElement decoded from data, with the same checks as Element.Decode.
+func (g Group) NewAffineElement(data []byte) (*AffineElement, error) {
+	e := g.NewElement()
+	if err := e.Decode(data); err != nil {
+		return nil, err
+	}
+
+	return e.Affine(), nil
+}
+
+// AddAffine sets the receiver to the sum of the receiver and the affine element, and returns the receiver. It uses the
+// mixed addition in Pallas with the default backend, and is the same as Add otherwise.
+func (e *Element) AddAffine(element *AffineElement) *Element {
+	if element == nil {
+		return e
+	}
+
+	e.Element.Add(element.element)
+
+	return debugElement(e, "AddAffine")
+}
+
+// Group returns the group's Identifier.
+func (a *AffineElement) Group() Group {
+	return Group(a.element.Group())
+}
+
+// Element returns a new Element set to the affine element.
+func (a *AffineElement) Element() *Element {
+	return newPoint(a.Group().get().NewElement().Set(a.element))
+}
+
+// Multiply returns a new Element set to the scalar multiplication of the affine element with the given Scalar.
+func (a *AffineElement) Multiply(scalar *Scalar) *Element {
+	return a.Element().Multiply(scalar)
+}
+
+// IsIdentity returns whether the affine element is the point at infinity of the Group's underlying curve.
+func (a *AffineElement) IsIdentity() bool {
+	return a.Group().IsIdentityEncoding(a.encoding)
+}
+
+// Equal returns whether the affine elements are equivalent, in constant time, by comparing their encodings.
+func (a *AffineElement) Equal(element *AffineElement) bool {
+	if element == nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(a.encoding, element.encoding) == 1
+}
+
+// EqualElement returns whether the affine element is equivalent to the element, in constant time.
+func (a *AffineElement) EqualElement(element *Element) bool {
+	if element == nil {
+		return false
+	}
+
+	return element.EqualBytes(a.encoding)
+}
+
+// Encode returns the compressed byte encoding of the affine element.
+func (a *AffineElement) Encode() []byte {
+	return append([]byte(nil), a.encoding...)
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of the affine element.
+func (a *AffineElement) Hex() string {
+	return hex.EncodeToString(a.encoding)
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package benchmarks provides standardized workloads to compare the performance of the groups on the hardware they run
+// on, e.g. to pick a suite empirically, without the go test tooling.
+//
+// Each workload is run for a given number of iterations, after its inputs have been prepared, and returns a Result with
+// the total and per-operation durations. Results depend on the environment, and running many iterations on an idle
+// machine gives more meaningful results.
+package benchmarks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// Workload names.
+	KeyGen       = "KeyGen"
+	BaseMultiply = "BaseMultiply"
+	Multiply     = "Multiply"
+	MSM          = "MSM"
+	HashToGroup  = "HashToGroup"
+	HashToScalar = "HashToScalar"
+
+	// hashInputLength is the byte length of the messages hashed in the hash-to-curve workloads.
+	hashInputLength = 64
+
+	dst = "ECC-Benchmarks-V01-HashToCurve"
+)
+
+// DefaultMSMSizes are the numbers of terms of the multi-scalar multiplications run by All.
+var DefaultMSMSizes = []int{2, 16, 64}
+
+// Result holds the outcome of a workload.
+type Result struct {
+	// Group is the group the workload was run on.
+	Group ecc.Group
+
+	// Workload is the name of the workload.
+	Workload string
+
+	// Size is the number of terms of an MSM workload, and 1 for the others.
+	Size int
+
+	// Iterations is the number of times the operation was run.
+	Iterations int
+
+	// Total is the time taken by all iterations.
+	Total time.Duration
+}
+
+// PerOp returns the average duration of one iteration, or 0 if none was run.
+func (r Result) PerOp() time.Duration {
+	if r.Iterations == 0 {
+		return 0
+	}
+
+	return r.Total / time.Duration(r.Iterations)
+}
+
+// String returns a one-line summary of the result.
+func (r Result) String() string {
+	name := r.Workload
+	if r.Size > 1 {
+		name = fmt.Sprintf("%s/%d", name, r.Size)
+	}
+
+	return fmt.Sprintf("%s %s: %d iterations, %s/op", r.Group, name, r.Iterations, r.PerOp())
+}
+
+// Prepare returns the operation to time for the given iteration. The preparation itself is not timed.
+type Prepare func(iteration int) func()
+
+// Run prepares the operations for the given number of iterations, times them, and returns the result for the named
+// workload with size 1.
+func Run(g ecc.Group, workload string, iterations int, prepare Prepare) Result {
+	res := Result{
+		Group:    g,
+		Workload: workload,
+		Size:     1,
+	}
+
+	if iterations <= 0 {
+		return res
+	}
+
+	ops := make([]func(), iterations)
+	for i := range ops {
+		ops[i] = prepare(i)
+	}
+
+	start := time.Now()
+	for _, op := range ops {
+		op()
+	}
+
+	res.Total = time.Since(start)
+	res.Iterations = iterations
+
+	return res
+}
+
+// RunKeyGen times the generation of key pairs, i.e. drawing a random scalar and multiplying the generator with it.
+func RunKeyGen(g ecc.Group, iterations int) Result {
+	return Run(g, KeyGen, iterations, func(int) func() {
+		return func() {
+			g.Base().Multiply(g.NewScalar().Random())
+		}
+	})
+}
+
+// RunBaseMultiply times multiplications of the generator with random scalars.
+func RunBaseMultiply(g ecc.Group, iterations int) Result {
+	return Run(g, BaseMultiply, iterations, func(int) func() {
+		s := g.NewScalar().Random()
+		e := g.Base()
+
+		return func() {
+			e.Multiply(s)
+		}
+	})
+}
+
+// RunMultiply times multiplications of random elements with random scalars.
+func RunMultiply(g ecc.Group, iterations int) Result {
+	return Run(g, Multiply, iterations, func(int) func() {
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		return func() {
+			e.Multiply(s)
+		}
+	})
+}
+
+// RunMSM times multi-scalar multiplications of size random elements and scalars with Group.MultiScalarMultVartime.
+// Compare it with size times the Multiply workload to see the gain over individual multiplications.
+func RunMSM(g ecc.Group, size, iterations int) Result {
+	res := Run(g, MSM, iterations, func(int) func() {
+		scalars := make([]*ecc.Scalar, size)
+		elements := make([]*ecc.Element, size)
+
+		for i := range size {
+			scalars[i] = g.NewScalar().Random()
+			elements[i] = g.Base().Multiply(g.NewScalar().Random())
+		}
+
+		return func() {
+			_ = g.MultiScalarMultVartime(scalars, elements)
+		}
+	})
+	res.Size = size
+
+	return res
+}
+
+// RunHashToGroup times hashing random messages to the group.
+func RunHashToGroup(g ecc.Group, iterations int) Result {
+	return Run(g, HashToGroup, iterations, func(int) func() {
+		msg := internal.RandomBytes(hashInputLength)
+
+		return func() {
+			g.HashToGroup(msg, []byte(dst))
+		}
+	})
+}
+
+// RunHashToScalar times hashing random messages to scalars.
+func RunHashToScalar(g ecc.Group, iterations int) Result {
+	return Run(g, HashToScalar, iterations, func(int) func() {
+		msg := internal.RandomBytes(hashInputLength)
+
+		return func() {
+			g.HashToScalar(msg, []byte(dst))
+		}
+	})
+}
+
+// All runs all workloads in the group with the given number of iterations, with an MSM workload for each of the sizes,
+// or DefaultMSMSizes if none are given.
+func All(g ecc.Group, iterations int, msmSizes ...int) []Result {
+	if len(msmSizes) == 0 {
+		msmSizes = DefaultMSMSizes
+	}
+
+	results := []Result{
+		RunKeyGen(g, iterations),
+		RunBaseMultiply(g, iterations),
+		RunMultiply(g, iterations),
+	}
+
+	for _, size := range msmSizes {
+		results = append(results, RunMSM(g, size, iterations))
+	}
+
+	return append(results,
+		RunHashToGroup(g, iterations),
+		RunHashToScalar(g, iterations),
+	)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package bitcoin provides Bitcoin-specific helpers over the Secp256k1 group, like BIP340 x-only keys and BIP341
+// Taproot key tweaking.
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	// XOnlyLength is the byte size of BIP340 x-only public keys.
+	XOnlyLength = 32
+
+	tapTweakTag = "TapTweak"
+)
+
+var (
+	errNotSecp256k1 = errors.New("input is not in the Secp256k1 group")
+	errXOnlyLength  = errors.New("invalid x-only public key length")
+	errInvalidTweak = errors.New("tweak is not lower than the group order")
+	errNilInput     = errors.New("nil input")
+	errZeroKey      = errors.New("private key is zero")
+	errInfinity     = errors.New("tweaked key is the point at infinity")
+)
+
+// TaggedHash returns the BIP340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || msg...).
+func TaggedHash(tag string, msg ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+
+	for _, m := range msg {
+		h.Write(m)
+	}
+
+	return h.Sum(nil)
+}
+
+// HasEvenY returns whether the element's y coordinate is even.
+func HasEvenY(e *ecc.Element) bool {
+	return e.Encode()[0] == 0x02
+}
+
+// XOnly returns the 32-byte BIP340 x-only encoding of the element.
+func XOnly(e *ecc.Element) []byte {
+	return e.XCoordinate()
+}
+
+// LiftX returns the element with the given x-only encoding and an even y coordinate.
+func LiftX(x []byte) (*ecc.Element, error) {
+	if len(x) != XOnlyLength {
+		return nil, errXOnlyLength
+	}
+
+	e := ecc.Secp256k1Sha256.NewElement()
+	if err := e.DecodeFromX(x, false); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func tapTweak(xOnly, merkleRoot []byte) (*ecc.Scalar, error) {
+	t := ecc.Secp256k1Sha256.NewScalar()
+	if err := t.Decode(TaggedHash(tapTweakTag, xOnly, merkleRoot)); err != nil {
+		return nil, errInvalidTweak
+	}
+
+	return t, nil
+}
+
+// TaprootTweakPubKey returns the BIP341 output key for the internal x-only public key and the script tree's merkle
+// root, which may be empty for key-path only outputs. It returns the x-only output key and whether its y coordinate
+// is odd, which is needed in the control block of script-path spends.
+func TaprootTweakPubKey(internalKey, merkleRoot []byte) (outputKey []byte, oddY bool, err error) {
+	p, err := LiftX(internalKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	t, err := tapTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, false, err
+	}
+
+	q := ecc.Secp256k1Sha256.Base().Multiply(t).Add(p)
+	if q.IsIdentity() {
+		return nil, false, errInfinity
+	}
+
+	return XOnly(q), !HasEvenY(q), nil
+}
+
+// TaprootTweakPrivKey returns the BIP341 tweaked private key for key-path spending of the output committing to the
+// merkle root, which may be empty for key-path only outputs. The input is not modified.
+func TaprootTweakPrivKey(sk *ecc.Scalar, merkleRoot []byte) (*ecc.Scalar, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.Group() != ecc.Secp256k1Sha256 {
+		return nil, errNotSecp256k1
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	p := ecc.Secp256k1Sha256.Base().Multiply(sk)
+
+	// The SEC 1 header 0x03 of an odd y-coordinate has its low bit set.
+	d := sk.Copy().ConditionalNegate(int(p.Encode()[0] & 1))
+
+	t, err := tapTweak(XOnly(p), merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Add(t)
+	if d.IsZero() {
+		return nil, errZeroKey
+	}
+
+	return d, nil
+}
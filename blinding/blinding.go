@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package blinding implements multiplicative key blinding, as used by Tor v3 onion services, with proofs that a
+// blinded public key derives from an original one. This allows rotating identities without running a new key
+// generation.
+package blinding
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	factorDST = "ECC-Blinding-V01-Factor"
+	proofDST  = "ECC-Blinding-V01-Proof"
+)
+
+var (
+	errNilInput      = errors.New("nil input")
+	errZeroTweak     = errors.New("the blinding tweak is zero")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errProofLength   = errors.New("invalid proof encoding length")
+)
+
+// DeriveFactor deterministically derives a blinding factor from the public key and the context (e.g. a time period
+// or a service name), so that both the key owner and third parties can compute the same blinded public key.
+func DeriveFactor(pk *ecc.Element, context []byte) *ecc.Scalar {
+	g := pk.Group()
+	input := append(pk.Encode(), context...)
+
+	return g.HashToScalar(input, []byte(factorDST))
+}
+
+func checkTweak(g ecc.Group, tweak *ecc.Scalar) error {
+	if tweak == nil {
+		return errNilInput
+	}
+
+	if tweak.Group() != g {
+		return errGroupMismatch
+	}
+
+	if tweak.IsZero() {
+		return errZeroTweak
+	}
+
+	return nil
+}
+
+// BlindScalar returns the blinded secret key sk * tweak. The inputs are not modified.
+func BlindScalar(sk, tweak *ecc.Scalar) (*ecc.Scalar, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if err := checkTweak(sk.Group(), tweak); err != nil {
+		return nil, err
+	}
+
+	return sk.Copy().Multiply(tweak), nil
+}
+
+// BlindElement returns the blinded public key tweak * pk. The inputs are not modified.
+func BlindElement(pk *ecc.Element, tweak *ecc.Scalar) (*ecc.Element, error) {
+	if pk == nil {
+		return nil, errNilInput
+	}
+
+	if err := checkTweak(pk.Group(), tweak); err != nil {
+		return nil, err
+	}
+
+	return pk.Copy().Multiply(tweak), nil
+}
+
+// UnblindScalar reverts BlindScalar, and returns the original secret key blinded / tweak.
+func UnblindScalar(blinded, tweak *ecc.Scalar) (*ecc.Scalar, error) {
+	if blinded == nil {
+		return nil, errNilInput
+	}
+
+	if err := checkTweak(blinded.Group(), tweak); err != nil {
+		return nil, err
+	}
+
+	return blinded.Copy().Multiply(tweak.Copy().Invert()), nil
+}
+
+// Proof is a non-interactive Schnorr proof of knowledge of the tweak t such that blinded = t * pk.
+type Proof struct {
+	Challenge *ecc.Scalar
+	Response  *ecc.Scalar
+}
+
+func challenge(pk, blinded, commitment *ecc.Element) *ecc.Scalar {
+	g := pk.Group()
+	input := make([]byte, 0, 3*g.ElementLength())
+	input = append(input, pk.Encode()...)
+	input = append(input, blinded.Encode()...)
+	input = append(input, commitment.Encode()...)
+
+	return g.HashToScalar(input, []byte(proofDST))
+}
+
+// Prove returns a proof that blinded = tweak * pk, without revealing the tweak.
+func Prove(pk, blinded *ecc.Element, tweak *ecc.Scalar) (*Proof, error) {
+	if pk == nil || blinded == nil {
+		return nil, errNilInput
+	}
+
+	g := pk.Group()
+	if blinded.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	if err := checkTweak(g, tweak); err != nil {
+		return nil, err
+	}
+
+	r := g.NewScalar().Random()
+	c := challenge(pk, blinded, pk.Copy().Multiply(r))
+
+	return &Proof{
+		Challenge: c,
+		Response:  c.Copy().Multiply(tweak).Add(r),
+	}, nil
+}
+
+// Verify returns whether the proof attests that blinded was derived from pk.
+func (p *Proof) Verify(pk, blinded *ecc.Element) bool {
+	if p == nil || p.Challenge == nil || p.Response == nil || pk == nil || blinded == nil {
+		return false
+	}
+
+	g := pk.Group()
+	if blinded.Group() != g || p.Challenge.Group() != g || p.Response.Group() != g {
+		return false
+	}
+
+	if pk.IsIdentity() || blinded.IsIdentity() {
+		return false
+	}
+
+	// R = s * pk - c * blinded
+	commitment := pk.Copy().Multiply(p.Response).Subtract(blinded.Copy().Multiply(p.Challenge))
+
+	return challenge(pk, blinded, commitment).Equal(p.Challenge)
+}
+
+// Encode returns the byte encoding of the proof, i.e. the concatenation of the challenge and the response.
+func (p *Proof) Encode() []byte {
+	return append(p.Challenge.Encode(), p.Response.Encode()...)
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *Proof) Decode(g ecc.Group, data []byte) error {
+	sLen := g.ScalarLength()
+	if len(data) != 2*sLen {
+		return errProofLength
+	}
+
+	c := g.NewScalar()
+	if err := c.Decode(data[:sLen]); err != nil {
+		return err
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(data[sLen:]); err != nil {
+		return err
+	}
+
+	p.Challenge, p.Response = c, s
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package bulletproofs implements the inner-product argument of Bulletproofs, and the range proofs built upon it,
+// which prove that Pedersen commitments hold values of 8, 16, 32, or 64 bits, with logarithmic size and aggregation of
+// several commitments into a single proof.
+package bulletproofs
+
+import (
+	"github.com/bytemare/ecc"
+)
+
+// Generators holds the generators of the value commitments, B and BlindingBase, the base U of the inner products, and
+// the vector generators G and H, which bound the number of bits that can be proven at once.
+type Generators struct {
+	B            *ecc.Element
+	BlindingBase *ecc.Element
+	U            *ecc.Element
+	G            []*ecc.Element
+	H            []*ecc.Element
+	Group        ecc.Group
+}
+
+// Setup returns generators for proofs over up to capacity bits in total, e.g. 4 * 64 to aggregate four 64-bit range
+// proofs. They are derived from the DST with Group.DeriveGenerators, such that the value commitments are those of
+// pedersen.Setup(g, 1, dst), and generators for different capacities with the same DST share their prefix.
+func Setup(g ecc.Group, capacity int, dst []byte) *Generators {
+	generators := g.DeriveGenerators(3+2*capacity, dst)
+	gens := &Generators{
+		BlindingBase: generators[0],
+		B:            generators[1],
+		U:            generators[2],
+		G:            make([]*ecc.Element, capacity),
+		H:            make([]*ecc.Element, capacity),
+		Group:        g,
+	}
+
+	for i := range capacity {
+		gens.G[i] = generators[3+2*i]
+		gens.H[i] = generators[4+2*i]
+	}
+
+	return gens
+}
+
+// Commit returns the commitment value * B + blind * BlindingBase. It runs in constant time.
+func (gens *Generators) Commit(value uint64, blind *ecc.Scalar) *ecc.Element {
+	v := gens.Group.NewScalar().SetUInt64(value)
+
+	return gens.B.Copy().Multiply(v).Add(gens.BlindingBase.Copy().Multiply(blind))
+}
+
+// powers returns the n first powers of x, starting at 1.
+func powers(x *ecc.Scalar, n int) []*ecc.Scalar {
+	p := make([]*ecc.Scalar, n)
+	if n == 0 {
+		return p
+	}
+
+	p[0] = x.Group().NewScalar().One()
+	for i := 1; i < n; i++ {
+		p[i] = p[i-1].Copy().Multiply(x)
+	}
+
+	return p
+}
+
+// innerProduct returns the sum of a[i] * b[i].
+func innerProduct(a, b []*ecc.Scalar) *ecc.Scalar {
+	s := a[0].Group().NewScalar()
+	for i := range a {
+		s.MulAdd(a[i], b[i], s)
+	}
+
+	return s
+}
+
+// commit returns the sum of scalars[i] * elements[i] in constant time, for secret scalars.
+func commit(g ecc.Group, scalars []*ecc.Scalar, elements []*ecc.Element) *ecc.Element {
+	c := g.NewElement()
+	for i, s := range scalars {
+		c.Add(elements[i].Copy().Multiply(s))
+	}
+
+	return c
+}
+
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package bulletproofs
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/transcript"
+)
+
+var (
+	errNilInput       = errors.New("nil input")
+	errGroupMismatch  = errors.New("inputs belong to different groups")
+	errVectorLength   = errors.New("vectors must have the same power of two length")
+	errProofLength    = errors.New("invalid proof encoding length")
+	errZeroChallenge  = errors.New("zero challenge")
+	errProofStructure = errors.New("invalid proof structure")
+)
+
+// InnerProductProof proves knowledge of vectors a and b such that P = <a, G> + <b, H> + <a, b> * Q, in log2(n)
+// rounds of commitments L and R, where n is the length of the vectors.
+type InnerProductProof struct {
+	A *ecc.Scalar
+	B *ecc.Scalar
+	L []*ecc.Element
+	R []*ecc.Element
+}
+
+// fold returns lo * x + hi * y for public x and y.
+func fold(g ecc.Group, lo, hi []*ecc.Element, x, y *ecc.Scalar) []*ecc.Element {
+	out := make([]*ecc.Element, len(lo))
+	for i := range lo {
+		out[i] = g.DoubleScalarMultVartime(x, lo[i], y, hi[i])
+	}
+
+	return out
+}
+
+// ProveInnerProduct returns a proof of knowledge of a and b for the commitment P = <a, G> + <b, H> + <a, b> * Q,
+// with challenges derived from the transcript, which must already bind the commitment. The vectors must have the same
+// power of two length. Secret vectors are committed to in constant time.
+func ProveInnerProduct(
+	t *transcript.Transcript,
+	g, h []*ecc.Element,
+	q *ecc.Element,
+	a, b []*ecc.Scalar,
+) (*InnerProductProof, error) {
+	n := len(a)
+	if !isPowerOfTwo(n) || len(b) != n || len(g) != n || len(h) != n {
+		return nil, errVectorLength
+	}
+
+	if t == nil || q == nil {
+		return nil, errNilInput
+	}
+
+	group := t.Group()
+	if q.Group() != group {
+		return nil, errGroupMismatch
+	}
+
+	a, b = append([]*ecc.Scalar(nil), a...), append([]*ecc.Scalar(nil), b...)
+	proof := &InnerProductProof{}
+
+	for n > 1 {
+		n /= 2
+		aLo, aHi, bLo, bHi := a[:n], a[n:], b[:n], b[n:]
+		gLo, gHi, hLo, hHi := g[:n], g[n:], h[:n], h[n:]
+
+		l := commit(group, aLo, gHi).Add(commit(group, bHi, hLo)).Add(q.Copy().Multiply(innerProduct(aLo, bHi)))
+		r := commit(group, aHi, gLo).Add(commit(group, bLo, hHi)).Add(q.Copy().Multiply(innerProduct(aHi, bLo)))
+		proof.L = append(proof.L, l)
+		proof.R = append(proof.R, r)
+
+		t.AppendElement("L", l)
+		t.AppendElement("R", r)
+
+		u, err := challenge(t, "u")
+		if err != nil {
+			return nil, err
+		}
+
+		uInv := u.Copy().Invert()
+
+		for i := range n {
+			aLo[i] = aLo[i].Copy().Multiply(u).Add(aHi[i].Copy().Multiply(uInv))
+			bLo[i] = bLo[i].Copy().Multiply(uInv).Add(bHi[i].Copy().Multiply(u))
+		}
+
+		a, b = aLo, bLo
+		g, h = fold(group, gLo, gHi, uInv, u), fold(group, hLo, hHi, u, uInv)
+	}
+
+	proof.A, proof.B = a[0].Copy(), b[0].Copy()
+
+	return proof, nil
+}
+
+// challenge returns the transcript's challenge for the label, and errZeroChallenge if it is zero, which prover and
+// verifier both reject since a zero challenge can't be inverted and would cancel the terms it weighs.
+func challenge(t *transcript.Transcript, label string) (*ecc.Scalar, error) {
+	c := t.ChallengeScalar(label)
+	if c.IsZero() {
+		return nil, errZeroChallenge
+	}
+
+	return c, nil
+}
+
+// verificationScalars replays the rounds of the proof on the transcript, and returns the squares of the challenges
+// and of their inverses, and the coefficients s of the folded generators, i.e. G' = <s, G> and H' = <1/s, H>, where
+// 1/s[i] = s[n-1-i].
+func (p *InnerProductProof) verificationScalars(
+	t *transcript.Transcript,
+	n int,
+) (u2, uInv2, s []*ecc.Scalar, err error) {
+	g := t.Group()
+	if !isPowerOfTwo(n) || p.A == nil || p.B == nil || p.A.Group() != g || p.B.Group() != g ||
+		len(p.L) != len(p.R) || 1<<len(p.L) != n {
+		return nil, nil, nil, errProofStructure
+	}
+
+	rounds := len(p.L)
+	u := make([]*ecc.Scalar, rounds)
+	u2, uInv2 = make([]*ecc.Scalar, rounds), make([]*ecc.Scalar, rounds)
+	s0 := g.NewScalar().One()
+
+	for k := range rounds {
+		if p.L[k] == nil || p.R[k] == nil || p.L[k].Group() != g || p.R[k].Group() != g {
+			return nil, nil, nil, errProofStructure
+		}
+
+		t.AppendElement("L", p.L[k])
+		t.AppendElement("R", p.R[k])
+
+		var err error
+		if u[k], err = challenge(t, "u"); err != nil {
+			return nil, nil, nil, err
+		}
+
+		uInv := u[k].Copy().Invert()
+		s0.Multiply(uInv)
+		u2[k], uInv2[k] = u[k].Copy().Square(), uInv.Square()
+	}
+
+	// The challenge of round k multiplies the generators whose index has its bit rounds-1-k set, and its inverse the
+	// others. Each index thus derives from the one without its highest bit.
+	s = make([]*ecc.Scalar, n)
+	s[0] = s0
+
+	for i := 1; i < n; i++ {
+		high := bitLength(i) - 1
+		s[i] = s[i-(1<<high)].Copy().Multiply(u2[rounds-1-high])
+	}
+
+	return u2, uInv2, s, nil
+}
+
+func bitLength(i int) int {
+	n := 0
+	for ; i > 0; i >>= 1 {
+		n++
+	}
+
+	return n
+}
+
+// Verify returns whether the proof is valid for the commitment P, with challenges derived from the transcript, which
+// must be in the same state as the prover's. It runs in variable time.
+func (p *InnerProductProof) Verify(t *transcript.Transcript, g, h []*ecc.Element, q, commitment *ecc.Element) bool {
+	if p == nil || t == nil || q == nil || commitment == nil || len(g) != len(h) {
+		return false
+	}
+
+	u2, uInv2, s, err := p.verificationScalars(t, len(g))
+	if err != nil {
+		return false
+	}
+
+	n := len(g)
+	group := t.Group()
+	scalars := make([]*ecc.Scalar, 0, 2*n+2*len(u2)+2)
+	elements := make([]*ecc.Element, 0, cap(scalars))
+
+	for i := range n {
+		scalars = append(scalars,
+			group.NewScalar().Subtract(p.A.Copy().Multiply(s[i])),
+			group.NewScalar().Subtract(p.B.Copy().Multiply(s[n-1-i])))
+		elements = append(elements, g[i], h[i])
+	}
+
+	scalars = append(append(scalars, u2...), uInv2...)
+	elements = append(append(elements, p.L...), p.R...)
+	scalars = append(scalars, group.NewScalar().Subtract(p.A.Copy().Multiply(p.B)), group.NewScalar().One())
+	elements = append(elements, q, commitment)
+
+	return group.MultiScalarMultVartime(scalars, elements).IsIdentity()
+}
+
+// Encode returns the byte encoding of the proof: a, b, and the L and R commitments of each round.
+func (p *InnerProductProof) Encode() []byte {
+	out := append(p.A.Encode(), p.B.Encode()...)
+	for i := range p.L {
+		out = append(out, p.L[i].Encode()...)
+		out = append(out, p.R[i].Encode()...)
+	}
+
+	return out
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *InnerProductProof) Decode(g ecc.Group, data []byte) error {
+	sLen, eLen := g.ScalarLength(), g.ElementLength()
+	if len(data) < 2*sLen || (len(data)-2*sLen)%(2*eLen) != 0 {
+		return errProofLength
+	}
+
+	a, b := g.NewScalar(), g.NewScalar()
+	if err := a.DecodeCanonical(data[:sLen]); err != nil {
+		return err
+	}
+
+	if err := b.DecodeCanonical(data[sLen : 2*sLen]); err != nil {
+		return err
+	}
+
+	rounds := (len(data) - 2*sLen) / (2 * eLen)
+	l, r := make([]*ecc.Element, rounds), make([]*ecc.Element, rounds)
+
+	for i, offset := 0, 2*sLen; i < rounds; i, offset = i+1, offset+2*eLen {
+		l[i], r[i] = g.NewElement(), g.NewElement()
+		if err := l[i].Decode(data[offset : offset+eLen]); err != nil {
+			return err
+		}
+
+		if err := r[i].Decode(data[offset+eLen : offset+2*eLen]); err != nil {
+			return err
+		}
+	}
+
+	p.A, p.B, p.L, p.R = a, b, l, r
+
+	return nil
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package bulletproofs
+
+import (
+	"errors"
+	"math"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/transcript"
+)
+
+const protocol = "Bulletproofs-RangeProof-V01"
+
+var (
+	errBits         = errors.New("the number of bits must be 8, 16, 32, or 64")
+	errValueCount   = errors.New("the number of values must be a power of two")
+	errCapacity     = errors.New("not enough generators for the number of values and bits")
+	errValueRange   = errors.New("a value doesn't fit in the number of bits")
+	errBlindsLength = errors.New("the number of values and blinding factors differ")
+)
+
+// RangeProof proves that each of a power of two number of commitments holds a value in [0, 2^bits).
+type RangeProof struct {
+	A    *ecc.Element
+	S    *ecc.Element
+	T1   *ecc.Element
+	T2   *ecc.Element
+	TauX *ecc.Scalar
+	Mu   *ecc.Scalar
+	THat *ecc.Scalar
+	IPA  *InnerProductProof
+}
+
+func checkParameters(gens *Generators, m, bits int) error {
+	if gens == nil {
+		return errNilInput
+	}
+
+	if bits != 8 && bits != 16 && bits != 32 && bits != 64 {
+		return errBits
+	}
+
+	if !isPowerOfTwo(m) {
+		return errValueCount
+	}
+
+	if m*bits > len(gens.G) || m*bits > len(gens.H) {
+		return errCapacity
+	}
+
+	return nil
+}
+
+func newTranscript(g ecc.Group, bits int, commitments []*ecc.Element, context []byte) *transcript.Transcript {
+	t := transcript.New(g, protocol)
+	t.AppendMessage("context", context)
+	t.AppendMessage("bits", []byte{byte(bits)})
+
+	for _, v := range commitments {
+		t.AppendElement("V", v)
+	}
+
+	return t
+}
+
+// challengePair returns the y and z challenges, which must both be non-zero.
+func challengePair(t *transcript.Transcript) (y, z *ecc.Scalar, err error) {
+	if y, err = challenge(t, "y"); err != nil {
+		return nil, nil, err
+	}
+
+	if z, err = challenge(t, "z"); err != nil {
+		return nil, nil, err
+	}
+
+	return y, z, nil
+}
+
+func randomScalars(g ecc.Group, n int) []*ecc.Scalar {
+	s := make([]*ecc.Scalar, n)
+	for i := range s {
+		s[i] = g.NewScalar().Random()
+	}
+
+	return s
+}
+
+// Prove returns an aggregated proof that each value is in [0, 2^bits), and the commitments to the values with their
+// blinding factors. The number of values must be a power of two, and fit in the generators with the number of bits.
+// Secret inputs are only used in constant-time operations.
+func Prove(
+	gens *Generators,
+	values []uint64,
+	blinds []*ecc.Scalar,
+	bits int,
+	context []byte,
+) (*RangeProof, []*ecc.Element, error) {
+	m := len(values)
+	if err := checkParameters(gens, m, bits); err != nil {
+		return nil, nil, err
+	}
+
+	if len(blinds) != m {
+		return nil, nil, errBlindsLength
+	}
+
+	g := gens.Group
+	commitments := make([]*ecc.Element, m)
+
+	for j, v := range values {
+		switch {
+		case blinds[j] == nil:
+			return nil, nil, errNilInput
+		case blinds[j].Group() != g:
+			return nil, nil, errGroupMismatch
+		case bits < 64 && v>>bits != 0:
+			return nil, nil, errValueRange
+		}
+
+		commitments[j] = gens.Commit(v, blinds[j])
+	}
+
+	t := newTranscript(g, bits, commitments, context)
+	n := m * bits
+	one := g.NewScalar().One()
+	aL, aR := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+
+	for j, v := range values {
+		for i := range bits {
+			aL[j*bits+i] = g.NewScalar().SetUInt64((v >> i) & 1)
+			aR[j*bits+i] = aL[j*bits+i].Copy().Subtract(one)
+		}
+	}
+
+	G, H := gens.G[:n], gens.H[:n]
+	alpha, rho := g.NewScalar().Random(), g.NewScalar().Random()
+	sL, sR := randomScalars(g, n), randomScalars(g, n)
+	proof := &RangeProof{
+		A: commit(g, aL, G).Add(commit(g, aR, H)).Add(gens.BlindingBase.Copy().Multiply(alpha)),
+		S: commit(g, sL, G).Add(commit(g, sR, H)).Add(gens.BlindingBase.Copy().Multiply(rho)),
+	}
+
+	t.AppendElement("A", proof.A)
+	t.AppendElement("S", proof.S)
+
+	y, z, err := challengePair(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// l(X) = l0 + sL * X and r(X) = r0 + r1 * X, such that t(X) = <l(X), r(X)> = t0 + t1 * X + t2 * X^2.
+	yN, twoN, zPow := powers(y, n), powers(g.NewScalar().SetUInt64(2), bits), powers(z, m+2)
+	l0, r0, r1 := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+
+	for k := range n {
+		l0[k] = aL[k].Copy().Subtract(z)
+		r0[k] = aR[k].Copy().Add(z).Multiply(yN[k]).Add(zPow[2+k/bits].Copy().Multiply(twoN[k%bits]))
+		r1[k] = sR[k].Copy().Multiply(yN[k])
+	}
+
+	t1 := innerProduct(l0, r1).Add(innerProduct(sL, r0))
+	t2 := innerProduct(sL, r1)
+	tau1, tau2 := g.NewScalar().Random(), g.NewScalar().Random()
+	proof.T1 = commit(g, []*ecc.Scalar{t1, tau1}, []*ecc.Element{gens.B, gens.BlindingBase})
+	proof.T2 = commit(g, []*ecc.Scalar{t2, tau2}, []*ecc.Element{gens.B, gens.BlindingBase})
+
+	t.AppendElement("T1", proof.T1)
+	t.AppendElement("T2", proof.T2)
+
+	x, err := challenge(t, "x")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof.TauX = tau2.Multiply(x).Add(tau1).Multiply(x)
+	proof.Mu = rho.Multiply(x).Add(alpha)
+
+	for j, blind := range blinds {
+		proof.TauX.MulAdd(zPow[2+j], blind, proof.TauX)
+	}
+
+	l, r := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+	for k := range n {
+		l[k] = sL[k].Copy().Multiply(x).Add(l0[k])
+		r[k] = r1[k].Copy().Multiply(x).Add(r0[k])
+	}
+
+	proof.THat = innerProduct(l, r)
+
+	t.AppendScalar("tau_x", proof.TauX)
+	t.AppendScalar("mu", proof.Mu)
+	t.AppendScalar("t_hat", proof.THat)
+
+	// The inner-product argument runs over H' = y^-k * H, such that <r, H'> is a commitment to the unscaled vectors.
+	w, err := challenge(t, "w")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := gens.U.Copy().Multiply(w)
+	yInvN := powers(y.Copy().Invert(), n)
+	hPrime := make([]*ecc.Element, n)
+
+	for k := range n {
+		hPrime[k] = g.DoubleScalarMultVartime(yInvN[k], H[k], nil, nil)
+	}
+
+	if proof.IPA, err = ProveInnerProduct(t, G, hPrime, q, l, r); err != nil {
+		return nil, nil, err
+	}
+
+	return proof, commitments, nil
+}
+
+func (p *RangeProof) isValid(g ecc.Group) bool {
+	if p == nil || p.IPA == nil {
+		return false
+	}
+
+	for _, e := range []*ecc.Element{p.A, p.S, p.T1, p.T2} {
+		if e == nil || e.Group() != g {
+			return false
+		}
+	}
+
+	for _, s := range []*ecc.Scalar{p.TauX, p.Mu, p.THat} {
+		if s == nil || s.Group() != g {
+			return false
+		}
+	}
+
+	return true
+}
+
+// delta returns (z - z^2) * <1, y^n> - sum over j of z^(3+j) * <1, 2^bits>.
+func delta(y, z *ecc.Scalar, zPow []*ecc.Scalar, n, bits int) *ecc.Scalar {
+	g := y.Group()
+	sumY := g.NewScalar()
+
+	for _, yk := range powers(y, n) {
+		sumY.Add(yk)
+	}
+
+	sumTwo := g.NewScalar().SetUInt64(math.MaxUint64 >> (64 - bits))
+	d := z.Copy().Subtract(zPow[2]).Multiply(sumY)
+
+	for j := range n / bits {
+		d.Subtract(zPow[3+j].Copy().Multiply(sumTwo))
+	}
+
+	return d
+}
+
+// Verify returns whether the proof attests that each commitment holds a value in [0, 2^bits). Both the polynomial
+// and the inner-product checks are batched into a single multi-scalar multiplication, with a random weight. It runs
+// in variable time.
+func (p *RangeProof) Verify(gens *Generators, commitments []*ecc.Element, bits int, context []byte) bool {
+	m := len(commitments)
+	if checkParameters(gens, m, bits) != nil || !p.isValid(gens.Group) {
+		return false
+	}
+
+	g := gens.Group
+	for _, v := range commitments {
+		if v == nil || v.Group() != g {
+			return false
+		}
+	}
+
+	n := m * bits
+	t := newTranscript(g, bits, commitments, context)
+	t.AppendElement("A", p.A)
+	t.AppendElement("S", p.S)
+
+	y, z, err := challengePair(t)
+	if err != nil {
+		return false
+	}
+
+	t.AppendElement("T1", p.T1)
+	t.AppendElement("T2", p.T2)
+
+	x, err := challenge(t, "x")
+	if err != nil {
+		return false
+	}
+
+	t.AppendScalar("tau_x", p.TauX)
+	t.AppendScalar("mu", p.Mu)
+	t.AppendScalar("t_hat", p.THat)
+
+	w, err := challenge(t, "w")
+	if err != nil {
+		return false
+	}
+
+	u2, uInv2, s, err := p.IPA.verificationScalars(t, n)
+	if err != nil {
+		return false
+	}
+
+	zPow := powers(z, m+3)
+	yInvN := powers(y.Copy().Invert(), n)
+	twoN := powers(g.NewScalar().SetUInt64(2), bits)
+	ab := p.IPA.A.Copy().Multiply(p.IPA.B)
+	weight := g.NewScalar().Random()
+
+	scalars := make([]*ecc.Scalar, 0, 2*n+2*len(u2)+m+7)
+	elements := make([]*ecc.Element, 0, cap(scalars))
+
+	// The inner-product check: A + x * S - mu * BlindingBase - <z, G> + <z + y^-k * z^(2+j) * 2^i, H> + tHat * Q
+	// must open to a and b.
+	for k := range n {
+		gk := g.NewScalar().Subtract(z).Subtract(p.IPA.A.Copy().Multiply(s[k]))
+		hk := zPow[2+k/bits].Copy().Multiply(twoN[k%bits]).Subtract(p.IPA.B.Copy().Multiply(s[n-1-k]))
+		scalars = append(scalars, gk, hk.Multiply(yInvN[k]).Add(z))
+		elements = append(elements, gens.G[k], gens.H[k])
+	}
+
+	scalars = append(append(scalars, u2...), uInv2...)
+	elements = append(append(elements, p.IPA.L...), p.IPA.R...)
+	scalars = append(scalars, g.NewScalar().One(), x, p.THat.Copy().Subtract(ab).Multiply(w))
+	elements = append(elements, p.A, p.S, gens.U)
+
+	// The polynomial check, weighted: tHat * B + tauX * BlindingBase = sum of z^(2+j) * V[j] + delta * B + x * T1 +
+	// x^2 * T2.
+	for j, v := range commitments {
+		scalars = append(scalars, g.NewScalar().Subtract(zPow[2+j].Copy().Multiply(weight)))
+		elements = append(elements, v)
+	}
+
+	scalars = append(scalars,
+		weight.Copy().Multiply(p.TauX).Subtract(p.Mu),
+		p.THat.Copy().Subtract(delta(y, z, zPow, n, bits)).Multiply(weight),
+		g.NewScalar().Subtract(weight.Copy().Multiply(x)),
+		g.NewScalar().Subtract(weight.Copy().Multiply(x).Multiply(x)),
+	)
+	elements = append(elements, gens.BlindingBase, gens.B, p.T1, p.T2)
+
+	return g.MultiScalarMultVartime(scalars, elements).IsIdentity()
+}
+
+// Encode returns the byte encoding of the proof: A, S, T1, T2, tauX, mu, tHat, and the inner-product proof.
+func (p *RangeProof) Encode() []byte {
+	var out []byte
+	for _, e := range []*ecc.Element{p.A, p.S, p.T1, p.T2} {
+		out = append(out, e.Encode()...)
+	}
+
+	for _, s := range []*ecc.Scalar{p.TauX, p.Mu, p.THat} {
+		out = append(out, s.Encode()...)
+	}
+
+	return append(out, p.IPA.Encode()...)
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *RangeProof) Decode(g ecc.Group, data []byte) error {
+	sLen, eLen := g.ScalarLength(), g.ElementLength()
+	if len(data) < 4*eLen+3*sLen {
+		return errProofLength
+	}
+
+	elements := make([]*ecc.Element, 4)
+	for i := range elements {
+		elements[i] = g.NewElement()
+		if err := elements[i].Decode(data[i*eLen : (i+1)*eLen]); err != nil {
+			return err
+		}
+	}
+
+	data = data[4*eLen:]
+	scalars := make([]*ecc.Scalar, 3)
+
+	for i := range scalars {
+		scalars[i] = g.NewScalar()
+		if err := scalars[i].DecodeCanonical(data[i*sLen : (i+1)*sLen]); err != nil {
+			return err
+		}
+	}
+
+	ipa := new(InnerProductProof)
+	if err := ipa.Decode(g, data[3*sLen:]); err != nil {
+		return err
+	}
+
+	p.A, p.S, p.T1, p.T2 = elements[0], elements[1], elements[2], elements[3]
+	p.TauX, p.Mu, p.THat, p.IPA = scalars[0], scalars[1], scalars[2], ipa
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+// CheckedElement is a non-panicking view of an Element: its operations verify that their arguments belong to the
+// same group as the element, and return an *ErrWrongGroup instead of panicking if they don't. The element is not
+// modified on error. Nil arguments behave as in the corresponding Element methods.
+type CheckedElement struct {
+	e *Element
+}
+
+// Checked returns a non-panicking view of the element, whose operations apply to the element.
+func (e *Element) Checked() CheckedElement {
+	return CheckedElement{e: e}
+}
+
+// CheckedScalar is a non-panicking view of a Scalar: its operations verify that their arguments belong to the
+// same group as the scalar, and return an *ErrWrongGroup instead of panicking if they don't. The scalar is not
+// modified on error. Nil arguments behave as in the corresponding Scalar methods.
+type CheckedScalar struct {
+	s *Scalar
+}
+
+// Checked returns a non-panicking view of the scalar, whose operations apply to the scalar.
+func (s *Scalar) Checked() CheckedScalar {
+	return CheckedScalar{s: s}
+}
+
+func checkGroup(expected, got Group) error {
+	if expected != got {
+		return &ErrWrongGroup{Expected: expected, Got: got}
+	}
+
+	return nil
+}
+
+func (c CheckedElement) checkElement(element *Element) error {
+	if element == nil {
+		return nil
+	}
+
+	return checkGroup(c.e.Group(), element.Group())
+}
+
+func (c CheckedElement) checkScalar(scalar *Scalar) error {
+	if scalar == nil {
+		return nil
+	}
+
+	return checkGroup(c.e.Group(), scalar.Group())
+}
+
+// Element returns the underlying element.
+func (c CheckedElement) Element() *Element {
+	return c.e
+}
+
+// Add sets the element to the sum of the input and the element, and returns the element.
+func (c CheckedElement) Add(element *Element) (*Element, error) {
+	if err := c.checkElement(element); err != nil {
+		return nil, err
+	}
+
+	return c.e.Add(element), nil
+}
+
+// Subtract subtracts the input from the element, and returns the element.
+func (c CheckedElement) Subtract(element *Element) (*Element, error) {
+	if err := c.checkElement(element); err != nil {
+		return nil, err
+	}
+
+	return c.e.Subtract(element), nil
+}
+
+// Multiply sets the element to its scalar multiplication with the given Scalar, and returns the element.
+func (c CheckedElement) Multiply(scalar *Scalar) (*Element, error) {
+	if err := c.checkScalar(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.e.Multiply(scalar), nil
+}
+
+// Equal returns true if the elements are equivalent, and false otherwise.
+func (c CheckedElement) Equal(element *Element) (bool, error) {
+	if err := c.checkElement(element); err != nil {
+		return false, err
+	}
+
+	return c.e.Equal(element), nil
+}
+
+// Set sets the element to the argument, and returns the element.
+func (c CheckedElement) Set(element *Element) (*Element, error) {
+	if err := c.checkElement(element); err != nil {
+		return nil, err
+	}
+
+	return c.e.Set(element), nil
+}
+
+func (c CheckedScalar) check(scalar *Scalar) error {
+	if scalar == nil {
+		return nil
+	}
+
+	return checkGroup(c.s.Group(), scalar.Group())
+}
+
+// Scalar returns the underlying scalar.
+func (c CheckedScalar) Scalar() *Scalar {
+	return c.s
+}
+
+// Add sets the scalar to the sum of the input and the scalar, and returns the scalar.
+func (c CheckedScalar) Add(scalar *Scalar) (*Scalar, error) {
+	if err := c.check(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.s.Add(scalar), nil
+}
+
+// Subtract subtracts the input from the scalar, and returns the scalar.
+func (c CheckedScalar) Subtract(scalar *Scalar) (*Scalar, error) {
+	if err := c.check(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.s.Subtract(scalar), nil
+}
+
+// Multiply multiplies the scalar with the input, and returns the scalar.
+func (c CheckedScalar) Multiply(scalar *Scalar) (*Scalar, error) {
+	if err := c.check(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.s.Multiply(scalar), nil
+}
+
+// Pow sets the scalar to s**scalar modulo the group order, and returns it.
+func (c CheckedScalar) Pow(scalar *Scalar) (*Scalar, error) {
+	if err := c.check(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.s.Pow(scalar), nil
+}
+
+// Equal returns true if the scalars are equal, and false otherwise.
+func (c CheckedScalar) Equal(scalar *Scalar) (bool, error) {
+	if err := c.check(scalar); err != nil {
+		return false, err
+	}
+
+	return c.s.Equal(scalar), nil
+}
+
+// LessOrEqual returns true if s <= scalar, and false otherwise.
+func (c CheckedScalar) LessOrEqual(scalar *Scalar) (bool, error) {
+	if err := c.check(scalar); err != nil {
+		return false, err
+	}
+
+	return c.s.LessOrEqual(scalar), nil
+}
+
+// Set sets the scalar to the value of the argument, and returns the scalar.
+func (c CheckedScalar) Set(scalar *Scalar) (*Scalar, error) {
+	if err := c.check(scalar); err != nil {
+		return nil, err
+	}
+
+	return c.s.Set(scalar), nil
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package compat bridges the groups of the ecc package with the standard library's crypto packages.
+package compat
+
+import (
+	"errors"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/ecc"
+)
+
+var errUnsupportedGroup = errors.New("group is not supported by the standard library")
+
+// nistPoint is the subset of the nistec point API used to convert between point encodings.
+type nistPoint[P any] interface {
+	SetBytes(b []byte) (P, error)
+	Bytes() []byte
+	BytesCompressed() []byte
+}
+
+func reencode[P nistPoint[P]](p P, data []byte, compressed bool) ([]byte, error) {
+	q, err := p.SetBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if compressed {
+		return q.BytesCompressed(), nil
+	}
+
+	return q.Bytes(), nil
+}
+
+// nistReencode converts a SEC 1 encoded point of a NIST group to its compressed or uncompressed encoding.
+func nistReencode(g ecc.Group, data []byte, compressed bool) ([]byte, error) {
+	switch g {
+	case ecc.P256Sha256:
+		return reencode(nistec.NewP256Point(), data, compressed)
+	case ecc.P384Sha384:
+		return reencode(nistec.NewP384Point(), data, compressed)
+	case ecc.P521Sha512:
+		return reencode(nistec.NewP521Point(), data, compressed)
+	default:
+		return nil, errUnsupportedGroup
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto/ecdh"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errWrongCurve      = errors.New("key is not on the curve of the group")
+	errNoX25519Element = errors.New("X25519 keys don't determine a unique Edwards25519 element")
+	errNoX25519Scalar  = errors.New("X25519 private keys are not Edwards25519 scalars")
+)
+
+// ECDHCurve returns the crypto/ecdh curve of the group. crypto/ecdh.Curve can't be implemented outside the standard
+// library, so this is the standard library's implementation of the same curve. For Edwards25519Sha512, it returns
+// X25519, over the birationally equivalent Montgomery curve. Other groups are not supported.
+func ECDHCurve(g ecc.Group) (ecdh.Curve, error) {
+	switch g {
+	case ecc.P256Sha256:
+		return ecdh.P256(), nil
+	case ecc.P384Sha384:
+		return ecdh.P384(), nil
+	case ecc.P521Sha512:
+		return ecdh.P521(), nil
+	case ecc.Edwards25519Sha512:
+		return ecdh.X25519(), nil
+	default:
+		return nil, errUnsupportedGroup
+	}
+}
+
+// ElementToECDHPublicKey returns the crypto/ecdh public key of the element. Edwards25519 elements are mapped to the
+// u-coordinate of the equivalent point on Curve25519.
+func ElementToECDHPublicKey(e *ecc.Element) (*ecdh.PublicKey, error) {
+	g := e.Group()
+
+	curve, err := ECDHCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	var key []byte
+
+	if g == ecc.Edwards25519Sha512 {
+		p, err := ElementToEdwardsPoint(e)
+		if err != nil {
+			return nil, err
+		}
+
+		key = p.BytesMontgomery()
+	} else {
+		if key, err = nistReencode(g, e.Encode(), false); err != nil {
+			return nil, err
+		}
+	}
+
+	return curve.NewPublicKey(key)
+}
+
+// ECDHPublicKeyToElement returns the element of the NIST group corresponding to the crypto/ecdh public key. X25519
+// keys are not supported, as they only hold a u-coordinate, which matches two Edwards25519 elements.
+func ECDHPublicKeyToElement(g ecc.Group, key *ecdh.PublicKey) (*ecc.Element, error) {
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errNoX25519Element
+	}
+
+	curve, err := ECDHCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Curve() != curve {
+		return nil, errWrongCurve
+	}
+
+	compressed, err := nistReencode(g, key.Bytes(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.NewElementFromBytesNonIdentity(compressed)
+}
+
+// ScalarToECDHPrivateKey returns the crypto/ecdh private key of the NIST group's scalar. X25519 is not supported, as
+// its private keys are clamped bit strings rather than scalars.
+func ScalarToECDHPrivateKey(s *ecc.Scalar) (*ecdh.PrivateKey, error) {
+	g := s.Group()
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errNoX25519Scalar
+	}
+
+	curve, err := ECDHCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return curve.NewPrivateKey(s.Encode())
+}
+
+// ECDHPrivateKeyToScalar returns the scalar of the NIST group corresponding to the crypto/ecdh private key.
+func ECDHPrivateKeyToScalar(g ecc.Group, key *ecdh.PrivateKey) (*ecc.Scalar, error) {
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errNoX25519Scalar
+	}
+
+	curve, err := ECDHCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Curve() != curve {
+		return nil, errWrongCurve
+	}
+
+	s := g.NewScalar()
+	if err = s.DecodeCanonical(key.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"errors"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	ed "github.com/bytemare/ecc/internal/edwards25519"
+)
+
+var (
+	errNotEdwardsScalar = errors.New("compat: scalar is not an Edwards25519 scalar")
+	errTorsion          = errors.New("compat: point is not in the prime-order subgroup")
+)
+
+// ElementToEdwardsPoint returns a copy of the Edwards25519 element as a filippo.io/edwards25519 point, without
+// encoding and decoding it.
+func ElementToEdwardsPoint(e *ecc.Element) (*edwards25519.Point, error) {
+	p, ok := e.Element.(*ed.Element)
+	if !ok {
+		return nil, errNotEdwards
+	}
+
+	return p.Point(), nil
+}
+
+// EdwardsPointToElement returns the filippo.io/edwards25519 point as an Edwards25519 element, and an error if it is
+// not in the prime-order subgroup, to which the elements of the group are restricted.
+func EdwardsPointToElement(p *edwards25519.Point) (*ecc.Element, error) {
+	e := ecc.Edwards25519Sha512.NewElement()
+	e.Element.(*ed.Element).SetPoint(p)
+
+	if !e.IsTorsionFree() {
+		return nil, errTorsion
+	}
+
+	return e, nil
+}
+
+// ScalarToEdwardsScalar returns a copy of the Edwards25519 scalar as a filippo.io/edwards25519 scalar.
+func ScalarToEdwardsScalar(s *ecc.Scalar) (*edwards25519.Scalar, error) {
+	sc, ok := s.Scalar.(*ed.Scalar)
+	if !ok {
+		return nil, errNotEdwardsScalar
+	}
+
+	return sc.EdScalar(), nil
+}
+
+// EdwardsScalarToScalar returns the filippo.io/edwards25519 scalar as an Edwards25519 scalar. Both types hold reduced
+// scalars, so the conversion always succeeds.
+func EdwardsScalarToScalar(s *edwards25519.Scalar) *ecc.Scalar {
+	sc := ecc.Edwards25519Sha512.NewScalar()
+	sc.Scalar.(*ed.Scalar).SetEdScalar(s)
+
+	return sc
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var errInvalidPoint = errors.New("compat: invalid point")
+
+// Curve implements the legacy crypto/elliptic.Curve interface on top of a short Weierstrass group, to ease the
+// migration of code passing elliptic.Curve values around. Points are affine coordinates, with (0, 0) standing for the
+// point at infinity, as in crypto/elliptic.
+//
+// Like crypto/elliptic, which is deprecated, this API is not meant for new code: converting between coordinates and
+// elements uses variable-time math/big operations, and methods panic on points that are not on the curve.
+type Curve struct {
+	params *elliptic.CurveParams
+	a      *big.Int
+	group  ecc.Group
+}
+
+// EllipticCurve returns the crypto/elliptic.Curve adapter of the group, which must be a short Weierstrass group, i.e.
+// a NIST group, secp256k1 or Pallas.
+func EllipticCurve(g ecc.Group) (*Curve, error) {
+	var (
+		params *elliptic.CurveParams
+		a      *big.Int
+	)
+
+	switch g {
+	case ecc.P256Sha256:
+		params = elliptic.P256().Params()
+	case ecc.P384Sha384:
+		params = elliptic.P384().Params()
+	case ecc.P521Sha512:
+		params = elliptic.P521().Params()
+	case ecc.Secp256k1Sha256:
+		params = &elliptic.CurveParams{
+			P:       hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+			B:       big.NewInt(7),
+			BitSize: 256,
+			Name:    "secp256k1",
+		}
+		a = new(big.Int)
+	case ecc.PallasBLAKE2b512:
+		params = &elliptic.CurveParams{
+			P:       hexInt("40000000000000000000000000000000224698fc094cf91b992d30ed00000001"),
+			B:       big.NewInt(5),
+			BitSize: 255,
+			Name:    "pallas",
+		}
+		a = new(big.Int)
+	default:
+		return nil, errUnsupportedGroup
+	}
+
+	if a == nil {
+		a = new(big.Int).Sub(params.P, big.NewInt(3))
+	}
+
+	c := &Curve{params: params, a: a, group: g}
+
+	if params.N == nil {
+		params.N = new(big.Int).SetBytes(g.Order())
+		params.Gx, params.Gy = c.FromElement(g.Base())
+	}
+
+	return c, nil
+}
+
+func hexInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex integer")
+	}
+
+	return i
+}
+
+// Params returns the parameters of the curve. Note that the generic methods of elliptic.CurveParams assume a = -3,
+// which doesn't hold for secp256k1 and Pallas, and must not be used for these curves.
+func (c *Curve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+// Group returns the group of the curve.
+func (c *Curve) Group() ecc.Group {
+	return c.group
+}
+
+// IsOnCurve reports whether the given (x, y) lies on the curve.
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	p := c.params.P
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+
+	return new(big.Int).Exp(y, big.NewInt(2), p).Cmp(c.rhs(x)) == 0
+}
+
+// rhs returns x^3 + ax + b mod p.
+func (c *Curve) rhs(x *big.Int) *big.Int {
+	r := new(big.Int).Mul(x, x)
+	r.Add(r, c.a)
+	r.Mul(r, x)
+	r.Add(r, c.params.B)
+
+	return r.Mod(r, c.params.P)
+}
+
+// ToElement returns the element with the given affine coordinates, and an error if they are not on the curve.
+func (c *Curve) ToElement(x, y *big.Int) (*ecc.Element, error) {
+	e := c.group.NewElement()
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return e, nil
+	}
+
+	if !c.IsOnCurve(x, y) {
+		return nil, errInvalidPoint
+	}
+
+	encoded := make([]byte, c.group.ElementLength())
+	encoded[0] = 0x02 | byte(y.Bit(0))
+	x.FillBytes(encoded[1:])
+
+	if err := e.Decode(encoded); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FromElement returns the affine coordinates of the element, which must belong to the group of the curve.
+func (c *Curve) FromElement(e *ecc.Element) (x, y *big.Int) {
+	if e.IsIdentity() {
+		return new(big.Int), new(big.Int)
+	}
+
+	encoded := e.Encode()
+	x = new(big.Int).SetBytes(encoded[1:])
+	y = new(big.Int).ModSqrt(c.rhs(x), c.params.P)
+
+	if y.Bit(0) != uint(encoded[0]&1) {
+		y.Sub(c.params.P, y)
+	}
+
+	return x, y
+}
+
+func (c *Curve) mustElement(x, y *big.Int) *ecc.Element {
+	e, err := c.ToElement(x, y)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// scalar returns k reduced modulo the group order.
+func (c *Curve) scalar(k []byte) *ecc.Scalar {
+	i := new(big.Int).SetBytes(k)
+	i.Mod(i, c.params.N)
+
+	s := c.group.NewScalar()
+	if err := s.Decode(i.FillBytes(make([]byte, c.group.ScalarLength()))); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Add returns the sum of (x1,y1) and (x2,y2).
+func (c *Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	return c.FromElement(c.mustElement(x1, y1).Add(c.mustElement(x2, y2)))
+}
+
+// Double returns 2*(x,y).
+func (c *Curve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	return c.FromElement(c.mustElement(x1, y1).Double())
+}
+
+// ScalarMult returns k*(x,y), where k is an integer in big-endian form.
+func (c *Curve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	return c.FromElement(c.mustElement(x1, y1).Multiply(c.scalar(k)))
+}
+
+// ScalarBaseMult returns k*G, where G is the base point of the group and k is an integer in big-endian form.
+func (c *Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return c.FromElement(c.group.Base().Multiply(c.scalar(k)))
+}
+
+var _ elliptic.Curve = (*Curve)(nil)
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+// JWS algorithm identifiers (RFC 7518, RFC 8037, and RFC 8812).
+const (
+	JWSAlgorithmES256  = "ES256"
+	JWSAlgorithmES384  = "ES384"
+	JWSAlgorithmES512  = "ES512"
+	JWSAlgorithmES256K = "ES256K"
+	JWSAlgorithmEdDSA  = "EdDSA"
+)
+
+type jwsAlgorithm struct {
+	name string
+	hash crypto.Hash
+}
+
+var (
+	jwsAlgorithms = map[ecc.Group]jwsAlgorithm{
+		ecc.P256Sha256:         {JWSAlgorithmES256, crypto.SHA256},
+		ecc.P384Sha384:         {JWSAlgorithmES384, crypto.SHA384},
+		ecc.P521Sha512:         {JWSAlgorithmES512, crypto.SHA512},
+		ecc.Secp256k1Sha256:    {JWSAlgorithmES256K, crypto.SHA256},
+		ecc.Edwards25519Sha512: {JWSAlgorithmEdDSA, 0},
+	}
+
+	errJWSAlgorithm = errors.New("JWS algorithm doesn't match the key")
+	errJWSSignature = errors.New("invalid JWS signature")
+)
+
+// JWSAlgorithm returns the JWS "alg" identifier of signatures with keys of the group, i.e. ES256, ES384, ES512,
+// ES256K, or EdDSA for Edwards25519.
+func JWSAlgorithm(g ecc.Group) (string, error) {
+	alg, ok := jwsAlgorithms[g]
+	if !ok {
+		return "", errUnsupportedGroup
+	}
+
+	return alg.name, nil
+}
+
+// SignJWS returns the JWS signature of the signing input, i.e. the ASCII of BASE64URL(header) || '.' ||
+// BASE64URL(payload), with the algorithm of JWSAlgorithm. ECDSA signatures are the fixed-size concatenation r || s
+// of JWS, rather than the ASN.1 DER encoding of Sign. rand is used as in Sign, and can be nil.
+func (k *PrivateKey) SignJWS(rand io.Reader, signingInput []byte) ([]byte, error) {
+	alg := jwsAlgorithms[k.scalar.Group()]
+	if alg.hash == 0 {
+		return k.Sign(rand, signingInput, crypto.Hash(0))
+	}
+
+	digest := jwsDigest(alg.hash, signingInput)
+
+	nonce, err := k.hedgedNonce(rand, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer nonce.Wipe()
+
+	return marshalJWS(k.signECDSA(nonce, digest))
+}
+
+// VerifyJWS returns nil if the signature of the signing input is valid for the public key and the algorithm, which
+// must be the one of the key's group, and an error otherwise.
+func VerifyJWS(pk *ecc.Element, algorithm string, signingInput, signature []byte) error {
+	g := pk.Group()
+
+	alg, ok := jwsAlgorithms[g]
+	if !ok {
+		return errUnsupportedGroup
+	}
+
+	if alg.name != algorithm {
+		return errJWSAlgorithm
+	}
+
+	if pk.IsIdentity() {
+		return ecc.ErrIdentity
+	}
+
+	if alg.hash == 0 {
+		if !ed25519.Verify(pk.Encode(), signingInput, signature) {
+			return errJWSSignature
+		}
+
+		return nil
+	}
+
+	r, s, err := parseJWS(g, signature)
+	if err != nil {
+		return err
+	}
+
+	digest := jwsDigest(alg.hash, signingInput)
+	e := ecdsaDigest(g, digest)
+
+	// R = (e * G + r * pk) / s, and the signature is valid if x(R) = r mod n.
+	w := s.Invert()
+	commitment := g.Base().Multiply(e.Multiply(w)).Add(pk.Copy().Multiply(r.Copy().Multiply(w)))
+
+	if commitment.IsIdentity() {
+		return errJWSSignature
+	}
+
+	if x, _ := ecdsaInputs(commitment, digest); !x.Equal(r) {
+		return errJWSSignature
+	}
+
+	return nil
+}
+
+// DERToJWS converts an ASN.1 DER encoded ECDSA signature in the group, e.g. from a crypto.Signer, to the r || s
+// format of JWS.
+func DERToJWS(g ecc.Group, der []byte) ([]byte, error) {
+	if _, ok := jwsAlgorithms[g]; !ok || g == ecc.Edwards25519Sha512 {
+		return nil, errUnsupportedGroup
+	}
+
+	var sig struct{ R, S *big.Int }
+
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil || len(rest) != 0 {
+		return nil, errJWSSignature
+	}
+
+	r, s := g.NewScalar(), g.NewScalar()
+	if !decodeBigScalar(r, sig.R) || !decodeBigScalar(s, sig.S) {
+		return nil, errJWSSignature
+	}
+
+	return marshalJWS(r, s)
+}
+
+// JWSToDER converts an r || s JWS signature in the group to the ASN.1 DER encoding of ECDSA signatures.
+func JWSToDER(g ecc.Group, signature []byte) ([]byte, error) {
+	if _, ok := jwsAlgorithms[g]; !ok || g == ecc.Edwards25519Sha512 {
+		return nil, errUnsupportedGroup
+	}
+
+	r, s, err := parseJWS(g, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalECDSA(r, s)
+}
+
+func jwsDigest(h crypto.Hash, signingInput []byte) []byte {
+	hash := h.New()
+	_, _ = hash.Write(signingInput)
+
+	return hash.Sum(nil)
+}
+
+func marshalJWS(r, s *ecc.Scalar) ([]byte, error) {
+	if r.IsZero() || s.IsZero() {
+		return nil, errZeroSignature
+	}
+
+	return append(r.Encode(), s.Encode()...), nil
+}
+
+// parseJWS returns the non-zero r and s of the r || s JWS signature.
+func parseJWS(g ecc.Group, signature []byte) (r, s *ecc.Scalar, err error) {
+	n := g.ScalarLength()
+	if len(signature) != 2*n {
+		return nil, nil, errJWSSignature
+	}
+
+	r, s = g.NewScalar(), g.NewScalar()
+	if r.Decode(signature[:n]) != nil || s.Decode(signature[n:]) != nil || r.IsZero() || s.IsZero() {
+		return nil, nil, errJWSSignature
+	}
+
+	return r, s, nil
+}
+
+// decodeBigScalar sets s to the value of i, and returns whether it is a non-zero scalar.
+func decodeBigScalar(s *ecc.Scalar, i *big.Int) bool {
+	if i == nil || i.Sign() <= 0 || i.BitLen() > 8*s.Group().ScalarLength() {
+		return false
+	}
+
+	return s.Decode(i.FillBytes(make([]byte, s.Group().ScalarLength()))) == nil
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errInvalidKey     = errors.New("compat: invalid key")
+	errNotEdwards     = errors.New("compat: element is not an Edwards25519 element")
+	errCurveMismatch  = errors.New("compat: public key doesn't match the private key")
+	errUnsupportedKey = errors.New("compat: unsupported key curve")
+)
+
+// curveGroup returns the group of a standard library curve or of a Curve adapter.
+func curveGroup(c elliptic.Curve) (ecc.Group, error) {
+	switch c {
+	case elliptic.P256():
+		return ecc.P256Sha256, nil
+	case elliptic.P384():
+		return ecc.P384Sha384, nil
+	case elliptic.P521():
+		return ecc.P521Sha512, nil
+	}
+
+	if adapter, ok := c.(*Curve); ok && adapter.group == ecc.Secp256k1Sha256 {
+		return adapter.group, nil
+	}
+
+	return 0, errUnsupportedKey
+}
+
+// FromECDSAPublicKey returns the element of the ECDSA public key, which must be on a NIST curve of the standard
+// library or on the secp256k1 Curve adapter, and not the point at infinity.
+func FromECDSAPublicKey(k *ecdsa.PublicKey) (*ecc.Element, error) {
+	if k == nil || k.X == nil || k.Y == nil {
+		return nil, errInvalidKey
+	}
+
+	g, err := curveGroup(k.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	c, _ := EllipticCurve(g)
+
+	e, err := c.ToElement(k.X, k.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsIdentity() {
+		return nil, ecc.ErrIdentity
+	}
+
+	return e, nil
+}
+
+// ToECDSAPublicKey returns the ECDSA public key of the element of a NIST group or secp256k1, the latter using the
+// Curve adapter.
+func ToECDSAPublicKey(e *ecc.Element) (*ecdsa.PublicKey, error) {
+	switch e.Group() {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+	default:
+		return nil, errUnsupportedGroup
+	}
+
+	if e.IsIdentity() {
+		return nil, ecc.ErrIdentity
+	}
+
+	return publicKey(e).(*ecdsa.PublicKey), nil
+}
+
+// FromECDSAKey returns the private scalar of the ECDSA key, and an error if the key is out of range or if its public
+// key doesn't match.
+func FromECDSAKey(k *ecdsa.PrivateKey) (*ecc.Scalar, error) {
+	if k == nil || k.D == nil {
+		return nil, errInvalidKey
+	}
+
+	pk, err := FromECDSAPublicKey(&k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	g := pk.Group()
+	if k.D.Sign() <= 0 || k.D.Cmp(new(big.Int).SetBytes(g.Order())) >= 0 {
+		return nil, errInvalidKey
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(k.D.FillBytes(make([]byte, g.ScalarLength()))); err != nil {
+		return nil, err
+	}
+
+	if !g.Base().Multiply(s).Equal(pk) {
+		return nil, errCurveMismatch
+	}
+
+	return s, nil
+}
+
+// ToECDSAKey returns the ECDSA private key of the non-zero scalar of a NIST group or secp256k1, the latter using the
+// Curve adapter.
+func ToECDSAKey(s *ecc.Scalar) (*ecdsa.PrivateKey, error) {
+	if s.IsZero() {
+		return nil, errZeroKey
+	}
+
+	pk, err := ToECDSAPublicKey(s.Group().Base().Multiply(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{PublicKey: *pk, D: new(big.Int).SetBytes(s.Encode())}, nil
+}
+
+// FromEd25519 returns the Edwards25519 private scalar and public key of the Ed25519 private key, the scalar being
+// derived from the seed as specified in RFC 8032, and an error if the embedded public key doesn't match. The
+// conversion is one-way: the seed can't be recovered from the scalar, hence there is no ToEd25519 for private keys.
+func FromEd25519(k ed25519.PrivateKey) (*ecc.Scalar, *ecc.Element, error) {
+	if len(k) != ed25519.PrivateKeySize {
+		return nil, nil, errInvalidKey
+	}
+
+	s, pk, err := Ed25519SeedToKeys(k.Seed())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	embedded, err := FromEd25519PublicKey(k.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !embedded.Equal(pk) {
+		return nil, nil, errCurveMismatch
+	}
+
+	return s, pk, nil
+}
+
+// Ed25519SeedToKeys derives the Edwards25519 private scalar and public key from an RFC 8032 Ed25519 seed.
+func Ed25519SeedToKeys(seed []byte) (*ecc.Scalar, *ecc.Element, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, nil, errInvalidKey
+	}
+
+	h := sha512.Sum512(seed)
+	defer clear(h[:])
+
+	s := ecc.Edwards25519Sha512.NewScalar()
+	if err := s.SetBytesWithClamping(h[:32]); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errInvalidKey, err)
+	}
+
+	return s, ecc.Edwards25519Sha512.Base().Multiply(s), nil
+}
+
+// FromEd25519PublicKey returns the Edwards25519 element of the Ed25519 public key, which must be a canonical encoding
+// of an element other than the identity.
+func FromEd25519PublicKey(pk ed25519.PublicKey) (*ecc.Element, error) {
+	return ecc.Edwards25519Sha512.NewElementFromBytesNonIdentity(pk)
+}
+
+// ToEd25519PublicKey returns the Ed25519 public key of the Edwards25519 element.
+func ToEd25519PublicKey(e *ecc.Element) (ed25519.PublicKey, error) {
+	if e.Group() != ecc.Edwards25519Sha512 {
+		return nil, errNotEdwards
+	}
+
+	if e.IsIdentity() {
+		return nil, ecc.ErrIdentity
+	}
+
+	return e.Encode(), nil
+}
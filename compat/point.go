@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+// MarshalUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the element of a short Weierstrass
+// group, as used by x509 and crypto/ecdh. The identity has no such encoding.
+func MarshalUncompressed(e *ecc.Element) ([]byte, error) {
+	curve, err := EllipticCurve(e.Group())
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsIdentity() {
+		return nil, ecc.ErrIdentity
+	}
+
+	size := e.Group().ElementLength() - 1
+	x, y := curve.FromElement(e)
+	out := make([]byte, 1+2*size)
+	out[0] = 0x04
+	x.FillBytes(out[1 : 1+size])
+	y.FillBytes(out[1+size:])
+
+	return out, nil
+}
+
+// ParsePoint decodes a compressed or uncompressed SEC 1 encoding of a point of a short Weierstrass group, and
+// returns an error if it is not a valid encoding of an element other than the identity.
+func ParsePoint(g ecc.Group, data []byte) (*ecc.Element, error) {
+	curve, err := EllipticCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	size := g.ElementLength() - 1
+	if len(data) != 1+2*size || data[0] != 0x04 {
+		return g.NewElementFromBytesNonIdentity(data)
+	}
+
+	e, err := curve.ToElement(new(big.Int).SetBytes(data[1:1+size]), new(big.Int).SetBytes(data[1+size:]))
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsIdentity() {
+		return nil, ecc.ErrIdentity
+	}
+
+	return e, nil
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto"
+	"errors"
+	"io"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errRemoteSignature = errors.New("external device returned an invalid signature")
+	errSharedSecret    = errors.New("shared secret is the identity")
+)
+
+// ECDH returns the shared secret of the key held by the device and the peer's public key, in the crypto/ecdh format:
+// the x-coordinate of the shared point for the NIST groups and secp256k1, and the u-coordinate of the equivalent
+// point on Curve25519 for Edwards25519.
+func ECDH(key ecc.RemoteScalar, peer *ecc.Element) ([]byte, error) {
+	g := key.Group()
+	switch g {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256, ecc.Edwards25519Sha512:
+	default:
+		return nil, errUnsupportedGroup
+	}
+
+	if peer.Group() != g || peer.IsIdentity() {
+		return nil, errInvalidPoint
+	}
+
+	shared, err := key.Multiply(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	if shared == nil || shared.Group() != g || shared.IsIdentity() {
+		return nil, errSharedSecret
+	}
+
+	if g != ecc.Edwards25519Sha512 {
+		return shared.XCoordinate(), nil
+	}
+
+	p, err := ElementToEdwardsPoint(shared)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.BytesMontgomery(), nil
+}
+
+// RemoteECDSAKey is an ECDSA private key held by an external device, which generates the nonce and computes the
+// signature value, so that neither the key nor the nonce leave it.
+type RemoteECDSAKey interface {
+	ecc.RemoteScalar
+
+	// Commit returns the commitment R = k * G of a fresh nonce k, which the device keeps for the matching Respond.
+	Commit() (*ecc.Element, error)
+
+	// Respond returns s = (e + r * d) / k, for the nonce k of the commitment R, the private key d, the x-coordinate r
+	// of R reduced modulo the order, and the truncated digest e. The device must then erase k, and never use it again.
+	Respond(commitment *ecc.Element, r, e *ecc.Scalar) (*ecc.Scalar, error)
+}
+
+// RemoteSigner implements crypto.Signer with an ECDSA key held by an external device. It produces ASN.1 DER encoded
+// signatures for the NIST groups and secp256k1.
+type RemoteSigner struct {
+	key RemoteECDSAKey
+}
+
+// NewRemoteSigner returns a signer for the device key, which must belong to a NIST group or secp256k1.
+func NewRemoteSigner(key RemoteECDSAKey) (*RemoteSigner, error) {
+	switch key.Group() {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+	default:
+		return nil, errUnsupportedGroup
+	}
+
+	if pk := key.Public(); pk == nil || pk.IsIdentity() {
+		return nil, errZeroKey
+	}
+
+	return &RemoteSigner{key: key}, nil
+}
+
+// Public returns the public key as an *ecdsa.PublicKey, using the Curve adapter for secp256k1.
+func (k *RemoteSigner) Public() crypto.PublicKey {
+	return publicKey(k.key.Public())
+}
+
+// Sign signs the digest on the device. The randomness and options are ignored, the nonce being generated by the
+// device. The signature is verified before being returned, so that a faulty device can't produce invalid signatures.
+func (k *RemoteSigner) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	commitment, err := k.key.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	g := k.key.Group()
+	if commitment == nil || commitment.Group() != g || commitment.IsIdentity() {
+		return nil, errRemoteSignature
+	}
+
+	r, e := ecdsaInputs(commitment, digest)
+
+	s, err := k.key.Respond(commitment, r, e)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil || s.Group() != g || s.IsZero() {
+		return nil, errRemoteSignature
+	}
+
+	// R = (e * G + r * pk) / s
+	w := s.Copy().Invert()
+	check := g.Base().Multiply(e.Copy().Multiply(w)).Add(k.key.Public().Copy().Multiply(r.Copy().Multiply(w)))
+
+	if !check.Equal(commitment) {
+		return nil, errRemoteSignature
+	}
+
+	return marshalECDSA(r, s)
+}
+
+var _ crypto.Signer = (*RemoteSigner)(nil)
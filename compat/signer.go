@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package compat
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	signerNonceDST     = "ECC-Compat-V01-SignerNonce"
+	signerNonceEntropy = 32
+)
+
+var (
+	errZeroKey       = errors.New("private key is zero")
+	errZeroSignature = errors.New("signature value is zero, retry with another nonce")
+	errEd25519Hashed = errors.New("ed25519: only pure Ed25519 is supported, the message must not be hashed")
+)
+
+// PrivateKey wraps a private scalar to implement crypto.Signer, so that keys managed with this package can be used
+// with x509, TLS, and JWT libraries. It produces ASN.1 DER encoded ECDSA signatures on the digest for the NIST groups
+// and secp256k1, and Ed25519 signatures on the message for Edwards25519Sha512.
+//
+// Ed25519 private keys are usually derived from a seed, which can't be recovered from a scalar. The signatures are
+// instead computed from the scalar with a hedged nonce, and verify with any Ed25519 implementation.
+type PrivateKey struct {
+	scalar *ecc.Scalar
+	public *ecc.Element
+}
+
+// NewPrivateKey returns a signer for the private scalar, which must be non-zero and belong to a NIST group,
+// secp256k1, or Edwards25519. The scalar is not copied.
+func NewPrivateKey(s *ecc.Scalar) (*PrivateKey, error) {
+	switch s.Group() {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256, ecc.Edwards25519Sha512:
+	default:
+		return nil, errUnsupportedGroup
+	}
+
+	if s.IsZero() {
+		return nil, errZeroKey
+	}
+
+	return &PrivateKey{
+		scalar: s,
+		public: s.Group().Base().Multiply(s),
+	}, nil
+}
+
+// Scalar returns the private scalar.
+func (k *PrivateKey) Scalar() *ecc.Scalar {
+	return k.scalar
+}
+
+// PublicElement returns the public key as an element.
+func (k *PrivateKey) PublicElement() *ecc.Element {
+	return k.public.Copy()
+}
+
+// Public returns the public key, as an *ecdsa.PublicKey for the NIST groups and secp256k1, the latter using the
+// Curve adapter, and as an ed25519.PublicKey for Edwards25519.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return publicKey(k.public)
+}
+
+func publicKey(pk *ecc.Element) crypto.PublicKey {
+	g := pk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		return ed25519.PublicKey(pk.Encode())
+	}
+
+	curve, _ := EllipticCurve(g)
+	x, y := curve.FromElement(pk)
+
+	return &ecdsa.PublicKey{Curve: standardCurve(curve), X: x, Y: y}
+}
+
+// standardCurve returns the standard library's implementation of the NIST curves, which other packages recognize,
+// and the adapter otherwise.
+func standardCurve(c *Curve) elliptic.Curve {
+	switch c.group {
+	case ecc.P256Sha256:
+		return elliptic.P256()
+	case ecc.P384Sha384:
+		return elliptic.P384()
+	case ecc.P521Sha512:
+		return elliptic.P521()
+	default:
+		return c
+	}
+}
+
+// Sign signs the digest with ECDSA, or the message with Ed25519, in which case opts.HashFunc() must be 0. rand is
+// used as additional entropy for the nonce, which is otherwise derived from the key and the input, and can be nil.
+func (k *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if k.scalar.Group() == ecc.Edwards25519Sha512 && opts != nil && opts.HashFunc() != 0 {
+		return nil, errEd25519Hashed
+	}
+
+	nonce, err := k.hedgedNonce(rand, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer nonce.Wipe()
+
+	if k.scalar.Group() == ecc.Edwards25519Sha512 {
+		return k.signEd25519(nonce, digest), nil
+	}
+
+	return marshalECDSA(k.signECDSA(nonce, digest))
+}
+
+// hedgedNonce reads the nonce entropy from rand, or from crypto/rand if it is nil, and derives the nonce for input.
+func (k *PrivateKey) hedgedNonce(rand io.Reader, input []byte) (*ecc.Scalar, error) {
+	entropy := make([]byte, signerNonceEntropy)
+	if rand == nil {
+		entropy = internal.RandomBytes(signerNonceEntropy)
+	} else if _, err := io.ReadFull(rand, entropy); err != nil {
+		return nil, fmt.Errorf("reading nonce entropy: %w", err)
+	}
+
+	return k.nonce(entropy, input), nil
+}
+
+// nonce derives a hedged nonce from the entropy, the private key, and the input.
+func (k *PrivateKey) nonce(entropy, input []byte) *ecc.Scalar {
+	g := k.scalar.Group()
+	data := make([]byte, 0, len(entropy)+g.ScalarLength()+len(input))
+	data = append(data, entropy...)
+	data = append(data, k.scalar.Encode()...)
+	data = append(data, input...)
+
+	return g.HashToScalar(data, []byte(signerNonceDST))
+}
+
+func (k *PrivateKey) signEd25519(r *ecc.Scalar, message []byte) []byte {
+	g := k.scalar.Group()
+	commitment := g.Base().Multiply(r).Encode()
+
+	h := sha512.New()
+	_, _ = h.Write(commitment)
+	_, _ = h.Write(k.public.Encode())
+	_, _ = h.Write(message)
+
+	reduced, _ := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	c := g.NewScalar()
+	_ = c.Decode(reduced.Bytes())
+
+	return append(commitment, c.Multiply(k.scalar).Add(r).Encode()...)
+}
+
+func (k *PrivateKey) signECDSA(nonce *ecc.Scalar, digest []byte) (r, s *ecc.Scalar) {
+	g := k.scalar.Group()
+	r, e := ecdsaInputs(g.Base().Multiply(nonce), digest)
+
+	// s = (e + r * d) / k
+	s = r.Copy().Multiply(k.scalar).Add(e).Multiply(nonce.Copy().Invert())
+
+	return r, s
+}
+
+// ecdsaInputs returns r, the x-coordinate of the nonce commitment reduced modulo the order, and e, the digest
+// truncated to the bit length of the order and reduced.
+func ecdsaInputs(commitment *ecc.Element, digest []byte) (r, e *ecc.Scalar) {
+	g := commitment.Group()
+	order := new(big.Int).SetBytes(g.Order())
+
+	x := new(big.Int).SetBytes(commitment.XCoordinate())
+	x.Mod(x, order)
+
+	r = g.NewScalar()
+	_ = r.Decode(x.FillBytes(make([]byte, g.ScalarLength())))
+
+	return r, ecdsaDigest(g, digest)
+}
+
+// ecdsaDigest returns the digest truncated to the bit length of the order and reduced.
+func ecdsaDigest(g ecc.Group, digest []byte) *ecc.Scalar {
+	order := new(big.Int).SetBytes(g.Order())
+
+	i := new(big.Int).SetBytes(digest)
+	if excess := 8*len(digest) - order.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	e := g.NewScalar()
+	_ = e.Decode(i.Mod(i, order).FillBytes(make([]byte, g.ScalarLength())))
+
+	return e
+}
+
+func marshalECDSA(r, s *ecc.Scalar) ([]byte, error) {
+	if r.IsZero() || s.IsZero() {
+		return nil, errZeroSignature
+	}
+
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{
+		new(big.Int).SetBytes(r.Encode()),
+		new(big.Int).SetBytes(s.Encode()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding signature: %w", err)
+	}
+
+	return sig, nil
+}
+
+var _ crypto.Signer = (*PrivateKey)(nil)
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ctcheck provides statistical timing tests to assess the constant-time behavior of the groups on the
+// hardware they run on.
+//
+// The tests follow the dudect methodology (https://eprint.iacr.org/2016/1123): an operation is timed on inputs from
+// two classes, a fixed input and random inputs, picked at random for each measurement, and Welch's t-test is applied
+// to the two timing distributions. A |t| above the threshold, 4.5 by default, is evidence of a timing leak, while a
+// lower value only means that no leak was detected with the given number of measurements. Results depend on the
+// environment, and running many measurements on an idle machine gives more meaningful results.
+package ctcheck
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultThreshold is the |t| value above which an operation is considered leaky, as in dudect.
+	DefaultThreshold = 4.5
+
+	// cropPercentile is the share of the slowest measurements kept, the others being discarded as noise from
+	// interrupts and scheduling.
+	cropPercentile = 0.95
+)
+
+// Result holds the outcome of a timing test.
+type Result struct {
+	// T is the Welch's t-statistic of the timings of the two classes.
+	T float64
+
+	// Measurements is the number of measurements used after cropping the outliers.
+	Measurements int
+
+	// Leaky is true if |T| is above the threshold.
+	Leaky bool
+}
+
+// Prepare returns the operation to time for an input of the given class, 0 for the fixed input and 1 for random
+// inputs. The preparation itself is not timed.
+type Prepare func(class int) func()
+
+// welford accumulates the mean and variance of a sample.
+type welford struct {
+	n    float64
+	mean float64
+	m2   float64
+}
+
+func (w *welford) push(x float64) {
+	w.n++
+	d := x - w.mean
+	w.mean += d / w.n
+	w.m2 += d * (x - w.mean)
+}
+
+func (w *welford) variance() float64 {
+	if w.n < 2 {
+		return 0
+	}
+
+	return w.m2 / (w.n - 1)
+}
+
+// welchT returns Welch's t-statistic of the two samples.
+func welchT(a, b *welford) float64 {
+	den := math.Sqrt(a.variance()/a.n + b.variance()/b.n)
+	if den == 0 {
+		return 0
+	}
+
+	return (a.mean - b.mean) / den
+}
+
+// Run times the operations returned by prepare for the given number of measurements, with a class picked at random
+// for each, and returns the result of the t-test with the given threshold, or DefaultThreshold if it is 0. At least
+// two measurements are required.
+func Run(measurements int, threshold float64, prepare Prepare) Result {
+	if threshold == 0 {
+		threshold = DefaultThreshold
+	}
+
+	if measurements < 2 {
+		return Result{}
+	}
+
+	classes := make([]int, measurements)
+	timings := make([]float64, measurements)
+
+	for i := range measurements {
+		classes[i] = rand.IntN(2) //nolint:gosec // the class choice doesn't need to be unpredictable.
+		op := prepare(classes[i])
+
+		start := time.Now()
+		op()
+		timings[i] = float64(time.Since(start))
+	}
+
+	sorted := slices.Clone(timings)
+	slices.Sort(sorted)
+	limit := sorted[int(cropPercentile*float64(len(sorted)-1))]
+
+	var stats [2]welford
+
+	for i, t := range timings {
+		if t <= limit {
+			stats[classes[i]].push(t)
+		}
+	}
+
+	t := welchT(&stats[0], &stats[1])
+
+	return Result{
+		T:            t,
+		Measurements: int(stats[0].n + stats[1].n),
+		Leaky:        math.Abs(t) > threshold,
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ctcheck
+
+import "github.com/bytemare/ecc"
+
+// Multiply tests the timing of the scalar multiplication of the generator, with the scalar 1 as fixed input against
+// random scalars.
+func Multiply(g ecc.Group, measurements int, threshold float64) Result {
+	fixed := g.NewScalar().One()
+
+	return Run(measurements, threshold, func(class int) func() {
+		s := fixed
+		if class == 1 {
+			s = g.NewScalar().Random()
+		}
+
+		e := g.Base()
+
+		return func() {
+			e.Multiply(s)
+		}
+	})
+}
+
+// Decode tests the timing of element decoding, with the encoding of the generator as fixed input against the
+// encodings of random elements.
+func Decode(g ecc.Group, measurements int, threshold float64) Result {
+	fixed := g.Base().Encode()
+
+	return Run(measurements, threshold, func(class int) func() {
+		encoded := fixed
+		if class == 1 {
+			encoded = g.Base().Multiply(g.NewScalar().Random()).Encode()
+		}
+
+		e := g.NewElement()
+
+		return func() {
+			_ = e.Decode(encoded)
+		}
+	})
+}
+
+// Equal tests the timing of element comparison, with equal elements as fixed input against random elements.
+func Equal(g ecc.Group, measurements int, threshold float64) Result {
+	base := g.Base()
+
+	return Run(measurements, threshold, func(class int) func() {
+		e := g.Base()
+		if class == 1 {
+			e.Multiply(g.NewScalar().Random())
+		}
+
+		return func() {
+			_ = base.Equal(e)
+		}
+	})
+}
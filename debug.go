@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build ecc_debug
+
+package ecc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DebugMode is true when the package is built with the ecc_debug tag. In that mode, the result of every arithmetic
+// operation on elements is checked to be a valid point of the prime-order subgroup, every scalar result is checked to
+// be canonical, and the inversion of 0 panics. This is much slower, and is intended for development and fuzzing.
+const DebugMode = true
+
+var (
+	errDebugElement       = errors.New("element is not a valid point of the prime-order group")
+	errDebugNotInSubgroup = errors.New("element is not in the prime-order subgroup")
+	errDebugScalar        = errors.New("scalar is not canonical")
+	errDebugInvertZero    = errors.New("inversion of the zero scalar")
+	errDebugEncoding      = errors.New("element doesn't match its own encoding")
+)
+
+func debugPanic(op string, err error) {
+	panic(fmt.Errorf("ecc debug: %s: %w", op, err))
+}
+
+// debugElement panics if e is not on the curve, in the prime-order subgroup, and equal to the decoding of its
+// encoding. It returns e.
+func debugElement(e *Element, op string) *Element {
+	if e.IsIdentity() {
+		return e
+	}
+
+	if !e.IsTorsionFree() {
+		debugPanic(op, errDebugNotInSubgroup)
+	}
+
+	d := e.Group().NewElement()
+	if err := d.Decode(e.Encode()); err != nil {
+		debugPanic(op, fmt.Errorf("%w: %w", errDebugElement, err))
+	}
+
+	if !d.Equal(e) {
+		debugPanic(op, errDebugEncoding)
+	}
+
+	return e
+}
+
+// debugScalar panics if s is not the canonical encoding of a value lower than the group order. It returns s.
+func debugScalar(s *Scalar, op string) *Scalar {
+	d := s.Group().NewScalar()
+	if err := d.Decode(s.Encode()); err != nil || !d.Equal(s) {
+		debugPanic(op, errDebugScalar)
+	}
+
+	return s
+}
+
+// debugInvert panics if s is 0.
+func debugInvert(s *Scalar) {
+	if s.IsZero() {
+		debugPanic("Invert", errDebugInvertZero)
+	}
+}
@@ -41,6 +41,10 @@ func BadScalarHigh(g ecc.Group) []byte {
 			255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254,
 			186, 174, 220, 230, 175, 72, 160, 59, 191, 210, 94, 140, 208, 54, 65, 66,
 		},
+		ecc.PallasBLAKE2b512: {
+			64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			34, 70, 152, 252, 9, 148, 168, 221, 140, 70, 235, 33, 0, 0, 0, 2,
+		},
 	}
 
 	return groupOrderPlusOne[g]
@@ -78,6 +82,11 @@ func BadElementOffCurve(g ecc.Group) []byte {
 			2, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 			255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254, 255, 255, 252, 47,
 		},
+		// x = 2 is not the abscissa of a point on Pallas, as 2^3 + 5 is not a square.
+		ecc.PallasBLAKE2b512: {
+			2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		},
 	}
 
 	return fieldOrdersBE[g]
@@ -113,6 +122,10 @@ func BadElementEncoding(g ecc.Group) []byte {
 			248, 194, 40, 96, 152, 251, 181, 46, 76, 234, 70, 112, 83, 163, 182, 140,
 			48, 35, 199, 44, 130, 86, 124, 138, 93, 210, 45, 56, 95, 208, 36, 234, 104,
 		},
+		ecc.PallasBLAKE2b512: {
+			4, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			0, 34, 70, 152, 252, 9, 76, 249, 27, 153, 45, 48, 237, 0, 0, 0, 0,
+		},
 	}
 
 	return badElements[g]
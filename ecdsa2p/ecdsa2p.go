@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ecdsa2p provides the group-side operations of Lindell's two-party ECDSA: multiplicative sharing of the key
+// and of the nonce, the commitments and proofs that keep the exchanged elements consistent, the second party's partial
+// signature, and the first party's final signature.
+//
+// The partial signature is computed on the first party's encrypted key share, with an additively homomorphic scheme
+// like Paillier, or with oblivious transfer. These operate outside the elliptic curve group, and are abstracted by the
+// Evaluator and Decrypter interfaces.
+//
+// The key is x1 * x2, with the public key Q = x1 * x2 * G, and the nonce is k1 * k2. The second party sends
+// Enc(k2^-1 * (e + r * x2 * x1)) to the first party, which decrypts it and multiplies it by k1^-1 to get s.
+package ecdsa2p
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/mta"
+)
+
+const commitmentDST = "ECC-2P-ECDSA-V01-Commitment"
+
+var (
+	errNilInput         = errors.New("nil input")
+	errGroupMismatch    = errors.New("inputs belong to different groups")
+	errUnsupportedGroup = errors.New("ECDSA is only supported for the NIST groups and secp256k1")
+	errZeroSecret       = errors.New("secret is zero")
+	errInvalidPeer      = errors.New("invalid peer element")
+	errInvalidSignature = errors.New("the signature is invalid")
+	errSignatureLength  = errors.New("invalid signature encoding length")
+)
+
+// Evaluator is the second party's side of the channel to the first party's encrypted key share Enc(x1), e.g. a
+// Paillier ciphertext sent during key generation with its range proofs.
+type Evaluator interface {
+	// Evaluate returns an encryption of a + b * x1, which must be masked so that it reveals nothing more than the
+	// value modulo the group order to the first party, e.g. by adding a random multiple of the order.
+	Evaluate(a, b *ecc.Scalar) ([]byte, error)
+}
+
+// Decrypter is the first party's side of the channel, holding the decryption key.
+type Decrypter interface {
+	// Decrypt returns the decryption of the ciphertext, reduced modulo the group order.
+	Decrypt(ciphertext []byte) (*ecc.Scalar, error)
+}
+
+func checkGroup(g ecc.Group) error {
+	switch g {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+		return nil
+	default:
+		return errUnsupportedGroup
+	}
+}
+
+// Contribution is a party's element, i.e. its public key share or nonce commitment, with a proof of knowledge of its
+// discrete logarithm.
+type Contribution struct {
+	Element *ecc.Element
+	Proof   *mta.DLogProof
+}
+
+// NewContribution returns the contribution of the secret, i.e. the key or nonce share, bound to the context. The
+// context must identify the session and the party.
+func NewContribution(secret *ecc.Scalar, context []byte) (*Contribution, error) {
+	if secret == nil {
+		return nil, errNilInput
+	}
+
+	if err := checkGroup(secret.Group()); err != nil {
+		return nil, err
+	}
+
+	if secret.IsZero() {
+		return nil, errZeroSecret
+	}
+
+	element := secret.Group().Base().Multiply(secret)
+
+	proof, err := mta.ProveDLog(secret, element, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Contribution{Element: element, Proof: proof}, nil
+}
+
+// Commit returns a commitment to the contribution. The first party sends it before the second party sends its own
+// contribution, and only then opens it, so that it can't choose its share as a function of the other.
+func (c *Contribution) Commit(context []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(commitmentDST))
+	h.Write(context)
+	h.Write(c.Element.Encode())
+	h.Write(c.Proof.Commitment.Encode())
+	h.Write(c.Proof.Response.Encode())
+
+	return h.Sum(nil)
+}
+
+// Verify returns whether the peer's contribution is a valid element of the group, other than the identity, with a
+// valid proof for the context.
+func (c *Contribution) Verify(g ecc.Group, context []byte) bool {
+	if c == nil || c.Element == nil || c.Proof == nil || c.Proof.Commitment == nil || c.Proof.Response == nil {
+		return false
+	}
+
+	if c.Element.Group() != g || c.Element.IsIdentity() || !c.Element.IsTorsionFree() {
+		return false
+	}
+
+	return c.Proof.Verify(c.Element, context)
+}
+
+// Open returns whether the peer's contribution is valid, and matches the commitment it sent first.
+func (c *Contribution) Open(g ecc.Group, commitment, context []byte) bool {
+	if !c.Verify(g, context) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(c.Commit(context), commitment) == 1
+}
+
+// KeyShare is a party's multiplicative share of the key.
+type KeyShare struct {
+	// Secret is the party's share of the private key.
+	Secret *ecc.Scalar
+
+	// PeerPublic is the public share of the other party.
+	PeerPublic *ecc.Element
+
+	// PublicKey is the joint public key, Secret * PeerPublic.
+	PublicKey *ecc.Element
+}
+
+// combine returns secret * peer, after checking the inputs. Callers must have verified the peer's contribution.
+func combine(secret *ecc.Scalar, peer *ecc.Element) (*ecc.Element, error) {
+	if secret == nil || peer == nil {
+		return nil, errNilInput
+	}
+
+	g := secret.Group()
+	if err := checkGroup(g); err != nil {
+		return nil, err
+	}
+
+	if peer.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	if peer.IsIdentity() {
+		return nil, errInvalidPeer
+	}
+
+	if secret.IsZero() {
+		return nil, errZeroSecret
+	}
+
+	return peer.Copy().Multiply(secret), nil
+}
+
+// NewKeyShare returns the party's key share, from its secret and the public share of the other party, whose
+// contribution must have been verified.
+func NewKeyShare(secret *ecc.Scalar, peerPublic *ecc.Element) (*KeyShare, error) {
+	pk, err := combine(secret, peerPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyShare{
+		Secret:     secret.Copy(),
+		PeerPublic: peerPublic.Copy(),
+		PublicKey:  pk,
+	}, nil
+}
+
+// Signature is an ECDSA signature.
+type Signature struct {
+	R *ecc.Scalar
+	S *ecc.Scalar
+}
+
+// nonce returns r, the x-coordinate of the joint nonce commitment k * peerNonce reduced modulo the order.
+func nonce(k *ecc.Scalar, peerNonce *ecc.Element) (*ecc.Scalar, error) {
+	commitment, err := combine(k, peerNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return xReduced(commitment), nil
+}
+
+// xReduced returns the x-coordinate of the element, reduced modulo the group order.
+func xReduced(e *ecc.Element) *ecc.Scalar {
+	g := e.Group()
+	order := new(big.Int).SetBytes(g.Order())
+	x := new(big.Int).SetBytes(e.XCoordinate())
+
+	r := g.NewScalar()
+	_ = r.Decode(x.Mod(x, order).FillBytes(make([]byte, g.ScalarLength())))
+
+	return r
+}
+
+// digestScalar returns the digest truncated to the bit length of the order and reduced.
+func digestScalar(g ecc.Group, digest []byte) *ecc.Scalar {
+	order := new(big.Int).SetBytes(g.Order())
+
+	i := new(big.Int).SetBytes(digest)
+	if excess := 8*len(digest) - order.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	e := g.NewScalar()
+	_ = e.Decode(i.Mod(i, order).FillBytes(make([]byte, g.ScalarLength())))
+
+	return e
+}
+
+// PartialSign is run by the second party with its key share, its nonce share k2, and the first party's verified nonce
+// contribution. It returns the encryption of k2^-1 * (e + r * x2 * x1), evaluated through the channel.
+func PartialSign(share *KeyShare, k2 *ecc.Scalar, peerNonce *ecc.Element, digest []byte, ev Evaluator) ([]byte, error) {
+	if share == nil || share.Secret == nil || ev == nil {
+		return nil, errNilInput
+	}
+
+	r, err := nonce(k2, peerNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	g := k2.Group()
+	if share.Secret.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	kInv := k2.Copy().Invert()
+	a := digestScalar(g, digest).Multiply(kInv)
+	b := r.Multiply(share.Secret).Multiply(kInv)
+
+	return ev.Evaluate(a, b)
+}
+
+// Sign is run by the first party with its key share, its nonce share k1, the second party's verified nonce
+// contribution, and its partial signature. It returns the signature, after checking that it is valid for the public
+// key, so that a malicious second party can't obtain an invalid signature that leaks information on x1.
+func Sign(
+	share *KeyShare,
+	k1 *ecc.Scalar,
+	peerNonce *ecc.Element,
+	digest, partial []byte,
+	dec Decrypter,
+) (*Signature, error) {
+	if share == nil || share.PublicKey == nil || dec == nil {
+		return nil, errNilInput
+	}
+
+	r, err := nonce(k1, peerNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sPrime, err := dec.Decrypt(partial)
+	if err != nil {
+		return nil, err
+	}
+
+	if sPrime == nil {
+		return nil, errNilInput
+	}
+
+	if sPrime.Group() != k1.Group() {
+		return nil, errGroupMismatch
+	}
+
+	sig := &Signature{
+		R: r,
+		S: k1.Copy().Invert().Multiply(sPrime),
+	}
+
+	if !Verify(share.PublicKey, digest, sig) {
+		return nil, errInvalidSignature
+	}
+
+	return sig, nil
+}
+
+// Verify returns whether the signature is a valid ECDSA signature of the digest under the public key. It runs in
+// variable time.
+func Verify(pk *ecc.Element, digest []byte, sig *Signature) bool {
+	if pk == nil || sig == nil || sig.R == nil || sig.S == nil {
+		return false
+	}
+
+	g := pk.Group()
+	if checkGroup(g) != nil || sig.R.Group() != g || sig.S.Group() != g ||
+		pk.IsIdentity() || sig.R.IsZero() || sig.S.IsZero() {
+		return false
+	}
+
+	sInv := sig.S.Copy().Invert()
+	u1 := digestScalar(g, digest).Multiply(sInv)
+	u2 := sig.R.Copy().Multiply(sInv)
+
+	x := g.DoubleScalarMultVartime(u1, g.Base(), u2, pk)
+	if x.IsIdentity() {
+		return false
+	}
+
+	return xReduced(x).Equal(sig.R)
+}
+
+// Encode returns the byte encoding of the signature, i.e. the concatenation of r and s.
+func (s *Signature) Encode() []byte {
+	return append(s.R.Encode(), s.S.Encode()...)
+}
+
+// Decode sets the signature to the decoding of the input, for the given group, and returns an error on failure.
+func (s *Signature) Decode(g ecc.Group, data []byte) error {
+	sLen := g.ScalarLength()
+	if len(data) != 2*sLen {
+		return errSignatureLength
+	}
+
+	r, sc := g.NewScalar(), g.NewScalar()
+	if err := r.DecodeCanonical(data[:sLen]); err != nil {
+		return err
+	}
+
+	if err := sc.DecodeCanonical(data[sLen:]); err != nil {
+		return err
+	}
+
+	s.R, s.S = r, sc
+
+	return nil
+}
@@ -9,12 +9,23 @@
 package ecc
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/bytemare/ecc/internal"
 )
 
+// stringHexLength is the number of hexadecimal characters of the encoding shown by Element.String.
+const stringHexLength = 10
+
+var (
+	errNoDecodeFromX  = errors.New("x-coordinate decoding is only available for NIST groups, secp256k1, and Pallas")
+	errNoUncompressed = errors.New("the uncompressed encoding is only available for the NIST groups and secp256k1")
+)
+
 // Element represents an element on the curve of the prime-order group.
 type Element struct {
 	_ disallowEqual
@@ -48,19 +59,19 @@ func (e *Element) Add(element *Element) *Element {
 
 	e.Element.Add(element.Element)
 
-	return e
+	return debugElement(e, "Add")
 }
 
 // Double sets the receiver to its double, and returns it.
 func (e *Element) Double() *Element {
 	e.Element.Double()
-	return e
+	return debugElement(e, "Double")
 }
 
 // Negate sets the receiver to its negation, and returns it.
 func (e *Element) Negate() *Element {
 	e.Element.Negate()
-	return e
+	return debugElement(e, "Negate")
 }
 
 // Subtract subtracts the input from the receiver, and returns the receiver.
@@ -71,7 +82,7 @@ func (e *Element) Subtract(element *Element) *Element {
 
 	e.Element.Subtract(element.Element)
 
-	return e
+	return debugElement(e, "Subtract")
 }
 
 // Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
@@ -81,9 +92,10 @@ func (e *Element) Multiply(scalar *Scalar) *Element {
 		return e
 	}
 
+	recordOperation(e.Group(), OpElementMultiply)
 	e.Element.Multiply(scalar.Scalar)
 
-	return e
+	return debugElement(e, "Multiply")
 }
 
 // Equal returns true if the elements are equivalent, and false otherwise.
@@ -95,11 +107,69 @@ func (e *Element) Equal(element *Element) bool {
 	return e.Element.Equal(element.Element) == 1
 }
 
+// EqualCT returns 1 if the elements are equivalent, and 0 otherwise, in constant time in all groups. The result can be
+// directly combined with the crypto/subtle functions, e.g. to select between values without branching on secrets.
+// A nil argument is never equal.
+func (e *Element) EqualCT(element *Element) int {
+	if element == nil {
+		return 0
+	}
+
+	return e.Element.Equal(element.Element)
+}
+
+// EqualBytes returns whether the encoded input is the encoding of the receiver, e.g. to match a wire-format public key
+// against an element, without decoding it. Since encodings are canonical, this is equivalent to decoding and comparing,
+// and the comparison is in constant time. Only the compressed encoding of Encode matches, and invalid encodings never
+// do.
+func (e *Element) EqualBytes(encoded []byte) bool {
+	return subtle.ConstantTimeCompare(e.Encode(), encoded) == 1
+}
+
+// EqualAny returns whether the receiver is equal to any of the elements. Nil elements and elements of other groups are
+// never equal. The receiver is encoded once and compared in constant time to the encodings of all elements, so the
+// running time doesn't depend on which one matches.
+func (e *Element) EqualAny(elements []*Element) bool {
+	g := e.Group()
+	enc := e.Encode()
+	found := 0
+
+	for _, element := range elements {
+		if element == nil || element.Group() != g {
+			continue
+		}
+
+		found |= subtle.ConstantTimeCompare(enc, element.Encode())
+	}
+
+	return found == 1
+}
+
 // IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
 func (e *Element) IsIdentity() bool {
 	return e.Element.IsIdentity()
 }
 
+// IsIdentityCT returns 1 if the Element is the point at infinity of the Group's underlying curve, and 0 otherwise, in
+// constant time in all groups, like EqualCT.
+func (e *Element) IsIdentityCT() int {
+	return e.Element.Equal(e.Element.Copy().Identity())
+}
+
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup. Decoded and hashed elements always
+// do, but this allows protocols to state their small-subgroup policy explicitly. It always returns true in
+// prime-order groups.
+func (e *Element) IsTorsionFree() bool {
+	return e.Element.IsTorsionFree()
+}
+
+// ClearCofactor sets the receiver to its multiplication by the group's cofactor, and returns it. For edwards25519,
+// this multiplies by 8, which maps any point to the prime-order subgroup. It is a no-op in prime-order groups.
+func (e *Element) ClearCofactor() *Element {
+	e.Element.ClearCofactor()
+	return debugElement(e, "ClearCofactor")
+}
+
 // Set sets the receiver to the argument, and returns the receiver.
 func (e *Element) Set(element *Element) *Element {
 	if element == nil {
@@ -123,34 +193,199 @@ func (e *Element) Encode() []byte {
 	return e.Element.Encode()
 }
 
+// EncodeUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the element, of
+// UncompressedElementLength bytes, for systems that mandate it. It returns an error if the element is the identity, which
+// has no such encoding, or if the group has no uncompressed encoding, i.e. is not a NIST group or secp256k1.
+func (e *Element) EncodeUncompressed() ([]byte, error) {
+	c, ok := e.Element.(internal.UncompressedCodec)
+	if !ok {
+		return nil, errNoUncompressed
+	}
+
+	if e.IsIdentity() {
+		return nil, ErrIdentity
+	}
+
+	return c.EncodeUncompressed(), nil
+}
+
 // XCoordinate returns the encoded x coordinate of the element.
 func (e *Element) XCoordinate() []byte {
 	return e.Element.XCoordinate()
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. Only the canonical
+// encoding of elements of the prime-order subgroup, other than the identity, is accepted.
 func (e *Element) Decode(data []byte) error {
 	if err := e.Element.Decode(data); err != nil {
-		return fmt.Errorf("element Decode: %w", err)
+		return newInvalidEncoding(e.Group(), "element Decode", err)
+	}
+
+	return nil
+}
+
+// DecodeNonIdentity sets the receiver to a decoding of the input data, and returns an error on failure or if the
+// decoded element is the identity. Rejections of the identity, including its encoding, always match ErrIdentity with
+// errors.Is, whichever the group. The receiver is not modified on failure.
+func (e *Element) DecodeNonIdentity(data []byte) error {
+	const op = "element DecodeNonIdentity"
+
+	g := e.Group()
+	if g.IsIdentityEncoding(data) {
+		return newInvalidEncoding(g, op, internal.ErrIdentity)
+	}
+
+	d := g.NewElement()
+	if err := d.Element.Decode(data); err != nil {
+		return newInvalidEncoding(g, op, err)
+	}
+
+	if d.IsIdentity() {
+		return newInvalidEncoding(g, op, internal.ErrIdentity)
+	}
+
+	e.Element.Set(d.Element)
+
+	return nil
+}
+
+// DecodeChecked sets the receiver to a decoding of the input data, and returns an error if the input is not the
+// canonical encoding of an element of the prime-order subgroup other than the identity. This holds whatever the group's
+// own decoder: the identity, including its encoding, always fails with ErrIdentity, and an input that doesn't encode
+// back to itself fails with ErrNonCanonicalEncoding. The receiver is not modified on failure.
+func (e *Element) DecodeChecked(data []byte) error {
+	const op = "element DecodeChecked"
+
+	g := e.Group()
+	if g.IsIdentityEncoding(data) {
+		return newInvalidEncoding(g, op, internal.ErrIdentity)
+	}
+
+	d := g.NewElement()
+	if err := d.Element.Decode(data); err != nil {
+		return newInvalidEncoding(g, op, err)
+	}
+
+	if d.IsIdentity() {
+		return newInvalidEncoding(g, op, internal.ErrIdentity)
+	}
+
+	if subtle.ConstantTimeCompare(d.Encode(), data) != 1 {
+		return newInvalidEncoding(g, op, internal.ErrParamNonCanonicalEncoding)
+	}
+
+	e.Element.Set(d.Element)
+
+	return nil
+}
+
+// DecodeUncompressed sets the receiver to the decoding of the SEC 1 uncompressed encoding, as returned by
+// EncodeUncompressed, and returns an error on failure, or if the group has no uncompressed encoding. It applies the
+// same checks as Decode, and the receiver is not modified on failure.
+func (e *Element) DecodeUncompressed(data []byte) error {
+	g := e.Group()
+
+	d := g.NewElement()
+	c, ok := d.Element.(internal.UncompressedCodec)
+	if !ok {
+		return errNoUncompressed
+	}
+
+	if err := c.DecodeUncompressed(data); err != nil {
+		return newInvalidEncoding(g, "element DecodeUncompressed", err)
+	}
+
+	if d.IsIdentity() {
+		return newInvalidEncoding(g, "element DecodeUncompressed", internal.ErrIdentity)
+	}
+
+	e.Element.Set(d.Element)
+
+	return nil
+}
+
+// DecodeFromX sets the receiver to the point with the given encoded x-coordinate, as returned by XCoordinate, and
+// the y-coordinate of the given parity, and returns an error on failure. It is only available for the short Weierstrass
+// groups of this package, i.e. the NIST groups, secp256k1, and Pallas, e.g. for BIP-340 x-only keys, and applies the
+// same checks as Decode. It returns an error for the other groups, including registered ones, whose element encodings
+// are unknown. The receiver is not modified on failure.
+func (e *Element) DecodeFromX(x []byte, yIsOdd bool) error {
+	g := e.Group()
+	if !g.sec1Compressed() {
+		return errNoDecodeFromX
+	}
+
+	if len(x) != g.ElementLength()-1 {
+		return newInvalidEncoding(g, "element DecodeFromX", internal.ErrDecodingInvalidLength)
+	}
+
+	header := byte(0x02)
+	if yIsOdd {
+		header = 0x03
+	}
+
+	if err := e.Element.Decode(append([]byte{header}, x...)); err != nil {
+		return newInvalidEncoding(g, "element DecodeFromX", err)
 	}
 
 	return nil
 }
 
+// sec1Compressed returns whether the group's elements are encoded as SEC 1 compressed points, i.e. 0x02 or 0x03
+// followed by the x-coordinate, on which DecodeFromX relies.
+func (g Group) sec1Compressed() bool {
+	switch g {
+	case P256Sha256, P384Sha384, P521Sha512, Secp256k1Sha256, PallasBLAKE2b512:
+		return true
+	default:
+		return false
+	}
+}
+
 // Hex returns the fixed-sized hexadecimal encoding of e.
 func (e *Element) Hex() string {
 	return e.Element.Hex()
 }
 
+// String returns the group name and the first bytes of the hexadecimal encoding of e, e.g. "P-256(03a1b2c3d4…)", for
+// logs and debugging. Use Hex for the full encoding.
+func (e *Element) String() string {
+	if e == nil || e.Element == nil {
+		return "<nil>"
+	}
+
+	if e.IsIdentity() {
+		return e.Group().Name() + "(identity)"
+	}
+
+	h := e.Hex()
+	if len(h) <= stringHexLength {
+		return e.Group().Name() + "(" + h + ")"
+	}
+
+	return e.Group().Name() + "(" + h[:stringHexLength] + "…)"
+}
+
+// GoString implements fmt.GoStringer, and returns the same as String, rather than the internal representation.
+func (e *Element) GoString() string {
+	return e.String()
+}
+
 // DecodeHex sets e to the decoding of the hex encoded element.
 func (e *Element) DecodeHex(h string) error {
 	if err := e.Element.DecodeHex(h); err != nil {
-		return fmt.Errorf("element DecodeHex: %w", err)
+		return newInvalidEncoding(e.Group(), "element DecodeHex", err)
 	}
 
 	return nil
 }
 
+// Wipe overwrites the element's internal representation in place and sets it to the identity, so that secret-derived
+// points don't linger in memory once they're no longer needed. Copies made before are not affected.
+func (e *Element) Wipe() {
+	e.Element.Wipe()
+}
+
 // MarshalJSON marshals the element into valid JSON.
 func (e *Element) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%q", e.Hex())), nil
@@ -170,8 +405,33 @@ func (e *Element) MarshalBinary() ([]byte, error) {
 // UnmarshalBinary sets e to the decoding of the byte encoded element.
 func (e *Element) UnmarshalBinary(data []byte) error {
 	if err := e.Element.Decode(data); err != nil {
-		return fmt.Errorf("element UnmarshalBinary: %w", err)
+		return newInvalidEncoding(e.Group(), "element UnmarshalBinary", err)
 	}
 
 	return nil
 }
+
+// WriteTo implements the io.WriterTo interface, and writes the encoding of the element to w, e.g. to stream it into a
+// connection or a hash.
+func (e *Element) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(e.Element.Encode())
+	return int64(n), err
+}
+
+// ReadFrom implements the io.ReaderFrom interface, and sets e to the decoding of the next ElementLength bytes of r.
+// Unlike most implementations, it doesn't read until EOF, so that consecutive elements can be read from the same stream.
+// The receiver is not modified on failure.
+func (e *Element) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, e.Group().ElementLength())
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return int64(n), err
+	}
+
+	if err = e.Element.Decode(buf); err != nil {
+		return int64(n), newInvalidEncoding(e.Group(), "element ReadFrom", err)
+	}
+
+	return int64(n), nil
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// This file implements the small subset of CBOR (RFC 8949) needed for COSE keys: maps with integer labels, and
+// integer, byte string, text string, and boolean values, with definite lengths only.
+
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborMap    = 5
+	cborSimple = 7
+
+	cborFalse = 20
+	cborTrue  = 21
+)
+
+var errCBOR = errors.New("invalid or unsupported CBOR encoding")
+
+// cborHeader appends the header of an item of the major type with the argument, in its shortest form.
+func cborHeader(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(b, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+	}
+}
+
+func cborInt(b []byte, i int64) []byte {
+	if i < 0 {
+		return cborHeader(b, cborNegInt, uint64(-1-i))
+	}
+
+	return cborHeader(b, cborUint, uint64(i))
+}
+
+func cborByteString(b, data []byte) []byte {
+	return append(cborHeader(b, cborBytes, uint64(len(data))), data...)
+}
+
+// cborEntry is a map entry with an integer label. value is an int64, a []byte, a string, or a bool.
+type cborEntry struct {
+	label int64
+	value any
+}
+
+// cborEncodeMap encodes the entries as a map, which must be given in the deterministic order of RFC 8949 section
+// 4.2.1, i.e. non-negative labels in ascending order followed by negative labels in descending order.
+func cborEncodeMap(entries []cborEntry) []byte {
+	b := cborHeader(nil, cborMap, uint64(len(entries)))
+
+	for _, e := range entries {
+		b = cborInt(b, e.label)
+
+		switch v := e.value.(type) {
+		case int64:
+			b = cborInt(b, v)
+		case []byte:
+			b = cborByteString(b, v)
+		case bool:
+			if v {
+				b = append(b, cborSimple<<5|cborTrue)
+			} else {
+				b = append(b, cborSimple<<5|cborFalse)
+			}
+		default:
+			panic("unsupported CBOR value type")
+		}
+	}
+
+	return b
+}
+
+type cborDecoder struct {
+	data []byte
+}
+
+func (d *cborDecoder) next(n uint64) ([]byte, error) {
+	if uint64(len(d.data)) < n {
+		return nil, errCBOR
+	}
+
+	b := d.data[:n]
+	d.data = d.data[n:]
+
+	return b, nil
+}
+
+// header reads the header of the next item, and returns its major type and argument.
+func (d *cborDecoder) header() (byte, uint64, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	major, info := b[0]>>5, b[0]&0x1f
+
+	var size uint64
+
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info <= 27:
+		size = 1 << (info - 24)
+	default:
+		// Indefinite lengths and reserved values.
+		return 0, 0, errCBOR
+	}
+
+	arg, err := d.next(size)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var v uint64
+	for _, a := range arg {
+		v = v<<8 | uint64(a)
+	}
+
+	return major, v, nil
+}
+
+func (d *cborDecoder) integer(major byte, arg uint64) (int64, error) {
+	if arg > math.MaxInt64 {
+		return 0, errCBOR
+	}
+
+	switch major {
+	case cborUint:
+		return int64(arg), nil
+	case cborNegInt:
+		return -1 - int64(arg), nil
+	default:
+		return 0, errCBOR
+	}
+}
+
+// value reads the next value.
+func (d *cborDecoder) value() (any, error) {
+	major, arg, err := d.header()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint, cborNegInt:
+		return d.integer(major, arg)
+	case cborBytes:
+		return d.next(arg)
+	case cborText:
+		s, err := d.next(arg)
+		return string(s), err
+	case cborSimple:
+		switch arg {
+		case cborFalse:
+			return false, nil
+		case cborTrue:
+			return true, nil
+		}
+	}
+
+	return nil, errCBOR
+}
+
+// cborDecodeMap decodes data, which must be exactly a map with unique integer labels.
+func cborDecodeMap(data []byte) (map[int64]any, error) {
+	d := &cborDecoder{data: data}
+
+	major, n, err := d.header()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each entry takes at least two bytes, which bounds the allocation.
+	if major != cborMap || n > uint64(len(d.data))/2 {
+		return nil, errCBOR
+	}
+
+	m := make(map[int64]any, n)
+
+	for range n {
+		major, arg, err := d.header()
+		if err != nil {
+			return nil, err
+		}
+
+		label, err := d.integer(major, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := m[label]; ok {
+			return nil, errCBOR
+		}
+
+		if m[label], err = d.value(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(d.data) != 0 {
+		return nil, errCBOR
+	}
+
+	return m, nil
+}
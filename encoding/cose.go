@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+// COSE key parameter labels and values, from RFC 9052, RFC 9053, and RFC 8812.
+const (
+	coseLabelKty = 1
+	coseLabelCrv = -1
+	coseLabelX   = -2
+	coseLabelY   = -3
+	coseLabelD   = -4
+
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+
+	coseCrvEd25519 = 6
+)
+
+var (
+	coseCurves = map[ecc.Group]int64{
+		ecc.P256Sha256:      1,
+		ecc.P384Sha384:      2,
+		ecc.P521Sha512:      3,
+		ecc.Secp256k1Sha256: 8,
+	}
+
+	errCOSEType      = errors.New("unsupported COSE key type or curve")
+	errCOSEParameter = errors.New("missing or invalid COSE key parameter")
+	errCOSENoPrivate = errors.New("COSE key has no private key")
+)
+
+// coseEntries returns the public parameters of the COSE key of pk.
+func coseEntries(pk *ecc.Element) ([]cborEntry, error) {
+	g := pk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		if pk.IsIdentity() {
+			return nil, ecc.ErrIdentity
+		}
+
+		return []cborEntry{
+			{coseLabelKty, int64(coseKtyOKP)},
+			{coseLabelCrv, int64(coseCrvEd25519)},
+			{coseLabelX, pk.Encode()},
+		}, nil
+	}
+
+	crv, ok := coseCurves[g]
+	if !ok {
+		return nil, errCOSEType
+	}
+
+	encoded, err := compat.MarshalUncompressed(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	coordinates := encoded[1:]
+	half := len(coordinates) / 2
+
+	return []cborEntry{
+		{coseLabelKty, int64(coseKtyEC2)},
+		{coseLabelCrv, crv},
+		{coseLabelX, coordinates[:half]},
+		{coseLabelY, coordinates[half:]},
+	}, nil
+}
+
+// MarshalCOSEPublicKey returns the deterministic CBOR encoding of the COSE_Key (RFC 9052) of the public key, as an
+// EC2 key for the NIST groups and secp256k1, and as an OKP Ed25519 key for Edwards25519.
+func MarshalCOSEPublicKey(pk *ecc.Element) ([]byte, error) {
+	entries, err := coseEntries(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return cborEncodeMap(entries), nil
+}
+
+// MarshalCOSEPrivateKey returns the deterministic CBOR encoding of the EC2 COSE_Key of the private scalar of a NIST
+// group or secp256k1, including its public key. Ed25519 keys can't be marshaled from their scalar, as their COSE_Key
+// holds the seed it is derived from.
+func MarshalCOSEPrivateKey(sk *ecc.Scalar) ([]byte, error) {
+	g := sk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errEd25519NoSeed
+	}
+
+	if _, ok := coseCurves[g]; !ok {
+		return nil, errCOSEType
+	}
+
+	if sk.IsZero() {
+		return nil, errInvalidKeyEncoding
+	}
+
+	entries, err := coseEntries(g.Base().Multiply(sk))
+	if err != nil {
+		return nil, err
+	}
+
+	return cborEncodeMap(append(entries, cborEntry{coseLabelD, sk.Encode()})), nil
+}
+
+// coseKey holds the parameters of a decoded COSE_Key.
+type coseKey struct {
+	params map[int64]any
+	group  ecc.Group
+}
+
+func decodeCOSEKey(data []byte) (*coseKey, error) {
+	params, err := cborDecodeMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	kty, ok1 := params[coseLabelKty].(int64)
+	crv, ok2 := params[coseLabelCrv].(int64)
+
+	if !ok1 || !ok2 {
+		return nil, errCOSEParameter
+	}
+
+	switch {
+	case kty == coseKtyOKP && crv == coseCrvEd25519:
+		return &coseKey{params: params, group: ecc.Edwards25519Sha512}, nil
+	case kty == coseKtyEC2:
+		for g, c := range coseCurves {
+			if c == crv {
+				return &coseKey{params: params, group: g}, nil
+			}
+		}
+	}
+
+	return nil, errCOSEType
+}
+
+func (k *coseKey) bytes(label int64) ([]byte, bool) {
+	b, ok := k.params[label].([]byte)
+	return b, ok
+}
+
+// publicKey returns the public key given by the x and y parameters.
+func (k *coseKey) publicKey() (*ecc.Element, error) {
+	x, ok := k.bytes(coseLabelX)
+	if !ok {
+		return nil, errCOSEParameter
+	}
+
+	if k.group == ecc.Edwards25519Sha512 {
+		return k.group.NewElementFromBytesNonIdentity(x)
+	}
+
+	length := k.group.ElementLength() - 1
+	if len(x) != length {
+		return nil, errCOSEParameter
+	}
+
+	var encoded []byte
+
+	// y is either the coordinate, or its sign bit for compressed points.
+	switch y := k.params[coseLabelY].(type) {
+	case []byte:
+		if len(y) != length {
+			return nil, errCOSEParameter
+		}
+
+		encoded = append(append([]byte{0x04}, x...), y...)
+	case bool:
+		prefix := byte(0x02)
+		if y {
+			prefix = 0x03
+		}
+
+		encoded = append([]byte{prefix}, x...)
+	default:
+		return nil, errCOSEParameter
+	}
+
+	return compat.ParsePoint(k.group, encoded)
+}
+
+// ParseCOSEPublicKey parses the CBOR encoding of an EC2 COSE_Key of a NIST curve or secp256k1, or of an OKP Ed25519
+// COSE_Key, and returns its public key. Compressed EC2 points, and parameters other than the key type, curve, and
+// coordinates, like the algorithm or key identifier, are accepted.
+func ParseCOSEPublicKey(data []byte) (*ecc.Element, error) {
+	key, err := decodeCOSEKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.publicKey()
+}
+
+// ParseCOSEPrivateKey parses the CBOR encoding of an EC2 or OKP COSE_Key holding a private key, and returns the
+// private scalar and the public key. If the key has public parameters, they must match the private key. For Ed25519,
+// the scalar is derived from the seed as specified in RFC 8032.
+func ParseCOSEPrivateKey(data []byte) (*ecc.Scalar, *ecc.Element, error) {
+	key, err := decodeCOSEKey(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, ok := key.bytes(coseLabelD)
+	if !ok {
+		return nil, nil, errCOSENoPrivate
+	}
+
+	var sk *ecc.Scalar
+
+	if key.group == ecc.Edwards25519Sha512 {
+		if sk, _, err = compat.Ed25519SeedToKeys(d); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if len(d) != key.group.ScalarLength() {
+			return nil, nil, errCOSEParameter
+		}
+
+		sk = key.group.NewScalar()
+		if err = sk.DecodeCanonical(d); err != nil {
+			return nil, nil, err
+		}
+
+		if sk.IsZero() {
+			return nil, nil, errInvalidKeyEncoding
+		}
+	}
+
+	pk := key.group.Base().Multiply(sk)
+
+	if _, ok = key.params[coseLabelX]; ok {
+		embedded, err := key.publicKey()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !embedded.Equal(pk) {
+			return nil, nil, errPublicKeyMismatch
+		}
+	}
+
+	return sk, pk, nil
+}
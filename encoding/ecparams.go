@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+// MarshalECParameters returns the DER encoded ECParameters of RFC 5480 (SEC 1) for the group, which must be a NIST
+// group or secp256k1, i.e. its namedCurve object identifier, as found in PKCS #11 CKA_EC_PARAMS attributes.
+func MarshalECParameters(g ecc.Group) ([]byte, error) {
+	curve, ok := oidNamedCurves[g]
+	if !ok {
+		return nil, errUnsupportedKey
+	}
+
+	der, err := asn1.Marshal(curve)
+	if err != nil {
+		return nil, fmt.Errorf("encoding curve parameters: %w", err)
+	}
+
+	return der, nil
+}
+
+// ParseECParameters parses DER encoded ECParameters and returns the group of the named curve. The implicitCurve and
+// specifiedCurve choices, which RFC 5480 forbids, are not supported.
+func ParseECParameters(der []byte) (ecc.Group, error) {
+	var curve asn1.ObjectIdentifier
+	if err := unmarshalStrict(der, &curve); err != nil {
+		return 0, err
+	}
+
+	return groupFromCurveOID(curve)
+}
+
+// MarshalECPoint returns the DER encoded ECPoint, i.e. the OCTET STRING wrapping the SEC 1 encoding of the element of
+// a NIST group or secp256k1, as found in PKCS #11 CKA_EC_POINT attributes. The point is compressed if compressed is
+// set, and uncompressed otherwise. The identity has no such encoding.
+func MarshalECPoint(e *ecc.Element, compressed bool) ([]byte, error) {
+	if _, ok := oidNamedCurves[e.Group()]; !ok {
+		return nil, errUnsupportedKey
+	}
+
+	var point []byte
+
+	switch {
+	case e.IsIdentity():
+		return nil, ecc.ErrIdentity
+	case compressed:
+		point = e.Encode()
+	default:
+		var err error
+		if point, err = compat.MarshalUncompressed(e); err != nil {
+			return nil, err
+		}
+	}
+
+	der, err := asn1.Marshal(point)
+	if err != nil {
+		return nil, fmt.Errorf("encoding point: %w", err)
+	}
+
+	return der, nil
+}
+
+// ParseECPoint parses a DER encoded ECPoint holding a compressed or uncompressed point of the group, which must be a
+// NIST group or secp256k1, and returns the element. The identity is rejected.
+func ParseECPoint(g ecc.Group, der []byte) (*ecc.Element, error) {
+	if _, ok := oidNamedCurves[g]; !ok {
+		return nil, errUnsupportedKey
+	}
+
+	var point []byte
+	if err := unmarshalStrict(der, &point); err != nil {
+		return nil, err
+	}
+
+	return compat.ParsePoint(g, point)
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+// EnvelopeVersion is the version of the envelopes produced by this package.
+const EnvelopeVersion = 1
+
+// Kinds of envelope payloads.
+const (
+	envelopeElement byte = iota + 1
+	envelopeScalar
+)
+
+// envelopeHeaderLength is the byte size of the version, group, kind, and payload length.
+const envelopeHeaderLength = 5
+
+var (
+	errEnvelopeEncoding = errors.New("invalid envelope encoding")
+	errEnvelopeVersion  = errors.New("unsupported envelope version")
+	errEnvelopeGroup    = errors.New("unavailable envelope group")
+	errEnvelopeKind     = errors.New("unexpected envelope payload kind")
+)
+
+// envelope returns version || group || kind || I2OSP(len(payload), 2) || payload.
+func envelope(g ecc.Group, kind byte, payload []byte) []byte {
+	out := make([]byte, 0, envelopeHeaderLength+len(payload))
+	out = append(out, EnvelopeVersion, byte(g), kind)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(payload)))
+
+	return append(out, payload...)
+}
+
+// openEnvelope returns the group and the payload of the envelope, and an error if it's malformed, of another version
+// or kind, or if its group is not available.
+func openEnvelope(data []byte, kind byte) (ecc.Group, []byte, error) {
+	if len(data) < envelopeHeaderLength {
+		return 0, nil, errEnvelopeEncoding
+	}
+
+	if data[0] != EnvelopeVersion {
+		return 0, nil, errEnvelopeVersion
+	}
+
+	g := ecc.Group(data[1])
+	if !g.Available() {
+		return 0, nil, errEnvelopeGroup
+	}
+
+	if data[2] != kind {
+		return 0, nil, errEnvelopeKind
+	}
+
+	payload := data[envelopeHeaderLength:]
+	if int(binary.BigEndian.Uint16(data[3:envelopeHeaderLength])) != len(payload) {
+		return 0, nil, errEnvelopeEncoding
+	}
+
+	return g, payload, nil
+}
+
+// EncodeElementEnvelope returns the element's encoding in a self-describing envelope: the envelope version, the group
+// identifier, the payload kind, and the 2-byte big-endian length of the encoding. Envelopes are meant for long-term
+// storage, where the group might not be known from the context, and its native encoding might evolve.
+func EncodeElementEnvelope(e *ecc.Element) []byte {
+	return envelope(e.Group(), envelopeElement, e.Encode())
+}
+
+// EncodeScalarEnvelope returns the scalar's encoding in an envelope, as EncodeElementEnvelope does for elements.
+func EncodeScalarEnvelope(s *ecc.Scalar) []byte {
+	return envelope(s.Group(), envelopeScalar, s.Encode())
+}
+
+// DecodeElementEnvelope returns the element in the envelope, and an error if the envelope or the element's encoding
+// is invalid, or if the envelope holds a scalar.
+func DecodeElementEnvelope(data []byte) (*ecc.Element, error) {
+	g, payload, err := openEnvelope(data, envelopeElement)
+	if err != nil {
+		return nil, err
+	}
+
+	e := g.NewElement()
+	if err = e.Decode(payload); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// DecodeScalarEnvelope returns the scalar in the envelope, and an error if the envelope or the scalar's encoding is
+// invalid, or if the envelope holds an element.
+func DecodeScalarEnvelope(data []byte) (*ecc.Scalar, error) {
+	g, payload, err := openEnvelope(data, envelopeScalar)
+	if err != nil {
+		return nil, err
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(payload); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
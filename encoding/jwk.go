@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+// JWK key types.
+const (
+	JWKTypeEC  = "EC"
+	JWKTypeOKP = "OKP"
+
+	jwkCurveEd25519 = "Ed25519"
+)
+
+var (
+	jwkCurves = map[ecc.Group]string{
+		ecc.P256Sha256:      "P-256",
+		ecc.P384Sha384:      "P-384",
+		ecc.P521Sha512:      "P-521",
+		ecc.Secp256k1Sha256: "secp256k1",
+	}
+
+	errJWKType       = errors.New("unsupported JWK key type or curve")
+	errJWKEncoding   = errors.New("invalid JWK member encoding")
+	errJWKNoPrivate  = errors.New("JWK has no private key")
+	errJWKThumbprint = errors.New("unavailable thumbprint hash function")
+)
+
+// JWK is a JSON Web Key (RFC 7517) holding an elliptic curve key, either of the "EC" key type (RFC 7518) for the NIST
+// curves and secp256k1 (RFC 8812), or of the "OKP" key type (RFC 8037) for Ed25519. Members are base64url encoded
+// without padding, and D is empty for public keys.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+}
+
+func jwkEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func jwkDecode(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errJWKEncoding, err)
+	}
+
+	return b, nil
+}
+
+// PublicJWK returns the JWK of the public key, which must belong to a NIST group, secp256k1, or Edwards25519, the
+// latter as an Ed25519 key.
+func PublicJWK(pk *ecc.Element) (*JWK, error) {
+	g := pk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		if pk.IsIdentity() {
+			return nil, ecc.ErrIdentity
+		}
+
+		return &JWK{Kty: JWKTypeOKP, Crv: jwkCurveEd25519, X: jwkEncode(pk.Encode())}, nil
+	}
+
+	crv, ok := jwkCurves[g]
+	if !ok {
+		return nil, errJWKType
+	}
+
+	encoded, err := compat.MarshalUncompressed(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	coordinates := encoded[1:]
+	half := len(coordinates) / 2
+
+	return &JWK{
+		Kty: JWKTypeEC,
+		Crv: crv,
+		X:   jwkEncode(coordinates[:half]),
+		Y:   jwkEncode(coordinates[half:]),
+	}, nil
+}
+
+// PrivateJWK returns the JWK of the private scalar of a NIST group or secp256k1, including its public key. Ed25519
+// keys can't be exported from their scalar, as their JWK holds the seed it is derived from.
+func PrivateJWK(sk *ecc.Scalar) (*JWK, error) {
+	g := sk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errEd25519NoSeed
+	}
+
+	if _, ok := jwkCurves[g]; !ok {
+		return nil, errJWKType
+	}
+
+	if sk.IsZero() {
+		return nil, errInvalidKeyEncoding
+	}
+
+	key, err := PublicJWK(g.Base().Multiply(sk))
+	if err != nil {
+		return nil, err
+	}
+
+	key.D = jwkEncode(sk.Encode())
+
+	return key, nil
+}
+
+// group returns the group of the key.
+func (k *JWK) group() (ecc.Group, error) {
+	switch k.Kty {
+	case JWKTypeOKP:
+		if k.Crv == jwkCurveEd25519 {
+			return ecc.Edwards25519Sha512, nil
+		}
+	case JWKTypeEC:
+		for g, crv := range jwkCurves {
+			if crv == k.Crv {
+				return g, nil
+			}
+		}
+	}
+
+	return 0, errJWKType
+}
+
+// PublicKey returns the public key of the JWK.
+func (k *JWK) PublicKey() (*ecc.Element, error) {
+	g, err := k.group()
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := jwkDecode(k.X)
+	if err != nil {
+		return nil, err
+	}
+
+	if g == ecc.Edwards25519Sha512 {
+		return g.NewElementFromBytesNonIdentity(x)
+	}
+
+	y, err := jwkDecode(k.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	// Coordinates must be exactly as long as the field elements, as required by RFC 7518.
+	length := g.ElementLength() - 1
+	if len(x) != length || len(y) != length {
+		return nil, errJWKEncoding
+	}
+
+	encoded := make([]byte, 0, 1+2*length)
+	encoded = append(encoded, 0x04)
+	encoded = append(encoded, x...)
+	encoded = append(encoded, y...)
+
+	return compat.ParsePoint(g, encoded)
+}
+
+// PrivateKey returns the private scalar and the public key of the JWK, and an error if the public members don't match
+// the private key. For Ed25519, the scalar is derived from the seed as specified in RFC 8032.
+func (k *JWK) PrivateKey() (*ecc.Scalar, *ecc.Element, error) {
+	if k.D == "" {
+		return nil, nil, errJWKNoPrivate
+	}
+
+	pk, err := k.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := jwkDecode(k.D)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer clear(d)
+
+	var sk *ecc.Scalar
+
+	if g := pk.Group(); g == ecc.Edwards25519Sha512 {
+		if sk, _, err = compat.Ed25519SeedToKeys(d); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if len(d) != g.ScalarLength() {
+			return nil, nil, errJWKEncoding
+		}
+
+		sk = g.NewScalar()
+		if err = sk.DecodeCanonical(d); err != nil {
+			return nil, nil, err
+		}
+
+		if sk.IsZero() {
+			return nil, nil, errInvalidKeyEncoding
+		}
+	}
+
+	if !pk.Group().Base().Multiply(sk).Equal(pk) {
+		return nil, nil, errPublicKeyMismatch
+	}
+
+	return sk, pk, nil
+}
+
+// Public returns a copy of the JWK without its private key.
+func (k *JWK) Public() *JWK {
+	return &JWK{Kty: k.Kty, Crv: k.Crv, X: k.X, Y: k.Y}
+}
+
+// Thumbprint returns the JWK thumbprint of the key (RFC 7638) with the given hash function, computed over the
+// required public members in lexicographic order.
+func (k *JWK) Thumbprint(h crypto.Hash) ([]byte, error) {
+	if !h.Available() {
+		return nil, errJWKThumbprint
+	}
+
+	if _, err := k.group(); err != nil {
+		return nil, err
+	}
+
+	// Struct fields are marshaled in order, and the members are base64url and curve names, which need no escaping.
+	var (
+		members []byte
+		err     error
+	)
+
+	if k.Kty == JWKTypeOKP {
+		members, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Crv, k.Kty, k.X})
+	} else {
+		members, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Crv, k.Kty, k.X, k.Y})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("encoding thumbprint members: %w", err)
+	}
+
+	hash := h.New()
+	_, _ = hash.Write(members)
+
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bytemare/ecc"
+)
+
+// pastaLength is the length of a Pallas point in the pasta_curves encoding.
+const pastaLength = 32
+
+var (
+	errNotPallas     = errors.New("element is not a Pallas point")
+	errPastaEncoding = errors.New("invalid pasta_curves point encoding")
+)
+
+// MarshalPallasZcash returns the 32-byte encoding of the Pallas element used by the pasta_curves crate, and hence Zcash
+// Orchard and Halo 2: the little-endian x-coordinate, with the parity of y in the most significant bit. The identity
+// is encoded as all zeroes.
+func MarshalPallasZcash(e *ecc.Element) ([]byte, error) {
+	if e.Group() != ecc.PallasBLAKE2b512 {
+		return nil, errNotPallas
+	}
+
+	// The element encoding is the sign byte followed by the big-endian x-coordinate, which is all zeroes for the
+	// identity.
+	encoded := e.Encode()
+	out := encoded[1:]
+	slices.Reverse(out)
+	out[pastaLength-1] |= (encoded[0] & 1) << 7
+
+	return out, nil
+}
+
+// ParsePallasZcash decodes a Pallas element from its pasta_curves encoding, and returns an error if the encoding is
+// not canonical or not on the curve. As in pasta_curves, all zeroes decode to the identity.
+func ParsePallasZcash(data []byte) (*ecc.Element, error) {
+	if len(data) != pastaLength {
+		return nil, errPastaEncoding
+	}
+
+	e := ecc.PallasBLAKE2b512.NewElement()
+	if subtle.ConstantTimeCompare(data, make([]byte, pastaLength)) == 1 {
+		return e, nil
+	}
+
+	// The x-coordinate is less than 2^255, which leaves the top bit for the sign of y.
+	x := slices.Clone(data)
+	yIsOdd := x[pastaLength-1]>>7 == 1
+	x[pastaLength-1] &= 0x7f
+	slices.Reverse(x)
+
+	if err := e.DecodeFromX(x, yIsOdd); err != nil {
+		return nil, fmt.Errorf("%w: %w", errPastaEncoding, err)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/pem"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+// PEM block types, as used by OpenSSL.
+const (
+	PEMTypePrivateKey   = "PRIVATE KEY"
+	PEMTypeECPrivateKey = "EC PRIVATE KEY"
+	PEMTypePublicKey    = "PUBLIC KEY"
+)
+
+var (
+	errNoPEMBlock      = errors.New("no PEM block found")
+	errPEMBlockType    = errors.New("unexpected PEM block type")
+	errPEMBlockHeaders = errors.New("encrypted PEM blocks are not supported")
+)
+
+// EncodePrivateKeyPEM returns the PEM encoded PKCS #8 private key of the scalar, in a "PRIVATE KEY" block.
+func EncodePrivateKeyPEM(sk *ecc.Scalar) ([]byte, error) {
+	der, err := MarshalPKCS8(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMTypePrivateKey, Bytes: der}), nil
+}
+
+// EncodeECPrivateKeyPEM returns the PEM encoded SEC 1 private key of the scalar, in an "EC PRIVATE KEY" block.
+func EncodeECPrivateKeyPEM(sk *ecc.Scalar) ([]byte, error) {
+	der, err := MarshalEC(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMTypeECPrivateKey, Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM returns the PEM encoded SubjectPublicKeyInfo of the public key, in a "PUBLIC KEY" block.
+func EncodePublicKeyPEM(pk *ecc.Element) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMTypePublicKey, Bytes: der}), nil
+}
+
+func decodePEM(data []byte) (*pem.Block, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoPEMBlock
+	}
+
+	if len(block.Headers) != 0 {
+		return nil, errPEMBlockHeaders
+	}
+
+	return block, nil
+}
+
+// DecodePrivateKeyPEM decodes the first PEM block of data, which must be a "PRIVATE KEY" or an "EC PRIVATE KEY"
+// block, and returns the private scalar and the public key.
+func DecodePrivateKeyPEM(data []byte) (*ecc.Scalar, *ecc.Element, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch block.Type {
+	case PEMTypePrivateKey:
+		return ParsePKCS8(block.Bytes)
+	case PEMTypeECPrivateKey:
+		return ParseEC(block.Bytes)
+	default:
+		return nil, nil, errPEMBlockType
+	}
+}
+
+// DecodePublicKeyPEM decodes the first PEM block of data, which must be a "PUBLIC KEY" block, and returns the public
+// key.
+func DecodePublicKeyPEM(data []byte) (*ecc.Element, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != PEMTypePublicKey {
+		return nil, errPEMBlockType
+	}
+
+	return ParsePKIXPublicKey(block.Bytes)
+}
@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+const ecPrivateKeyVersion = 1
+
+var (
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPublicKeyEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	oidNamedCurves = map[ecc.Group]asn1.ObjectIdentifier{
+		ecc.P256Sha256:      {1, 2, 840, 10045, 3, 1, 7},
+		ecc.P384Sha384:      {1, 3, 132, 0, 34},
+		ecc.P521Sha512:      {1, 3, 132, 0, 35},
+		ecc.Secp256k1Sha256: {1, 3, 132, 0, 10},
+	}
+
+	errUnsupportedKey     = errors.New("unsupported key algorithm or curve")
+	errInvalidKeyEncoding = errors.New("invalid key encoding")
+	errPublicKeyMismatch  = errors.New("public key doesn't match the private key")
+	errEd25519NoSeed      = errors.New("ed25519 PKCS #8 keys hold a seed, which can't be recovered from a scalar")
+)
+
+// algorithmIdentifier is the AlgorithmIdentifier structure of RFC 5280, with an optional named curve parameter.
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+// pkcs8 is the PKCS #8 PrivateKeyInfo structure of RFC 5208 and RFC 5958.
+type pkcs8 struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+}
+
+// ecPrivateKey is the ECPrivateKey structure of RFC 5915 (SEC 1).
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+func groupFromCurveOID(oid asn1.ObjectIdentifier) (ecc.Group, error) {
+	for g, curve := range oidNamedCurves {
+		if curve.Equal(oid) {
+			return g, nil
+		}
+	}
+
+	return 0, errUnsupportedKey
+}
+
+func unmarshalStrict(der []byte, v any) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidKeyEncoding, err)
+	}
+
+	if len(rest) != 0 {
+		return errInvalidKeyEncoding
+	}
+
+	return nil
+}
+
+// ParsePKCS8 parses a DER encoded PKCS #8 private key of a NIST curve, secp256k1, or Ed25519, and returns the private
+// scalar and the public key of its group. For Ed25519, the scalar is derived from the seed as specified in RFC 8032.
+func ParsePKCS8(der []byte) (*ecc.Scalar, *ecc.Element, error) {
+	var key pkcs8
+	if err := unmarshalStrict(der, &key); err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case key.Algorithm.Algorithm.Equal(oidPublicKeyEd25519):
+		var seed []byte
+		if err := unmarshalStrict(key.PrivateKey, &seed); err != nil {
+			return nil, nil, err
+		}
+
+		return compat.Ed25519SeedToKeys(seed)
+	case key.Algorithm.Algorithm.Equal(oidPublicKeyECDSA):
+		g, err := groupFromCurveOID(key.Algorithm.Parameters)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return parseEC(key.PrivateKey, g)
+	default:
+		return nil, nil, errUnsupportedKey
+	}
+}
+
+// MarshalPKCS8 returns the DER encoded PKCS #8 private key of the scalar of a NIST group or secp256k1. Ed25519 keys
+// can't be marshaled from their scalar, as their PKCS #8 encoding holds the seed it is derived from.
+func MarshalPKCS8(sk *ecc.Scalar) ([]byte, error) {
+	g := sk.Group()
+	if g == ecc.Edwards25519Sha512 {
+		return nil, errEd25519NoSeed
+	}
+
+	curve, ok := oidNamedCurves[g]
+	if !ok {
+		return nil, errUnsupportedKey
+	}
+
+	// The curve is given in the algorithm identifier, and omitted in the inner key as in crypto/x509.
+	inner, err := marshalEC(sk, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(pkcs8{
+		Version:    0,
+		Algorithm:  algorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: curve},
+		PrivateKey: inner,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding PKCS #8 key: %w", err)
+	}
+
+	return der, nil
+}
+
+// ParseEC parses a DER encoded SEC 1 (RFC 5915) private key of a NIST curve or secp256k1, and returns the private
+// scalar and the public key of its group. If the key embeds its public key, it must match the private key.
+func ParseEC(der []byte) (*ecc.Scalar, *ecc.Element, error) {
+	return parseEC(der, 0)
+}
+
+// parseEC parses an ECPrivateKey, with g the curve given by the enclosing structure, if any.
+func parseEC(der []byte, g ecc.Group) (*ecc.Scalar, *ecc.Element, error) {
+	var key ecPrivateKey
+	if err := unmarshalStrict(der, &key); err != nil {
+		return nil, nil, err
+	}
+
+	if key.Version != ecPrivateKeyVersion {
+		return nil, nil, errInvalidKeyEncoding
+	}
+
+	if len(key.NamedCurveOID) != 0 {
+		curve, err := groupFromCurveOID(key.NamedCurveOID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if g != 0 && g != curve {
+			return nil, nil, errInvalidKeyEncoding
+		}
+
+		g = curve
+	}
+
+	if g == 0 || len(key.PrivateKey) > g.ScalarLength() {
+		return nil, nil, errInvalidKeyEncoding
+	}
+
+	// Some encoders strip the leading zeroes of the private key.
+	encoded := make([]byte, g.ScalarLength())
+	copy(encoded[g.ScalarLength()-len(key.PrivateKey):], key.PrivateKey)
+
+	sk := g.NewScalar()
+	if err := sk.DecodeCanonical(encoded); err != nil {
+		return nil, nil, err
+	}
+
+	clear(encoded)
+
+	if sk.IsZero() {
+		return nil, nil, errInvalidKeyEncoding
+	}
+
+	pk := g.Base().Multiply(sk)
+
+	if key.PublicKey.BitLength != 0 {
+		embedded, err := compat.ParsePoint(g, key.PublicKey.RightAlign())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !embedded.Equal(pk) {
+			return nil, nil, errPublicKeyMismatch
+		}
+	}
+
+	return sk, pk, nil
+}
+
+// MarshalEC returns the DER encoded SEC 1 (RFC 5915) private key of the scalar of a NIST group or secp256k1,
+// including the named curve and the uncompressed public key.
+func MarshalEC(sk *ecc.Scalar) ([]byte, error) {
+	curve, ok := oidNamedCurves[sk.Group()]
+	if !ok {
+		return nil, errUnsupportedKey
+	}
+
+	return marshalEC(sk, curve)
+}
+
+func marshalEC(sk *ecc.Scalar, curve asn1.ObjectIdentifier) ([]byte, error) {
+	if sk.IsZero() {
+		return nil, errInvalidKeyEncoding
+	}
+
+	pk, err := compat.MarshalUncompressed(sk.Group().Base().Multiply(sk))
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       ecPrivateKeyVersion,
+		PrivateKey:    sk.Encode(),
+		NamedCurveOID: curve,
+		PublicKey:     asn1.BitString{Bytes: pk, BitLength: 8 * len(pk)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding EC key: %w", err)
+	}
+
+	return der, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure of RFC 5280.
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalPKIXPublicKey returns the DER encoded SubjectPublicKeyInfo of the public key of a NIST group, secp256k1, or
+// Edwards25519, the latter as an Ed25519 key. The points of the short Weierstrass groups are uncompressed.
+func MarshalPKIXPublicKey(pk *ecc.Element) ([]byte, error) {
+	var (
+		info subjectPublicKeyInfo
+		key  []byte
+		err  error
+	)
+
+	switch g := pk.Group(); g {
+	case ecc.Edwards25519Sha512:
+		if pk.IsIdentity() {
+			return nil, ecc.ErrIdentity
+		}
+
+		info.Algorithm.Algorithm = oidPublicKeyEd25519
+		key = pk.Encode()
+	default:
+		curve, ok := oidNamedCurves[g]
+		if !ok {
+			return nil, errUnsupportedKey
+		}
+
+		if key, err = compat.MarshalUncompressed(pk); err != nil {
+			return nil, err
+		}
+
+		info.Algorithm = algorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: curve}
+	}
+
+	info.PublicKey = asn1.BitString{Bytes: key, BitLength: 8 * len(key)}
+
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("encoding public key: %w", err)
+	}
+
+	return der, nil
+}
+
+// ParsePKIXPublicKey parses a DER encoded SubjectPublicKeyInfo of a NIST curve, secp256k1, or Ed25519 public key, and
+// returns it as an element of its group.
+func ParsePKIXPublicKey(der []byte) (*ecc.Element, error) {
+	var info subjectPublicKeyInfo
+	if err := unmarshalStrict(der, &info); err != nil {
+		return nil, err
+	}
+
+	key := info.PublicKey.RightAlign()
+
+	switch {
+	case info.Algorithm.Algorithm.Equal(oidPublicKeyEd25519):
+		return ecc.Edwards25519Sha512.NewElementFromBytesNonIdentity(key)
+	case info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA):
+		g, err := groupFromCurveOID(info.Algorithm.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		return compat.ParsePoint(g, key)
+	default:
+		return nil, errUnsupportedKey
+	}
+}
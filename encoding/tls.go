@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/ecdh"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+// NamedGroup is a TLS 1.3 NamedGroup code point (RFC 8446, section 4.2.7).
+type NamedGroup uint16
+
+// NamedGroup values of the supported groups.
+const (
+	Secp256r1 NamedGroup = 0x0017
+	Secp384r1 NamedGroup = 0x0018
+	Secp521r1 NamedGroup = 0x0019
+	X25519    NamedGroup = 0x001d
+)
+
+var (
+	tlsGroups = map[NamedGroup]ecc.Group{
+		Secp256r1: ecc.P256Sha256,
+		Secp384r1: ecc.P384Sha384,
+		Secp521r1: ecc.P521Sha512,
+		X25519:    ecc.Edwards25519Sha512,
+	}
+
+	errTLSGroup          = errors.New("unsupported TLS named group")
+	errKeyShareEncoding  = errors.New("invalid key_share encoding")
+	errKeyShareDuplicate = errors.New("duplicate named group in key_share")
+)
+
+// TLSNamedGroup returns the TLS NamedGroup for ECDHE over the group. Edwards25519 maps to X25519, over the
+// birationally equivalent Montgomery curve.
+func TLSNamedGroup(g ecc.Group) (NamedGroup, error) {
+	for n, group := range tlsGroups {
+		if group == g {
+			return n, nil
+		}
+	}
+
+	return 0, errTLSGroup
+}
+
+// Group returns the group of the named group.
+func (n NamedGroup) Group() (ecc.Group, error) {
+	g, ok := tlsGroups[n]
+	if !ok {
+		return 0, errTLSGroup
+	}
+
+	return g, nil
+}
+
+// KeyShareEntry is a TLS 1.3 KeyShareEntry (RFC 8446, section 4.2.8): a named group and the ECDHE public value, which
+// is an uncompressed point for the NIST curves, and a u-coordinate for X25519.
+type KeyShareEntry struct {
+	KeyExchange []byte
+	Group       NamedGroup
+}
+
+// NewKeyShareEntry returns the key share entry of the public key, which must belong to a NIST group or Edwards25519.
+func NewKeyShareEntry(pk *ecc.Element) (*KeyShareEntry, error) {
+	n, err := TLSNamedGroup(pk.Group())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := compat.ElementToECDHPublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyShareEntry{Group: n, KeyExchange: key.Bytes()}, nil
+}
+
+// Marshal returns the wire encoding of the entry.
+func (k *KeyShareEntry) Marshal() []byte {
+	out := make([]byte, 0, 4+len(k.KeyExchange))
+	out = binary.BigEndian.AppendUint16(out, uint16(k.Group))
+	out = binary.BigEndian.AppendUint16(out, uint16(len(k.KeyExchange)))
+
+	return append(out, k.KeyExchange...)
+}
+
+// PublicKey returns the key exchange value as a crypto/ecdh public key, and an error if it is not a valid public key
+// of the named group.
+func (k *KeyShareEntry) PublicKey() (*ecdh.PublicKey, error) {
+	g, err := k.Group.Group()
+	if err != nil {
+		return nil, err
+	}
+
+	curve, err := compat.ECDHCurve(g)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := curve.NewPublicKey(k.KeyExchange)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errKeyShareEncoding, err)
+	}
+
+	return key, nil
+}
+
+// Element returns the key exchange value of a NIST curve entry as an element. X25519 values are u-coordinates, which
+// can't be mapped back to Edwards25519 elements, and must be used through PublicKey instead.
+func (k *KeyShareEntry) Element() (*ecc.Element, error) {
+	key, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	g, _ := k.Group.Group()
+
+	return compat.ECDHPublicKeyToElement(g, key)
+}
+
+// ParseKeyShareEntry parses a key share entry at the start of data, and returns it with the remaining bytes. The key
+// exchange value is not validated, so that entries of unsupported groups can be skipped.
+func ParseKeyShareEntry(data []byte) (*KeyShareEntry, []byte, error) {
+	if len(data) < 4 {
+		return nil, nil, errKeyShareEncoding
+	}
+
+	n := NamedGroup(binary.BigEndian.Uint16(data))
+	length := int(binary.BigEndian.Uint16(data[2:]))
+	data = data[4:]
+
+	if length == 0 || len(data) < length {
+		return nil, nil, errKeyShareEncoding
+	}
+
+	return &KeyShareEntry{Group: n, KeyExchange: append([]byte(nil), data[:length]...)}, data[length:], nil
+}
+
+// MarshalClientShares returns the client_shares vector of a KeyShareClientHello, holding the entries in the given
+// order of preference.
+func MarshalClientShares(entries ...*KeyShareEntry) ([]byte, error) {
+	var shares []byte
+	for _, e := range entries {
+		shares = append(shares, e.Marshal()...)
+	}
+
+	if len(shares) > math.MaxUint16 {
+		return nil, errKeyShareEncoding
+	}
+
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(shares))), shares...), nil
+}
+
+// ParseClientShares parses the client_shares vector of a KeyShareClientHello, and returns an error if it is malformed
+// or if a named group appears more than once, as forbidden by RFC 8446.
+func ParseClientShares(data []byte) ([]*KeyShareEntry, error) {
+	if len(data) < 2 || int(binary.BigEndian.Uint16(data)) != len(data)-2 {
+		return nil, errKeyShareEncoding
+	}
+
+	var (
+		entries []*KeyShareEntry
+		seen    = make(map[NamedGroup]bool)
+	)
+
+	for data = data[2:]; len(data) != 0; {
+		var (
+			e   *KeyShareEntry
+			err error
+		)
+
+		if e, data, err = ParseKeyShareEntry(data); err != nil {
+			return nil, err
+		}
+
+		if seen[e.Group] {
+			return nil, errKeyShareDuplicate
+		}
+
+		seen[e.Group] = true
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// Sentinel errors, to be matched with errors.Is against the errors returned by this package.
+var (
+	// ErrInvalidGroup indicates usage of an unavailable or invalid group.
+	ErrInvalidGroup = internal.ErrInvalidGroup
+
+	// ErrIdentity indicates that the identity element has been encountered.
+	ErrIdentity = internal.ErrIdentity
+
+	// ErrInvalidPointEncoding indicates that the input is not the encoding of an element of the group.
+	ErrInvalidPointEncoding = internal.ErrParamInvalidPointEncoding
+
+	// ErrNonCanonicalEncoding indicates that an element encoding is valid but not the canonical one.
+	ErrNonCanonicalEncoding = internal.ErrParamNonCanonicalEncoding
+
+	// ErrNotInSubgroup indicates that a decoded point is not in the prime-order subgroup.
+	ErrNotInSubgroup = internal.ErrParamNotInSubgroup
+
+	// ErrScalarLength indicates an invalid scalar encoding length.
+	ErrScalarLength = internal.ErrParamScalarLength
+
+	// ErrInvalidScalarEncoding indicates an invalid scalar encoding, or a value greater or equal to the group order.
+	ErrInvalidScalarEncoding = internal.ErrParamScalarInvalidEncoding
+
+	// ErrZeroScalar indicates that the scalar 0 has been encountered where it is not allowed.
+	ErrZeroScalar = internal.ErrParamZeroScalar
+
+	// ErrSelfTest indicates that a group failed its known-answer self tests.
+	ErrSelfTest = internal.ErrSelfTest
+
+	// ErrShortDST indicates a DST shorter than 16 bytes, which is rejected by ValidateDST and the WithStrictDST option.
+	ErrShortDST = internal.ErrShortDST
+
+	// ErrUnknownSuite indicates a hash-to-curve suite identifier that doesn't match any available group.
+	ErrUnknownSuite = internal.ErrUnknownSuite
+)
+
+// ErrInvalidEncoding is returned when decoding an element or a scalar fails. Reason holds the cause, which can be
+// matched with errors.Is against the sentinel errors of this package.
+type ErrInvalidEncoding struct {
+	// Reason is the underlying cause of the failure.
+	Reason error
+
+	// Group is the group the input was decoded for.
+	Group Group
+}
+
+// Error implements the error interface.
+func (e *ErrInvalidEncoding) Error() string {
+	return e.Reason.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ErrInvalidEncoding) Unwrap() error {
+	return e.Reason
+}
+
+// ErrWrongGroup is returned when an operation is given a scalar or an element from a different group than expected.
+type ErrWrongGroup struct {
+	// Expected is the group of the receiver.
+	Expected Group
+
+	// Got is the group of the offending argument.
+	Got Group
+}
+
+// Error implements the error interface.
+func (e *ErrWrongGroup) Error() string {
+	return fmt.Sprintf("wrong group: expected group %d, got group %d", e.Expected, e.Got)
+}
+
+// Is returns true if target is an ErrWrongGroup, regardless of its groups, so that any group mismatch can be matched
+// with errors.Is(err, &ErrWrongGroup{}).
+func (e *ErrWrongGroup) Is(target error) bool {
+	_, ok := target.(*ErrWrongGroup)
+	return ok
+}
+
+func newInvalidEncoding(g Group, op string, err error) error {
+	return fmt.Errorf("%s: %w", op, &ErrInvalidEncoding{Reason: err, Group: g})
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ethereum provides Ethereum-specific helpers over the Secp256k1 group, like Keccak-256 address derivation,
+// and recoverable [R || S || V] signatures with EIP-2 low-S values.
+package ethereum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// AddressLength is the byte size of Ethereum addresses.
+	AddressLength = 20
+
+	// HashLength is the byte size of the message hashes that are signed.
+	HashLength = 32
+
+	// SignatureLength is the byte size of [R || S || V] signatures.
+	SignatureLength = 65
+
+	// legacyV is the offset of the recovery identifier in pre-EIP-155 signatures.
+	legacyV = 27
+
+	signerNonceDST     = "ECC-Ethereum-V01-SignerNonce"
+	signerNonceEntropy = 32
+)
+
+var (
+	errNotSecp256k1    = errors.New("input is not in the Secp256k1 group")
+	errZeroKey         = errors.New("private key is zero")
+	errHashLength      = errors.New("invalid message hash length")
+	errSignatureLength = errors.New("invalid signature length")
+	errRecoveryID      = errors.New("invalid signature recovery identifier")
+	errHighS           = errors.New("signature s value is higher than half the group order")
+	errInvalidSig      = errors.New("invalid signature")
+
+	// halfOrder is the big-endian encoding of (n - 1) / 2, the highest s value allowed by EIP-2.
+	halfOrder = func() []byte {
+		n := new(big.Int).SetBytes(ecc.Secp256k1Sha256.Order())
+		return n.Rsh(n, 1).FillBytes(make([]byte, HashLength))
+	}()
+)
+
+// Keccak256 returns the Keccak-256 hash of the concatenated inputs, as used in Ethereum, which differs from SHA3-256
+// in its padding.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// Address returns the 20-byte address of the public key, i.e. the last 20 bytes of the Keccak-256 hash of its
+// uncompressed X || Y coordinates.
+func Address(pk *ecc.Element) ([]byte, error) {
+	if pk.Group() != ecc.Secp256k1Sha256 {
+		return nil, errNotSecp256k1
+	}
+
+	enc, err := compat.MarshalUncompressed(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256(enc[1:])[HashLength-AddressLength:], nil
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case hexadecimal encoding of the address, with the 0x prefix.
+func ChecksumAddress(address []byte) string {
+	h := []byte(hex.EncodeToString(address))
+	digest := Keccak256(h)
+
+	for i, c := range h {
+		if c >= 'a' && (digest[i/2]>>(4*(1-i%2)))&0xf >= 8 {
+			h[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(h)
+}
+
+// IsLowS returns whether the signature value s is at most half the group order, as required by EIP-2.
+func IsLowS(s *ecc.Scalar) bool {
+	return bytes.Compare(s.Encode(), halfOrder) <= 0
+}
+
+// Sign returns the 65-byte [R || S || V] signature of the 32-byte message hash, with a low s value and the recovery
+// identifier V in {0, 1}. The nonce is derived from the key, the hash, and fresh randomness.
+func Sign(sk *ecc.Scalar, hash []byte) ([]byte, error) {
+	if sk.Group() != ecc.Secp256k1Sha256 {
+		return nil, errNotSecp256k1
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	if len(hash) != HashLength {
+		return nil, errHashLength
+	}
+
+	g := ecc.Secp256k1Sha256
+	e := hashToScalar(hash)
+
+	for {
+		k := nonce(sk, hash)
+		commitment := g.Base().Multiply(k)
+		x := commitment.XCoordinate()
+
+		// Only recovery identifiers 0 and 1 are used, so x must be a valid scalar.
+		r := g.NewScalar()
+		if err := r.Decode(x); err != nil || r.IsZero() {
+			continue
+		}
+
+		// s = (e + r * d) / k
+		s := r.Copy().Multiply(sk).Add(e).Multiply(k.Invert())
+		k.Wipe()
+
+		if s.IsZero() {
+			continue
+		}
+
+		v := commitment.Encode()[0] & 1
+		if !IsLowS(s) {
+			s = ecc.Secp256k1Sha256.NewScalar().Subtract(s)
+			v ^= 1
+		}
+
+		return append(append(r.Encode(), s.Encode()...), v), nil
+	}
+}
+
+func nonce(sk *ecc.Scalar, hash []byte) *ecc.Scalar {
+	data := make([]byte, 0, signerNonceEntropy+sk.Group().ScalarLength()+len(hash))
+	data = append(data, internal.RandomBytes(signerNonceEntropy)...)
+	data = append(data, sk.Encode()...)
+	data = append(data, hash...)
+
+	return ecc.Secp256k1Sha256.HashToScalar(data, []byte(signerNonceDST))
+}
+
+// hashToScalar returns the message hash reduced modulo the group order.
+func hashToScalar(hash []byte) *ecc.Scalar {
+	i := new(big.Int).SetBytes(hash)
+	i.Mod(i, new(big.Int).SetBytes(ecc.Secp256k1Sha256.Order()))
+
+	e := ecc.Secp256k1Sha256.NewScalar()
+	_ = e.Decode(i.FillBytes(make([]byte, HashLength)))
+
+	return e
+}
+
+// ToLowS returns a copy of the [R || S || V] signature with s replaced by n - s and V flipped if s is higher than
+// half the group order, so that malleated signatures are accepted by EIP-2 verifiers.
+func ToLowS(sig []byte) ([]byte, error) {
+	r, s, v, err := parseSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsLowS(s) {
+		s = ecc.Secp256k1Sha256.NewScalar().Subtract(s)
+		v ^= 1
+	}
+
+	return append(append(r.Encode(), s.Encode()...), v), nil
+}
+
+// parseSignature returns the non-zero r and s, and the recovery identifier in {0, 1}, of the [R || S || V]
+// signature. V can also be in the legacy {27, 28} range.
+func parseSignature(sig []byte) (r, s *ecc.Scalar, v byte, err error) {
+	if len(sig) != SignatureLength {
+		return nil, nil, 0, errSignatureLength
+	}
+
+	v = sig[SignatureLength-1]
+	if v >= legacyV {
+		v -= legacyV
+	}
+
+	if v > 1 {
+		return nil, nil, 0, errRecoveryID
+	}
+
+	g := ecc.Secp256k1Sha256
+	r, s = g.NewScalar(), g.NewScalar()
+
+	if r.Decode(sig[:HashLength]) != nil || s.Decode(sig[HashLength:2*HashLength]) != nil || r.IsZero() ||
+		s.IsZero() {
+		return nil, nil, 0, errInvalidSig
+	}
+
+	return r, s, v, nil
+}
+
+// Recover returns the public key that produced the [R || S || V] signature of the 32-byte message hash, like the
+// ecrecover precompile. Signatures with a high s value are rejected, as mandated by EIP-2.
+func Recover(hash, sig []byte) (*ecc.Element, error) {
+	if len(hash) != HashLength {
+		return nil, errHashLength
+	}
+
+	r, s, v, err := parseSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsLowS(s) {
+		return nil, errHighS
+	}
+
+	g := ecc.Secp256k1Sha256
+
+	commitment := g.NewElement()
+	if err = commitment.DecodeFromX(r.Encode(), v == 1); err != nil {
+		return nil, errInvalidSig
+	}
+
+	// Q = (s * R - e * G) / r
+	rInv := r.Invert()
+	e := hashToScalar(hash)
+	pk := commitment.Multiply(s.Multiply(rInv)).Subtract(g.Base().Multiply(e.Multiply(rInv)))
+
+	if pk.IsIdentity() {
+		return nil, errInvalidSig
+	}
+
+	return pk, nil
+}
+
+// Verify returns nil if the [R || S || V] signature of the 32-byte message hash is valid for the public key, and has
+// a low s value.
+func Verify(pk *ecc.Element, hash, sig []byte) error {
+	if pk.Group() != ecc.Secp256k1Sha256 {
+		return errNotSecp256k1
+	}
+
+	recovered, err := Recover(hash, sig)
+	if err != nil {
+		return err
+	}
+
+	if !recovered.Equal(pk) {
+		return errInvalidSig
+	}
+
+	return nil
+}
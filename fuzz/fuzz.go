@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package fuzz exports the fuzz harnesses of the ecc package, so that they can be run in other fuzzing fleets, and
+// against other build tags (e.g. ecc_nobig or ecc_debug). Go only runs fuzz targets declared in test files, so each
+// harness is registered from a _test.go file:
+//
+//	func FuzzDecodeElement(f *testing.F) { fuzz.FuzzDecodeElement(f) }
+//
+// The harnesses seed the corpus with valid encodings in every available group, and fail on panics or on violated
+// properties, e.g. a successful decoding that doesn't re-encode to the input.
+package fuzz
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+// groups returns the available groups.
+func groups() []ecc.Group {
+	var g []ecc.Group
+
+	for id := range byte(0xff) {
+		if ecc.Group(id).Available() {
+			g = append(g, ecc.Group(id))
+		}
+	}
+
+	return g
+}
+
+// FuzzDecodeElement fuzzes element decoding in all groups. A successful decoding must be canonical, i.e. re-encode to
+// the input, and yield an element in the prime-order subgroup.
+func FuzzDecodeElement(f *testing.F) {
+	for _, g := range groups() {
+		f.Add(byte(g), g.Base().Encode())
+		f.Add(byte(g), g.Base().Multiply(g.NewScalar().Random()).Encode())
+		f.Add(byte(g), g.NewElement().Encode())
+	}
+
+	f.Fuzz(func(t *testing.T, id byte, data []byte) {
+		g := ecc.Group(id)
+		if !g.Available() {
+			return
+		}
+
+		e := g.NewElement()
+		if err := e.Decode(data); err != nil {
+			return
+		}
+
+		if !bytes.Equal(e.Encode(), data) {
+			t.Fatalf("%s: decoded element doesn't re-encode to the input %x", g.Name(), data)
+		}
+
+		if !e.IsTorsionFree() {
+			t.Fatalf("%s: decoded element %x is not in the prime-order subgroup", g.Name(), data)
+		}
+
+		if binary := g.NewElement(); binary.UnmarshalBinary(data) != nil || !binary.Equal(e) {
+			t.Fatalf("%s: UnmarshalBinary and Decode differ for %x", g.Name(), data)
+		}
+	})
+}
+
+// FuzzDecodeScalar fuzzes scalar decoding in all groups. A successful decoding must re-encode to the input, and be
+// consistent with the group's arithmetic.
+func FuzzDecodeScalar(f *testing.F) {
+	for _, g := range groups() {
+		f.Add(byte(g), g.NewScalar().Random().Encode())
+		f.Add(byte(g), g.NewScalar().MinusOne().Encode())
+		f.Add(byte(g), g.NewScalar().Encode())
+		f.Add(byte(g), g.Order())
+	}
+
+	f.Fuzz(func(t *testing.T, id byte, data []byte) {
+		g := ecc.Group(id)
+		if !g.Available() {
+			return
+		}
+
+		s := g.NewScalar()
+		if err := s.Decode(data); err != nil {
+			return
+		}
+
+		if !bytes.Equal(s.Encode(), data) {
+			t.Fatalf("%s: decoded scalar doesn't re-encode to the input %x", g.Name(), data)
+		}
+
+		if !s.Copy().Add(g.NewScalar().One()).Subtract(g.NewScalar().One()).Equal(s) {
+			t.Fatalf("%s: s + 1 - 1 != s for %x", g.Name(), data)
+		}
+
+		if !s.IsZero() && !s.Copy().Invert().Multiply(s).Equal(g.NewScalar().One()) {
+			t.Fatalf("%s: s * 1/s != 1 for %x", g.Name(), data)
+		}
+	})
+}
+
+// FuzzDecodeHex fuzzes the hexadecimal decoding of elements and scalars in all groups, which must decode the same as
+// the bytes of the hexadecimal string.
+func FuzzDecodeHex(f *testing.F) {
+	for _, g := range groups() {
+		f.Add(byte(g), g.Base().Hex())
+		f.Add(byte(g), g.NewScalar().Random().Hex())
+	}
+
+	f.Fuzz(func(t *testing.T, id byte, h string) {
+		g := ecc.Group(id)
+		if !g.Available() {
+			return
+		}
+
+		data, hexErr := hex.DecodeString(h)
+
+		e := g.NewElement()
+		if err := e.DecodeHex(h); err == nil {
+			if hexErr != nil || !bytes.Equal(e.Encode(), data) {
+				t.Fatalf("%s: DecodeHex accepted %q, which doesn't match Decode", g.Name(), h)
+			}
+		}
+
+		s := g.NewScalar()
+		if err := s.DecodeHex(h); err == nil {
+			if hexErr != nil || !bytes.Equal(s.Encode(), data) {
+				t.Fatalf("%s: scalar DecodeHex accepted %q, which doesn't match Decode", g.Name(), h)
+			}
+		}
+	})
+}
+
+// FuzzHashToCurve fuzzes hash-to-scalar and hash-to-curve in all groups, for DSTs accepted by ValidateDST. The results
+// must be deterministic, and elements must be in the prime-order subgroup.
+func FuzzHashToCurve(f *testing.F) {
+	for _, g := range groups() {
+		f.Add(byte(g), []byte("input"), g.MakeDST("fuzz", 1))
+	}
+
+	f.Fuzz(func(t *testing.T, id byte, input, dst []byte) {
+		g := ecc.Group(id)
+		if !g.Available() || ecc.ValidateDST(dst) != nil {
+			return
+		}
+
+		if s := g.HashToScalar(input, dst); !s.Equal(g.HashToScalar(input, dst)) {
+			t.Fatalf("%s: HashToScalar is not deterministic", g.Name())
+		}
+
+		for name, h := range map[string]func(input, dst []byte) *ecc.Element{
+			"HashToGroup":   g.HashToGroup,
+			"EncodeToGroup": g.EncodeToGroup,
+		} {
+			e := h(input, dst)
+			if !e.IsTorsionFree() {
+				t.Fatalf("%s: %s yielded an element out of the prime-order subgroup", g.Name(), name)
+			}
+
+			if !e.Equal(h(input, dst)) {
+				t.Fatalf("%s: %s is not deterministic", g.Name(), name)
+			}
+		}
+	})
+}
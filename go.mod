@@ -5,16 +5,14 @@ go 1.24.2
 require (
 	filippo.io/edwards25519 v1.1.0
 	filippo.io/nistec v0.0.3
+	github.com/bytemare/hash v0.5.2
 	github.com/bytemare/hash2curve v0.5.4
 	github.com/bytemare/secp256k1 v0.3.0
 	github.com/gtank/ristretto255 v0.1.2
+	golang.org/x/crypto v0.38.0
 )
 
-require (
-	github.com/bytemare/hash v0.5.2 // indirect
-	golang.org/x/crypto v0.38.0 // indirect
-	golang.org/x/sys v0.33.0 // indirect
-)
+require golang.org/x/sys v0.33.0 // indirect
 
 replace (
 	github.com/bytemare/hash v0.5.2 => github.com/MarkCherepovskyi/hash v0.0.0-20260203153423-018996cc7c5e
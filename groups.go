@@ -14,8 +14,12 @@ package ecc
 
 import (
 	"crypto"
+	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 	"sync"
 
 	"github.com/bytemare/ecc/internal"
@@ -51,7 +55,7 @@ const (
 	// Secp256k1Sha256 identifies the SECp256k1 group with SHA2-256 hash-to-group hashing.
 	Secp256k1Sha256
 
-	// PallasSha256 identifies the Pallas group with SHA2-256 hash-to-group hashing.
+	// PallasBLAKE2b512 identifies the Pallas group with BLAKE2b-512 hash-to-group hashing.
 	PallasBLAKE2b512
 
 	maxID
@@ -59,17 +63,27 @@ const (
 	dstfmt               = "%s-V%02d-CS%02d-%s"
 	minLength            = 0
 	recommendedMinLength = 16
+
+	deriveKeyPairDST      = "DeriveKeyPair"
+	deriveGeneratorsDST   = "DeriveGenerators-"
+	deriveKeyPairMaxTries = 256
+	maxInfoLength         = 1<<16 - 1
 )
 
 var (
-	once          [maxID - 1]sync.Once
-	groups        [maxID - 1]internal.Group
+	once          [maxGroups - 1]sync.Once
+	groups        [maxGroups - 1]internal.Group
+	identities    [maxGroups - 1][]byte
 	errZeroLenDST = errors.New("zero-length DST")
+
+	groupNames = [maxID - 1]string{
+		"Ristretto255", "decaf448", "P-256", "P-384", "P-521", "Edwards25519", "secp256k1", "Pallas",
+	}
 )
 
-// Available reports whether the given Group is linked into the binary.
+// Available reports whether the given Group is linked into the binary, or has been registered with Register.
 func (g Group) Available() bool {
-	return 0 < g && g < maxID && g != decaf448Shake256
+	return (0 < g && g < maxID && g != decaf448Shake256) || g.isRegistered()
 }
 
 // MakeDST builds a domain separation tag in the form of <app>-V<version>-CS<id>-<hash-to-curve-ID>,
@@ -84,6 +98,20 @@ func (g Group) String() string {
 	return g.get().Ciphersuite()
 }
 
+// Name returns the human-readable name of the group's curve, e.g. "P-256", for logs and error messages, or the
+// ciphersuite identifier for registered groups. Unlike String, it doesn't panic on an unavailable group.
+func (g Group) Name() string {
+	if !g.Available() {
+		return fmt.Sprintf("Group(%d)", byte(g))
+	}
+
+	if g.isRegistered() {
+		return g.String()
+	}
+
+	return groupNames[g-1]
+}
+
 // NewScalar returns a new scalar set to 0.
 func (g Group) NewScalar() *Scalar {
 	return newScalar(g.get().NewScalar())
@@ -94,17 +122,131 @@ func (g Group) NewElement() *Element {
 	return newPoint(g.get().NewElement())
 }
 
+// Identity returns a new identity element, i.e. the point at infinity of the group's curve. It is the same as
+// NewElement, for protocols that want to make their use of the identity explicit.
+func (g Group) Identity() *Element {
+	return newPoint(g.get().NewElement())
+}
+
+// IsIdentityEncoding returns whether data is the encoding of the identity element, as returned by Encode, in constant
+// time. The encoding is computed once per group, so this is a cheap check to filter wire input before decoding it.
+func (g Group) IsIdentityEncoding(data []byte) bool {
+	g.get()
+
+	return subtle.ConstantTimeCompare(data, identities[g-1]) == 1
+}
+
 // Base returns the group's base point a.k.a. canonical generator.
 func (g Group) Base() *Element {
 	return newPoint(g.get().Base())
 }
 
-func checkDST(dst []byte) {
-	if len(dst) < recommendedMinLength {
-		if len(dst) == minLength {
-			panic(errZeroLenDST)
+// NewElementFromBytesNonIdentity returns the decoded element, and an error if the decoding fails or if the element is
+// the identity, e.g. for public keys or blinded elements received from a peer.
+func (g Group) NewElementFromBytesNonIdentity(data []byte) (*Element, error) {
+	e := g.NewElement()
+	if err := e.DecodeNonIdentity(data); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// NewElementFromHex returns the element decoded from its hexadecimal encoding, and an error if the decoding fails.
+func (g Group) NewElementFromHex(h string) (*Element, error) {
+	e := g.NewElement()
+	if err := e.DecodeHex(h); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// NewScalarFromHex returns the scalar decoded from its hexadecimal encoding, and an error if the decoding fails.
+func (g Group) NewScalarFromHex(h string) (*Scalar, error) {
+	s := g.NewScalar()
+	if err := s.DecodeHex(h); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// DecodeAny returns the element decoded from any of its encodings, e.g. for data from heterogeneous sources: the
+// compressed encoding of Decode, the uncompressed encoding of the groups that have one, and the identity, either as its
+// encoding by Encode, or as the single 0x00 byte of SEC 1. The encoding is told apart by its length and prefix, and
+// the checks of the corresponding decoding apply.
+func (g Group) DecodeAny(data []byte) (*Element, error) {
+	e := g.NewElement()
+
+	switch {
+	case len(data) == 1 && data[0] == 0x00:
+		return e, nil
+	case len(data) == g.ElementLength() && subtle.ConstantTimeCompare(data, e.Encode()) == 1:
+		return e, nil
+	case len(data) != 0 && len(data) == g.UncompressedElementLength() && data[0] == 0x04:
+		if err := e.DecodeUncompressed(data); err != nil {
+			return nil, err
+		}
+	default:
+		if err := e.Decode(data); err != nil {
+			return nil, err
 		}
 	}
+
+	return e, nil
+}
+
+// MultiEqual compares the elements pairwise, and returns for each the index of the first element equal to it, which is
+// its own index on its first occurrence, or -1 if it is nil or of another group. This encodes each element only once,
+// e.g. to deduplicate a set by keeping the elements whose result is their own index. Its running time depends on the
+// elements, so it must only be used with public ones, like public keys.
+func (g Group) MultiEqual(elements []*Element) []int {
+	first := make(map[string]int, len(elements))
+	res := make([]int, len(elements))
+
+	for i, e := range elements {
+		if e == nil || e.Group() != g {
+			res[i] = -1
+			continue
+		}
+
+		enc := string(e.Encode())
+		if j, ok := first[enc]; ok {
+			res[i] = j
+			continue
+		}
+
+		first[enc] = i
+		res[i] = i
+	}
+
+	return res
+}
+
+// ValidateDST returns an error if the DST is empty, or ErrShortDST if it is shorter than the 16 bytes recommended by
+// RFC 9380, which allows checking application-provided DSTs beforehand. The hash-to-curve methods only reject empty
+// DSTs, unless the WithStrictDST option is given.
+func ValidateDST(dst []byte) error {
+	return checkDSTLength(dst, true)
+}
+
+func checkDSTLength(dst []byte, strict bool) error {
+	if len(dst) == minLength {
+		return errZeroLenDST
+	}
+
+	if strict && len(dst) < recommendedMinLength {
+		return ErrShortDST
+	}
+
+	return nil
+}
+
+func checkDST(dst []byte) {
+	if err := checkDSTLength(dst, false); err != nil {
+		panic(err)
+	}
 }
 
 // HashFunc returns the RFC9380 associated hash function of the group.
@@ -113,24 +255,123 @@ func (g Group) HashFunc() crypto.Hash {
 }
 
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes, which ValidateDST checks.
 func (g Group) HashToScalar(input, dst []byte) *Scalar {
 	checkDST(dst)
-	return newScalar(g.get().HashToScalar(input, dst))
+	recordOperation(g, OpHashToScalar)
+
+	return debugScalar(newScalar(g.get().HashToScalar(input, dst)), "HashToScalar")
+}
+
+// HashToScalarWithOptions is HashToScalar with options, of which only WithStrictDST is supported, as hashing to scalars
+// always uses the group's RFC 9380 hash_to_field. It returns an error instead of panicking on an invalid DST, and on
+// options changing the encoding.
+func (g Group) HashToScalarWithOptions(input, dst []byte, opts ...Option) (*Scalar, error) {
+	s, err := g.hashToScalarWithOptions(input, dst, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	recordOperation(g, OpHashToScalar)
+
+	return debugScalar(newScalar(s), "HashToScalar"), nil
 }
 
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes, which ValidateDST checks.
 func (g Group) HashToGroup(input, dst []byte) *Element {
 	checkDST(dst)
-	return newPoint(g.get().HashToGroup(input, dst))
+	recordOperation(g, OpHashToGroup)
+
+	return debugElement(newPoint(g.get().HashToGroup(input, dst)), "HashToGroup")
+}
+
+// HashToGroupWithOptions is HashToGroup with options replacing the expander, hash function, security length, or count
+// of the group's RFC 9380 suite, or enforcing a strict DST. It returns an error instead of panicking on an invalid DST,
+// on invalid options, or if the group doesn't support the options.
+func (g Group) HashToGroupWithOptions(input, dst []byte, opts ...Option) (*Element, error) {
+	e, err := g.hashWithOptions(input, dst, false, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	recordOperation(g, OpHashToGroup)
+
+	return debugElement(newPoint(e), "HashToGroup"), nil
 }
 
 // EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes, which ValidateDST checks.
 func (g Group) EncodeToGroup(input, dst []byte) *Element {
 	checkDST(dst)
-	return newPoint(g.get().EncodeToGroup(input, dst))
+	recordOperation(g, OpEncodeToGroup)
+
+	return debugElement(newPoint(g.get().EncodeToGroup(input, dst)), "EncodeToGroup")
+}
+
+// EncodeToGroupWithOptions is EncodeToGroup with options replacing the expander, hash function, security length, or
+// count of the group's RFC 9380 suite, or enforcing a strict DST. It returns an error instead of panicking on an invalid
+// DST, on invalid options, or if the group doesn't support the options.
+func (g Group) EncodeToGroupWithOptions(input, dst []byte, opts ...Option) (*Element, error) {
+	e, err := g.hashWithOptions(input, dst, true, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	recordOperation(g, OpEncodeToGroup)
+
+	return debugElement(newPoint(e), "EncodeToGroup"), nil
+}
+
+// DeriveKeyPair deterministically derives a non-zero private key and its public key from the seed and info, following
+// the RFC 9497 DeriveKeyPair construction, using the group's ciphersuite string as the context string.
+func (g Group) DeriveKeyPair(seed, info []byte) (*Scalar, *Element, error) {
+	return g.DeriveKeyPairWithContext(seed, info, []byte(g.String()))
+}
+
+// DeriveKeyPairWithContext is the same as DeriveKeyPair, but uses the given context string in the DST, e.g.
+// "OPRFV1-\x00-ristretto255-SHA512" to reproduce the keys of an RFC 9497 ciphersuite.
+func (g Group) DeriveKeyPairWithContext(seed, info, context []byte) (*Scalar, *Element, error) {
+	if len(info) > maxInfoLength {
+		return nil, nil, internal.ErrParamInfoTooLong
+	}
+
+	dst := append([]byte(deriveKeyPairDST), context...)
+
+	input := make([]byte, 0, len(seed)+2+len(info)+1)
+	input = append(input, seed...)
+	input = binary.BigEndian.AppendUint16(input, uint16(len(info)))
+	input = append(input, info...)
+	input = append(input, 0)
+
+	for counter := range deriveKeyPairMaxTries {
+		input[len(input)-1] = byte(counter)
+
+		sk := g.HashToScalar(input, dst)
+		if !sk.IsZero() {
+			return sk, g.Base().Multiply(sk), nil
+		}
+	}
+
+	return nil, nil, internal.ErrDeriveKeyPair
+}
+
+// DeriveGenerators returns n generators with unknown discrete logarithms to each other and to the base point, e.g. for
+// Pedersen vector commitments. The i-th generator is the hash to the group of I2OSP(i, 4), with the DST prefixed with
+// "DeriveGenerators-", so that they don't collide with other uses of the protocol's DST. The DST must identify the
+// protocol, and follows the same rules as for HashToGroup. The same DST and index always give the same generator, so
+// that derivations with different n share their first generators. n must be lower than 2^32.
+func (g Group) DeriveGenerators(n int, dst []byte) []*Element {
+	checkDST(dst)
+
+	dst = append([]byte(deriveGeneratorsDST), dst...)
+	generators := make([]*Element, max(n, 0))
+
+	for i := range generators {
+		generators[i] = g.HashToGroup(binary.BigEndian.AppendUint32(nil, uint32(i)), dst)
+	}
+
+	return generators
 }
 
 // ScalarLength returns the byte size of an encoded scalar.
@@ -143,11 +384,123 @@ func (g Group) ElementLength() int {
 	return g.get().ElementLength()
 }
 
-// Order returns the order of the canonical group of scalars.
+// UncompressedElementLength returns the byte size of the uncompressed encoding of an element, or 0 if the group has no
+// such encoding, i.e. is not a NIST group or secp256k1.
+func (g Group) UncompressedElementLength() int {
+	if _, ok := g.get().NewElement().(internal.UncompressedCodec); !ok {
+		return 0
+	}
+
+	return 2*g.ElementLength() - 1
+}
+
+// Order returns the order of the canonical group of scalars, in the byte order of the scalar encoding, i.e.
+// little-endian for Ristretto255 and Edwards25519, and big-endian for the other groups.
 func (g Group) Order() []byte {
 	return g.get().Order()
 }
 
+// OrderBig returns the order of the group, as a new big.Int.
+func (g Group) OrderBig() *big.Int {
+	order := g.Order()
+	if g.littleEndianScalars() {
+		slices.Reverse(order)
+	}
+
+	return new(big.Int).SetBytes(order)
+}
+
+// FieldOrder returns the big-endian encoding of the order of the base field of the group's curve, or nil for registered
+// groups that don't implement a FieldOrder() []byte method.
+func (g Group) FieldOrder() []byte {
+	if f, ok := g.get().(interface{ FieldOrder() []byte }); ok {
+		return f.FieldOrder()
+	}
+
+	return nil
+}
+
+// FieldOrderBig returns the order of the base field of the group's curve, as a new big.Int, or nil if FieldOrder is
+// nil.
+func (g Group) FieldOrderBig() *big.Int {
+	p := g.FieldOrder()
+	if p == nil {
+		return nil
+	}
+
+	return new(big.Int).SetBytes(p)
+}
+
+// Cofactor returns the big-endian encoding of the cofactor of the group's curve, i.e. the number of points on the curve
+// divided by the group order. It is 8 for Edwards25519 and Ristretto255, which is built from the same curve but whose
+// encoding removes the cofactor, so that its elements need no cofactor clearing, and 1 for the prime-order curves.
+// Registered groups that don't implement a Cofactor() []byte method are assumed to have a cofactor of 1.
+func (g Group) Cofactor() []byte {
+	switch g {
+	case Ristretto255Sha512, Edwards25519Sha512:
+		return []byte{8}
+	case P256Sha256, P384Sha384, P521Sha512, Secp256k1Sha256, PallasBLAKE2b512:
+		return []byte{1}
+	default:
+	}
+
+	if c, ok := g.get().(interface{ Cofactor() []byte }); ok {
+		return c.Cofactor()
+	}
+
+	return []byte{1}
+}
+
+// SecurityLevel returns k, the target security level in bits of the group's hash-to-curve suite, which is 128 for all
+// groups but P-384 and P-521, with 192 and 256. It is 0 for registered groups that don't implement a
+// SecurityLevel() int method.
+func (g Group) SecurityLevel() int {
+	switch g {
+	case P384Sha384:
+		return 192
+	case P521Sha512:
+		return 256
+	case Ristretto255Sha512, P256Sha256, Edwards25519Sha512, Secp256k1Sha256, PallasBLAKE2b512:
+		return 128
+	default:
+	}
+
+	if s, ok := g.get().(interface{ SecurityLevel() int }); ok {
+		return s.SecurityLevel()
+	}
+
+	return 0
+}
+
+// HashToScalarLength returns the byte length of the uniform strings that HashToScalar expands the input to, and reduces
+// modulo the group order. It is L = ceil((log2(order) + k) / 8) of RFC 9380, with k the SecurityLevel, so that the
+// bias of the resulting scalars is at most 2^-k, except for Ristretto255 which reduces 64 bytes, as in RFC 9496. It is
+// 0 for registered groups that don't implement a HashToScalarLength() int method.
+func (g Group) HashToScalarLength() int {
+	switch g {
+	case Ristretto255Sha512:
+		return 64
+	case P256Sha256, Edwards25519Sha512, Secp256k1Sha256, PallasBLAKE2b512:
+		return 48
+	case P384Sha384:
+		return 72
+	case P521Sha512:
+		return 98
+	default:
+	}
+
+	if l, ok := g.get().(interface{ HashToScalarLength() int }); ok {
+		return l.HashToScalarLength()
+	}
+
+	return 0
+}
+
+// CofactorBig returns the cofactor of the group's curve, as a new big.Int.
+func (g Group) CofactorBig() *big.Int {
+	return new(big.Int).SetBytes(g.Cofactor())
+}
+
 func (g Group) get() internal.Group {
 	if !g.Available() {
 		panic(internal.ErrInvalidGroup)
@@ -160,6 +513,7 @@ func (g Group) get() internal.Group {
 
 func (g Group) initGroup(get func() internal.Group) {
 	groups[g-1] = get()
+	identities[g-1] = groups[g-1].NewElement().Encode()
 }
 
 func (g Group) init() {
@@ -179,7 +533,11 @@ func (g Group) init() {
 	case PallasBLAKE2b512:
 		g.initGroup(pallas.New)
 	default:
-		panic("group not recognized")
+		if !g.isRegistered() {
+			panic("group not recognized")
+		}
+
+		g.initGroup(*registered[g-1].Load())
 	}
 }
 
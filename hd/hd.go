@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package hd implements hierarchical deterministic key derivation with chain codes.
+//
+// For Secp256k1, derivation strictly follows BIP32
+// (https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki). For the other groups, the same hardened and
+// non-hardened additive-tweak construction is used, but the HMAC output is mapped to a scalar with the group's
+// hash-to-scalar function, so that the tweak is uniform in every group whatever its order.
+//
+// SLIP-0010 derivation is available for Edwards25519, P-256, and Secp256k1 through NewMasterSLIP10, so that these keys
+// can be derived from the same seed hierarchy.
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // HASH160 is mandated by BIP32.
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	// HardenedOffset is the first index of hardened child keys.
+	HardenedOffset uint32 = 0x80000000
+
+	// ChainCodeLength is the byte size of chain codes.
+	ChainCodeLength = 32
+
+	bip32MasterKey = "Bitcoin seed"
+	masterKeyFmt   = "ECC-HD-V01 seed %s"
+	tweakDSTFmt    = "ECC-HD-V01-Tweak-%s"
+
+	minSeedLength = 16
+	maxSeedLength = 64
+)
+
+var (
+	// ErrInvalidKey indicates that the derived key is invalid (zero or out of range). As per BIP32, the caller should
+	// proceed with the next index.
+	ErrInvalidKey = errors.New("derived key is invalid, proceed with the next index")
+
+	errSeedLength      = errors.New("invalid seed length")
+	errHardenedPublic  = errors.New("cannot derive a hardened child from a public key")
+	errInvalidPath     = errors.New("invalid derivation path")
+	errDepthOverflow   = errors.New("maximum derivation depth reached")
+	errNilExtendedKey  = errors.New("nil extended key")
+	errNoPublicKeySet  = errors.New("public key not set")
+	errChainCodeLength = errors.New("invalid chain code length")
+)
+
+// ExtendedKey is a node in a derivation tree. If PrivateKey is nil, it is a public extended key and only non-hardened
+// children can be derived.
+type ExtendedKey struct {
+	PrivateKey        *ecc.Scalar
+	PublicKey         *ecc.Element
+	ChainCode         []byte
+	ParentFingerprint [4]byte
+	Index             uint32
+	Depth             uint8
+	Group             ecc.Group
+	scheme            scheme
+	seed              []byte
+}
+
+// scheme identifies the derivation rules applying to an extended key.
+type scheme byte
+
+const (
+	schemeBIP32 scheme = iota
+	schemeSLIP10
+)
+
+func hmacSHA512(key []byte, data ...[]byte) (il, ir []byte) {
+	mac := hmac.New(sha512.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
+
+// tweak maps the left half of the HMAC output to a scalar. For Secp256k1 the BIP32 rules apply, and
+// ErrInvalidKey is returned if the value is not lower than the group order.
+func tweak(g ecc.Group, il []byte) (*ecc.Scalar, error) {
+	if g == ecc.Secp256k1Sha256 {
+		s := g.NewScalar()
+		if err := s.Decode(il); err != nil {
+			return nil, ErrInvalidKey
+		}
+
+		return s, nil
+	}
+
+	return g.HashToScalar(il, []byte(fmt.Sprintf(tweakDSTFmt, g))), nil
+}
+
+// NewMaster derives the master extended private key from the seed, which must be between 16 and 64 bytes long.
+func NewMaster(g ecc.Group, seed []byte) (*ExtendedKey, error) {
+	if len(seed) < minSeedLength || len(seed) > maxSeedLength {
+		return nil, errSeedLength
+	}
+
+	key := []byte(bip32MasterKey)
+	if g != ecc.Secp256k1Sha256 {
+		key = []byte(fmt.Sprintf(masterKeyFmt, g))
+	}
+
+	il, ir := hmacSHA512(key, seed)
+
+	sk, err := tweak(g, il)
+	if err != nil || sk.IsZero() {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{
+		PrivateKey: sk,
+		PublicKey:  g.Base().Multiply(sk),
+		ChainCode:  ir,
+		Group:      g,
+	}, nil
+}
+
+// NewPublic returns a public extended key from the given public key and chain code, e.g. as received from a third
+// party.
+func NewPublic(pk *ecc.Element, chainCode []byte) (*ExtendedKey, error) {
+	if pk == nil {
+		return nil, errNoPublicKeySet
+	}
+
+	if len(chainCode) != ChainCodeLength {
+		return nil, errChainCodeLength
+	}
+
+	return &ExtendedKey{
+		PublicKey: pk.Copy(),
+		ChainCode: append([]byte(nil), chainCode...),
+		Group:     pk.Group(),
+	}, nil
+}
+
+// IsPrivate returns whether the extended key holds a private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.PrivateKey != nil
+}
+
+// Public returns the public extended key of k, without the private key.
+func (k *ExtendedKey) Public() *ExtendedKey {
+	return &ExtendedKey{
+		PublicKey:         k.PublicKey.Copy(),
+		ChainCode:         append([]byte(nil), k.ChainCode...),
+		ParentFingerprint: k.ParentFingerprint,
+		Index:             k.Index,
+		Depth:             k.Depth,
+		Group:             k.Group,
+		scheme:            k.scheme,
+	}
+}
+
+// Fingerprint returns the first 4 bytes of the identifier of the key, identifying it as a parent. For Secp256k1 and
+// SLIP-0010 keys, it is the BIP32 HASH160, i.e. RIPEMD-160(SHA-256(K)) of the 33-byte serialized public key K, which is
+// the compressed point, or 0x00 followed by the public key for ed25519. For the other groups, which these don't define,
+// it is the SHA-512 hash of the encoded public key.
+func (k *ExtendedKey) Fingerprint() [4]byte {
+	var fp [4]byte
+
+	if k.Group == ecc.Secp256k1Sha256 || k.scheme == schemeSLIP10 {
+		copy(fp[:], hash160(k.serializedPublicKey()))
+		return fp
+	}
+
+	h := sha512.Sum512(k.PublicKey.Encode())
+	copy(fp[:], h[:4])
+
+	return fp
+}
+
+// hash160 returns RIPEMD-160(SHA-256(data)).
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+
+	return h.Sum(nil)
+}
+
+// Child derives the child key at the given index. Indexes at or above HardenedOffset produce hardened children, which
+// require a private extended key. If ErrInvalidKey is returned, the caller should proceed with the next index.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k == nil {
+		return nil, errNilExtendedKey
+	}
+
+	if k.Depth == 0xff {
+		return nil, errDepthOverflow
+	}
+
+	if k.scheme == schemeSLIP10 {
+		return k.childSLIP10(index)
+	}
+
+	var i [4]byte
+
+	binary.BigEndian.PutUint32(i[:], index)
+
+	var il, ir []byte
+
+	if index >= HardenedOffset {
+		if !k.IsPrivate() {
+			return nil, errHardenedPublic
+		}
+
+		il, ir = hmacSHA512(k.ChainCode, []byte{0}, k.PrivateKey.Encode(), i[:])
+	} else {
+		il, ir = hmacSHA512(k.ChainCode, k.PublicKey.Encode(), i[:])
+	}
+
+	t, err := tweak(k.Group, il)
+	if err != nil {
+		return nil, err
+	}
+
+	child := &ExtendedKey{
+		ChainCode:         ir,
+		ParentFingerprint: k.Fingerprint(),
+		Index:             index,
+		Depth:             k.Depth + 1,
+		Group:             k.Group,
+	}
+
+	if k.IsPrivate() {
+		child.PrivateKey = t.Add(k.PrivateKey)
+		if child.PrivateKey.IsZero() {
+			return nil, ErrInvalidKey
+		}
+
+		child.PublicKey = k.Group.Base().Multiply(child.PrivateKey)
+	} else {
+		child.PublicKey = k.Group.Base().Multiply(t).Add(k.PublicKey)
+		if child.PublicKey.IsIdentity() {
+			return nil, ErrInvalidKey
+		}
+	}
+
+	return child, nil
+}
+
+// Derive successively derives the children along the path.
+func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	key := k
+
+	for _, index := range path {
+		var err error
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ParsePath parses a derivation path in the form of "m/44'/0'/0'/0/1". Hardened indexes are marked with ', h, or H.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, errInvalidPath
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+
+	for _, p := range parts[1:] {
+		offset := uint32(0)
+
+		if trimmed := strings.TrimRight(p, "'hH"); len(trimmed) == len(p)-1 {
+			offset = HardenedOffset
+			p = trimmed
+		}
+
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidPath, err)
+		}
+
+		indexes = append(indexes, uint32(i)+offset)
+	}
+
+	return indexes, nil
+}
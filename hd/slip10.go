@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hd
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	slip10Ed25519Key   = "ed25519 seed"
+	slip10P256Key      = "Nist256p1 seed"
+	slip10Secp256k1Key = "Bitcoin seed"
+)
+
+var (
+	errSLIP10Group       = errors.New("group not supported by SLIP-0010")
+	errSLIP10NonHardened = errors.New("SLIP-0010 only supports hardened derivation for ed25519")
+)
+
+func slip10CurveKey(g ecc.Group) ([]byte, error) {
+	switch g {
+	case ecc.Edwards25519Sha512:
+		return []byte(slip10Ed25519Key), nil
+	case ecc.P256Sha256:
+		return []byte(slip10P256Key), nil
+	case ecc.Secp256k1Sha256:
+		return []byte(slip10Secp256k1Key), nil
+	default:
+		return nil, errSLIP10Group
+	}
+}
+
+// parseSLIP10 interprets il as a scalar, and returns false if it is not lower than the group order.
+func parseSLIP10(g ecc.Group, il []byte) (*ecc.Scalar, bool) {
+	s := g.NewScalar()
+	if err := s.Decode(il); err != nil {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// ed25519Scalar returns the secret scalar of an RFC 8032 ed25519 private key seed.
+func ed25519Scalar(seed []byte) *ecc.Scalar {
+	h := sha512.Sum512(seed)
+
+	sk := ecc.Edwards25519Sha512.NewScalar()
+	if err := sk.SetBytesWithClamping(h[:32]); err != nil {
+		panic(err)
+	}
+
+	return sk
+}
+
+func newSLIP10Key(g ecc.Group, il, ir []byte, sk *ecc.Scalar) *ExtendedKey {
+	k := &ExtendedKey{
+		ChainCode: ir,
+		Group:     g,
+		scheme:    schemeSLIP10,
+	}
+
+	if g == ecc.Edwards25519Sha512 {
+		k.seed = il
+		sk = ed25519Scalar(il)
+	}
+
+	k.PrivateKey = sk
+	k.PublicKey = g.Base().Multiply(sk)
+
+	return k
+}
+
+// NewMasterSLIP10 derives the master extended private key from the seed following SLIP-0010
+// (https://github.com/satoshilabs/slips/blob/master/slip-0010.md). The supported groups are Edwards25519Sha512,
+// P256Sha256, and Secp256k1Sha256. For Edwards25519Sha512, PrivateKey holds the RFC 8032 secret scalar, and the
+// private key seed is available with Ed25519PrivateKey.
+func NewMasterSLIP10(g ecc.Group, seed []byte) (*ExtendedKey, error) {
+	if len(seed) < minSeedLength || len(seed) > maxSeedLength {
+		return nil, errSeedLength
+	}
+
+	key, err := slip10CurveKey(g)
+	if err != nil {
+		return nil, err
+	}
+
+	il, ir := hmacSHA512(key, seed)
+	if g == ecc.Edwards25519Sha512 {
+		return newSLIP10Key(g, il, ir, nil), nil
+	}
+
+	for {
+		if sk, ok := parseSLIP10(g, il); ok && !sk.IsZero() {
+			return newSLIP10Key(g, il, ir, sk), nil
+		}
+
+		il, ir = hmacSHA512(key, il, ir)
+	}
+}
+
+// Ed25519PrivateKey returns the RFC 8032 private key of a SLIP-0010 ed25519 extended private key, and nil otherwise.
+func (k *ExtendedKey) Ed25519PrivateKey() ed25519.PrivateKey {
+	if k.seed == nil {
+		return nil
+	}
+
+	return ed25519.NewKeyFromSeed(k.seed)
+}
+
+// serializedPublicKey returns the SLIP-0010 serialization of the public key, which is also the BIP32 one for
+// Secp256k1.
+func (k *ExtendedKey) serializedPublicKey() []byte {
+	if k.Group == ecc.Edwards25519Sha512 {
+		return append([]byte{0}, k.PublicKey.Encode()...)
+	}
+
+	return k.PublicKey.Encode()
+}
+
+func (k *ExtendedKey) childSLIP10(index uint32) (*ExtendedKey, error) {
+	var i [4]byte
+
+	binary.BigEndian.PutUint32(i[:], index)
+
+	hardened := index >= HardenedOffset
+	if hardened && !k.IsPrivate() {
+		return nil, errHardenedPublic
+	}
+
+	if !hardened && k.Group == ecc.Edwards25519Sha512 {
+		return nil, errSLIP10NonHardened
+	}
+
+	var il, ir []byte
+
+	switch {
+	case k.Group == ecc.Edwards25519Sha512:
+		il, ir = hmacSHA512(k.ChainCode, []byte{0}, k.seed, i[:])
+		child := newSLIP10Key(k.Group, il, ir, nil)
+		child.setParent(k, index)
+
+		return child, nil
+	case hardened:
+		il, ir = hmacSHA512(k.ChainCode, []byte{0}, k.PrivateKey.Encode(), i[:])
+	default:
+		il, ir = hmacSHA512(k.ChainCode, k.serializedPublicKey(), i[:])
+	}
+
+	for {
+		if t, ok := parseSLIP10(k.Group, il); ok {
+			child := &ExtendedKey{
+				ChainCode: ir,
+				Group:     k.Group,
+				scheme:    schemeSLIP10,
+			}
+			child.setParent(k, index)
+
+			if k.IsPrivate() {
+				child.PrivateKey = t.Add(k.PrivateKey)
+				if !child.PrivateKey.IsZero() {
+					child.PublicKey = k.Group.Base().Multiply(child.PrivateKey)
+					return child, nil
+				}
+			} else {
+				child.PublicKey = k.Group.Base().Multiply(t).Add(k.PublicKey)
+				if !child.PublicKey.IsIdentity() {
+					return child, nil
+				}
+			}
+		}
+
+		il, ir = hmacSHA512(k.ChainCode, []byte{1}, ir, i[:])
+	}
+}
+
+func (k *ExtendedKey) setParent(parent *ExtendedKey, index uint32) {
+	k.ParentFingerprint = parent.Fingerprint()
+	k.Index = index
+	k.Depth = parent.Depth + 1
+}
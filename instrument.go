@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// Operation identifies an operation reported to the operation hook.
+type Operation byte
+
+const (
+	// OpElementMultiply is a scalar multiplication of an element, including with precomputed tables.
+	OpElementMultiply Operation = iota
+
+	// OpScalarMultiply is a multiplication of scalars, including in MulAdd and Square.
+	OpScalarMultiply
+
+	// OpScalarInvert is a scalar inversion.
+	OpScalarInvert
+
+	// OpHashToGroup is a call to HashToGroup.
+	OpHashToGroup
+
+	// OpEncodeToGroup is a call to EncodeToGroup.
+	OpEncodeToGroup
+
+	// OpHashToScalar is a call to HashToScalar.
+	OpHashToScalar
+
+	numOperations
+)
+
+var operationNames = [numOperations]string{
+	"ElementMultiply",
+	"ScalarMultiply",
+	"ScalarInvert",
+	"HashToGroup",
+	"EncodeToGroup",
+	"HashToScalar",
+}
+
+// String returns the name of the operation.
+func (o Operation) String() string {
+	if o >= numOperations {
+		return "Operation(unknown)"
+	}
+
+	return operationNames[o]
+}
+
+var operationHook atomic.Pointer[func(g Group, op Operation)]
+
+// SetOperationHook sets the function called on each instrumented operation, with the group it runs in, e.g. to
+// attribute the costs of a protocol without a profiler. A nil hook disables the instrumentation, which is the default.
+// The hook is called synchronously and possibly concurrently, so it must be fast and safe for concurrent use, like
+// OperationCounter.Record.
+func SetOperationHook(hook func(g Group, op Operation)) {
+	if hook == nil {
+		operationHook.Store(nil)
+		return
+	}
+
+	operationHook.Store(&hook)
+}
+
+// recordOperation calls the operation hook, if any.
+func recordOperation(g Group, op Operation) {
+	if hook := operationHook.Load(); hook != nil {
+		(*hook)(g, op)
+	}
+}
+
+// OperationCounter counts the operations per group, and is safe for concurrent use. Use its Record method as the
+// operation hook.
+type OperationCounter struct {
+	counts [maxGroups][numOperations]atomic.Uint64
+}
+
+// Record increments the count of the operation in the group.
+func (c *OperationCounter) Record(g Group, op Operation) {
+	if op < numOperations {
+		c.counts[g][op].Add(1)
+	}
+}
+
+// Count returns the number of operations recorded in the group.
+func (c *OperationCounter) Count(g Group, op Operation) uint64 {
+	if op >= numOperations {
+		return 0
+	}
+
+	return c.counts[g][op].Load()
+}
+
+// Reset sets all counts to 0.
+func (c *OperationCounter) Reset() {
+	for g := range c.counts {
+		for op := range c.counts[g] {
+			c.counts[g][op].Store(0)
+		}
+	}
+}
+
+// Snapshot returns the non-zero counts, by group name and operation name.
+func (c *OperationCounter) Snapshot() map[string]map[string]uint64 {
+	snapshot := make(map[string]map[string]uint64)
+
+	for g := range c.counts {
+		for op := range c.counts[g] {
+			n := c.counts[g][op].Load()
+			if n == 0 {
+				continue
+			}
+
+			name := Group(g).Name()
+			if snapshot[name] == nil {
+				snapshot[name] = make(map[string]uint64)
+			}
+
+			snapshot[name][Operation(op).String()] = n
+		}
+	}
+
+	return snapshot
+}
+
+// Publish exports the counter's snapshots as an expvar variable with the given name. Like expvar.Publish, it panics if
+// the name is already in use.
+func (c *OperationCounter) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return c.Snapshot()
+	}))
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package blake2b implements BLAKE2b-512 with a personalization string, as used by the Zcash protocol, e.g. for the
+// RedPallas H*. golang.org/x/crypto/blake2b only exposes the key of the BLAKE2 parameter block, and not its
+// personalization field, hence this implementation of RFC 7693 with the parameter block of the BLAKE2 specification.
+// Only unkeyed hashing of a complete input is needed.
+package blake2b
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	blake2bBlockSize = 128
+
+	// Size is the byte size of the digests.
+	Size = 64
+)
+
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var blake2bSigma = [12][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+}
+
+// Sum512Personal returns the 64-byte BLAKE2b digest of the input with the 16-byte personalization string. With an
+// all-zero personalization, it is the BLAKE2b-512 digest of RFC 7693.
+func Sum512Personal(personal *[16]byte, input []byte) [Size]byte {
+	h := blake2bIV
+	h[0] ^= 0x01010000 | Size // digest length, no key, fanout and depth of 1
+	h[6] ^= binary.LittleEndian.Uint64(personal[:8])
+	h[7] ^= binary.LittleEndian.Uint64(personal[8:])
+
+	var counter uint64
+
+	// All blocks but the last, which is always compressed with the final flag even if it's full or empty.
+	for len(input) > blake2bBlockSize {
+		counter += blake2bBlockSize
+		blake2bCompress(&h, input[:blake2bBlockSize], counter, false)
+		input = input[blake2bBlockSize:]
+	}
+
+	var last [blake2bBlockSize]byte
+	copy(last[:], input)
+	counter += uint64(len(input))
+	blake2bCompress(&h, last[:], counter, true)
+
+	var out [Size]byte
+	for i, v := range h {
+		binary.LittleEndian.PutUint64(out[8*i:], v)
+	}
+
+	return out
+}
+
+// blake2bCompress is the BLAKE2b compression function F. The counter never exceeds 2^64 bytes here.
+func blake2bCompress(h *[8]uint64, block []byte, counter uint64, final bool) {
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[8*i:])
+	}
+
+	var v [16]uint64
+	copy(v[:8], h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= counter
+
+	if final {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, d int, x, y uint64) {
+		v[a] += v[b] + x
+		v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] += v[b] + y
+		v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+
+	for _, s := range blake2bSigma {
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
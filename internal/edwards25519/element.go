@@ -9,6 +9,7 @@
 package edwards25519
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 
@@ -104,6 +105,18 @@ func (e *Element) IsIdentity() bool {
 	return e.element.Equal(ed.NewIdentityPoint()) == 1
 }
 
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, i.e. has no component in the
+// small-order subgroup of order 8.
+func (e *Element) IsTorsionFree() bool {
+	return isTorsionFree(&e.element)
+}
+
+// ClearCofactor sets the receiver to its multiplication by the cofactor 8, and returns it.
+func (e *Element) ClearCofactor() internal.Element {
+	e.element.MultByCofactor(&e.element)
+	return e
+}
+
 // Set sets the receiver to the value of the argument, and returns the receiver.
 func (e *Element) Set(element internal.Element) internal.Element {
 	if element == nil {
@@ -125,6 +138,17 @@ func (e *Element) Copy() internal.Element {
 	return &Element{*ed.NewIdentityPoint().Set(&e.element)}
 }
 
+// Point returns a copy of the underlying filippo.io/edwards25519 point.
+func (e *Element) Point() *ed.Point {
+	return ed.NewIdentityPoint().Set(&e.element)
+}
+
+// SetPoint sets the receiver to the filippo.io/edwards25519 point, and returns it.
+func (e *Element) SetPoint(p *ed.Point) *Element {
+	e.element.Set(p)
+	return e
+}
+
 // Encode returns the compressed byte encoding of the element.
 func (e *Element) Encode() []byte {
 	return e.element.Bytes()
@@ -149,7 +173,24 @@ func decodeElement(element []byte) (*ed.Point, error) {
 	return e, nil
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// orderMinusOne is l - 1, used to multiply by the group order, which doesn't fit in a reduced scalar.
+var orderMinusOne = func() *ed.Scalar {
+	s, err := ed.NewScalar().SetCanonicalBytes(scMinusOne)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}()
+
+// isTorsionFree returns whether [l]P is the identity, computed as [l-1]P + P.
+func isTorsionFree(p *ed.Point) bool {
+	q := ed.NewIdentityPoint().ScalarMult(orderMinusOne, p)
+	return q.Add(q, p).Equal(ed.NewIdentityPoint()) == 1
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. The encoding must be
+// canonical, and the point must be in the prime-order subgroup.
 func (e *Element) Decode(data []byte) error {
 	element, err := decodeElement(data)
 	if err != nil {
@@ -161,6 +202,15 @@ func (e *Element) Decode(data []byte) error {
 		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrIdentity)
 	}
 
+	// SetBytes accepts non-canonical encodings of y and of the sign of x = 0.
+	if !bytes.Equal(element.Bytes(), data) {
+		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrParamNonCanonicalEncoding)
+	}
+
+	if !isTorsionFree(element) {
+		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrParamNotInSubgroup)
+	}
+
 	e.element = *element
 
 	return nil
@@ -180,3 +230,8 @@ func (e *Element) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element) Wipe() {
+	e.element = *ed.NewIdentityPoint()
+}
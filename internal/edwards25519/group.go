@@ -23,6 +23,7 @@ const (
 	Identifier = byte(6)
 
 	canonicalEncodingLength = 32
+	securityLength          = 48
 	orderPrime              = "7237005577332262213973186563042994240857116359379907606001950938285454250989"
 )
 
@@ -72,6 +73,23 @@ func (g Group) EncodeToGroup(input, dst []byte) internal.Element {
 	return &Element{*EncodeToEdwards25519(input, dst)}
 }
 
+// FieldOrder returns the big-endian encoding of the order of the base field.
+func (g Group) FieldOrder() []byte {
+	return fieldPrime.FillBytes(make([]byte, canonicalEncodingLength))
+}
+
+// SecurityLength returns L, the byte length of the uniform strings reduced to field elements in the group's
+// hash-to-curve suite.
+func (g Group) SecurityLength() uint {
+	return securityLength
+}
+
+// MapToGroup maps each of the big-endian encoded field elements to the curve with Elligator2, and returns their sum
+// with the cofactor cleared.
+func (g Group) MapToGroup(u ...[]byte) internal.Element {
+	return &Element{*MapToEdwards25519(u...)}
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func (g Group) Ciphersuite() string {
 	return H2C
@@ -11,6 +11,7 @@ package edwards25519
 import (
 	"crypto"
 	"math/big"
+	"slices"
 
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
@@ -24,6 +25,10 @@ const (
 	// p25519 is the prime 2^255 - 19 for the field.
 	// = 0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed.
 	p25519 = "57896044618658097711785492504343953926634992332820282019728792003956564819949"
+
+	// hashToScalarLength is the number of bytes reduced to a scalar, i.e. L = ceil((253 + 128) / 8) in RFC 9380 for
+	// the 253-bit group order and k = 128.
+	hashToScalarLength = 48
 )
 
 var (
@@ -72,7 +77,7 @@ func reverse(b []byte) []byte {
 
 // HashToEdwards25519Field implements hash-to-scalar mapping modulo the order of Edwards25519 using input with dst.
 func HashToEdwards25519Field(input, dst []byte) *edwards25519.Scalar {
-	sc := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 48, &order)
+	sc := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, hashToScalarLength, &order)
 	b := adjust(sc[0].Bytes())
 
 	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
@@ -106,6 +111,18 @@ func EncodeToEdwards25519(input, dst []byte) *edwards25519.Point {
 	return p0
 }
 
+// MapToEdwards25519 maps each of the big-endian encoded field elements to Edwards25519 with Elligator2, and returns
+// their sum with the cofactor cleared.
+func MapToEdwards25519(u ...[]byte) *edwards25519.Point {
+	p := edwards25519.NewIdentityPoint()
+
+	for _, ui := range u {
+		p.Add(p, Elligator2Edwards(element(adjust(slices.Clone(ui)))))
+	}
+
+	return p.MultByCofactor(p)
+}
+
 // Elligator2Edwards maps the field element to a point on Edwards25519.
 func Elligator2Edwards(e *field.Element) *edwards25519.Point {
 	u, v := Elligator2Montgomery(e)
@@ -310,6 +310,17 @@ func (s *Scalar) Copy() internal.Scalar {
 	return &Scalar{*ed.NewScalar().Set(&s.scalar)}
 }
 
+// EdScalar returns a copy of the underlying filippo.io/edwards25519 scalar.
+func (s *Scalar) EdScalar() *ed.Scalar {
+	return ed.NewScalar().Set(&s.scalar)
+}
+
+// SetEdScalar sets the receiver to the filippo.io/edwards25519 scalar, and returns it.
+func (s *Scalar) SetEdScalar(scalar *ed.Scalar) *Scalar {
+	s.scalar.Set(scalar)
+	return s
+}
+
 // Encode returns the compressed byte encoding of the scalar.
 func (s *Scalar) Encode() []byte {
 	return s.scalar.Bytes()
@@ -335,6 +346,11 @@ func (s *Scalar) DecodeHex(h string) error {
 	return s.Decode(b)
 }
 
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	s.scalar = *ed.NewScalar()
+}
+
 func (s *Scalar) set(scalar *ed.Scalar) {
 	s.scalar = *scalar
 }
@@ -361,7 +377,7 @@ func (s *Scalar) decodeScalar(scalar []byte) error {
 	}
 
 	if _, err := s.scalar.SetCanonicalBytes(scalar); err != nil {
-		return fmt.Errorf("%w", err)
+		return internal.ErrParamScalarInvalidEncoding
 	}
 
 	return nil
@@ -35,12 +35,18 @@ type Element interface {
 	// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
 	Multiply(s Scalar) Element
 
-	// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+	// Equal returns 1 if the elements are equivalent, and 0 otherwise. It must run in constant time.
 	Equal(e Element) int
 
 	// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
 	IsIdentity() bool
 
+	// IsTorsionFree returns whether the element belongs to the prime-order subgroup.
+	IsTorsionFree() bool
+
+	// ClearCofactor sets the receiver to its multiplication by the group's cofactor, and returns it.
+	ClearCofactor() Element
+
 	// Set sets the receiver to the value of the argument, and returns the receiver.
 	Set(e Element) Element
 
@@ -61,4 +67,7 @@ type Element interface {
 
 	// DecodeHex sets e to the decoding of the hex encoded element.
 	DecodeHex(data string) error
+
+	// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+	Wipe()
 }
@@ -33,6 +33,13 @@ type Field struct {
 	byteLen     int
 }
 
+// Wipe overwrites the words backing x, including its unused capacity, and sets x to 0.
+func Wipe(x *big.Int) {
+	words := x.Bits()
+	clear(words[:cap(words)])
+	x.SetInt64(0)
+}
+
 // NewField returns a newly instantiated field for the given prime order.
 func NewField(prime *big.Int) Field {
 	// pMinus1div2 is used to determine whether a big Int is a quadratic square.
@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package field
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Limbs holds a 256-bit integer as four 64-bit words, least significant first.
+type Limbs [4]uint64
+
+// Montgomery implements constant-time arithmetic modulo an odd prime m < 2^255, on elements in the Montgomery domain
+// (x * 2^256 mod m) held in Limbs. It uses neither math/big nor heap allocations, unlike Field, which is still built
+// alongside it. Unless stated otherwise, inputs and outputs are in the Montgomery domain, and outputs may alias inputs.
+type Montgomery struct {
+	m       Limbs
+	mMinus2 Limbs  // exponent for inversion
+	r       Limbs  // 2^256 mod m, i.e. 1 in the Montgomery domain
+	r2      Limbs  // 2^512 mod m, to convert into the Montgomery domain
+	mInv    uint64 // -m^-1 mod 2^64
+}
+
+// LimbsFromBytes returns the big-endian encoding of a 256-bit integer as Limbs.
+func LimbsFromBytes(b *[32]byte) Limbs {
+	return Limbs{
+		binary.BigEndian.Uint64(b[24:]),
+		binary.BigEndian.Uint64(b[16:]),
+		binary.BigEndian.Uint64(b[8:]),
+		binary.BigEndian.Uint64(b[:]),
+	}
+}
+
+// Bytes returns the 32-byte big-endian encoding of l.
+func (l *Limbs) Bytes() []byte {
+	out := make([]byte, 32)
+	binary.BigEndian.PutUint64(out, l[3])
+	binary.BigEndian.PutUint64(out[8:], l[2])
+	binary.BigEndian.PutUint64(out[16:], l[1])
+	binary.BigEndian.PutUint64(out[24:], l[0])
+
+	return out
+}
+
+// Bit returns the value of the i-th bit of l.
+func (l *Limbs) Bit(i int) uint64 {
+	return l[i/64] >> (i % 64) & 1
+}
+
+// NewMontgomery returns the arithmetic modulo the odd prime m < 2^255, given in big-endian.
+func NewMontgomery(modulus *[32]byte) *Montgomery {
+	m := LimbsFromBytes(modulus)
+	if m[0]&1 == 0 || m[3]>>63 != 0 {
+		panic("invalid Montgomery modulus")
+	}
+
+	f := &Montgomery{m: m}
+
+	// Newton's iteration doubles the number of correct low bits of m^-1, starting from 3 for odd m.
+	inv := m[0]
+	for range 5 {
+		inv *= 2 - m[0]*inv
+	}
+
+	f.mInv = -inv
+
+	// 2^256 and 2^512 mod m, by doubling 1.
+	x := Limbs{1}
+	for range 256 {
+		f.Add(&x, &x, &x)
+	}
+
+	f.r = x
+
+	for range 256 {
+		f.Add(&x, &x, &x)
+	}
+
+	f.r2 = x
+
+	var b uint64
+	f.mMinus2[0], b = bits.Sub64(m[0], 2, 0)
+	f.mMinus2[1], b = bits.Sub64(m[1], 0, b)
+	f.mMinus2[2], b = bits.Sub64(m[2], 0, b)
+	f.mMinus2[3], _ = bits.Sub64(m[3], 0, b)
+
+	return f
+}
+
+// Modulus returns the modulus.
+func (f *Montgomery) Modulus() Limbs {
+	return f.m
+}
+
+// reduce sets z to x - m if x >= m, and to x otherwise, where x is given by its four low limbs and a carry.
+func (f *Montgomery) reduce(z, x *Limbs, carry uint64) {
+	var d Limbs
+
+	var b uint64
+	d[0], b = bits.Sub64(x[0], f.m[0], 0)
+	d[1], b = bits.Sub64(x[1], f.m[1], b)
+	d[2], b = bits.Sub64(x[2], f.m[2], b)
+	d[3], b = bits.Sub64(x[3], f.m[3], b)
+	_, b = bits.Sub64(carry, 0, b)
+
+	// b is 1 if x < m.
+	Select(z, x, &d, int(b))
+}
+
+// Select sets z to a if cond is 1, and to b if cond is 0, in constant time.
+func Select(z, a, b *Limbs, cond int) {
+	mask := -uint64(cond)
+	for i := range z {
+		z[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+}
+
+// Add sets z = x + y mod m.
+func (f *Montgomery) Add(z, x, y *Limbs) {
+	var (
+		s Limbs
+		c uint64
+	)
+
+	s[0], c = bits.Add64(x[0], y[0], 0)
+	s[1], c = bits.Add64(x[1], y[1], c)
+	s[2], c = bits.Add64(x[2], y[2], c)
+	s[3], c = bits.Add64(x[3], y[3], c)
+
+	f.reduce(z, &s, c)
+}
+
+// Sub sets z = x - y mod m.
+func (f *Montgomery) Sub(z, x, y *Limbs) {
+	var (
+		d    Limbs
+		b, c uint64
+	)
+
+	d[0], b = bits.Sub64(x[0], y[0], 0)
+	d[1], b = bits.Sub64(x[1], y[1], b)
+	d[2], b = bits.Sub64(x[2], y[2], b)
+	d[3], b = bits.Sub64(x[3], y[3], b)
+
+	// Add m back on underflow.
+	mask := -b
+	z[0], c = bits.Add64(d[0], f.m[0]&mask, 0)
+	z[1], c = bits.Add64(d[1], f.m[1]&mask, c)
+	z[2], c = bits.Add64(d[2], f.m[2]&mask, c)
+	z[3], _ = bits.Add64(d[3], f.m[3]&mask, c)
+}
+
+// Neg sets z = -x mod m.
+func (f *Montgomery) Neg(z, x *Limbs) {
+	f.Sub(z, &Limbs{}, x)
+}
+
+// Mul sets z = x * y / 2^256 mod m, i.e. the product in the Montgomery domain, with the CIOS method. It also converts
+// a value x < 2^256 into the Montgomery domain with y = 2^512 mod m, and out of it with y = 1.
+func (f *Montgomery) Mul(z, x, y *Limbs) {
+	var t [6]uint64
+
+	for i := range 4 {
+		var c, cc, hi, lo uint64
+
+		for j := range 4 {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		u := t[0] * f.mInv
+		hi, lo = bits.Mul64(u, f.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(u, f.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+
+	f.reduce(z, (*Limbs)(t[:4]), t[4])
+}
+
+// Square sets z = x^2.
+func (f *Montgomery) Square(z, x *Limbs) {
+	f.Mul(z, x, x)
+}
+
+// Exp sets z = x^e, with e a plain integer. The sequence of operations doesn't depend on x nor e.
+func (f *Montgomery) Exp(z, x *Limbs, e *Limbs) {
+	res, base := f.r, *x
+
+	var t Limbs
+
+	for i := 255; i >= 0; i-- {
+		f.Square(&res, &res)
+		f.Mul(&t, &res, &base)
+		Select(&res, &t, &res, int(e.Bit(i)))
+	}
+
+	*z = res
+}
+
+// Inv sets z = 1/x, and to 0 if x is 0.
+func (f *Montgomery) Inv(z, x *Limbs) {
+	f.Exp(z, x, &f.mMinus2)
+}
+
+// One sets z to 1.
+func (f *Montgomery) One(z *Limbs) {
+	*z = f.r
+}
+
+// SetUint64 sets z to v mod m.
+func (f *Montgomery) SetUint64(z *Limbs, v uint64) {
+	f.Mul(z, &Limbs{v}, &f.r2)
+}
+
+// SetBytes sets z to the big-endian 32-byte value, and returns 1 if it is canonical, i.e. less than m, and 0
+// otherwise, in which case z is set to the value reduced modulo m.
+func (f *Montgomery) SetBytes(z *Limbs, b *[32]byte) int {
+	v := LimbsFromBytes(b)
+
+	var bw uint64
+	_, bw = bits.Sub64(v[0], f.m[0], 0)
+	_, bw = bits.Sub64(v[1], f.m[1], bw)
+	_, bw = bits.Sub64(v[2], f.m[2], bw)
+	_, bw = bits.Sub64(v[3], f.m[3], bw)
+
+	f.Mul(z, &v, &f.r2)
+
+	return int(bw)
+}
+
+// SetWideBytes sets z to the big-endian value of up to 64 bytes reduced modulo m, e.g. for hash-to-field.
+func (f *Montgomery) SetWideBytes(z *Limbs, b []byte) {
+	if len(b) > 64 {
+		panic("wide input is longer than 64 bytes")
+	}
+
+	var wide [64]byte
+	copy(wide[64-len(b):], b)
+
+	// z = lo + hi * 2^256, where multiplying a Montgomery value by 2^512 mod m yields x * 2^256 in the domain.
+	lo, hi := LimbsFromBytes((*[32]byte)(wide[32:])), LimbsFromBytes((*[32]byte)(wide[:32]))
+	f.Mul(&lo, &lo, &f.r2)
+	f.Mul(&hi, &hi, &f.r2)
+	f.Mul(&hi, &hi, &f.r2)
+	f.Add(z, &lo, &hi)
+
+	clear(wide[:])
+}
+
+// Canonical returns the plain, fully reduced integer value of x.
+func (f *Montgomery) Canonical(x *Limbs) Limbs {
+	var z Limbs
+	f.Mul(&z, x, &Limbs{1})
+
+	return z
+}
+
+// Bytes returns the big-endian 32-byte encoding of the value of x.
+func (f *Montgomery) Bytes(x *Limbs) []byte {
+	z := f.Canonical(x)
+	return z.Bytes()
+}
+
+// IsZero returns 1 if x is 0, and 0 otherwise.
+func IsZero(x *Limbs) int {
+	or := x[0] | x[1] | x[2] | x[3]
+	return int(1 ^ (or|-or)>>63)
+}
+
+// Equal returns 1 if x == y, and 0 otherwise.
+func Equal(x, y *Limbs) int {
+	return IsZero(&Limbs{x[0] ^ y[0], x[1] ^ y[1], x[2] ^ y[2], x[3] ^ y[3]})
+}
+
+// Parity returns the least significant bit of the value of x, i.e. sgn0 in RFC 9380.
+func (f *Montgomery) Parity(x *Limbs) int {
+	z := f.Canonical(x)
+	return int(z[0] & 1)
+}
+
+// WipeLimbs overwrites l with zeroes.
+func WipeLimbs(l *Limbs) {
+	clear(l[:])
+}
@@ -49,3 +49,47 @@ type Group interface {
 	// Order returns the order of the canonical group of scalars.
 	Order() []byte
 }
+
+// FieldMapper is implemented by groups whose hash-to-curve maps elements of the curve's base field to the group, so
+// that the hash-to-field parameters can be chosen by the caller.
+type FieldMapper interface {
+	// FieldOrder returns the big-endian encoding of the order of the base field.
+	FieldOrder() []byte
+
+	// SecurityLength returns L, the byte length of the uniform strings reduced to field elements in the group's
+	// hash-to-curve suite.
+	SecurityLength() uint
+
+	// MapToGroup maps each of the big-endian encoded field elements, of the byte length of the field order, to the
+	// curve, and returns their sum with the cofactor cleared.
+	MapToGroup(u ...[]byte) Element
+}
+
+// UniformMapper is implemented by groups that map uniform byte strings of a fixed length to the group, like
+// ristretto255, instead of field elements.
+type UniformMapper interface {
+	// UniformLength returns the byte length of the uniform strings mapped to the group.
+	UniformLength() uint
+
+	// MapUniform returns the element the uniform string maps to.
+	MapUniform(uniform []byte) Element
+}
+
+// UncompressedCodec is implemented by the elements of the groups over short Weierstrass curves that have a SEC 1
+// uncompressed encoding, i.e. 0x04 || x || y.
+type UncompressedCodec interface {
+	// EncodeUncompressed returns the uncompressed encoding of the element, which must not be the identity.
+	EncodeUncompressed() []byte
+
+	// DecodeUncompressed sets the receiver to the decoding of the uncompressed encoding, and returns an error if it
+	// is not the encoding of a point on the curve.
+	DecodeUncompressed(data []byte) error
+}
+
+// Normalizer is implemented by the elements whose representation can be normalized to affine coordinates, i.e. Z = 1,
+// and whose addition is faster when the argument is. Only the Pallas elements implement it, as the other backends'
+// dependencies have no mixed addition.
+type Normalizer interface {
+	// Normalize sets the receiver's representation to affine coordinates, without changing the element.
+	Normalize()
+}
@@ -44,6 +44,12 @@ var (
 	// ErrIdentity indicates that the identity point (or point at infinity) has been encountered.
 	ErrIdentity = errors.New("infinity/identity point")
 
+	// ErrParamNonCanonicalEncoding indicates that a point encoding is valid but not the canonical one.
+	ErrParamNonCanonicalEncoding = errors.New("non-canonical point encoding")
+
+	// ErrParamNotInSubgroup indicates that a point is not in the prime-order subgroup.
+	ErrParamNotInSubgroup = errors.New("point is not in the prime-order subgroup")
+
 	// ErrBigIntConversion reports an error in converting to a *big.int.
 	ErrBigIntConversion = errors.New("conversion error")
 
@@ -56,6 +62,9 @@ var (
 	// ErrParamScalarInvalidEncoding indicates an invalid scalar encoding has been provided, or that it's too big.
 	ErrParamScalarInvalidEncoding = errors.New("invalid scalar encoding")
 
+	// ErrParamZeroScalar indicates that the scalar 0 has been encountered where it is not allowed.
+	ErrParamZeroScalar = errors.New("zero scalar")
+
 	// ErrUInt64TooBig indicates that the scalar is higher than the allowed values for uint64.
 	ErrUInt64TooBig = errors.New("scalar is too big to be uint64")
 
@@ -64,6 +73,21 @@ var (
 
 	// ErrDecodingInvalidJSONEncoding indicates an invalid JSON encoding.
 	ErrDecodingInvalidJSONEncoding = errors.New("invalid JSON encoding")
+
+	// ErrParamInfoTooLong indicates that the info parameter exceeds 65535 bytes.
+	ErrParamInfoTooLong = errors.New("info is too long")
+
+	// ErrDeriveKeyPair indicates that no valid key pair could be derived after 256 attempts.
+	ErrDeriveKeyPair = errors.New("key pair derivation failed")
+
+	// ErrSelfTest indicates that a known-answer self test failed.
+	ErrSelfTest = errors.New("self test failed")
+
+	// ErrShortDST indicates a DST shorter than the recommended 16 bytes.
+	ErrShortDST = errors.New("DST is shorter than 16 bytes")
+
+	// ErrUnknownSuite indicates a hash-to-curve suite identifier that doesn't match any available group.
+	ErrUnknownSuite = errors.New("unknown hash-to-curve suite")
 )
 
 // An Encoder can encode itself to machine or human-readable forms.
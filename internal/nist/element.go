@@ -9,6 +9,7 @@
 package nist
 
 import (
+	"bytes"
 	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
@@ -148,11 +149,30 @@ func (e *Element[P]) Multiply(scalar internal.Scalar) internal.Element {
 	return e
 }
 
-// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+// Equal returns 1 if the elements are equivalent, and 0 otherwise, in constant time. nistec encodes the identity to a
+// single byte, so the encodings are compared at a fixed length to not leak whether either element is the identity.
 func (e *Element[Point]) Equal(element internal.Element) int {
 	ec := checkElement[Point](element)
+	length := e.compressedLength()
 
-	return subtle.ConstantTimeCompare(e.p.Bytes(), ec.p.Bytes())
+	a := make([]byte, length)
+	b := make([]byte, length)
+
+	copy(a, e.p.BytesCompressed())
+	copy(b, ec.p.BytesCompressed())
+
+	return subtle.ConstantTimeCompare(a, b)
+}
+
+func (e *Element[Point]) compressedLength() int {
+	switch e.Group() {
+	case IdentifierP256:
+		return p256CompressedEncodingLength
+	case IdentifierP384:
+		return p384CompressedEncodingLength
+	default:
+		return p521CompressedEncodingLength
+	}
 }
 
 // IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
@@ -163,6 +183,17 @@ func (e *Element[P]) IsIdentity() bool {
 	return subtle.ConstantTimeCompare(b, i) == 1
 }
 
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, which is always the case in a
+// prime-order group.
+func (e *Element[P]) IsTorsionFree() bool {
+	return true
+}
+
+// ClearCofactor multiplies the receiver by the cofactor, and returns it. The cofactor is 1, so this is a no-op.
+func (e *Element[P]) ClearCofactor() internal.Element {
+	return e
+}
+
 // Set sets the receiver to the value of the argument, and returns the receiver.
 func (e *Element[P]) Set(element internal.Element) internal.Element {
 	if element == nil {
@@ -229,12 +260,48 @@ func (e *Element[P]) XCoordinate() []byte {
 	return b
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. Only the compressed
+// encoding of points other than the identity is accepted.
 func (e *Element[P]) Decode(data []byte) error {
-	if _, err := e.p.SetBytes(data); err != nil {
+	p, err := e.new().SetBytes(data)
+	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
 
+	// SetBytes also accepts the single byte identity encoding.
+	if len(data) == 1 {
+		return fmt.Errorf("%w", internal.ErrIdentity)
+	}
+
+	// SetBytes also accepts the uncompressed encoding.
+	if !bytes.Equal(p.BytesCompressed(), data) {
+		return fmt.Errorf("%w", internal.ErrParamNonCanonicalEncoding)
+	}
+
+	e.p.Set(p)
+
+	return nil
+}
+
+// EncodeUncompressed returns the uncompressed encoding of the element, which must not be the identity.
+func (e *Element[P]) EncodeUncompressed() []byte {
+	return e.p.Bytes()
+}
+
+// DecodeUncompressed sets the receiver to the decoding of the uncompressed encoding, and returns an error on failure.
+func (e *Element[P]) DecodeUncompressed(data []byte) error {
+	// SetBytes also accepts the compressed and the identity encodings.
+	if len(data) == 0 || data[0] != 0x04 {
+		return fmt.Errorf("%w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	p, err := e.new().SetBytes(data)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	e.p.Set(p)
+
 	return nil
 }
 
@@ -252,3 +319,8 @@ func (e *Element[P]) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element[P]) Wipe() {
+	e.p.Set(e.new())
+}
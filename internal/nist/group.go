@@ -12,6 +12,7 @@ package nist
 
 import (
 	"crypto"
+	"crypto/elliptic"
 	"sync"
 
 	"filippo.io/nistec"
@@ -146,6 +147,12 @@ func (g Group[P]) EncodeToGroup(input, dst []byte) internal.Element {
 	return g.newPoint(g.mapToCurve(input, dst))
 }
 
+// FieldOrder returns the big-endian encoding of the order of the base field.
+func (g Group[P]) FieldOrder() []byte {
+	out := make([]byte, g.baseField.ByteLen())
+	return g.baseField.Order().FillBytes(out)
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func (g Group[P]) Ciphersuite() string {
 	return g.h2c
@@ -187,6 +194,7 @@ func initP256() {
 		nistP256.HashToCurve,
 		nistP256.EncodeToCurve,
 	)
+	p256.setBaseField(elliptic.P256().Params().P)
 	setScalarField(&p256, "0xffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551")
 }
 
@@ -200,6 +208,7 @@ func initP384() {
 		nistP384.HashToCurve,
 		nistP384.EncodeToCurve,
 	)
+	p384.setBaseField(elliptic.P384().Params().P)
 	setScalarField(&p384,
 		"0xffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973",
 	)
@@ -215,6 +224,7 @@ func initP521() {
 		nistP521.HashToCurve,
 		nistP521.EncodeToCurve,
 	)
+	p521.setBaseField(elliptic.P521().Params().P)
 	setScalarField(&p521,
 		"0x1fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"+
 			"a51868783bf2f966b7fcc0148f709a5d03bb5c9b8899c47aebb6fb71e91386409",
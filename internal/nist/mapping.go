@@ -11,12 +11,15 @@ package nist
 import (
 	"crypto"
 	"math/big"
+
+	"github.com/bytemare/ecc/internal/field"
 )
 
 type mapping[point nistECPoint[point]] struct {
 	hashToScalar hashToScalar[point]
 	hashToCurve  hashToCurve[point]
 	mapToCurve   mapToCurve[point]
+	baseField    field.Field
 	hash         crypto.Hash
 }
 
@@ -37,3 +40,8 @@ func (m *mapping[point]) setMapping(
 	m.hashToCurve = h2c
 	m.mapToCurve = m2c
 }
+
+// setBaseField sets the base field of the curve, with the given prime order.
+func (m *mapping[point]) setBaseField(p *big.Int) {
+	m.baseField = field.NewField(p)
+}
@@ -144,7 +144,7 @@ func (s *Scalar) Equal(scalar internal.Scalar) int {
 
 	sc := s.assert(scalar)
 
-	return subtle.ConstantTimeCompare(s.scalar.Bytes(), sc.scalar.Bytes())
+	return subtle.ConstantTimeCompare(s.Encode(), sc.Encode())
 }
 
 // LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
@@ -268,6 +268,11 @@ func (s *Scalar) DecodeHex(h string) error {
 	return s.Decode(b)
 }
 
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	field.Wipe(&s.scalar)
+}
+
 func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
 	_sc, ok := scalar.(*Scalar)
 	if !ok {
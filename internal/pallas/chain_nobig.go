@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+// chainWindow is the window width of the addition chains, with 2^(chainWindow-1) precomputed odd powers.
+const chainWindow = 4
+
+// chainStep is a step of an addition chain: squarings of the accumulator, followed by a multiplication with the odd
+// power of index mul in the precomputed table, if mul is not negative.
+type chainStep struct {
+	squarings int
+	mul       int
+}
+
+// newChain returns the sliding window addition chain computing x^e from the odd powers x, x^3, ...,
+// x^(2^chainWindow - 1), for the non-zero exponent e of the given bit length, with bit returning its i-th bit. The
+// chain only depends on the exponent, which is a public constant, so that exponentiations along it run in constant
+// time. The accumulator starts at the power of the first step, without squarings.
+func newChain(bitLen int, bit func(i int) uint) []chainStep {
+	var steps []chainStep
+
+	pending := 0
+
+	for i := bitLen - 1; i >= 0; {
+		if bit(i) == 0 {
+			pending++
+			i--
+
+			continue
+		}
+
+		// The window spans bits i down to j, and ends with a set bit, so that its value is odd.
+		j := max(i-chainWindow+1, 0)
+		for bit(j) == 0 {
+			j++
+		}
+
+		value := 0
+		for k := i; k >= j; k-- {
+			value = value<<1 | int(bit(k))
+		}
+
+		steps = append(steps, chainStep{squarings: pending + i - j + 1, mul: value >> 1})
+		pending = 0
+		i = j - 1
+	}
+
+	steps[0].squarings = 0
+
+	if pending != 0 {
+		steps = append(steps, chainStep{squarings: pending, mul: -1})
+	}
+
+	return steps
+}
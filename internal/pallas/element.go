@@ -6,6 +6,8 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !tinygo && !ecc_nobig
+
 package pallas
 
 import (
@@ -111,19 +113,25 @@ func (e *Element) Add(element internal.Element) internal.Element {
 	z1z1 := new(big.Int).Mul(&e.z, &e.z)
 	z1z1.Mod(z1z1, p)
 
-	z2z2 := new(big.Int).Mul(&q.z, &q.z)
-	z2z2.Mod(z2z2, p)
+	// If q is normalized, i.e. Z2 = 1, this is the mixed addition, and the products with Z2 are skipped.
+	affine := q.z.IsInt64() && q.z.Int64() == 1
+	z2z2, u1, s1 := big.NewInt(1), new(big.Int).Set(&e.x), new(big.Int).Set(&e.y)
+
+	if !affine {
+		z2z2.Mul(&q.z, &q.z)
+		z2z2.Mod(z2z2, p)
 
-	u1 := new(big.Int).Mul(&e.x, z2z2)
-	u1.Mod(u1, p)
+		u1.Mul(u1, z2z2)
+		u1.Mod(u1, p)
+
+		s1.Mul(s1, &q.z)
+		s1.Mul(s1, z2z2)
+		s1.Mod(s1, p)
+	}
 
 	u2 := new(big.Int).Mul(&q.x, z1z1)
 	u2.Mod(u2, p)
 
-	s1 := new(big.Int).Mul(&e.y, &q.z)
-	s1.Mul(s1, z2z2)
-	s1.Mod(s1, p)
-
 	s2 := new(big.Int).Mul(&q.y, &e.z)
 	s2.Mul(s2, z1z1)
 	s2.Mod(s2, p)
@@ -186,6 +194,19 @@ func (e *Element) Add(element internal.Element) internal.Element {
 	return e
 }
 
+// Normalize sets the receiver's representation to affine coordinates, i.e. Z = 1, so that adding it to other elements
+// uses the cheaper mixed addition. The identity is left as is.
+func (e *Element) Normalize() {
+	if e.isIdentityInternal() {
+		return
+	}
+
+	x, y := e.toAffine()
+	e.x.Set(x)
+	e.y.Set(y)
+	e.z.SetInt64(1)
+}
+
 // Double sets the receiver to its double, and returns it.
 // Uses doubling formula for short Weierstrass curves with a=0.
 func (e *Element) Double() internal.Element {
@@ -316,6 +337,17 @@ func (e *Element) IsIdentity() bool {
 	return e.isIdentityInternal()
 }
 
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, which is always the case in a
+// prime-order group.
+func (e *Element) IsTorsionFree() bool {
+	return true
+}
+
+// ClearCofactor multiplies the receiver by the cofactor, and returns it. The cofactor is 1, so this is a no-op.
+func (e *Element) ClearCofactor() internal.Element {
+	return e
+}
+
 // Set sets the receiver to the value of the argument, and returns the receiver.
 func (e *Element) Set(element internal.Element) internal.Element {
 	if element == nil {
@@ -339,17 +371,15 @@ func (e *Element) Copy() internal.Element {
 	return cpy
 }
 
-// toAffine converts from Jacobian to affine coordinates.
+// toAffine converts from Jacobian to affine coordinates. z is inverted by exponentiation rather than with the extended
+// Euclidean algorithm, and the inverse of 0 is 0, so the identity maps to (0, 0) without branching on it.
 func (e *Element) toAffine() (x, y *big.Int) {
-	if e.isIdentityInternal() {
-		return big.NewInt(0), big.NewInt(0)
-	}
-
 	p := e.field.Order()
 
 	// z^-1
-	zinv := new(big.Int).ModInverse(&e.z, p)
-	
+	zinv := new(big.Int)
+	e.field.Inv(zinv, &e.z)
+
 	// z^-2
 	zinv2 := new(big.Int).Mul(zinv, zinv)
 	zinv2.Mod(zinv2, p)
@@ -371,56 +401,38 @@ func (e *Element) toAffine() (x, y *big.Int) {
 
 // Encode returns the compressed byte encoding of the element.
 // Format: 0x00 for identity, 0x02/0x03 + x-coordinate (33 bytes total)
+// The identity and the sign of y are selected with masks and the x-coordinate is written at a fixed length, so the
+// encoding doesn't branch on the element. Note that big.Int arithmetic itself is not guaranteed to run in constant
+// time: use the ecc_nobig build for secret-derived points.
 func (e *Element) Encode() []byte {
-	if e.isIdentityInternal() {
-		return make([]byte, elementLength)
-	}
-
 	x, y := e.toAffine()
+	notIdentity := 1 ^ subtle.ConstantTimeByteEq(byte(e.z.Sign()), 0)
 
 	enc := make([]byte, elementLength)
-	
-	// Determine sign of y
-	if y.Bit(0) == 0 {
-		enc[0] = 0x02
-	} else {
-		enc[0] = 0x03
-	}
-
-	xBytes := x.Bytes()
-	copy(enc[elementLength-len(xBytes):], xBytes)
+	enc[0] = byte(subtle.ConstantTimeSelect(notIdentity, 0x02|int(y.Bit(0)), 0))
+	x.FillBytes(enc[1:])
 
 	return enc
 }
 
-// XCoordinate returns the encoded x coordinate of the element.
+// XCoordinate returns the encoded x coordinate of the element, which is 0 for the identity.
 func (e *Element) XCoordinate() []byte {
-	if e.isIdentityInternal() {
-		return make([]byte, scalarLength)
-	}
-
 	x, _ := e.toAffine()
 	out := make([]byte, scalarLength)
+
 	return x.FillBytes(out)
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. The square root and the
+// selection of the root matching the sign bit run in constant time, so decoding secret-derived points doesn't leak
+// them.
 func (e *Element) Decode(data []byte) error {
 	if len(data) != elementLength {
 		return internal.ErrParamInvalidPointEncoding
 	}
 
-	// Check for identity
-	isZero := true
-	for _, b := range data {
-		if b != 0 {
-			isZero = false
-			break
-		}
-	}
-	if isZero {
-		e.Identity()
-		return nil
+	if subtle.ConstantTimeCompare(data, make([]byte, elementLength)) == 1 {
+		return internal.ErrIdentity
 	}
 
 	// Check header
@@ -428,30 +440,37 @@ func (e *Element) Decode(data []byte) error {
 		return internal.ErrParamInvalidPointEncoding
 	}
 
-	p := e.field.Order()
+	f := e.field
+	p := f.Order()
 
 	// Extract x coordinate
 	x := new(big.Int).SetBytes(data[1:])
-	
+
 	if x.Cmp(p) >= 0 {
 		return internal.ErrParamInvalidPointEncoding
 	}
 
 	// Compute y² = x³ + b (for Pallas, a=0, b=5)
-	y2 := new(big.Int).Mul(x, x)
-	y2.Mul(y2, x)
-	y2.Add(y2, curveB)
-	y2.Mod(y2, p)
-
-	// Compute y = sqrt(y²) using Tonelli-Shanks
-	y := e.sqrt(y2, p)
-	if y == nil {
+	y2 := new(big.Int)
+	f.Mul(y2, x, x)
+	f.Mul(y2, y2, x)
+	f.Add(y2, y2, curveB)
+
+	y, isSquare := baseSqrt.sqrt(f, y2)
+
+	// Select the root with the parity of the sign bit.
+	sign := int(data[0] & 1)
+	flip := int(y.Bit(0)) ^ sign
+	negY := f.Sub(new(big.Int), p, y)
+	ctSelect(f, y, negY, y, flip)
+
+	// y = 0 has a single encoding, with the even sign: the root then has the wrong parity.
+	if isSquare == 0 {
 		return internal.ErrParamInvalidPointEncoding
 	}
 
-	// Select the correct root based on parity
-	if (data[0] == 0x02) != (y.Bit(0) == 0) {
-		y.Sub(p, y)
+	if int(y.Bit(0)) != sign {
+		return internal.ErrParamNonCanonicalEncoding
 	}
 
 	// Set the point in Jacobian coordinates (affine: Z=1)
@@ -462,71 +481,6 @@ func (e *Element) Decode(data []byte) error {
 	return nil
 }
 
-// sqrt computes the modular square root using the Tonelli-Shanks algorithm.
-// Returns nil if n is not a quadratic residue mod p.
-func (e *Element) sqrt(n, p *big.Int) *big.Int {
-	// For Pallas, p ≡ 1 (mod 4), so we can use the simpler formula
-	// when p ≡ 3 (mod 4): sqrt = n^((p+1)/4)
-	// But Pallas p ≡ 1 (mod 4), so we need Tonelli-Shanks
-
-	// Check if n is a quadratic residue
-	legendre := new(big.Int).Exp(n, new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 1), p)
-	if legendre.Cmp(big.NewInt(1)) != 0 {
-		return nil // Not a quadratic residue
-	}
-
-	// Factor out powers of 2 from p - 1
-	// p - 1 = Q * 2^S
-	q := new(big.Int).Sub(p, big.NewInt(1))
-	s := uint(0)
-	for q.Bit(0) == 0 {
-		q.Rsh(q, 1)
-		s++
-	}
-
-	// Find a non-residue z
-	z := big.NewInt(2)
-	for new(big.Int).Exp(z, new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 1), p).Cmp(new(big.Int).Sub(p, big.NewInt(1))) != 0 {
-		z.Add(z, big.NewInt(1))
-	}
-
-	m := s
-	c := new(big.Int).Exp(z, q, p)
-	t := new(big.Int).Exp(n, q, p)
-	r := new(big.Int).Exp(n, new(big.Int).Add(new(big.Int).Rsh(q, 0), big.NewInt(1)).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1), p)
-
-	for {
-		if t.Cmp(big.NewInt(1)) == 0 {
-			return r
-		}
-
-		// Find the least i such that t^(2^i) = 1
-		i := uint(1)
-		tmp := new(big.Int).Mul(t, t)
-		tmp.Mod(tmp, p)
-		for tmp.Cmp(big.NewInt(1)) != 0 {
-			tmp.Mul(tmp, tmp)
-			tmp.Mod(tmp, p)
-			i++
-		}
-
-		// b = c^(2^(m-i-1))
-		b := new(big.Int).Set(c)
-		for j := uint(0); j < m-i-1; j++ {
-			b.Mul(b, b)
-			b.Mod(b, p)
-		}
-
-		m = i
-		c.Mul(b, b)
-		c.Mod(c, p)
-		t.Mul(t, c)
-		t.Mod(t, p)
-		r.Mul(r, b)
-		r.Mod(r, p)
-	}
-}
-
 // Hex returns the fixed-sized hexadecimal encoding of e.
 func (e *Element) Hex() string {
 	return hex.EncodeToString(e.Encode())
@@ -541,3 +495,11 @@ func (e *Element) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element) Wipe() {
+	field.Wipe(&e.x)
+	field.Wipe(&e.y)
+	field.Wipe(&e.z)
+	e.Identity()
+}
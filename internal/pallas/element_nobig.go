@@ -0,0 +1,405 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/field"
+)
+
+// Generator point coordinates
+// Gx = 0x40000000000000000000000000000000224698fc094cf91b992d30ed00000000
+// Gy = 0x02
+const generatorXHex = "0x40000000000000000000000000000000224698fc094cf91b992d30ed00000000"
+
+// The curve constants, in the Montgomery domain of the base field: b = 5, 3b for the complete formulas, and the
+// generator.
+var curveB, curveB3, generatorX, generatorY field.Limbs
+
+func initCurveConstants() {
+	fp.SetUint64(&curveB, 5)
+	fp.SetUint64(&curveB3, 15)
+	fp.SetBytes(&generatorX, hexToBytes(generatorXHex))
+	fp.SetUint64(&generatorY, 2)
+}
+
+// Element implements the Element interface for the Pallas group element.
+// Points are stored in projective coordinates (X, Y, Z) where the affine point is (X/Z, Y/Z), and the identity is
+// (0, 1, 0). Additions use the complete formulas of Renes, Costello, and Batina for a = 0, which have no exceptional
+// cases and don't branch.
+type Element struct {
+	x, y, z field.Limbs
+}
+
+func newElement() *Element {
+	e := &Element{}
+	e.Identity()
+
+	return e
+}
+
+func assertElement(element internal.Element) *Element {
+	if element == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	ec, ok := element.(*Element)
+	if !ok {
+		panic(internal.ErrCastElement)
+	}
+
+	return ec
+}
+
+// Group returns the group's Identifier.
+func (e *Element) Group() byte {
+	return Identifier
+}
+
+// Base sets the element to the group's base point a.k.a. canonical generator.
+func (e *Element) Base() internal.Element {
+	e.x = generatorX
+	e.y = generatorY
+	fp.One(&e.z)
+
+	return e
+}
+
+// Identity sets the element to the point at infinity of the Group's underlying curve.
+func (e *Element) Identity() internal.Element {
+	e.x = field.Limbs{}
+	fp.One(&e.y)
+	e.z = field.Limbs{}
+
+	return e
+}
+
+// add sets e = p + q, with Algorithm 7 of "Complete addition formulas for prime order elliptic curves".
+func (e *Element) add(p, q *Element) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 field.Limbs
+
+	fp.Mul(&t0, &p.x, &q.x) // t0 = X1 * X2
+	fp.Mul(&t1, &p.y, &q.y) // t1 = Y1 * Y2
+	fp.Mul(&t2, &p.z, &q.z) // t2 = Z1 * Z2
+	fp.Add(&t3, &p.x, &p.y) // t3 = X1 + Y1
+	fp.Add(&t4, &q.x, &q.y) // t4 = X2 + Y2
+	fp.Mul(&t3, &t3, &t4)   // t3 = t3 * t4
+	fp.Add(&t4, &t0, &t1)   // t4 = t0 + t1
+	fp.Sub(&t3, &t3, &t4)   // t3 = t3 - t4
+	fp.Add(&t4, &p.y, &p.z) // t4 = Y1 + Z1
+	fp.Add(&x3, &q.y, &q.z) // X3 = Y2 + Z2
+	fp.Mul(&t4, &t4, &x3)   // t4 = t4 * X3
+	fp.Add(&x3, &t1, &t2)   // X3 = t1 + t2
+	fp.Sub(&t4, &t4, &x3)   // t4 = t4 - X3
+	fp.Add(&x3, &p.x, &p.z) // X3 = X1 + Z1
+	fp.Add(&y3, &q.x, &q.z) // Y3 = X2 + Z2
+	fp.Mul(&x3, &x3, &y3)   // X3 = X3 * Y3
+	fp.Add(&y3, &t0, &t2)   // Y3 = t0 + t2
+	fp.Sub(&y3, &x3, &y3)   // Y3 = X3 - Y3
+	fp.Add(&x3, &t0, &t0)   // X3 = t0 + t0
+	fp.Add(&t0, &x3, &t0)   // t0 = X3 + t0
+	fp.Mul(&t2, &curveB3, &t2)
+	fp.Add(&z3, &t1, &t2) // Z3 = t1 + t2
+	fp.Sub(&t1, &t1, &t2) // t1 = t1 - t2
+	fp.Mul(&y3, &curveB3, &y3)
+	fp.Mul(&x3, &t4, &y3) // X3 = t4 * Y3
+	fp.Mul(&t2, &t3, &t1) // t2 = t3 * t1
+	fp.Sub(&x3, &t2, &x3) // X3 = t2 - X3
+	fp.Mul(&y3, &y3, &t0) // Y3 = Y3 * t0
+	fp.Mul(&t1, &t1, &z3) // t1 = t1 * Z3
+	fp.Add(&y3, &t1, &y3) // Y3 = t1 + Y3
+	fp.Mul(&t0, &t0, &t3) // t0 = t0 * t3
+	fp.Mul(&z3, &z3, &t4) // Z3 = Z3 * t4
+	fp.Add(&z3, &z3, &t0) // Z3 = Z3 + t0
+
+	e.x, e.y, e.z = x3, y3, z3
+}
+
+// double sets e = 2p, with Algorithm 9 of "Complete addition formulas for prime order elliptic curves".
+func (e *Element) double(p *Element) {
+	var t0, t1, t2, x3, y3, z3 field.Limbs
+
+	fp.Square(&t0, &p.y)    // t0 = Y * Y
+	fp.Add(&z3, &t0, &t0)   // Z3 = t0 + t0
+	fp.Add(&z3, &z3, &z3)   // Z3 = Z3 + Z3
+	fp.Add(&z3, &z3, &z3)   // Z3 = Z3 + Z3
+	fp.Mul(&t1, &p.y, &p.z) // t1 = Y * Z
+	fp.Square(&t2, &p.z)    // t2 = Z * Z
+	fp.Mul(&t2, &curveB3, &t2)
+	fp.Mul(&x3, &t2, &z3)   // X3 = t2 * Z3
+	fp.Add(&y3, &t0, &t2)   // Y3 = t0 + t2
+	fp.Mul(&z3, &t1, &z3)   // Z3 = t1 * Z3
+	fp.Add(&t1, &t2, &t2)   // t1 = t2 + t2
+	fp.Add(&t2, &t1, &t2)   // t2 = t1 + t2
+	fp.Sub(&t0, &t0, &t2)   // t0 = t0 - t2
+	fp.Mul(&y3, &t0, &y3)   // Y3 = t0 * Y3
+	fp.Add(&y3, &x3, &y3)   // Y3 = X3 + Y3
+	fp.Mul(&t1, &p.x, &p.y) // t1 = X * Y
+	fp.Mul(&x3, &t0, &t1)   // X3 = t0 * t1
+	fp.Add(&x3, &x3, &x3)   // X3 = X3 + X3
+
+	e.x, e.y, e.z = x3, y3, z3
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *Element) Add(element internal.Element) internal.Element {
+	q := assertElement(element)
+	e.add(e, q)
+
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *Element) Double() internal.Element {
+	e.double(e)
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *Element) Negate() internal.Element {
+	fp.Neg(&e.y, &e.y)
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *Element) Subtract(element internal.Element) internal.Element {
+	q := assertElement(element)
+	neg := &Element{x: q.x, z: q.z}
+	fp.Neg(&neg.y, &q.y)
+	e.add(e, neg)
+
+	return e
+}
+
+// selectElement sets e to a if cond is 1, and to b if cond is 0, in constant time.
+func (e *Element) selectElement(a, b *Element, cond int) {
+	field.Select(&e.x, &a.x, &b.x, cond)
+	field.Select(&e.y, &a.y, &b.y, cond)
+	field.Select(&e.z, &a.z, &b.z, cond)
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// It uses a fixed 4-bit window with constant-time table lookups, so the sequence of operations doesn't depend on
+// the scalar.
+func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
+	if scalar == nil {
+		return e.Identity()
+	}
+
+	sc := assertScalar(scalar)
+
+	var table [16]Element
+
+	table[0].Identity()
+	table[1] = *e
+
+	for i := 2; i < 16; i++ {
+		table[i].add(&table[i-1], e)
+	}
+
+	k := fq.Canonical(&sc.scalar)
+	res := newElement()
+
+	var t Element
+
+	for i := 252; i >= 0; i -= 4 {
+		for range 4 {
+			res.double(res)
+		}
+
+		w := int(k[i/64] >> (i % 64) & 0xf)
+		for j := range table {
+			t.selectElement(&table[j], &t, subtle.ConstantTimeEq(int32(j), int32(w)))
+		}
+
+		res.add(res, &t)
+	}
+
+	*e = *res
+
+	return e
+}
+
+// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+func (e *Element) Equal(element internal.Element) int {
+	q := assertElement(element)
+
+	var l, r field.Limbs
+
+	fp.Mul(&l, &e.x, &q.z)
+	fp.Mul(&r, &q.x, &e.z)
+	x := field.Equal(&l, &r)
+
+	fp.Mul(&l, &e.y, &q.z)
+	fp.Mul(&r, &q.y, &e.z)
+
+	return x & field.Equal(&l, &r)
+}
+
+// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
+func (e *Element) IsIdentity() bool {
+	return field.IsZero(&e.z) == 1
+}
+
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, which is always the case in a
+// prime-order group.
+func (e *Element) IsTorsionFree() bool {
+	return true
+}
+
+// ClearCofactor multiplies the receiver by the cofactor, and returns it. The cofactor is 1, so this is a no-op.
+func (e *Element) ClearCofactor() internal.Element {
+	return e
+}
+
+// Set sets the receiver to the value of the argument, and returns the receiver.
+func (e *Element) Set(element internal.Element) internal.Element {
+	if element == nil {
+		return e.Identity()
+	}
+
+	q := assertElement(element)
+	*e = *q
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *Element) Copy() internal.Element {
+	cpy := *e
+	return &cpy
+}
+
+// Normalize sets the receiver's representation to affine coordinates, i.e. Z = 1, as the math/big backend does, in
+// constant time. The identity is left as is. The complete formulas have no cheaper mixed addition, so it only keeps
+// the representations of both backends alike.
+func (e *Element) Normalize() {
+	var one field.Limbs
+
+	x, y := e.toAffine()
+	fp.One(&one)
+	notIdentity := 1 ^ field.IsZero(&e.z)
+
+	field.Select(&e.x, &x, &e.x, notIdentity)
+	field.Select(&e.y, &y, &e.y, notIdentity)
+	field.Select(&e.z, &one, &e.z, notIdentity)
+}
+
+// toAffine returns the affine coordinates of e. Since the inverse of 0 is 0, the identity maps to (0, 0).
+func (e *Element) toAffine() (x, y field.Limbs) {
+	var zInv field.Limbs
+
+	fp.Inv(&zInv, &e.z)
+	fp.Mul(&x, &e.x, &zInv)
+	fp.Mul(&y, &e.y, &zInv)
+
+	return x, y
+}
+
+// Encode returns the compressed byte encoding of the element.
+// Format: 0x00 for identity, 0x02/0x03 + x-coordinate (33 bytes total)
+// It runs in constant time: the header is masked to 0 for the identity, whose affine x-coordinate is 0, so encoding
+// secret-derived points is safe.
+func (e *Element) Encode() []byte {
+	x, y := e.toAffine()
+	notIdentity := byte(1 ^ field.IsZero(&e.z))
+
+	enc := make([]byte, elementLength)
+	enc[0] = -notIdentity & (0x02 | byte(fp.Parity(&y)))
+	copy(enc[1:], fp.Bytes(&x))
+
+	return enc
+}
+
+// XCoordinate returns the encoded x coordinate of the element, which is 0 for the identity.
+func (e *Element) XCoordinate() []byte {
+	x, _ := e.toAffine()
+
+	return fp.Bytes(&x)
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. The square root and the
+// selection of the root matching the sign bit run in constant time, so decoding secret-derived points doesn't leak
+// them.
+func (e *Element) Decode(data []byte) error {
+	if len(data) != elementLength {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	if subtle.ConstantTimeCompare(data, make([]byte, elementLength)) == 1 {
+		return internal.ErrIdentity
+	}
+
+	// Check header
+	if data[0] != 0x02 && data[0] != 0x03 {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	var x, y, y2, negY field.Limbs
+
+	if fp.SetBytes(&x, (*[32]byte)(data[1:])) == 0 {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	// Compute y² = x³ + b (for Pallas, a=0, b=5)
+	fp.Square(&y2, &x)
+	fp.Mul(&y2, &y2, &x)
+	fp.Add(&y2, &y2, &curveB)
+
+	isSquare := baseSqrt.sqrt(fp, &y, &y2)
+
+	// Select the root with the parity of the sign bit.
+	sign := int(data[0] & 1)
+	fp.Neg(&negY, &y)
+	field.Select(&y, &negY, &y, fp.Parity(&y)^sign)
+
+	// y = 0 has a single encoding, with the even sign: the root then has the wrong parity.
+	if isSquare == 0 {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	if fp.Parity(&y) != sign {
+		return internal.ErrParamNonCanonicalEncoding
+	}
+
+	e.x = x
+	e.y = y
+	fp.One(&e.z)
+
+	return nil
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of e.
+func (e *Element) Hex() string {
+	return hex.EncodeToString(e.Encode())
+}
+
+// DecodeHex sets e to the decoding of the hex encoded element.
+func (e *Element) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return e.Decode(b)
+}
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element) Wipe() {
+	field.WipeLimbs(&e.x)
+	field.WipeLimbs(&e.y)
+	field.WipeLimbs(&e.z)
+	e.Identity()
+}
@@ -6,7 +6,8 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
-// Package pallas allows simple and abstracted operations in the Pallas group.
+//go:build !tinygo && !ecc_nobig
+
 package pallas
 
 import (
@@ -17,35 +18,12 @@ import (
 	"github.com/bytemare/ecc/internal/field"
 )
 
-const (
-	// Identifier distinguishes this group from the others by a byte representation.
-	Identifier = byte(8)
-
-	// H2CPallas represents the hash-to-curve string identifier for Pallas.
-	H2CPallas = "pallas_XMD:BLAKE2b-256_SSWU_RO_"
-
-	// E2CPallas represents the encode-to-curve string identifier for Pallas.
-	E2CPallas = "pallas_XMD:BLAKE2b-256_SSWU_NU_"
-
-	// scalarLength is the byte size of encoded scalars.
-	scalarLength = 32
-
-	// elementLength is the byte size of compressed encoded elements.
-	elementLength = 33
-
-	// Pallas curve parameters
-	// p is the field modulus: 0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001
-	// n is the group order: 0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001
-	// a = 0, b = 5 (curve equation: y² = x³ + 5)
-	// h = 1 (cofactor)
-
-	pallasFieldOrder = "0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"
-	pallasGroupOrder = "0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"
-)
-
 var (
 	initOnce    sync.Once
 	groupPallas *Group
+
+	// baseSqrt holds the square root constants of the base field, used in point decompression.
+	baseSqrt *sqrtConstants
 )
 
 // Group represents the Pallas group. It exposes a prime-order group API with hash-to-curve operations.
@@ -68,6 +46,8 @@ func initPallas() {
 		scalarField: field.NewField(&scalarOrder),
 		baseField:   field.NewField(&fieldOrder),
 	}
+
+	baseSqrt = newSqrtConstants(&groupPallas.baseField, pallasNonSquare)
 }
 
 // NewScalar returns a new scalar set to 0.
@@ -110,6 +90,24 @@ func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
 	return encodeToGroup(&g.baseField, input, dst)
 }
 
+// FieldOrder returns the big-endian encoding of the order of the base field.
+func (g *Group) FieldOrder() []byte {
+	out := make([]byte, scalarLength)
+	return g.baseField.Order().FillBytes(out)
+}
+
+// SecurityLength returns L, the byte length of the uniform strings reduced to field elements in the group's
+// hash-to-curve suite.
+func (g *Group) SecurityLength() uint {
+	return hashToFieldLength
+}
+
+// MapToGroup maps each of the big-endian encoded field elements to the curve with the simplified SWU map and the
+// 3-isogeny, and returns their sum.
+func (g *Group) MapToGroup(u ...[]byte) internal.Element {
+	return mapToGroup(&g.baseField, u...)
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func (g *Group) Ciphersuite() string {
 	return H2CPallas
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+import (
+	"crypto"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/field"
+)
+
+var (
+	initOnce    sync.Once
+	groupPallas *Group
+
+	// fp and fq are the arithmetic of the base field and of the scalar field.
+	fp, fq *field.Montgomery
+
+	// baseSqrt holds the square root constants of the base field, used in point decompression.
+	baseSqrt *sqrtConstants
+)
+
+// Group represents the Pallas group. It exposes a prime-order group API with hash-to-curve operations.
+type Group struct{}
+
+// New returns a new instantiation of the Pallas Group.
+func New() internal.Group {
+	initOnce.Do(initPallas)
+	return groupPallas
+}
+
+func initPallas() {
+	fp = field.NewMontgomery(hexToBytes(pallasFieldOrder))
+	fq = field.NewMontgomery(hexToBytes(pallasGroupOrder))
+	baseSqrt = newSqrtConstants(fp, pallasNonSquare)
+
+	initCurveConstants()
+	initMapConstants()
+
+	groupPallas = &Group{}
+}
+
+// hexToBytes returns the 32-byte big-endian value of the "0x"-prefixed hexadecimal constant.
+func hexToBytes(h string) *[32]byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(h, "0x"))
+	if err != nil || len(b) > 32 {
+		panic("invalid Pallas constant")
+	}
+
+	var out [32]byte
+	copy(out[32-len(b):], b)
+
+	return &out
+}
+
+// NewScalar returns a new scalar set to 0.
+func (g *Group) NewScalar() internal.Scalar {
+	return newScalar()
+}
+
+// NewElement returns the identity element (point at infinity).
+func (g *Group) NewElement() internal.Element {
+	return newElement()
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (g *Group) Base() internal.Element {
+	return newElement().Base()
+}
+
+// HashFunc returns the RFC9380 associated hash function of the group.
+func (g *Group) HashFunc() crypto.Hash {
+	return crypto.BLAKE2b_512
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
+	return hashToScalar(input, dst)
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToGroup(input, dst []byte) internal.Element {
+	return hashToGroup(input, dst)
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
+	return encodeToGroup(input, dst)
+}
+
+// FieldOrder returns the big-endian encoding of the order of the base field.
+func (g *Group) FieldOrder() []byte {
+	m := fp.Modulus()
+	return m.Bytes()
+}
+
+// SecurityLength returns L, the byte length of the uniform strings reduced to field elements in the group's
+// hash-to-curve suite.
+func (g *Group) SecurityLength() uint {
+	return hashToFieldLength
+}
+
+// MapToGroup maps each of the big-endian encoded field elements to the curve with the simplified SWU map and the
+// 3-isogeny, and returns their sum.
+func (g *Group) MapToGroup(u ...[]byte) internal.Element {
+	return mapToGroup(u...)
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier.
+func (g *Group) Ciphersuite() string {
+	return H2CPallas
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (g *Group) ScalarLength() int {
+	return scalarLength
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (g *Group) ElementLength() int {
+	return elementLength
+}
+
+// Order returns the order of the canonical group of scalars.
+func (g *Group) Order() []byte {
+	m := fq.Modulus()
+	return m.Bytes()
+}
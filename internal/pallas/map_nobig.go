@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+import (
+	"crypto"
+
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/field"
+)
+
+// hashToScalar implements hash-to-scalar mapping for Pallas.
+func hashToScalar(input, dst []byte) internal.Scalar {
+	uniform := hash2curve.ExpandXMD(crypto.BLAKE2b_512, input, dst, hashToScalarLength)
+
+	s := newScalar()
+	fq.SetWideBytes(&s.scalar, uniform)
+
+	return s
+}
+
+// hashToGroup implements the pasta hash-to-curve for Pallas, i.e. the RFC 9380 random oracle encoding with
+// BLAKE2b-512 expand_message_xmd and the simplified SWU map to a 3-isogenous curve. It uses the pasta_curves
+// constants, but hasn't been checked against pasta_curves' own outputs.
+func hashToGroup(input, dst []byte) internal.Element {
+	uniform := hash2curve.ExpandXMD(crypto.BLAKE2b_512, input, dst, 2*hashToFieldLength)
+
+	var u0, u1 field.Limbs
+	fp.SetWideBytes(&u0, uniform[:hashToFieldLength])
+	fp.SetWideBytes(&u1, uniform[hashToFieldLength:])
+
+	q0 := sswuMap(&u0)
+	q0.Add(sswuMap(&u1))
+
+	return q0
+}
+
+// encodeToGroup implements the non-uniform encoding for Pallas, using the same map as hashToGroup.
+func encodeToGroup(input, dst []byte) internal.Element {
+	uniform := hash2curve.ExpandXMD(crypto.BLAKE2b_512, input, dst, hashToFieldLength)
+
+	var u field.Limbs
+	fp.SetWideBytes(&u, uniform)
+
+	return sswuMap(&u)
+}
+
+// mapToGroup maps each of the big-endian encoded field elements to Pallas, and returns their sum.
+func mapToGroup(u ...[]byte) internal.Element {
+	q := newElement()
+	for _, ui := range u {
+		var l field.Limbs
+		fp.SetBytes(&l, (*[32]byte)(ui))
+		q.Add(sswuMap(&l))
+	}
+
+	return q
+}
+
+// The constants of the map, in the Montgomery domain of the base field.
+var isoA, isoB, isoZ field.Limbs
+
+var isogenyConstants [13]field.Limbs
+
+func initMapConstants() {
+	fp.SetBytes(&isoA, hexToBytes(isoAHex))
+	fp.SetUint64(&isoB, isoBInt)
+	fp.SetUint64(&isoZ, -isoZInt)
+	fp.Neg(&isoZ, &isoZ)
+
+	for i, h := range isogenyConstantsHex {
+		fp.SetBytes(&isogenyConstants[i], hexToBytes(h))
+	}
+}
+
+// sswuMap maps the field element to Pallas with the simplified SWU map to E' (RFC 9380, Section 6.6.2) followed by
+// the 3-isogeny. Conditional moves don't branch on secret values.
+func sswuMap(u *field.Limbs) *Element {
+	x, y := sswuMapToIsogenousCurve(u)
+
+	e := newElement()
+	isoMap(&e.x, &e.y, &x, &y)
+	fp.One(&e.z)
+
+	return e
+}
+
+// sswuMapToIsogenousCurve implements the straight-line simplified SWU map to E' of RFC 9380, Appendix F.2.
+func sswuMapToIsogenousCurve(u *field.Limbs) (x, y field.Limbs) {
+	var one, tv1, tv2, tv3, tv4, tv5, tv6, negTv2, y1 field.Limbs
+
+	fp.One(&one)
+
+	fp.Square(&tv1, u)        // 1. tv1 = u^2
+	fp.Mul(&tv1, &isoZ, &tv1) // 2. tv1 = Z * tv1
+	fp.Square(&tv2, &tv1)     // 3. tv2 = tv1^2
+	fp.Add(&tv2, &tv2, &tv1)  // 4. tv2 = tv2 + tv1
+	fp.Add(&tv3, &tv2, &one)  // 5. tv3 = tv2 + 1
+	fp.Mul(&tv3, &isoB, &tv3) // 6. tv3 = B * tv3
+	fp.Neg(&negTv2, &tv2)
+	field.Select(&tv4, &negTv2, &isoZ, 1-field.IsZero(&tv2)) // 7. tv4 = CMOV(Z, -tv2, tv2 != 0)
+	fp.Mul(&tv4, &isoA, &tv4)                                // 8. tv4 = A * tv4
+	fp.Square(&tv2, &tv3)                                    // 9. tv2 = tv3^2
+	fp.Square(&tv6, &tv4)                                    // 10. tv6 = tv4^2
+	fp.Mul(&tv5, &isoA, &tv6)                                // 11. tv5 = A * tv6
+	fp.Add(&tv2, &tv2, &tv5)                                 // 12. tv2 = tv2 + tv5
+	fp.Mul(&tv2, &tv2, &tv3)                                 // 13. tv2 = tv2 * tv3
+	fp.Mul(&tv6, &tv6, &tv4)                                 // 14. tv6 = tv6 * tv4
+	fp.Mul(&tv5, &isoB, &tv6)                                // 15. tv5 = B * tv6
+	fp.Add(&tv2, &tv2, &tv5)                                 // 16. tv2 = tv2 + tv5
+	fp.Mul(&x, &tv1, &tv3)                                   // 17. x = tv1 * tv3
+
+	isQR := sqrtRatio(&y1, &tv2, &tv6) // 18. (is_gx1_square, y1) = sqrt_ratio(tv2, tv6)
+
+	fp.Mul(&y, &tv1, u)              // 19. y = tv1 * u
+	fp.Mul(&y, &y, &y1)              // 20. y = y * y1
+	field.Select(&x, &tv3, &x, isQR) // 21. x = CMOV(x, tv3, is_gx1_square)
+	field.Select(&y, &y1, &y, isQR)  // 22. y = CMOV(y, y1, is_gx1_square)
+
+	e1 := fp.Parity(u) ^ fp.Parity(&y) ^ 1 // 23. e1 = sgn0(u) == sgn0(y)
+
+	var negY field.Limbs
+	fp.Neg(&negY, &y)
+	field.Select(&y, &y, &negY, e1) // 24. y = CMOV(-y, y, e1)
+
+	fp.Inv(&tv4, &tv4)
+	fp.Mul(&x, &x, &tv4) // 25. x = x / tv4
+
+	return x, y
+}
+
+// sqrtRatio sets z to sqrt(u/v) and returns 1 if u/v is square, and sets z to sqrt(Z * u/v) and returns 0 otherwise.
+// v must not be 0.
+func sqrtRatio(z, u, v *field.Limbs) int {
+	var r, zr, s1, s2 field.Limbs
+
+	fp.Inv(&r, v)
+	fp.Mul(&r, &r, u)
+	fp.Mul(&zr, &isoZ, &r)
+
+	isQR := baseSqrt.sqrt(fp, &s1, &r)
+	baseSqrt.sqrt(fp, &s2, &zr)
+	field.Select(z, &s1, &s2, isQR)
+
+	return isQR
+}
+
+// horner evaluates the polynomial with the given coefficients, from the highest degree down, at x. If monic is set,
+// the leading coefficient 1 is implicit.
+func horner(coefficients []field.Limbs, x *field.Limbs, monic bool) field.Limbs {
+	var res field.Limbs
+	if monic {
+		fp.One(&res)
+	}
+
+	for i := range coefficients {
+		fp.Mul(&res, &res, x)
+		fp.Add(&res, &res, &coefficients[i])
+	}
+
+	return res
+}
+
+// isoMap applies the 3-isogeny from E' to Pallas (RFC 9380, Appendix E), and sets (px, py) to the result.
+func isoMap(px, py, x, y *field.Limbs) {
+	c := isogenyConstants[:]
+
+	xNum := horner(c[0:4], x, false)
+	xDen := horner(c[4:6], x, true)
+	yNum := horner(c[6:10], x, false)
+	yDen := horner(c[10:13], x, true)
+
+	fp.Inv(&xDen, &xDen)
+	fp.Mul(px, &xNum, &xDen)
+
+	fp.Inv(&yDen, &yDen)
+	fp.Mul(py, &yNum, &yDen)
+	fp.Mul(py, py, y)
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package pallas allows simple and abstracted operations in the Pallas group.
+//
+// The default backend uses math/big. Building with the tinygo or ecc_nobig tags selects a backend on fixed-size
+// limbs instead, whose field and group arithmetic run in constant time and use neither math/big nor heap allocations.
+// The tags only change this backend: the other groups, the big.Int Field of internal/field, and the ecc package still
+// use math/big, so that it remains in the build.
+package pallas
+
+const (
+	// Identifier distinguishes this group from the others by a byte representation.
+	Identifier = byte(8)
+
+	// H2CPallas represents the hash-to-curve string identifier for Pallas.
+	H2CPallas = "pallas_XMD:BLAKE2b_SSWU_RO_"
+
+	// E2CPallas represents the encode-to-curve string identifier for Pallas.
+	E2CPallas = "pallas_XMD:BLAKE2b_SSWU_NU_"
+
+	// scalarLength is the byte size of encoded scalars.
+	scalarLength = 32
+
+	// elementLength is the byte size of compressed encoded elements.
+	elementLength = 33
+
+	// Pallas curve parameters
+	// p is the field modulus: 0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001
+	// n is the group order: 0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001
+	// a = 0, b = 5 (curve equation: y² = x³ + 5)
+	// h = 1 (cofactor)
+
+	pallasFieldOrder = "0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"
+	pallasGroupOrder = "0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"
+)
+
+// pallasNonSquare is the smallest non-square of the Pallas base field.
+const pallasNonSquare = 5
+
+// hashToFieldLength is the number of bytes reduced to each field element, as in pasta_curves.
+const hashToFieldLength = 64
+
+// sqrtWindow is the width of the windows of the discrete logarithms in the square root, in bits. It divides the
+// 2-adicity 32 of the pasta fields.
+const sqrtWindow = 4
+
+// hashToScalarLength is the number of bytes reduced to a scalar, i.e. L = ceil((255 + 128) / 8) in RFC 9380 for the
+// 255-bit group order and k = 128.
+const hashToScalarLength = 48
+
+// The isogenous curve E': y² = x³ + A'x + B', with A' = isoAHex and B' = 1265, and Z = -13. Z is the value selected by
+// find_z_sswu of RFC 9380, Appendix H.2, for E'.
+const (
+	isoAHex = "0x18354a2eb0ea8c9c49be2d7258370742b74134581a27a59f92bb4b0b657a014b"
+	isoBInt = 1265
+	isoZInt = -13
+)
+
+// isogenyConstantsHex are the coefficients of the rational maps of the 3-isogeny from E' to Pallas, in the order of
+// pasta_curves: x_num (from x³ down), x_den (from x, x² being implicit), y_num (from x³ down), and y_den (from x²,
+// x³ being implicit).
+var isogenyConstantsHex = [13]string{
+	"0x0e38e38e38e38e38e38e38e38e38e38e4081775473d8375b775f6034aaaaaaab",
+	"0x3509afd51872d88e267c7ffa51cf412a0f93b82ee4b994958cf863b02814fb76",
+	"0x17329b9ec525375398c7d7ac3d98fd13380af066cfeb6d690eb64faef37ea4f7",
+	"0x1c71c71c71c71c71c71c71c71c71c71c8102eea8e7b06eb6eebec06955555580",
+	"0x1d572e7ddc099cff5a607fcce0494a799c434ac1c96b6980c47f2ab668bcd71f",
+	"0x325669becaecd5d11d13bf2a7f22b105b4abf9fb9a1fc81c2aa3af1eae5b6604",
+	"0x1a12f684bda12f684bda12f684bda12f7642b01ad461bad25ad985b5e38e38e4",
+	"0x1a84d7ea8c396c47133e3ffd28e7a09507c9dc17725cca4ac67c31d8140a7dbb",
+	"0x3fb98ff0d2ddcadd303216cce1db9ff11765e924f745937802e2be87d225b234",
+	"0x025ed097b425ed097b425ed097b425ed0ac03e8e134eb3e493e53ab371c71c4f",
+	"0x0c02c5bcca0e6b7f0790bfb3506defb65941a3a4a97aa1b35a28279b1d1b42ae",
+	"0x17033d3c60c68173573b3d7f7d681310d976bbfabbc5661d4d90ab820b12320a",
+	"0x40000000000000000000000000000000224698fc094cf91b992d30ecfffffde5",
+}
@@ -6,6 +6,8 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !tinygo && !ecc_nobig
+
 package pallas
 
 import (
@@ -264,3 +266,8 @@ func (s *Scalar) DecodeHex(h string) error {
 
 	return s.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	field.Wipe(&s.scalar)
+}
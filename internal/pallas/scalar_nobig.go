@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/field"
+)
+
+// Scalar implements the Scalar interface for Pallas group scalars. The value is held in the Montgomery domain.
+type Scalar struct {
+	scalar field.Limbs
+}
+
+func newScalar() *Scalar {
+	return &Scalar{}
+}
+
+func assertScalar(scalar internal.Scalar) *Scalar {
+	sc, ok := scalar.(*Scalar)
+	if !ok {
+		panic(internal.ErrCastScalar)
+	}
+
+	return sc
+}
+
+// Group returns the group's Identifier.
+func (s *Scalar) Group() byte {
+	return Identifier
+}
+
+// Zero sets s to 0, and returns it.
+func (s *Scalar) Zero() internal.Scalar {
+	s.scalar = field.Limbs{}
+	return s
+}
+
+// One sets s to 1, and returns it.
+func (s *Scalar) One() internal.Scalar {
+	fq.One(&s.scalar)
+	return s
+}
+
+// MinusOne sets the scalar to order-1, and returns it.
+func (s *Scalar) MinusOne() internal.Scalar {
+	fq.One(&s.scalar)
+	fq.Neg(&s.scalar, &s.scalar)
+
+	return s
+}
+
+// Random sets s to a new random scalar and returns it.
+// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+func (s *Scalar) Random() internal.Scalar {
+	for {
+		fq.SetWideBytes(&s.scalar, internal.RandomBytes(2*scalarLength))
+
+		if !s.IsZero() {
+			return s
+		}
+	}
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (s *Scalar) Add(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s
+	}
+
+	sc := assertScalar(scalar)
+	fq.Add(&s.scalar, &s.scalar, &sc.scalar)
+
+	return s
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (s *Scalar) Subtract(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s
+	}
+
+	sc := assertScalar(scalar)
+	fq.Sub(&s.scalar, &s.scalar, &sc.scalar)
+
+	return s
+}
+
+// Multiply multiplies the receiver with the input, and returns the receiver.
+func (s *Scalar) Multiply(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s.Zero()
+	}
+
+	sc := assertScalar(scalar)
+	fq.Mul(&s.scalar, &s.scalar, &sc.scalar)
+
+	return s
+}
+
+// Pow sets s to s**scalar modulo the group order, and returns s. If scalar is nil, it returns 1.
+func (s *Scalar) Pow(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s.One()
+	}
+
+	sc := assertScalar(scalar)
+	e := fq.Canonical(&sc.scalar)
+	fq.Exp(&s.scalar, &s.scalar, &e)
+
+	return s
+}
+
+// Invert sets the receiver to its modular inverse ( 1 / s ), and returns it.
+func (s *Scalar) Invert() internal.Scalar {
+	fq.Inv(&s.scalar, &s.scalar)
+	return s
+}
+
+// Equal returns 1 if the scalars are equal, and 0 otherwise.
+func (s *Scalar) Equal(scalar internal.Scalar) int {
+	if scalar == nil {
+		return 0
+	}
+
+	sc := assertScalar(scalar)
+
+	return field.Equal(&s.scalar, &sc.scalar)
+}
+
+// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
+func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
+	sc := assertScalar(scalar)
+	a, b := fq.Canonical(&s.scalar), fq.Canonical(&sc.scalar)
+
+	// b - a borrows if and only if a > b.
+	var borrow uint64
+	_, borrow = bits.Sub64(b[0], a[0], 0)
+	_, borrow = bits.Sub64(b[1], a[1], borrow)
+	_, borrow = bits.Sub64(b[2], a[2], borrow)
+	_, borrow = bits.Sub64(b[3], a[3], borrow)
+
+	return int(1 - borrow)
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *Scalar) IsZero() bool {
+	return field.IsZero(&s.scalar) == 1
+}
+
+// Set sets the receiver to the value of the argument scalar, and returns the receiver.
+func (s *Scalar) Set(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s.Zero()
+	}
+
+	sc := assertScalar(scalar)
+	s.scalar = sc.scalar
+
+	return s
+}
+
+// SetUInt64 sets s to i modulo the field order, and returns an error if one occurs.
+func (s *Scalar) SetUInt64(i uint64) internal.Scalar {
+	fq.SetUint64(&s.scalar, i)
+	return s
+}
+
+// UInt64 returns the uint64 representation of the scalar,
+// or an error if its value is higher than the authorized limit for uint64.
+func (s *Scalar) UInt64() (uint64, error) {
+	v := fq.Canonical(&s.scalar)
+	if v[1]|v[2]|v[3] != 0 {
+		return 0, internal.ErrUInt64TooBig
+	}
+
+	return v[0], nil
+}
+
+// Copy returns a copy of the Scalar.
+func (s *Scalar) Copy() internal.Scalar {
+	return &Scalar{scalar: s.scalar}
+}
+
+// Encode returns the compressed byte encoding of the scalar.
+func (s *Scalar) Encode() []byte {
+	return fq.Bytes(&s.scalar)
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (s *Scalar) Decode(in []byte) error {
+	switch len(in) {
+	case 0:
+		return internal.ErrParamNilScalar
+	case scalarLength:
+		break
+	default:
+		return internal.ErrParamScalarLength
+	}
+
+	var tmp field.Limbs
+	if fq.SetBytes(&tmp, (*[32]byte)(in)) == 0 {
+		return internal.ErrParamScalarInvalidEncoding
+	}
+
+	s.scalar = tmp
+
+	return nil
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of s.
+func (s *Scalar) Hex() string {
+	return hex.EncodeToString(s.Encode())
+}
+
+// DecodeHex sets s to the decoding of the hex encoded scalar.
+func (s *Scalar) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return s.Decode(b)
+}
+
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	field.WipeLimbs(&s.scalar)
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !tinygo && !ecc_nobig
+
+package pallas
+
+import (
+	"crypto/subtle"
+	"math/big"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+// sqrtWindows is the number of windows of the discrete logarithms in the square root.
+const sqrtWindows = 32 / sqrtWindow
+
+// sqrtTable holds the fixed-length big-endian encodings of the powers of a root of unity for the 2^sqrtWindow values
+// of a window, so that entries can be compared and selected in constant time.
+type sqrtTable [1 << sqrtWindow][]byte
+
+// sqrtConstants holds the precomputed constants of the square root in a field of order p, with p - 1 = 2^32 * t and t
+// odd, i.e. the 2-adicity of the pasta fields. With g a primitive 2^32-th root of unity, x^t = g^e for some e, which
+// is even if and only if x is a square, in which case x^((t+1)/2) * g^(-e/2) is a square root of x. The discrete
+// logarithm e is found sqrtWindow bits at a time with constant-time lookups in tables of powers of g, which is much
+// faster than the quadratic number of squarings of the Tonelli-Shanks loop.
+type sqrtConstants struct {
+	c3       *big.Int               // (t - 1) / 2
+	roots    sqrtTable              // roots[k] = g^(k * 2^(32 - sqrtWindow))
+	inverses [sqrtWindows]sqrtTable // inverses[i][k] = g^(-k * 2^(sqrtWindow * i))
+	halves   [sqrtWindows]sqrtTable // halves[i][k] = g^(-floor(k * 2^(sqrtWindow * i) / 2))
+}
+
+// powers sets table[k] = base^k.
+func powers(f *field.Field, table *sqrtTable, base *big.Int) {
+	p := big.NewInt(1)
+	for k := range table {
+		table[k] = p.FillBytes(make([]byte, f.ByteLen()))
+		f.Mul(p, p, base)
+	}
+}
+
+// square sets x to x^(2^n).
+func square(f *field.Field, x *big.Int, n int) {
+	for range n {
+		f.Mul(x, x, x)
+	}
+}
+
+func newSqrtConstants(f *field.Field, nonSquare int64) *sqrtConstants {
+	t := f.PMinusOne()
+	t.Rsh(t, 32)
+
+	if t.Bit(0) == 0 {
+		panic("the field's 2-adicity is not 32")
+	}
+
+	c := &sqrtConstants{c3: new(big.Int).Rsh(t, 1)}
+
+	g := f.Exponent(new(big.Int), big.NewInt(nonSquare), t)
+	gInv := new(big.Int)
+	f.Inv(gInv, g)
+
+	base := new(big.Int).Set(g)
+	square(f, base, 32-sqrtWindow)
+	powers(f, &c.roots, base)
+
+	// halves[0][k] = g^(-floor(k / 2)).
+	p := big.NewInt(1)
+	for k := range c.halves[0] {
+		c.halves[0][k] = p.FillBytes(make([]byte, f.ByteLen()))
+		if k&1 == 1 {
+			f.Mul(p, p, gInv)
+		}
+	}
+
+	// inv = g^(-2^(sqrtWindow * i)), and half = g^(-2^(sqrtWindow * i - 1)) for i > 0.
+	inv, half := new(big.Int).Set(gInv), new(big.Int)
+
+	for i := range sqrtWindows {
+		powers(f, &c.inverses[i], inv)
+
+		if i > 0 {
+			powers(f, &c.halves[i], half)
+		}
+
+		half.Set(inv)
+		square(f, half, sqrtWindow-1)
+		f.Mul(inv, half, half)
+	}
+
+	return c
+}
+
+// ctEqual returns 1 if a == b, and 0 otherwise, without branching on their values.
+func ctEqual(f *field.Field, a, b *big.Int) int {
+	ab := a.FillBytes(make([]byte, f.ByteLen()))
+	bb := b.FillBytes(make([]byte, f.ByteLen()))
+
+	return subtle.ConstantTimeCompare(ab, bb)
+}
+
+// ctSelect sets res to a if cond is 1, and to b if cond is 0, as b + cond * (a - b), and returns res.
+func ctSelect(f *field.Field, res, a, b *big.Int, cond int) *big.Int {
+	d := f.Sub(new(big.Int), a, b)
+	f.Mul(d, d, big.NewInt(int64(cond)))
+	f.Add(res, b, d)
+
+	return res
+}
+
+// lookup returns the index of the encoded value in the table, and 0 if it is not in it, in constant time.
+func (t *sqrtTable) lookup(encoded []byte) int {
+	index := 0
+	for k := range t {
+		index |= k & -subtle.ConstantTimeCompare(t[k], encoded)
+	}
+
+	return index
+}
+
+// get sets z to the entry at index in the table, in constant time, and returns z.
+func (t *sqrtTable) get(z *big.Int, index int) *big.Int {
+	buf := make([]byte, len(t[0]))
+	for k := range t {
+		subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(k), int32(index)), buf, t[k])
+	}
+
+	return z.SetBytes(buf)
+}
+
+// sqrt returns a square root of x and 1 if x is a square, and an unspecified value and 0 otherwise. The sequence of
+// operations doesn't depend on the value of x.
+func (c *sqrtConstants) sqrt(f *field.Field, x *big.Int) (*big.Int, int) {
+	// v = x^((t-1)/2), z = x^((t+1)/2), and r = x^t = g^e. big.Int's exponentiation is faster than an addition chain
+	// of modular multiplications.
+	v := f.Exponent(new(big.Int), x, c.c3)
+	z, r := new(big.Int), new(big.Int)
+	f.Mul(z, v, x)
+	f.Mul(r, z, v)
+
+	// Find e window by window, from the least significant, by clearing the found windows from r, and accumulate
+	// g^(-e/2) into z.
+	s, entry := new(big.Int), new(big.Int)
+	buf := make([]byte, f.ByteLen())
+
+	for i := range sqrtWindows {
+		s.Set(r)
+		square(f, s, 32-sqrtWindow*(i+1))
+
+		k := c.roots.lookup(s.FillBytes(buf))
+
+		f.Mul(r, r, c.inverses[i].get(entry, k))
+		f.Mul(z, z, c.halves[i].get(entry, k))
+	}
+
+	check := new(big.Int)
+	f.Mul(check, z, z)
+
+	return z, ctEqual(f, check, x)
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build tinygo || ecc_nobig
+
+package pallas
+
+import (
+	"crypto/subtle"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+// sqrtWindows is the number of windows of the discrete logarithms in the square root.
+const sqrtWindows = 32 / sqrtWindow
+
+// sqrtTable holds the powers of a root of unity for the 2^sqrtWindow values of a window.
+type sqrtTable [1 << sqrtWindow]field.Limbs
+
+// sqrtConstants holds the precomputed constants of the square root in a field of order p, with p - 1 = 2^32 * t and t
+// odd, i.e. the 2-adicity of the pasta fields. With g a primitive 2^32-th root of unity, x^t = g^e for some e, which
+// is even if and only if x is a square, in which case x^((t+1)/2) * g^(-e/2) is a square root of x. The discrete
+// logarithm e is found sqrtWindow bits at a time with constant-time lookups in tables of powers of g, which is much
+// faster than the quadratic number of squarings of the Tonelli-Shanks loop.
+type sqrtConstants struct {
+	chain    []chainStep            // the addition chain of (t - 1) / 2
+	roots    sqrtTable              // roots[k] = g^(k * 2^(32 - sqrtWindow))
+	inverses [sqrtWindows]sqrtTable // inverses[i][k] = g^(-k * 2^(sqrtWindow * i))
+	halves   [sqrtWindows]sqrtTable // halves[i][k] = g^(-floor(k * 2^(sqrtWindow * i) / 2))
+}
+
+// shiftRight sets l to l >> n, for 0 < n < 64.
+func shiftRight(l *field.Limbs, n uint) {
+	for i := range 3 {
+		l[i] = l[i]>>n | l[i+1]<<(64-n)
+	}
+
+	l[3] >>= n
+}
+
+// bitLen returns the bit length of l.
+func bitLen(l *field.Limbs) int {
+	for i := 255; i >= 0; i-- {
+		if l.Bit(i) == 1 {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// powers sets table[k] = base^k.
+func powers(f *field.Montgomery, table *sqrtTable, base *field.Limbs) {
+	f.One(&table[0])
+	for k := 1; k < len(table); k++ {
+		f.Mul(&table[k], &table[k-1], base)
+	}
+}
+
+func newSqrtConstants(f *field.Montgomery, nonSquare uint64) *sqrtConstants {
+	t := f.Modulus()
+	t[0]-- // the modulus is odd
+
+	shiftRight(&t, 32)
+
+	if t[0]&1 == 0 {
+		panic("the field's 2-adicity is not 32")
+	}
+
+	c := &sqrtConstants{}
+
+	// (t - 1) / 2, with t odd.
+	c3 := t
+	shiftRight(&c3, 1)
+	c.chain = newChain(bitLen(&c3), func(i int) uint { return uint(c3.Bit(i)) })
+
+	var g, gInv, base field.Limbs
+
+	f.SetUint64(&g, nonSquare)
+	f.Exp(&g, &g, &t)
+	f.Inv(&gInv, &g)
+
+	base = g
+	for range 32 - sqrtWindow {
+		f.Square(&base, &base)
+	}
+
+	powers(f, &c.roots, &base)
+
+	// halves[0][k] = g^(-floor(k / 2)).
+	f.One(&c.halves[0][0])
+	c.halves[0][1] = c.halves[0][0]
+
+	for k := 2; k < len(c.halves[0]); k++ {
+		f.Mul(&c.halves[0][k], &c.halves[0][k-2], &gInv)
+	}
+
+	// inv = g^(-2^(sqrtWindow * i)), and half = g^(-2^(sqrtWindow * i - 1)) for i > 0.
+	var half field.Limbs
+
+	inv := gInv
+
+	for i := range sqrtWindows {
+		powers(f, &c.inverses[i], &inv)
+
+		if i > 0 {
+			powers(f, &c.halves[i], &half)
+		}
+
+		half = inv
+		for range sqrtWindow - 1 {
+			f.Square(&half, &half)
+		}
+
+		f.Square(&inv, &half)
+	}
+
+	return c
+}
+
+// exp sets z = x^((t - 1) / 2) along the addition chain.
+func (c *sqrtConstants) exp(f *field.Montgomery, z, x *field.Limbs) {
+	var odd [1 << (chainWindow - 1)]field.Limbs
+
+	var x2, res field.Limbs
+
+	odd[0] = *x
+	f.Square(&x2, x)
+
+	for k := 1; k < len(odd); k++ {
+		f.Mul(&odd[k], &odd[k-1], &x2)
+	}
+
+	res = odd[c.chain[0].mul]
+
+	for _, step := range c.chain[1:] {
+		for range step.squarings {
+			f.Square(&res, &res)
+		}
+
+		if step.mul >= 0 {
+			f.Mul(&res, &res, &odd[step.mul])
+		}
+	}
+
+	*z = res
+}
+
+// lookup returns the index of s in the table, and 0 if it is not in it, in constant time.
+func (t *sqrtTable) lookup(s *field.Limbs) int {
+	index := 0
+	for k := range t {
+		index |= k & -field.Equal(&t[k], s)
+	}
+
+	return index
+}
+
+// get sets z to the entry at index in the table, in constant time.
+func (t *sqrtTable) get(z *field.Limbs, index int) {
+	*z = t[0]
+	for k := 1; k < len(t); k++ {
+		field.Select(z, &t[k], z, subtle.ConstantTimeEq(int32(k), int32(index)))
+	}
+}
+
+// sqrt sets z to a square root of x and returns 1 if x is a square, and sets z to an unspecified value and returns 0
+// otherwise. The sequence of operations doesn't depend on the value of x.
+func (c *sqrtConstants) sqrt(f *field.Montgomery, z, x *field.Limbs) int {
+	var v, res, r, s, entry, check field.Limbs
+
+	// v = x^((t-1)/2), res = x^((t+1)/2), and r = x^t = g^e.
+	c.exp(f, &v, x)
+	f.Mul(&res, &v, x)
+	f.Mul(&r, &res, &v)
+
+	// Find e window by window, from the least significant, by clearing the found windows from r, and accumulate
+	// g^(-e/2) into res.
+	for i := range sqrtWindows {
+		s = r
+		for range 32 - sqrtWindow*(i+1) {
+			f.Square(&s, &s)
+		}
+
+		k := c.roots.lookup(&s)
+
+		c.inverses[i].get(&entry, k)
+		f.Mul(&r, &r, &entry)
+
+		c.halves[i].get(&entry, k)
+		f.Mul(&res, &res, &entry)
+	}
+
+	f.Square(&check, &res)
+	*z = res
+
+	return field.Equal(&check, x)
+}
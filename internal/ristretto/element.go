@@ -106,6 +106,18 @@ func (e *Element) IsIdentity() bool {
 	return e.element.Equal(id) == 1
 }
 
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, which is always the case for
+// ristretto255 elements.
+func (e *Element) IsTorsionFree() bool {
+	return true
+}
+
+// ClearCofactor multiplies the receiver by the cofactor, and returns it. The ristretto255 group has prime order, so
+// this is a no-op.
+func (e *Element) ClearCofactor() internal.Element {
+	return e
+}
+
 // Set sets the receiver to the value of the argument, and returns the receiver.
 func (e *Element) Set(element internal.Element) internal.Element {
 	if element == nil {
@@ -186,3 +198,8 @@ func (e *Element) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element) Wipe() {
+	e.element.Zero()
+}
@@ -10,6 +10,7 @@
 package ristretto
 
 import (
+	"bytes"
 	"crypto"
 	"slices"
 
@@ -78,6 +79,16 @@ func (g Group) EncodeToGroup(input, dst []byte) internal.Element {
 	return g.HashToGroup(input, dst)
 }
 
+// UniformLength returns the byte length of the uniform strings mapped to the group.
+func (g Group) UniformLength() uint {
+	return inputLength
+}
+
+// MapUniform returns the element the 64-byte uniform string maps to, with the ristretto255 one-way map.
+func (g Group) MapUniform(uniform []byte) internal.Element {
+	return &Element{*ristretto255.NewElement().FromUniformBytes(uniform)}
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func (g Group) Ciphersuite() string {
 	return H2C
@@ -97,3 +108,12 @@ func (g Group) ElementLength() int {
 func (g Group) Order() []byte {
 	return slices.Clone(orderBytes)
 }
+
+// FieldOrder returns the big-endian encoding of the order of the base field of Curve25519, i.e. 2^255 - 19.
+func (g Group) FieldOrder() []byte {
+	p := bytes.Repeat([]byte{0xff}, canonicalEncodingLength)
+	p[0] = 0x7f
+	p[canonicalEncodingLength-1] = 0xed
+
+	return p
+}
@@ -342,6 +342,11 @@ func (s *Scalar) copy() *Scalar {
 	return &Scalar{*ristretto255.NewScalar().Add(ristretto255.NewScalar(), &s.scalar)}
 }
 
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	s.scalar.Zero()
+}
+
 func (s *Scalar) decodeScalar(scalar []byte) error {
 	if len(scalar) == 0 {
 		return internal.ErrParamNilScalar
@@ -352,7 +357,7 @@ func (s *Scalar) decodeScalar(scalar []byte) error {
 	}
 
 	if err := s.scalar.Decode(scalar); err != nil {
-		return fmt.Errorf("%w", err)
+		return internal.ErrParamScalarInvalidEncoding
 	}
 
 	return nil
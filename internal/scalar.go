@@ -42,7 +42,7 @@ type Scalar interface {
 	// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
 	Invert() Scalar
 
-	// Equal returns 1 if the scalars are equal, and 0 otherwise.
+	// Equal returns 1 if the scalars are equal, and 0 otherwise. It must run in constant time.
 	Equal(s Scalar) int
 
 	// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
@@ -75,4 +75,7 @@ type Scalar interface {
 
 	// DecodeHex sets s to the decoding of the hex encoded scalar.
 	DecodeHex(data string) error
+
+	// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+	Wipe()
 }
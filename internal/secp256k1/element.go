@@ -107,6 +107,17 @@ func (e *Element) IsIdentity() bool {
 	return e.element.IsIdentity()
 }
 
+// IsTorsionFree returns whether the element belongs to the prime-order subgroup, which is always the case in a
+// prime-order group.
+func (e *Element) IsTorsionFree() bool {
+	return true
+}
+
+// ClearCofactor multiplies the receiver by the cofactor, and returns it. The cofactor is 1, so this is a no-op.
+func (e *Element) ClearCofactor() internal.Element {
+	return e
+}
+
 // Set sets the receiver to the value of the argument, and returns the receiver.
 func (e *Element) Set(element internal.Element) internal.Element {
 	if element == nil {
@@ -154,6 +165,20 @@ func (e *Element) Decode(data []byte) error {
 	return nil
 }
 
+// EncodeUncompressed returns the uncompressed encoding of the element, which must not be the identity.
+func (e *Element) EncodeUncompressed() []byte {
+	return e.element.EncodeUncompressed()
+}
+
+// DecodeUncompressed sets the receiver to the decoding of the uncompressed encoding, and returns an error on failure.
+func (e *Element) DecodeUncompressed(data []byte) error {
+	if err := e.element.DecodeUncompressed(data); err != nil {
+		return fmt.Errorf("invalid secp256k1 encoding: %w", err)
+	}
+
+	return nil
+}
+
 // Hex returns the fixed-sized hexadecimal encoding of e.
 func (e *Element) Hex() string {
 	return hex.EncodeToString(e.Encode())
@@ -168,3 +193,8 @@ func (e *Element) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// Wipe overwrites the internal representation of the element in place, leaving it set to the identity.
+func (e *Element) Wipe() {
+	e.element.Identity()
+}
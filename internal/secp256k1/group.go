@@ -11,6 +11,7 @@ package secp256k1
 
 import (
 	"crypto"
+	"encoding/hex"
 
 	"github.com/bytemare/secp256k1"
 
@@ -28,6 +29,8 @@ const (
 	E2CSECP256K1 = "secp256k1_XMD:SHA-256_SSWU_NU_"
 
 	scalarLength = 32
+
+	fieldOrderHex = "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"
 )
 
 // Group represents the SECp256k1 group. It exposes a prime-order group API with hash-to-curve operations.
@@ -76,6 +79,12 @@ func (g Group) EncodeToGroup(input, dst []byte) internal.Element {
 	return &Element{element: secp256k1.EncodeToGroup(input, dst)}
 }
 
+// FieldOrder returns the big-endian encoding of the order of the base field.
+func (g Group) FieldOrder() []byte {
+	p, _ := hex.DecodeString(fieldOrderHex)
+	return p
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func (g Group) Ciphersuite() string {
 	return H2CSECP256K1
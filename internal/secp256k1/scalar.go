@@ -10,6 +10,7 @@ package secp256k1
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/bytemare/secp256k1"
@@ -192,7 +193,13 @@ func (s *Scalar) Encode() []byte {
 
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (s *Scalar) Decode(in []byte) error {
-	if err := s.scalar.Decode(in); err != nil {
+	if len(in) != scalarLength {
+		return internal.ErrParamScalarLength
+	}
+
+	// Decode into a temporary scalar, as the library reduces the receiver before rejecting values above the order.
+	sc := secp256k1.NewScalar()
+	if err := sc.Decode(in); err != nil {
 		if err.Error() == "scalar too big" {
 			return internal.ErrParamScalarInvalidEncoding
 		}
@@ -200,6 +207,8 @@ func (s *Scalar) Decode(in []byte) error {
 		return fmt.Errorf("%w", err)
 	}
 
+	s.scalar.Set(sc)
+
 	return nil
 }
 
@@ -210,9 +219,15 @@ func (s *Scalar) Hex() string {
 
 // DecodeHex sets s to the decoding of the hex encoded scalar.
 func (s *Scalar) DecodeHex(h string) error {
-	if err := s.scalar.DecodeHex(h); err != nil {
+	b, err := hex.DecodeString(h)
+	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
 
-	return nil
+	return s.Decode(b)
+}
+
+// Wipe overwrites the internal representation of the scalar in place, leaving it set to 0.
+func (s *Scalar) Wipe() {
+	s.scalar.Zero()
 }
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import "errors"
+
+var (
+	errKeyPairNil      = errors.New("key pair: nil secret or public key")
+	errKeyPairZero     = errors.New("key pair: zero secret key")
+	errKeyPairMismatch = errors.New("key pair: public key doesn't match the secret key")
+	errKeyPairLength   = errors.New("key pair: invalid encoding length")
+)
+
+// KeyPair holds a secret key and its public key, i.e. the generator multiplied with it, in a group.
+type KeyPair struct {
+	Secret *Scalar
+	Public *Element
+	Group  Group
+}
+
+// GenerateKeyPair returns a new key pair with a random non-zero secret key.
+func (g Group) GenerateKeyPair() *KeyPair {
+	sk := g.NewScalar().Random()
+	for sk.IsZero() {
+		sk.Random()
+	}
+
+	return &KeyPair{
+		Group:  g,
+		Secret: sk,
+		Public: g.Base().Multiply(sk),
+	}
+}
+
+// KeyPairFromSecret returns the key pair of a copy of the secret key, and an error if it is nil or zero.
+func KeyPairFromSecret(secret *Scalar) (*KeyPair, error) {
+	if secret == nil {
+		return nil, errKeyPairNil
+	}
+
+	if secret.IsZero() {
+		return nil, errKeyPairZero
+	}
+
+	g := secret.Group()
+
+	return &KeyPair{
+		Group:  g,
+		Secret: secret.Copy(),
+		Public: g.Base().Multiply(secret),
+	}, nil
+}
+
+// KeyPairFromSeed deterministically derives a key pair from the seed and info, with DeriveKeyPair.
+func (g Group) KeyPairFromSeed(seed, info []byte) (*KeyPair, error) {
+	sk, pk, err := g.DeriveKeyPair(seed, info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{
+		Group:  g,
+		Secret: sk,
+		Public: pk,
+	}, nil
+}
+
+// Validate returns an error if a key is nil or of another group, if the secret key is zero, or if the public key
+// doesn't match the secret key.
+func (k *KeyPair) Validate() error {
+	if k.Secret == nil || k.Public == nil {
+		return errKeyPairNil
+	}
+
+	if g := k.Secret.Group(); g != k.Group {
+		return &ErrWrongGroup{Expected: k.Group, Got: g}
+	}
+
+	if g := k.Public.Group(); g != k.Group {
+		return &ErrWrongGroup{Expected: k.Group, Got: g}
+	}
+
+	if k.Secret.IsZero() {
+		return errKeyPairZero
+	}
+
+	if !k.Group.Base().Multiply(k.Secret).Equal(k.Public) {
+		return errKeyPairMismatch
+	}
+
+	return nil
+}
+
+// Encode returns the encoding of the key pair, i.e. the group identifier byte followed by the encodings of the secret
+// and public keys. It contains the secret key, and must be handled as such.
+func (k *KeyPair) Encode() []byte {
+	out := make([]byte, 0, 1+k.Group.ScalarLength()+k.Group.ElementLength())
+	out = append(out, byte(k.Group))
+	out = append(out, k.Secret.Encode()...)
+
+	return append(out, k.Public.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the key pair, and returns an error if the encoding or the key pair is
+// invalid, in which case the receiver is not modified.
+func (k *KeyPair) Decode(data []byte) error {
+	const op = "key pair Decode"
+
+	if len(data) == 0 {
+		return newInvalidEncoding(0, op, errKeyPairLength)
+	}
+
+	g := Group(data[0])
+	if !g.Available() {
+		return newInvalidEncoding(g, op, ErrInvalidGroup)
+	}
+
+	sLen := g.ScalarLength()
+	if len(data) != 1+sLen+g.ElementLength() {
+		return newInvalidEncoding(g, op, errKeyPairLength)
+	}
+
+	d := &KeyPair{
+		Group:  g,
+		Secret: g.NewScalar(),
+		Public: g.NewElement(),
+	}
+
+	if err := d.Secret.Decode(data[1 : 1+sLen]); err != nil {
+		return err
+	}
+
+	if err := d.Public.Decode(data[1+sLen:]); err != nil {
+		return err
+	}
+
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	*k = *d
+
+	return nil
+}
+
+// Wipe sets the secret key to zero.
+func (k *KeyPair) Wipe() {
+	if k.Secret != nil {
+		k.Secret.Wipe()
+	}
+}
@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package kvac implements keyed-verification anonymous credentials based on the MAC_GGM algebraic MAC of Chase,
+// Meiklejohn, and Zaverucha (CMZ14), as used by Signal's private group system.
+//
+// The issuer holds the secret key (x0, x0~, x1, ..., xn) and publishes the parameters Cx0 = x0 * G + x0~ * H and
+// Xi = xi * H. A credential on the attributes (m1, ..., mn) is the pair (U, V = (x0 + sum(xi * mi)) * U), issued with
+// a proof that it was computed with the published key. The holder presents it unlinkably by committing to the
+// attributes, and the issuer verifies the presentation with its secret key.
+//
+// The construction requires a prime-order group, like Ristretto255.
+package kvac
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/sigma"
+)
+
+const (
+	generatorDST    = "ECC-KVAC-V01-Generator"
+	issuanceDST     = "ECC-KVAC-V01-Issuance"
+	presentationDST = "ECC-KVAC-V01-Presentation"
+)
+
+var (
+	errNilInput         = errors.New("nil input")
+	errGroupMismatch    = errors.New("inputs belong to different groups")
+	errNoAttributes     = errors.New("the number of attributes must be positive")
+	errAttributesLength = errors.New("the number of attributes does not match the key")
+)
+
+// Generator returns the second generator H of the group, whose discrete logarithm relative to the base point is
+// unknown.
+func Generator(g ecc.Group) *ecc.Element {
+	return g.HashToGroup([]byte(g.String()), []byte(generatorDST))
+}
+
+// SecretKey is the issuer's MAC key.
+type SecretKey struct {
+	X0      *ecc.Scalar
+	X0Tilde *ecc.Scalar
+	X       []*ecc.Scalar
+}
+
+// PublicParameters are the issuer's public parameters, committing to its secret key.
+type PublicParameters struct {
+	CX0 *ecc.Element
+	X   []*ecc.Element
+}
+
+// KeyGen returns a new random secret key for credentials on n attributes.
+func KeyGen(g ecc.Group, n int) (*SecretKey, error) {
+	if n <= 0 {
+		return nil, errNoAttributes
+	}
+
+	x := make([]*ecc.Scalar, n)
+	for i := range x {
+		x[i] = g.NewScalar().Random()
+	}
+
+	return &SecretKey{
+		X0:      g.NewScalar().Random(),
+		X0Tilde: g.NewScalar().Random(),
+		X:       x,
+	}, nil
+}
+
+// Public returns the public parameters of the secret key.
+func (sk *SecretKey) Public() *PublicParameters {
+	g := sk.X0.Group()
+	h := Generator(g)
+
+	x := make([]*ecc.Element, len(sk.X))
+	for i, xi := range sk.X {
+		x[i] = h.Copy().Multiply(xi)
+	}
+
+	return &PublicParameters{
+		CX0: g.Base().Multiply(sk.X0).Add(h.Copy().Multiply(sk.X0Tilde)),
+		X:   x,
+	}
+}
+
+// Credential is a MAC_GGM tag on a set of attributes.
+type Credential struct {
+	U *ecc.Element
+	V *ecc.Element
+}
+
+func checkAttributes(g ecc.Group, attributes []*ecc.Scalar, n int) error {
+	if len(attributes) != n {
+		return errAttributesLength
+	}
+
+	for _, m := range attributes {
+		if m == nil {
+			return errNilInput
+		}
+
+		if m.Group() != g {
+			return errGroupMismatch
+		}
+	}
+
+	return nil
+}
+
+// issuanceRelations returns the statement that the credential was computed with the key committed to in the public
+// parameters. The witnesses are (x0, x0~, x1, ..., xn).
+func issuanceRelations(pp *PublicParameters, attributes []*ecc.Scalar, cred *Credential) []sigma.Relation {
+	g := pp.CX0.Group()
+	h := Generator(g)
+	n := len(pp.X)
+
+	relations := make([]sigma.Relation, 0, n+2)
+	relations = append(relations, sigma.Relation{
+		LHS:   pp.CX0,
+		Terms: []sigma.Term{{Base: g.Base(), Index: 0}, {Base: h, Index: 1}},
+	})
+
+	mac := sigma.Relation{
+		LHS:   cred.V,
+		Terms: []sigma.Term{{Base: cred.U, Index: 0}},
+	}
+
+	for i := range n {
+		relations = append(relations, sigma.Relation{
+			LHS:   pp.X[i],
+			Terms: []sigma.Term{{Base: h, Index: i + 2}},
+		})
+		mac.Terms = append(mac.Terms, sigma.Term{Base: cred.U.Copy().Multiply(attributes[i]), Index: i + 2})
+	}
+
+	return append(relations, mac)
+}
+
+// Issue returns a credential on the attributes, and a proof for the holder that it was correctly computed. The context
+// binds the proof to the session.
+func (sk *SecretKey) Issue(attributes []*ecc.Scalar, context []byte) (*Credential, *Proof, error) {
+	g := sk.X0.Group()
+	if err := checkAttributes(g, attributes, len(sk.X)); err != nil {
+		return nil, nil, err
+	}
+
+	u := g.Base().Multiply(g.NewScalar().Random())
+
+	// V = (x0 + sum(xi * mi)) * U
+	exp := sk.X0.Copy()
+	for i, xi := range sk.X {
+		exp.Add(xi.Copy().Multiply(attributes[i]))
+	}
+
+	cred := &Credential{U: u, V: u.Copy().Multiply(exp)}
+
+	witnesses := make([]*ecc.Scalar, 0, len(sk.X)+2)
+	witnesses = append(witnesses, sk.X0, sk.X0Tilde)
+	witnesses = append(witnesses, sk.X...)
+
+	proof, err := sigma.Prove(g, issuanceDST, issuanceRelations(sk.Public(), attributes, cred), witnesses, context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cred, proof, nil
+}
+
+// VerifyIssuance returns whether the proof attests that the credential on the attributes was computed with the key
+// of the public parameters.
+func (pp *PublicParameters) VerifyIssuance(attributes []*ecc.Scalar, cred *Credential, proof *Proof, context []byte) bool {
+	if cred == nil || cred.U == nil || cred.V == nil {
+		return false
+	}
+
+	g := pp.CX0.Group()
+	if cred.U.Group() != g || cred.V.Group() != g || cred.U.IsIdentity() {
+		return false
+	}
+
+	if checkAttributes(g, attributes, len(pp.X)) != nil {
+		return false
+	}
+
+	return proof.Verify(g, issuanceDST, issuanceRelations(pp, attributes, cred), len(pp.X)+2, context)
+}
+
+// Presentation is an unlinkable showing of a credential, hiding all its attributes.
+type Presentation struct {
+	U     *ecc.Element
+	CV    *ecc.Element
+	C     []*ecc.Element
+	Proof *Proof
+}
+
+// presentationRelations returns the statement that the commitments open to a valid credential, given
+// Z = x0 * U + sum(xi * Ci) - CV. The witnesses are (z1, ..., zn, -r, m1, ..., mn).
+func presentationRelations(pp *PublicParameters, p *Presentation, z *ecc.Element) []sigma.Relation {
+	g := pp.CX0.Group()
+	h := Generator(g)
+	n := len(pp.X)
+
+	relations := make([]sigma.Relation, 0, n+1)
+	zRel := sigma.Relation{LHS: z, Terms: make([]sigma.Term, 0, n+1)}
+
+	for i := range n {
+		zRel.Terms = append(zRel.Terms, sigma.Term{Base: pp.X[i], Index: i})
+		relations = append(relations, sigma.Relation{
+			LHS:   p.C[i],
+			Terms: []sigma.Term{{Base: p.U, Index: n + 1 + i}, {Base: h, Index: i}},
+		})
+	}
+
+	zRel.Terms = append(zRel.Terms, sigma.Term{Base: g.Base(), Index: n})
+
+	return append(relations, zRel)
+}
+
+// Present returns a presentation of the credential on the attributes, which must have been verified with
+// VerifyIssuance. The context binds the presentation to the session.
+func (pp *PublicParameters) Present(cred *Credential, attributes []*ecc.Scalar, context []byte) (*Presentation, error) {
+	if cred == nil || cred.U == nil || cred.V == nil {
+		return nil, errNilInput
+	}
+
+	g := pp.CX0.Group()
+	if cred.U.Group() != g || cred.V.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	if err := checkAttributes(g, attributes, len(pp.X)); err != nil {
+		return nil, err
+	}
+
+	n := len(pp.X)
+	h := Generator(g)
+	a := g.NewScalar().Random()
+	r := g.NewScalar().Random()
+	u := cred.U.Copy().Multiply(a)
+
+	p := &Presentation{
+		U:  u,
+		CV: cred.V.Copy().Multiply(a).Add(g.Base().Multiply(r)),
+		C:  make([]*ecc.Element, n),
+	}
+
+	witnesses := make([]*ecc.Scalar, 2*n+1)
+	witnesses[n] = g.NewScalar().Subtract(r)
+	z := g.Base().Multiply(witnesses[n])
+
+	for i, m := range attributes {
+		zi := g.NewScalar().Random()
+		witnesses[i], witnesses[n+1+i] = zi, m
+		p.C[i] = u.Copy().Multiply(m).Add(h.Copy().Multiply(zi))
+		z.Add(pp.X[i].Copy().Multiply(zi))
+	}
+
+	proof, err := sigma.Prove(g, presentationDST, presentationRelations(pp, p, z), witnesses, context)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Proof = proof
+
+	return p, nil
+}
+
+// VerifyPresentation returns whether the presentation shows a valid credential issued with the secret key.
+func (sk *SecretKey) VerifyPresentation(pp *PublicParameters, p *Presentation, context []byte) bool {
+	if p == nil || p.U == nil || p.CV == nil || len(p.C) != len(sk.X) || len(pp.X) != len(sk.X) {
+		return false
+	}
+
+	g := sk.X0.Group()
+	if p.U.Group() != g || p.CV.Group() != g || p.U.IsIdentity() {
+		return false
+	}
+
+	// Z = x0 * U + sum(xi * Ci) - CV
+	z := p.U.Copy().Multiply(sk.X0).Subtract(p.CV)
+
+	for i, c := range p.C {
+		if c == nil || c.Group() != g {
+			return false
+		}
+
+		z.Add(c.Copy().Multiply(sk.X[i]))
+	}
+
+	return p.Proof.Verify(g, presentationDST, presentationRelations(pp, p, z), 2*len(sk.X)+1, context)
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package kvac
+
+import "github.com/bytemare/ecc/sigma"
+
+// Proof is a non-interactive proof of knowledge of the witnesses satisfying a set of linear relations between
+// elements.
+type Proof = sigma.Proof
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package matrix implements matrices over the scalar field of a group, e.g. for packed secret sharing or MPC
+// protocols based on linear codes.
+//
+// Gaussian elimination branches on the zero entries of the matrix, and hence runs in variable time: row reduction,
+// Solve, Inverse, and Rank must only be used on public matrices, like Vandermonde or parity-check matrices.
+package matrix
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errDimensions    = errors.New("incompatible matrix dimensions")
+	errEmpty         = errors.New("empty matrix")
+	errSingular      = errors.New("the matrix is singular")
+)
+
+// Matrix is a matrix of scalars of a group, of fixed dimensions.
+type Matrix struct {
+	entries    [][]*ecc.Scalar
+	rows, cols int
+	group      ecc.Group
+}
+
+// New returns the zero matrix of the given dimensions, which must be positive.
+func New(g ecc.Group, rows, cols int) (*Matrix, error) {
+	if rows <= 0 || cols <= 0 {
+		return nil, errEmpty
+	}
+
+	m := &Matrix{
+		entries: make([][]*ecc.Scalar, rows),
+		rows:    rows,
+		cols:    cols,
+		group:   g,
+	}
+
+	for i := range m.entries {
+		m.entries[i] = make([]*ecc.Scalar, cols)
+		for j := range m.entries[i] {
+			m.entries[i][j] = g.NewScalar()
+		}
+	}
+
+	return m, nil
+}
+
+// Identity returns the identity matrix of size n.
+func Identity(g ecc.Group, n int) (*Matrix, error) {
+	m, err := New(g, n, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range n {
+		m.entries[i][i].One()
+	}
+
+	return m, nil
+}
+
+// FromRows returns the matrix with copies of the given rows, which must be non-empty, of the same length, and in the
+// same group.
+func FromRows(rows [][]*ecc.Scalar) (*Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, errEmpty
+	}
+
+	if rows[0][0] == nil {
+		return nil, errNilInput
+	}
+
+	m, err := New(rows[0][0].Group(), len(rows), len(rows[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return nil, errDimensions
+		}
+
+		for j, s := range row {
+			if s == nil {
+				return nil, errNilInput
+			}
+
+			if s.Group() != m.group {
+				return nil, errGroupMismatch
+			}
+
+			m.entries[i][j].Set(s)
+		}
+	}
+
+	return m, nil
+}
+
+// Vandermonde returns the matrix with rows (1, x, x^2, ..., x^(cols-1)) for each of the points x.
+func Vandermonde(points []*ecc.Scalar, cols int) (*Matrix, error) {
+	if len(points) == 0 {
+		return nil, errEmpty
+	}
+
+	rows := make([][]*ecc.Scalar, len(points))
+	for i, x := range points {
+		if x == nil {
+			return nil, errNilInput
+		}
+
+		rows[i] = make([]*ecc.Scalar, cols)
+		if cols > 0 {
+			rows[i][0] = x.Group().NewScalar().One()
+		}
+
+		for j := 1; j < cols; j++ {
+			rows[i][j] = rows[i][j-1].Copy().Multiply(x)
+		}
+	}
+
+	return FromRows(rows)
+}
+
+// Group returns the group of the matrix's scalars.
+func (m *Matrix) Group() ecc.Group {
+	return m.group
+}
+
+// Rows returns the number of rows.
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns.
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// At returns a copy of the entry at row i and column j, which must be in range.
+func (m *Matrix) At(i, j int) *ecc.Scalar {
+	return m.entries[i][j].Copy()
+}
+
+// Set sets the entry at row i and column j, which must be in range, to a copy of the scalar, and returns an error if
+// it's nil or from another group.
+func (m *Matrix) Set(i, j int, s *ecc.Scalar) error {
+	if s == nil {
+		return errNilInput
+	}
+
+	if s.Group() != m.group {
+		return errGroupMismatch
+	}
+
+	m.entries[i][j].Set(s)
+
+	return nil
+}
+
+// Copy returns a deep copy of the matrix.
+func (m *Matrix) Copy() *Matrix {
+	c, _ := New(m.group, m.rows, m.cols)
+	for i, row := range m.entries {
+		for j, s := range row {
+			c.entries[i][j].Set(s)
+		}
+	}
+
+	return c
+}
+
+// Equal returns whether the matrices have the same group, dimensions, and entries.
+func (m *Matrix) Equal(n *Matrix) bool {
+	if n == nil || m.group != n.group || m.rows != n.rows || m.cols != n.cols {
+		return false
+	}
+
+	for i, row := range m.entries {
+		for j, s := range row {
+			if !s.Equal(n.entries[i][j]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Transpose returns the transpose of the matrix.
+func (m *Matrix) Transpose() *Matrix {
+	t, _ := New(m.group, m.cols, m.rows)
+	for i, row := range m.entries {
+		for j, s := range row {
+			t.entries[j][i].Set(s)
+		}
+	}
+
+	return t
+}
+
+// Mul returns the product m * n, and an error if the dimensions or groups don't match.
+func (m *Matrix) Mul(n *Matrix) (*Matrix, error) {
+	if n == nil {
+		return nil, errNilInput
+	}
+
+	if m.group != n.group {
+		return nil, errGroupMismatch
+	}
+
+	if m.cols != n.rows {
+		return nil, errDimensions
+	}
+
+	p, _ := New(m.group, m.rows, n.cols)
+	for i := range m.rows {
+		for j := range n.cols {
+			for k := range m.cols {
+				p.entries[i][j].MulAdd(m.entries[i][k], n.entries[k][j], p.entries[i][j])
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// MulVec returns the product of the matrix with the column vector, and an error if the dimensions or groups don't
+// match.
+func (m *Matrix) MulVec(v []*ecc.Scalar) ([]*ecc.Scalar, error) {
+	col, err := m.column(v)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := m.Mul(col)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.columnVector(0), nil
+}
+
+// column returns the vector as a column matrix, with the matrix's number of columns as length.
+func (m *Matrix) column(v []*ecc.Scalar) (*Matrix, error) {
+	if len(v) != m.cols {
+		return nil, errDimensions
+	}
+
+	rows := make([][]*ecc.Scalar, len(v))
+	for i, s := range v {
+		rows[i] = []*ecc.Scalar{s}
+	}
+
+	col, err := FromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if col.group != m.group {
+		return nil, errGroupMismatch
+	}
+
+	return col, nil
+}
+
+// columnVector returns copies of the entries of column j.
+func (m *Matrix) columnVector(j int) []*ecc.Scalar {
+	v := make([]*ecc.Scalar, m.rows)
+	for i := range v {
+		v[i] = m.entries[i][j].Copy()
+	}
+
+	return v
+}
+
+// RowReduce returns the reduced row echelon form of the matrix, and its rank.
+func (m *Matrix) RowReduce() (*Matrix, int) {
+	r := m.Copy()
+	rank := 0
+
+	for col := 0; col < r.cols && rank < r.rows; col++ {
+		pivot := -1
+
+		for i := rank; i < r.rows; i++ {
+			if !r.entries[i][col].IsZero() {
+				pivot = i
+				break
+			}
+		}
+
+		if pivot < 0 {
+			continue
+		}
+
+		r.entries[rank], r.entries[pivot] = r.entries[pivot], r.entries[rank]
+
+		inv := r.entries[rank][col].Copy().Invert()
+		for _, s := range r.entries[rank] {
+			s.Multiply(inv)
+		}
+
+		for i := range r.rows {
+			if i == rank || r.entries[i][col].IsZero() {
+				continue
+			}
+
+			f := r.entries[i][col].Copy()
+			for j, s := range r.entries[rank] {
+				r.entries[i][j].Subtract(f.Copy().Multiply(s))
+			}
+		}
+
+		rank++
+	}
+
+	return r, rank
+}
+
+// Rank returns the rank of the matrix.
+func (m *Matrix) Rank() int {
+	_, rank := m.RowReduce()
+	return rank
+}
+
+// augment returns the matrix [m | n].
+func (m *Matrix) augment(n *Matrix) *Matrix {
+	a, _ := New(m.group, m.rows, m.cols+n.cols)
+	for i := range m.rows {
+		for j, s := range m.entries[i] {
+			a.entries[i][j].Set(s)
+		}
+
+		for j, s := range n.entries[i] {
+			a.entries[i][m.cols+j].Set(s)
+		}
+	}
+
+	return a
+}
+
+// leftIsIdentity returns whether the row reduced augmented matrix starts with the identity matrix of size n, i.e.
+// whether the square matrix it was augmented from is invertible. In reduced row echelon form, it suffices to check the
+// last entry of the diagonal, since that of a singular matrix is 0.
+func (m *Matrix) leftIsIdentity(n int) bool {
+	return m.entries[n-1][n-1].Equal(m.group.NewScalar().One())
+}
+
+// Inverse returns the inverse of the square matrix, and an error if it is not square or is singular.
+func (m *Matrix) Inverse() (*Matrix, error) {
+	if m.rows != m.cols {
+		return nil, errDimensions
+	}
+
+	id, _ := Identity(m.group, m.rows)
+
+	r, _ := m.augment(id).RowReduce()
+	if !r.leftIsIdentity(m.cols) {
+		return nil, errSingular
+	}
+
+	inv, _ := New(m.group, m.rows, m.cols)
+	for i := range m.rows {
+		for j := range m.cols {
+			inv.entries[i][j].Set(r.entries[i][m.cols+j])
+		}
+	}
+
+	return inv, nil
+}
+
+// Solve returns the solution x of m * x = b for the square invertible matrix, and an error if the matrix is not square
+// or is singular, or if the dimensions or groups don't match.
+func (m *Matrix) Solve(b []*ecc.Scalar) ([]*ecc.Scalar, error) {
+	if m.rows != m.cols {
+		return nil, errDimensions
+	}
+
+	col, err := m.column(b)
+	if err != nil {
+		return nil, err
+	}
+
+	r, _ := m.augment(col).RowReduce()
+	if !r.leftIsIdentity(m.cols) {
+		return nil, errSingular
+	}
+
+	return r.columnVector(m.cols), nil
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package memlock keeps secret scalars in locked memory pages, that the operating system doesn't swap to disk.
+//
+// A Scalar stores the encoding of a secret scalar in its own memory mapping, locked with mlock where the platform
+// supports it. If the pages can't be locked, e.g. because of RLIMIT_MEMLOCK, the scalar is still usable and Locked
+// reports false, so that callers can decide whether to proceed. The scalar is only decoded for the duration of Use,
+// into a heap-allocated ecc.Scalar that is wiped afterwards, which limits but doesn't prevent the exposure of the
+// secret in swappable memory.
+package memlock
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var errDestroyed = errors.New("locked scalar has been destroyed")
+
+// Scalar is a secret scalar stored in locked memory. It must be released with Destroy.
+type Scalar struct {
+	mem    *region
+	group  ecc.Group
+	length int
+}
+
+// New returns a locked copy of the scalar. The input scalar is not modified, and should be wiped by the caller.
+func New(s *ecc.Scalar) *Scalar {
+	g := s.Group()
+	l := &Scalar{
+		mem:    allocate(g.ScalarLength()),
+		group:  g,
+		length: g.ScalarLength(),
+	}
+
+	l.Set(s)
+
+	return l
+}
+
+// Group returns the group of the scalar.
+func (l *Scalar) Group() ecc.Group {
+	return l.group
+}
+
+// Locked returns whether the memory holding the scalar is locked.
+func (l *Scalar) Locked() bool {
+	return l.mem != nil && l.mem.locked
+}
+
+// Set stores the value of s, which must belong to the same group, and panics otherwise.
+func (l *Scalar) Set(s *ecc.Scalar) {
+	if l.mem == nil {
+		panic(errDestroyed)
+	}
+
+	if s.Group() != l.group {
+		panic(&ecc.ErrWrongGroup{Expected: l.group, Got: s.Group()})
+	}
+
+	encoded := s.Encode()
+	copy(l.mem.buf[:l.length], encoded)
+	clear(encoded)
+}
+
+// Use decodes the scalar into a temporary ecc.Scalar, calls f with it, and wipes it once f returns. f must not retain
+// the scalar.
+func (l *Scalar) Use(f func(s *ecc.Scalar) error) error {
+	if l.mem == nil {
+		return errDestroyed
+	}
+
+	s := l.group.NewScalar()
+	defer s.Wipe()
+
+	if err := s.Decode(l.mem.buf[:l.length]); err != nil {
+		return err
+	}
+
+	return f(s)
+}
+
+// Destroy wipes the scalar and releases its memory. The scalar must not be used afterwards.
+func (l *Scalar) Destroy() {
+	if l.mem == nil {
+		return
+	}
+
+	clear(l.mem.buf)
+	l.mem.release()
+	l.mem = nil
+}
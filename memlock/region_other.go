@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package memlock
+
+// region is a memory area dedicated to secrets. Memory locking is not supported on this platform, and regions are
+// allocated on the heap.
+type region struct {
+	buf    []byte
+	locked bool
+}
+
+func allocate(size int) *region {
+	return &region{buf: make([]byte, size)}
+}
+
+func (r *region) release() {
+	r.buf = nil
+}
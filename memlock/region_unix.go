@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package memlock
+
+import (
+	"os"
+	"syscall"
+)
+
+// region is a memory area dedicated to secrets.
+type region struct {
+	buf    []byte
+	mapped bool
+	locked bool
+}
+
+// allocate returns a region of at least size bytes, in its own anonymous mapping locked in memory if possible, and
+// falls back to the heap if the mapping fails.
+func allocate(size int) *region {
+	pageSize := os.Getpagesize()
+	length := (size + pageSize - 1) / pageSize * pageSize
+
+	buf, err := syscall.Mmap(-1, 0, length, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return &region{buf: make([]byte, size)}
+	}
+
+	return &region{
+		buf:    buf,
+		mapped: true,
+		locked: syscall.Mlock(buf) == nil,
+	}
+}
+
+func (r *region) release() {
+	if !r.mapped {
+		return
+	}
+
+	if r.locked {
+		_ = syscall.Munlock(r.buf)
+	}
+
+	_ = syscall.Munmap(r.buf)
+	r.buf = nil
+}
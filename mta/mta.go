@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package mta provides the group-side primitives of multiplicative-to-additive (MtA) share conversion used by
+// GG18/CGGMP-style threshold ECDSA: conversion of Shamir shares and their public points to additive shares,
+// commitments binding MtA outputs to the parties' inputs, and Schnorr proofs of knowledge for committed elements.
+//
+// The Paillier-based range proofs of these protocols operate outside the elliptic curve group and are not covered
+// here. Only the checks that relate their outputs to group elements are.
+package mta
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/secretsharing"
+)
+
+const dlogProofDST = "ECC-MtA-V01-DLog"
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+)
+
+// LagrangeCoefficient returns the Lagrange coefficient at 0 of the participant id among the participants, i.e.
+// the product of j / (j - id) for all other participants j.
+func LagrangeCoefficient(g ecc.Group, id uint64, participants []uint64) (*ecc.Scalar, error) {
+	return secretsharing.LagrangeCoefficient(g, id, participants)
+}
+
+// ToAdditive converts the Shamir share of the participant id into an additive share among the participants.
+func ToAdditive(share *ecc.Scalar, id uint64, participants []uint64) (*ecc.Scalar, error) {
+	if share == nil {
+		return nil, errNilInput
+	}
+
+	l, err := LagrangeCoefficient(share.Group(), id, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Multiply(share), nil
+}
+
+// PublicToAdditive converts the public share (i.e. share * G) of the participant id into the public counterpart
+// of its additive share, so that the other parties can re-share and verify the points without the secrets.
+func PublicToAdditive(public *ecc.Element, id uint64, participants []uint64) (*ecc.Element, error) {
+	if public == nil {
+		return nil, errNilInput
+	}
+
+	l, err := LagrangeCoefficient(public.Group(), id, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	return public.Copy().Multiply(l), nil
+}
+
+// CombinePublicShares returns the group public key interpolated from the public shares of the participants, indexed
+// by their identifiers.
+func CombinePublicShares(shares map[uint64]*ecc.Element) (*ecc.Element, error) {
+	return secretsharing.CombineElements(shares)
+}
+
+// Commitment binds the responder's side of an MtA exchange, in which the initiator holds a, the responder holds b,
+// and they obtain alpha and beta such that alpha + beta = a * b.
+type Commitment struct {
+	// B is the commitment to the responder's input, b * G.
+	B *ecc.Element
+
+	// Beta is the commitment to the responder's output share, beta * G.
+	Beta *ecc.Element
+}
+
+// Commit returns the responder's commitment to its input b and its output share beta.
+func Commit(b, beta *ecc.Scalar) (*Commitment, error) {
+	if b == nil || beta == nil {
+		return nil, errNilInput
+	}
+
+	if b.Group() != beta.Group() {
+		return nil, errGroupMismatch
+	}
+
+	g := b.Group()
+
+	return &Commitment{
+		B:    g.Base().Multiply(b),
+		Beta: g.Base().Multiply(beta),
+	}, nil
+}
+
+// Verify is run by the initiator to check that its output share alpha is consistent with its input a and the
+// responder's commitment, i.e. that alpha * G + beta * G = a * B.
+func (c *Commitment) Verify(a, alpha *ecc.Scalar) bool {
+	if c == nil || c.B == nil || c.Beta == nil || a == nil || alpha == nil {
+		return false
+	}
+
+	g := c.B.Group()
+	if c.Beta.Group() != g || a.Group() != g || alpha.Group() != g {
+		return false
+	}
+
+	left := g.Base().Multiply(alpha).Add(c.Beta)
+
+	return left.Equal(c.B.Copy().Multiply(a))
+}
+
+// DLogProof is a non-interactive Schnorr proof of knowledge of the discrete logarithm of a committed element.
+type DLogProof struct {
+	Commitment *ecc.Element
+	Response   *ecc.Scalar
+}
+
+func dlogChallenge(public, commitment *ecc.Element, context []byte) *ecc.Scalar {
+	g := public.Group()
+	input := make([]byte, 0, 2*g.ElementLength()+len(context))
+	input = append(input, g.Base().Encode()...)
+	input = append(input, public.Encode()...)
+	input = append(input, commitment.Encode()...)
+	input = append(input, context...)
+
+	return g.HashToScalar(input, []byte(dlogProofDST))
+}
+
+// ProveDLog proves knowledge of secret such that public = secret * G. The context binds the proof to a session, and
+// should at least contain the prover's identifier.
+func ProveDLog(secret *ecc.Scalar, public *ecc.Element, context []byte) (*DLogProof, error) {
+	if secret == nil || public == nil {
+		return nil, errNilInput
+	}
+
+	g := secret.Group()
+	if public.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	r := g.NewScalar().Random()
+	commitment := g.Base().Multiply(r)
+	c := dlogChallenge(public, commitment, context)
+
+	return &DLogProof{
+		Commitment: commitment,
+		Response:   c.Multiply(secret).Add(r),
+	}, nil
+}
+
+// Verify returns whether the proof attests knowledge of the discrete logarithm of public.
+func (p *DLogProof) Verify(public *ecc.Element, context []byte) bool {
+	if p == nil || p.Commitment == nil || p.Response == nil || public == nil {
+		return false
+	}
+
+	g := public.Group()
+	if p.Commitment.Group() != g || p.Response.Group() != g {
+		return false
+	}
+
+	c := dlogChallenge(public, p.Commitment, context)
+	right := public.Copy().Multiply(c).Add(p.Commitment)
+
+	return g.Base().Multiply(p.Response).Equal(right)
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// MustDecodeElement returns the decoded element, and panics if the input is not a valid encoding in the group. It is
+// intended for package-level fixtures and tests, where the input is a constant: use Element.Decode on untrusted input.
+// The panic value is an error wrapping the cause.
+func (g Group) MustDecodeElement(data []byte) *Element {
+	e := g.NewElement()
+	if err := e.Decode(data); err != nil {
+		panic(fmt.Errorf("ecc: MustDecodeElement: invalid %s element %x: %w", g.Name(), data, err))
+	}
+
+	return e
+}
+
+// MustDecodeScalar returns the decoded scalar, and panics if the input is not a valid encoding in the group. It is
+// intended for package-level fixtures and tests, where the input is a constant: use Scalar.Decode on untrusted input.
+// The panic value is an error wrapping the cause.
+func (g Group) MustDecodeScalar(data []byte) *Scalar {
+	s := g.NewScalar()
+	if err := s.Decode(data); err != nil {
+		panic(fmt.Errorf("ecc: MustDecodeScalar: invalid %s scalar: %w", g.Name(), err))
+	}
+
+	return s
+}
+
+// MustHex returns the bytes of the hexadecimal string, and panics if it is not valid hexadecimal. It is intended for
+// fixtures, e.g. g.MustDecodeElement(ecc.MustHex("...")).
+func MustHex(h string) []byte {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		panic(fmt.Errorf("ecc: MustHex: %w", err))
+	}
+
+	return b
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !ecc_debug
+
+package ecc
+
+// DebugMode is true when the package is built with the ecc_debug tag, which enables invariant checks on the results
+// of arithmetic operations.
+const DebugMode = false
+
+func debugElement(e *Element, _ string) *Element {
+	return e
+}
+
+func debugScalar(s *Scalar, _ string) *Scalar {
+	return s
+}
+
+func debugInvert(_ *Scalar) {}
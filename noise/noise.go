@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package noise exposes the groups of the ecc package as DH functions of the Noise protocol framework
+// (https://noiseprotocol.org/noise.html), including the groups Noise doesn't specify, like the NIST curves.
+//
+// DH mirrors the DHFunc interface of github.com/flynn/noise, which this package doesn't depend on. Since the key pair
+// type must be the one of that package, plugging DH into it takes a small adapter:
+//
+//	type dh struct{ noise.DH }
+//
+//	func (d dh) GenerateKeypair(r io.Reader) (flynn.DHKey, error) {
+//		k, err := d.DH.GenerateKeypair(r)
+//		return flynn.DHKey{Private: k.Private, Public: k.Public}, err
+//	}
+//
+// Public keys and DH outputs are encoded elements, and private keys are encoded scalars. Note that Noise specifies
+// DH outputs as x-coordinates for X25519 and X448, and that these groups are not interoperable with them.
+package noise
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/bytemare/ecc"
+)
+
+const keyGenDST = "ECC-Noise-V01-KeyGen"
+
+var errUnsupportedGroup = errors.New("the group has no Noise DH name")
+
+// names of the DH functions, as used in Noise protocol names, e.g. "Noise_XX_P256_AESGCM_SHA256".
+var names = map[ecc.Group]string{
+	ecc.Ristretto255Sha512: "ristretto255",
+	ecc.P256Sha256:         "P256",
+	ecc.P384Sha384:         "P384",
+	ecc.P521Sha512:         "P521",
+	ecc.Edwards25519Sha512: "Edwards25519",
+	ecc.Secp256k1Sha256:    "secp256k1",
+	ecc.PallasBLAKE2b512:   "Pallas",
+}
+
+// KeyPair is a DH key pair of encoded keys.
+type KeyPair struct {
+	Private []byte
+	Public  []byte
+}
+
+// DH is the Noise DH function over a group.
+type DH struct {
+	group ecc.Group
+}
+
+// New returns the DH function over the group, and an error if the group has no Noise name.
+func New(g ecc.Group) (DH, error) {
+	if _, ok := names[g]; !ok {
+		return DH{}, errUnsupportedGroup
+	}
+
+	return DH{group: g}, nil
+}
+
+// GenerateKeypair returns a new key pair, drawing randomness from the reader, or from crypto/rand if it is nil.
+func (d DH) GenerateKeypair(random io.Reader) (KeyPair, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+
+	// Twice the scalar length makes the bias of the reduction negligible.
+	seed := make([]byte, 2*d.group.ScalarLength())
+
+	for {
+		if _, err := io.ReadFull(random, seed); err != nil {
+			return KeyPair{}, err
+		}
+
+		sk := d.group.HashToScalar(seed, []byte(keyGenDST))
+		if !sk.IsZero() {
+			return KeyPair{Private: sk.Encode(), Public: d.group.Base().Multiply(sk).Encode()}, nil
+		}
+	}
+}
+
+// DH returns the encoding of the element shared between the private and the public key, and an error if a key is
+// invalid, or if the public key is the identity.
+func (d DH) DH(privateKey, publicKey []byte) ([]byte, error) {
+	sk := d.group.NewScalar()
+	if err := sk.DecodeCanonical(privateKey); err != nil {
+		return nil, err
+	}
+	defer sk.Wipe()
+
+	pk, err := d.group.NewElementFromBytesNonIdentity(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pk.Multiply(sk).Encode(), nil
+}
+
+// DHLen returns the byte size of public keys and DH outputs.
+func (d DH) DHLen() int {
+	return d.group.ElementLength()
+}
+
+// DHName returns the name of the DH function in Noise protocol names.
+func (d DH) DHName() string {
+	return names[d.group]
+}
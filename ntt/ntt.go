@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ntt implements the radix-2 number theoretic transform, i.e. the FFT over the scalar field of a group, to
+// evaluate and interpolate polynomials on the subgroups of roots of unity, e.g. for polynomial commitments and proof
+// systems.
+//
+// The transform of size n = 2^k needs a primitive n-th root of unity, which exists if 2^k divides the group order
+// minus one. The scalar field of Pallas, like the other pasta field, has a 2-adicity of 32, which allows all sizes up
+// to 2^32, while the other groups only allow small sizes, e.g. 2^6 for secp256k1.
+package ntt
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errSize          = errors.New("the size must be a power of two dividing the group order minus one")
+	errLength        = errors.New("the number of values doesn't match the domain size")
+)
+
+// Domain holds the precomputed roots of unity of a transform size.
+type Domain struct {
+	omega    *ecc.Scalar
+	sizeInv  *ecc.Scalar
+	roots    []*ecc.Scalar // omega^i for i < size/2
+	invRoots []*ecc.Scalar // omega^-i for i < size/2
+	size     int
+	group    ecc.Group
+}
+
+// TwoAdicity returns the largest k such that 2^k divides the group order minus one, i.e. the log2 of the maximal size
+// of a domain in the group.
+func TwoAdicity(g ecc.Group) int {
+	return int(new(big.Int).Sub(g.OrderBig(), big.NewInt(1)).TrailingZeroBits())
+}
+
+// scalar returns the scalar of the integer value, which must be lower than the group order.
+func scalar(g ecc.Group, v *big.Int) *ecc.Scalar {
+	s := g.NewScalar()
+	if err := s.SetString(v.String()); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// rootOfUnity returns a primitive 2^k-th root of unity, for k at most the two-adicity, as c^((q - 1) / 2^k) for c the
+// smallest quadratic non-residue modulo the order q.
+func rootOfUnity(g ecc.Group, k int) *ecc.Scalar {
+	qMinusOne := new(big.Int).Sub(g.OrderBig(), big.NewInt(1))
+	half := scalar(g, new(big.Int).Rsh(qMinusOne, 1))
+	minusOne := g.NewScalar().MinusOne()
+
+	c := g.NewScalar().SetUInt64(2)
+	for !c.Copy().Pow(half).Equal(minusOne) {
+		c.Add(g.NewScalar().One())
+	}
+
+	return c.Pow(scalar(g, new(big.Int).Rsh(qMinusOne, uint(k))))
+}
+
+// NewDomain returns the domain of the transforms of the given size in the group, and an error if the size isn't a power
+// of two at most 2^TwoAdicity(g).
+func NewDomain(g ecc.Group, size int) (*Domain, error) {
+	if size <= 0 || size&(size-1) != 0 {
+		return nil, errSize
+	}
+
+	k := 0
+	for 1<<k < size {
+		k++
+	}
+
+	if k > TwoAdicity(g) {
+		return nil, errSize
+	}
+
+	d := &Domain{
+		group:    g,
+		size:     size,
+		sizeInv:  g.NewScalar().SetUInt64(uint64(size)).Invert(),
+		roots:    make([]*ecc.Scalar, max(size/2, 1)),
+		invRoots: make([]*ecc.Scalar, max(size/2, 1)),
+	}
+
+	d.omega = rootOfUnity(g, k)
+	omegaInv := d.omega.Copy().Invert()
+	d.roots[0], d.invRoots[0] = g.NewScalar().One(), g.NewScalar().One()
+
+	for i := 1; i < len(d.roots); i++ {
+		d.roots[i] = d.roots[i-1].Copy().Multiply(d.omega)
+		d.invRoots[i] = d.invRoots[i-1].Copy().Multiply(omegaInv)
+	}
+
+	return d, nil
+}
+
+// Group returns the group of the domain's scalars.
+func (d *Domain) Group() ecc.Group {
+	return d.group
+}
+
+// Size returns the size of the transforms.
+func (d *Domain) Size() int {
+	return d.size
+}
+
+// Generator returns omega, the primitive root of unity of the domain's size.
+func (d *Domain) Generator() *ecc.Scalar {
+	return d.omega.Copy()
+}
+
+// Element returns omega^i, the i-th point of the domain.
+func (d *Domain) Element(i int) *ecc.Scalar {
+	i %= d.size
+	if i < 0 {
+		i += d.size
+	}
+
+	if i < len(d.roots) {
+		return d.roots[i].Copy()
+	}
+
+	// omega^(n/2) = -1.
+	return d.group.NewScalar().Subtract(d.roots[i-d.size/2])
+}
+
+// check returns an error if the values don't fit the domain.
+func (d *Domain) check(values []*ecc.Scalar) error {
+	if len(values) != d.size {
+		return errLength
+	}
+
+	for _, v := range values {
+		if v == nil {
+			return errNilInput
+		}
+
+		if v.Group() != d.group {
+			return errGroupMismatch
+		}
+	}
+
+	return nil
+}
+
+// NTT replaces in place the coefficients of a polynomial of degree lower than the size, from the constant term, with
+// its evaluations at the points of the domain, omega^0, omega^1, ... in order.
+func (d *Domain) NTT(values []*ecc.Scalar) error {
+	if err := d.check(values); err != nil {
+		return err
+	}
+
+	d.transform(values, d.roots)
+
+	return nil
+}
+
+// INTT is the inverse of NTT, and replaces in place the evaluations at the points of the domain with the coefficients
+// of the interpolating polynomial.
+func (d *Domain) INTT(values []*ecc.Scalar) error {
+	if err := d.check(values); err != nil {
+		return err
+	}
+
+	d.transform(values, d.invRoots)
+
+	for _, v := range values {
+		v.Multiply(d.sizeInv)
+	}
+
+	return nil
+}
+
+// transform runs the iterative Cooley-Tukey transform with the given roots.
+func (d *Domain) transform(values, roots []*ecc.Scalar) {
+	n := d.size
+
+	// Bit-reversal permutation.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+
+		j ^= bit
+
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+
+	t := d.group.NewScalar()
+
+	for length := 2; length <= n; length <<= 1 {
+		half, stride := length/2, n/length
+
+		for start := 0; start < n; start += length {
+			for k := range half {
+				u, v := values[start+k], values[start+k+half]
+				t.Set(v).Multiply(roots[k*stride])
+				v.Set(u).Subtract(t)
+				u.Add(t)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package oprf implements the verifiable (VOPRF) and partially-oblivious (POPRF) modes of the RFC 9497 oblivious
+// pseudorandom functions, for the ciphersuites of the RFC that this module supports.
+package oprf
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+// Mode identifies an RFC 9497 protocol variant.
+type Mode byte
+
+const (
+	// ModeVOPRF identifies the verifiable mode.
+	ModeVOPRF Mode = 0x01
+
+	// ModePOPRF identifies the partially-oblivious mode.
+	ModePOPRF Mode = 0x02
+
+	contextPrefix   = "OPRFV1-"
+	hashToGroupDST  = "HashToGroup-"
+	hashToScalarDST = "HashToScalar-"
+	seedDST         = "Seed-"
+	labelChallenge  = "Challenge"
+	labelComposite  = "Composite"
+	labelFinalize   = "Finalize"
+	labelInfo       = "Info"
+	maxInputLength  = 1<<16 - 1
+)
+
+var (
+	errUnsupportedGroup = errors.New("group is not an RFC 9497 ciphersuite")
+	errNilInput         = errors.New("nil input")
+	errGroupMismatch    = errors.New("inputs belong to different groups")
+	errInputTooLong     = errors.New("input is too long")
+	errInvalidInput     = errors.New("input maps to the identity element")
+	errInverse          = errors.New("the tweaked key is not invertible")
+	errVerify           = errors.New("the proof is invalid")
+	errProofLength      = errors.New("invalid proof encoding length")
+	errBatchLength      = errors.New("the batch inputs have different lengths")
+)
+
+var identifiers = map[ecc.Group]string{
+	ecc.Ristretto255Sha512: "ristretto255-SHA512",
+	ecc.P256Sha256:         "P256-SHA256",
+	ecc.P384Sha384:         "P384-SHA384",
+	ecc.P521Sha512:         "P521-SHA512",
+}
+
+// suite holds the ciphersuite parameters common to all modes.
+type suite struct {
+	group   ecc.Group
+	context []byte
+}
+
+func newSuite(g ecc.Group, mode Mode) (suite, error) {
+	id, ok := identifiers[g]
+	if !ok {
+		return suite{}, errUnsupportedGroup
+	}
+
+	context := append([]byte(contextPrefix), byte(mode), '-')
+
+	return suite{group: g, context: append(context, id...)}, nil
+}
+
+// lengthPrefixed appends the 2-byte length prefixed inputs to out.
+func lengthPrefixed(out []byte, inputs ...[]byte) []byte {
+	for _, in := range inputs {
+		out = binary.BigEndian.AppendUint16(out, uint16(len(in)))
+		out = append(out, in...)
+	}
+
+	return out
+}
+
+func (s suite) hash(input []byte) []byte {
+	h := s.group.HashFunc().New()
+	h.Write(input)
+
+	return h.Sum(nil)
+}
+
+func (s suite) hashToGroup(input []byte) *ecc.Element {
+	return s.group.HashToGroup(input, append([]byte(hashToGroupDST), s.context...))
+}
+
+func (s suite) hashToScalar(input []byte) *ecc.Scalar {
+	return s.group.HashToScalar(input, append([]byte(hashToScalarDST), s.context...))
+}
+
+// DeriveKeyPair deterministically derives a private and public key pair from the seed and info.
+func (s suite) DeriveKeyPair(seed, info []byte) (*ecc.Scalar, *ecc.Element, error) {
+	return s.group.DeriveKeyPairWithContext(seed, info, s.context)
+}
+
+// Blind returns the blinded element of the input, and the blind needed to finalize the evaluation. If blind is nil, a
+// random one is used.
+func (s suite) Blind(input []byte, blind *ecc.Scalar) (*ecc.Scalar, *ecc.Element, error) {
+	if len(input) > maxInputLength {
+		return nil, nil, errInputTooLong
+	}
+
+	if blind == nil {
+		blind = s.group.NewScalar().Random()
+	} else if blind.Group() != s.group {
+		return nil, nil, errGroupMismatch
+	}
+
+	e := s.hashToGroup(input)
+	if e.IsIdentity() {
+		return nil, nil, errInvalidInput
+	}
+
+	return blind, e.Multiply(blind), nil
+}
+
+// unblind returns the serialization of blind^-1 * evaluated.
+func unblind(blind *ecc.Scalar, evaluated *ecc.Element) []byte {
+	return evaluated.Copy().Multiply(blind.Copy().Invert()).Encode()
+}
+
+func (s suite) checkElements(elements ...*ecc.Element) error {
+	for _, e := range elements {
+		if e == nil {
+			return errNilInput
+		}
+
+		if e.Group() != s.group {
+			return errGroupMismatch
+		}
+	}
+
+	return nil
+}
+
+func (s suite) checkBatch(key *ecc.Element, blinds []*ecc.Scalar, evaluated, blinded []*ecc.Element) error {
+	for _, b := range blinds {
+		if b == nil {
+			return errNilInput
+		}
+
+		if b.Group() != s.group {
+			return errGroupMismatch
+		}
+	}
+
+	if err := s.checkElements(key); err != nil {
+		return err
+	}
+
+	if err := s.checkElements(evaluated...); err != nil {
+		return err
+	}
+
+	return s.checkElements(blinded...)
+}
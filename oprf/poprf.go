@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package oprf
+
+import (
+	"crypto/subtle"
+
+	"github.com/bytemare/ecc"
+)
+
+// POPRF implements the RFC 9497 partially-oblivious mode, in which both parties agree on public info bound to the
+// evaluation.
+type POPRF struct {
+	suite
+}
+
+// NewPOPRF returns the POPRF ciphersuite over the group.
+func NewPOPRF(g ecc.Group) (*POPRF, error) {
+	s, err := newSuite(g, ModePOPRF)
+	if err != nil {
+		return nil, err
+	}
+
+	return &POPRF{s}, nil
+}
+
+func (p *POPRF) infoScalar(info []byte) (*ecc.Scalar, error) {
+	if len(info) > maxInputLength {
+		return nil, errInputTooLong
+	}
+
+	return p.hashToScalar(lengthPrefixed([]byte(labelInfo), info)), nil
+}
+
+// TweakKey returns the server's public key tweaked with the public info, which the client needs to finalize.
+func (p *POPRF) TweakKey(pk *ecc.Element, info []byte) (*ecc.Element, error) {
+	if err := p.checkElements(pk); err != nil {
+		return nil, err
+	}
+
+	m, err := p.infoScalar(info)
+	if err != nil {
+		return nil, err
+	}
+
+	tweaked := p.group.Base().Multiply(m).Add(pk)
+	if tweaked.IsIdentity() {
+		return nil, errInvalidInput
+	}
+
+	return tweaked, nil
+}
+
+// tweakScalar returns the private key tweaked with the public info.
+func (p *POPRF) tweakScalar(sk *ecc.Scalar, info []byte) (*ecc.Scalar, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.Group() != p.group {
+		return nil, errGroupMismatch
+	}
+
+	m, err := p.infoScalar(info)
+	if err != nil {
+		return nil, err
+	}
+
+	t := m.Add(sk)
+	if t.IsZero() {
+		return nil, errInverse
+	}
+
+	return t, nil
+}
+
+// BlindEvaluate returns the server's evaluation of the blinded elements under the private key and the public info,
+// and a single proof for the whole batch.
+func (p *POPRF) BlindEvaluate(sk *ecc.Scalar, info []byte, blinded ...*ecc.Element) ([]*ecc.Element, *Proof, error) {
+	if err := p.checkElements(blinded...); err != nil {
+		return nil, nil, err
+	}
+
+	t, err := p.tweakScalar(sk, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tInv := t.Copy().Invert()
+	evaluated := make([]*ecc.Element, len(blinded))
+
+	for i, b := range blinded {
+		evaluated[i] = b.Copy().Multiply(tInv)
+	}
+
+	proof := p.generateProof(t, p.group.Base(), p.group.Base().Multiply(t), evaluated, blinded)
+
+	return evaluated, proof, nil
+}
+
+func (p *POPRF) output(input, info, unblinded []byte) []byte {
+	transcript := lengthPrefixed(nil, input, info, unblinded)
+	return p.hash(append(transcript, labelFinalize...))
+}
+
+// Finalize verifies the proof over the batch and returns the PRF outputs for the inputs, given the tweaked key
+// returned by TweakKey.
+func (p *POPRF) Finalize(inputs [][]byte, blinds []*ecc.Scalar, evaluated, blinded []*ecc.Element,
+	proof *Proof, info []byte, tweakedKey *ecc.Element,
+) ([][]byte, error) {
+	if len(inputs) != len(blinds) || len(inputs) != len(evaluated) || len(inputs) != len(blinded) {
+		return nil, errBatchLength
+	}
+
+	if err := p.checkBatch(tweakedKey, blinds, evaluated, blinded); err != nil {
+		return nil, err
+	}
+
+	if !p.verifyProof(p.group.Base(), tweakedKey, evaluated, blinded, proof) {
+		return nil, errVerify
+	}
+
+	outputs := make([][]byte, len(inputs))
+	for i, input := range inputs {
+		outputs[i] = p.output(input, info, unblind(blinds[i], evaluated[i]))
+	}
+
+	return outputs, nil
+}
+
+// Evaluate returns the PRF output of the input under the private key and the public info, as computed by a client.
+func (p *POPRF) Evaluate(sk *ecc.Scalar, input, info []byte) ([]byte, error) {
+	if len(input) > maxInputLength {
+		return nil, errInputTooLong
+	}
+
+	t, err := p.tweakScalar(sk, info)
+	if err != nil {
+		return nil, err
+	}
+
+	e := p.hashToGroup(input)
+	if e.IsIdentity() {
+		return nil, errInvalidInput
+	}
+
+	return p.output(input, info, e.Multiply(t.Invert()).Encode()), nil
+}
+
+// VerifyOutput returns whether the output is the PRF output of the input under the private key and the public info.
+func (p *POPRF) VerifyOutput(sk *ecc.Scalar, input, info, output []byte) bool {
+	expected, err := p.Evaluate(sk, input, info)
+	return err == nil && subtle.ConstantTimeCompare(expected, output) == 1
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package oprf
+
+import (
+	"github.com/bytemare/ecc"
+)
+
+// Proof is an RFC 9497 discrete logarithm equivalence proof.
+type Proof struct {
+	C *ecc.Scalar
+	S *ecc.Scalar
+}
+
+// computeComposites returns the composite elements M and Z for the batch (C, D). If k is not nil, Z is computed as
+// k * M.
+func (s suite) computeComposites(k *ecc.Scalar, b *ecc.Element, c, d []*ecc.Element) (m, z *ecc.Element) {
+	seed := s.hash(lengthPrefixed(nil, b.Encode(), append([]byte(seedDST), s.context...)))
+	m, z = s.group.NewElement(), s.group.NewElement()
+
+	for i := range c {
+		transcript := lengthPrefixed(nil, seed)
+		transcript = append(transcript, byte(i>>8), byte(i))
+		transcript = lengthPrefixed(transcript, c[i].Encode(), d[i].Encode())
+		transcript = append(transcript, labelComposite...)
+
+		di := s.hashToScalar(transcript)
+		m.Add(c[i].Copy().Multiply(di))
+
+		if k == nil {
+			z.Add(d[i].Copy().Multiply(di))
+		}
+	}
+
+	if k != nil {
+		z = m.Copy().Multiply(k)
+	}
+
+	return m, z
+}
+
+func (s suite) challenge(b, m, z, t2, t3 *ecc.Element) *ecc.Scalar {
+	transcript := lengthPrefixed(nil, b.Encode(), m.Encode(), z.Encode(), t2.Encode(), t3.Encode())
+	return s.hashToScalar(append(transcript, labelChallenge...))
+}
+
+// generateProof returns a proof that k = log_a(b) = log_c[i](d[i]) for all i.
+func (s suite) generateProof(k *ecc.Scalar, a, b *ecc.Element, c, d []*ecc.Element) *Proof {
+	m, z := s.computeComposites(k, b, c, d)
+	r := s.group.NewScalar().Random()
+	t2 := a.Copy().Multiply(r)
+	t3 := m.Copy().Multiply(r)
+	ch := s.challenge(b, m, z, t2, t3)
+
+	return &Proof{
+		C: ch,
+		S: r.Subtract(ch.Copy().Multiply(k)),
+	}
+}
+
+// verifyProof returns whether the proof attests that log_a(b) = log_c[i](d[i]) for all i.
+func (s suite) verifyProof(a, b *ecc.Element, c, d []*ecc.Element, proof *Proof) bool {
+	if proof == nil || proof.C == nil || proof.S == nil ||
+		proof.C.Group() != s.group || proof.S.Group() != s.group {
+		return false
+	}
+
+	m, z := s.computeComposites(nil, b, c, d)
+	t2 := a.Copy().Multiply(proof.S).Add(b.Copy().Multiply(proof.C))
+	t3 := m.Copy().Multiply(proof.S).Add(z.Copy().Multiply(proof.C))
+
+	return s.challenge(b, m, z, t2, t3).Equal(proof.C)
+}
+
+// Encode returns the byte encoding of the proof, i.e. the concatenation of c and s.
+func (p *Proof) Encode() []byte {
+	return append(p.C.Encode(), p.S.Encode()...)
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *Proof) Decode(g ecc.Group, data []byte) error {
+	sLen := g.ScalarLength()
+	if len(data) != 2*sLen {
+		return errProofLength
+	}
+
+	c := g.NewScalar()
+	if err := c.Decode(data[:sLen]); err != nil {
+		return err
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(data[sLen:]); err != nil {
+		return err
+	}
+
+	p.C, p.S = c, s
+
+	return nil
+}
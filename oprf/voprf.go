@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package oprf
+
+import (
+	"crypto/subtle"
+
+	"github.com/bytemare/ecc"
+)
+
+// VOPRF implements the RFC 9497 verifiable mode.
+type VOPRF struct {
+	suite
+}
+
+// NewVOPRF returns the VOPRF ciphersuite over the group.
+func NewVOPRF(g ecc.Group) (*VOPRF, error) {
+	s, err := newSuite(g, ModeVOPRF)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VOPRF{s}, nil
+}
+
+// BlindEvaluate returns the server's evaluation of the blinded elements under the private key, and a single proof for
+// the whole batch.
+func (v *VOPRF) BlindEvaluate(sk *ecc.Scalar, pk *ecc.Element, blinded ...*ecc.Element) ([]*ecc.Element, *Proof, error) {
+	if sk == nil {
+		return nil, nil, errNilInput
+	}
+
+	if err := v.checkElements(pk); err != nil {
+		return nil, nil, err
+	}
+
+	if err := v.checkElements(blinded...); err != nil {
+		return nil, nil, err
+	}
+
+	if sk.Group() != v.group {
+		return nil, nil, errGroupMismatch
+	}
+
+	evaluated := make([]*ecc.Element, len(blinded))
+	for i, b := range blinded {
+		evaluated[i] = b.Copy().Multiply(sk)
+	}
+
+	return evaluated, v.generateProof(sk, v.group.Base(), pk, blinded, evaluated), nil
+}
+
+// Finalize verifies the proof over the batch and returns the PRF outputs for the inputs.
+func (v *VOPRF) Finalize(inputs [][]byte, blinds []*ecc.Scalar, evaluated, blinded []*ecc.Element,
+	pk *ecc.Element, proof *Proof,
+) ([][]byte, error) {
+	if len(inputs) != len(blinds) || len(inputs) != len(evaluated) || len(inputs) != len(blinded) {
+		return nil, errBatchLength
+	}
+
+	if err := v.checkBatch(pk, blinds, evaluated, blinded); err != nil {
+		return nil, err
+	}
+
+	if !v.verifyProof(v.group.Base(), pk, blinded, evaluated, proof) {
+		return nil, errVerify
+	}
+
+	outputs := make([][]byte, len(inputs))
+	for i, input := range inputs {
+		transcript := lengthPrefixed(nil, input, unblind(blinds[i], evaluated[i]))
+		outputs[i] = v.hash(append(transcript, labelFinalize...))
+	}
+
+	return outputs, nil
+}
+
+// Evaluate returns the PRF output of the input under the private key, as computed by a client.
+func (v *VOPRF) Evaluate(sk *ecc.Scalar, input []byte) ([]byte, error) {
+	if len(input) > maxInputLength {
+		return nil, errInputTooLong
+	}
+
+	e := v.hashToGroup(input)
+	if e.IsIdentity() {
+		return nil, errInvalidInput
+	}
+
+	transcript := lengthPrefixed(nil, input, e.Multiply(sk).Encode())
+
+	return v.hash(append(transcript, labelFinalize...)), nil
+}
+
+// VerifyOutput returns whether the output is the PRF output of the input under the private key.
+func (v *VOPRF) VerifyOutput(sk *ecc.Scalar, input, output []byte) bool {
+	expected, err := v.Evaluate(sk, input)
+	return err == nil && subtle.ConstantTimeCompare(expected, output) == 1
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/hash"
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	errOptionXMD            = errors.New("hash-to-curve option: unavailable hash function for expand_message_xmd")
+	errOptionXOF            = errors.New("hash-to-curve option: hash function is not an extendable output function")
+	errOptionCount          = errors.New("hash-to-curve option: the count of field elements must be positive")
+	errOptionLength         = errors.New("hash-to-curve option: count times security length exceeds 65535 bytes")
+	errOptionXMDLength      = errors.New("hash-to-curve option: expand_message_xmd output exceeds 255 hash blocks")
+	errOptionSecurityLength = errors.New("hash-to-curve option: security length is too short for the field")
+	errOptionUniformLength  = errors.New("hash-to-curve option: the group only maps uniform strings of fixed length")
+	errOptionUnsupported    = errors.New("hash-to-curve option: the group doesn't support hashing options")
+	errOptionScalar         = errors.New("hash-to-curve option: hashing to scalars only supports WithStrictDST")
+)
+
+// Option sets a parameter of the hash-to-curve encodings of HashToGroupWithOptions and EncodeToGroupWithOptions, which
+// default to the RFC 9380 suite of the group. These functions return an error on invalid options. WithStrictDST applies
+// to all groups, and to HashToScalarWithOptions, but the options changing the encoding are only supported by the groups
+// whose map to the curve is implemented in this module, i.e. Ristretto255, Edwards25519, and Pallas, as the others use
+// the complete hash-to-curve implementations of their dependencies.
+type Option func(*hashToCurveConfig)
+
+type hashToCurveConfig struct {
+	xmd            crypto.Hash
+	xof            hash.Hash
+	securityLength uint
+	count          uint
+	countSet       bool
+	strictDST      bool
+}
+
+// WithStrictDST rejects DSTs shorter than the 16 bytes recommended by RFC 9380 with ErrShortDST, as ValidateDST does,
+// instead of only rejecting empty DSTs.
+func WithStrictDST() Option {
+	return func(c *hashToCurveConfig) {
+		c.strictDST = true
+	}
+}
+
+// WithExpandXMD selects expand_message_xmd with the given hash function.
+func WithExpandXMD(h crypto.Hash) Option {
+	return func(c *hashToCurveConfig) {
+		c.xmd = h
+		c.xof = 0
+	}
+}
+
+// WithExpandXOF selects expand_message_xof with the given extendable output function, e.g. hash.SHAKE256.
+func WithExpandXOF(h hash.Hash) Option {
+	return func(c *hashToCurveConfig) {
+		c.xof = h
+		c.xmd = 0
+	}
+}
+
+// WithSecurityLength sets L, the byte length of the uniform strings reduced to each field element, e.g.
+// ceil((ceil(log2(p)) + k) / 8) for a security level of k bits. It can't be shorter than the field order. For
+// Ristretto255, which maps 64-byte strings, it must be 64.
+func WithSecurityLength(length uint) Option {
+	return func(c *hashToCurveConfig) {
+		c.securityLength = length
+	}
+}
+
+// WithCount sets the number of field elements that are mapped and summed to the output element, which is 2 for
+// HashToGroup and 1 for EncodeToGroup. For Ristretto255, it is the number of 64-byte strings mapped and summed. The
+// count times the security length can't exceed 65535 bytes, nor 255 outputs of the hash function of expand_message_xmd.
+func WithCount(count uint) Option {
+	return func(c *hashToCurveConfig) {
+		c.count = count
+		c.countSet = true
+	}
+}
+
+// maxExpandLength is the maximum byte length of the uniform strings of expand_message_xmd and expand_message_xof,
+// whose length is encoded on 2 bytes.
+const maxExpandLength = 1<<16 - 1
+
+// maxXMDBlocks is the maximum number of hash outputs expand_message_xmd concatenates.
+const maxXMDBlocks = 255
+
+// validate returns an error if the expander is unavailable, the count is zero, or the length to expand, i.e. the count
+// times the security length, exceeds the limits of RFC 9380: 65535 bytes, and 255 hash outputs for expand_message_xmd.
+func (c *hashToCurveConfig) validate() error {
+	if c.xof != 0 {
+		if !c.xof.Available() || c.xof.Type() != hash.ExtendableOutputFunction {
+			return errOptionXOF
+		}
+	} else if !c.xmd.Available() {
+		return errOptionXMD
+	}
+
+	if c.count == 0 {
+		return errOptionCount
+	}
+
+	if c.securityLength > maxExpandLength/c.count {
+		return errOptionLength
+	}
+
+	if c.xof == 0 {
+		size := uint(c.xmd.Size())
+		if (c.count*c.securityLength+size-1)/size > maxXMDBlocks {
+			return errOptionXMDLength
+		}
+	}
+
+	return nil
+}
+
+// expand returns the uniform byte string of the given length, with the configured expander, which must be valid.
+func (c *hashToCurveConfig) expand(input, dst []byte, length uint) []byte {
+	if c.xof != 0 {
+		return hash2curve.ExpandXOF(c.xof.GetXOF(), input, dst, length)
+	}
+
+	return hash2curve.ExpandXMD(c.xmd, input, dst, length)
+}
+
+// hashWithOptions maps the input to the group with the options applied over the group's default parameters, i.e. the
+// HashToGroup or EncodeToGroup suite. The map is the group's own, the same as for HashToGroup and EncodeToGroup.
+func (g Group) hashWithOptions(input, dst []byte, encode bool, opts []Option) (internal.Element, error) {
+	c := g.newHashToCurveConfig(opts)
+
+	if err := checkDSTLength(dst, c.strictDST); err != nil {
+		return nil, err
+	}
+
+	count := uint(2)
+	if encode {
+		count = 1
+	}
+
+	switch m := g.get().(type) {
+	case internal.UniformMapper:
+		c.setDefaults(m.UniformLength(), 1)
+
+		if err := c.validate(); err != nil {
+			return nil, err
+		}
+
+		return mapUniform(m, &c, input, dst)
+	case internal.FieldMapper:
+		c.setDefaults(m.SecurityLength(), count)
+
+		if err := c.validate(); err != nil {
+			return nil, err
+		}
+
+		return mapField(m, &c, input, dst)
+	default:
+		if c.changesEncoding(g) {
+			return nil, errOptionUnsupported
+		}
+
+		if encode {
+			return g.get().EncodeToGroup(input, dst), nil
+		}
+
+		return g.get().HashToGroup(input, dst), nil
+	}
+}
+
+// hashToScalarWithOptions hashes the input to a scalar with the group's HashToScalar, after checking the DST as set by
+// the options, which can't change the encoding.
+func (g Group) hashToScalarWithOptions(input, dst []byte, opts []Option) (internal.Scalar, error) {
+	c := g.newHashToCurveConfig(opts)
+
+	if err := checkDSTLength(dst, c.strictDST); err != nil {
+		return nil, err
+	}
+
+	if c.changesEncoding(g) {
+		return nil, errOptionScalar
+	}
+
+	return g.get().HashToScalar(input, dst), nil
+}
+
+// newHashToCurveConfig returns the configuration with the options applied over the group's hash function.
+func (g Group) newHashToCurveConfig(opts []Option) hashToCurveConfig {
+	c := hashToCurveConfig{
+		xmd: g.HashFunc(),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+
+	return c
+}
+
+// changesEncoding returns whether options other than WithStrictDST were set.
+func (c *hashToCurveConfig) changesEncoding(g Group) bool {
+	return c.xof != 0 || c.xmd != g.HashFunc() || c.securityLength != 0 || c.countSet
+}
+
+// setDefaults sets the security length and count that were not set by options.
+func (c *hashToCurveConfig) setDefaults(securityLength, count uint) {
+	if c.securityLength == 0 {
+		c.securityLength = securityLength
+	}
+
+	if !c.countSet {
+		c.count = count
+	}
+}
+
+func mapUniform(m internal.UniformMapper, c *hashToCurveConfig, input, dst []byte) (internal.Element, error) {
+	if c.securityLength != m.UniformLength() {
+		return nil, errOptionUniformLength
+	}
+
+	uniform := c.expand(input, dst, c.count*c.securityLength)
+	q := m.MapUniform(uniform[:c.securityLength])
+
+	for i := uint(1); i < c.count; i++ {
+		q.Add(m.MapUniform(uniform[i*c.securityLength : (i+1)*c.securityLength]))
+	}
+
+	return q, nil
+}
+
+func mapField(m internal.FieldMapper, c *hashToCurveConfig, input, dst []byte) (internal.Element, error) {
+	order := m.FieldOrder()
+	if c.securityLength < uint(len(order)) {
+		return nil, errOptionSecurityLength
+	}
+
+	p := new(big.Int).SetBytes(order)
+	uniform := c.expand(input, dst, c.count*c.securityLength)
+	u := make([][]byte, c.count)
+	e := new(big.Int)
+
+	for i := range c.count {
+		e.SetBytes(uniform[i*c.securityLength : (i+1)*c.securityLength])
+		u[i] = e.Mod(e, p).FillBytes(make([]byte, len(order)))
+	}
+
+	return m.MapToGroup(u...), nil
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ot implements the base messages of the Chou-Orlandi "Simplest OT" 1-out-of-2 oblivious transfer protocol.
+//
+// The sender publishes A = a * G once. For each transfer, the receiver with choice bit c answers with B = b * G if c is
+// 0, and B = A + b * G if c is 1, and derives its key from b * A. The sender derives the two keys from a * B and
+// a * (B - A), of which the receiver knows exactly the one of its choice. The keys can then be used to encrypt the
+// two messages of the transfer, e.g. in an OT extension.
+//
+// The protocol requires a prime-order group, like Ristretto255.
+package ot
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const keyDST = "ECC-SimplestOT-V01-Key"
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errIdentity      = errors.New("the message is the identity element")
+)
+
+// deriveKey returns the key derived from the transcript and the shared element.
+func deriveKey(index uint64, a, b, shared *ecc.Element) []byte {
+	g := a.Group()
+	h := g.HashFunc().New()
+	h.Write([]byte(keyDST))
+	h.Write(binary.BigEndian.AppendUint64(nil, index))
+	h.Write(a.Encode())
+	h.Write(b.Encode())
+	h.Write(shared.Encode())
+
+	return h.Sum(nil)
+}
+
+func checkMessage(g ecc.Group, e *ecc.Element) error {
+	if e == nil {
+		return errNilInput
+	}
+
+	if e.Group() != g {
+		return errGroupMismatch
+	}
+
+	if e.IsIdentity() {
+		return errIdentity
+	}
+
+	return nil
+}
+
+// Sender holds the sender's secret state, which can be used for any number of transfers.
+type Sender struct {
+	a *ecc.Scalar
+	A *ecc.Element
+}
+
+// NewSender returns a new sender state, whose public message A must be sent to the receiver.
+func NewSender(g ecc.Group) *Sender {
+	a := g.NewScalar().Random()
+
+	return &Sender{
+		a: a,
+		A: g.Base().Multiply(a),
+	}
+}
+
+// Keys returns the two keys of the transfer at the given index, given the receiver's message.
+func (s *Sender) Keys(index uint64, b *ecc.Element) (k0, k1 []byte, err error) {
+	if err = checkMessage(s.A.Group(), b); err != nil {
+		return nil, nil, err
+	}
+
+	k0 = deriveKey(index, s.A, b, b.Copy().Multiply(s.a))
+	k1 = deriveKey(index, s.A, b, b.Copy().Subtract(s.A).Multiply(s.a))
+
+	return k0, k1, nil
+}
+
+// Receiver holds the receiver's secret state for one transfer.
+type Receiver struct {
+	b     *ecc.Scalar
+	a     *ecc.Element
+	B     *ecc.Element
+	index uint64
+}
+
+// NewReceiver returns the receiver's state for the transfer at the given index, given the sender's message A and the
+// choice bit. The public message B must be sent to the sender.
+func NewReceiver(index uint64, a *ecc.Element, choice bool) (*Receiver, error) {
+	if a == nil {
+		return nil, errNilInput
+	}
+
+	g := a.Group()
+	if err := checkMessage(g, a); err != nil {
+		return nil, err
+	}
+
+	b := g.NewScalar().Random()
+	bm := g.Base().Multiply(b)
+
+	if choice {
+		bm.Add(a)
+	}
+
+	return &Receiver{
+		b:     b,
+		a:     a.Copy(),
+		B:     bm,
+		index: index,
+	}, nil
+}
+
+// Key returns the key of the receiver's choice.
+func (r *Receiver) Key() []byte {
+	return deriveKey(r.index, r.a, r.B, r.a.Copy().Multiply(r.b))
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package pedersen implements Pedersen vector commitments, C = sum of v[i] * G[i] + r * H, with generators derived
+// by hashing to the group, and proofs opening the commitment at some of its indices without revealing the others.
+package pedersen
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/sigma"
+)
+
+const openingDST = "ECC-Pedersen-V01-Opening"
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errVectorLength  = errors.New("the vector is longer than the number of generators")
+	errIndex         = errors.New("invalid or duplicate index")
+)
+
+// Parameters holds the generators of the commitments to vectors of up to len(Generators) scalars, and the generator of
+// the blinding factor.
+type Parameters struct {
+	H          *ecc.Element
+	Generators []*ecc.Element
+	Group      ecc.Group
+}
+
+// Setup returns the parameters for vectors of up to n scalars. The generators are derived from the DST, which must
+// identify the application, so that all parties derive the same ones and no one knows their discrete logarithms.
+// Parameters for different n with the same DST share their generators.
+func Setup(g ecc.Group, n int, dst []byte) *Parameters {
+	generators := g.DeriveGenerators(n+1, dst)
+
+	return &Parameters{
+		H:          generators[0],
+		Generators: generators[1:],
+		Group:      g,
+	}
+}
+
+func (p *Parameters) checkScalars(scalars ...*ecc.Scalar) error {
+	for _, s := range scalars {
+		if s == nil {
+			return errNilInput
+		}
+
+		if s.Group() != p.Group {
+			return errGroupMismatch
+		}
+	}
+
+	return nil
+}
+
+// VectorCommit returns the commitment to the values with the blinding factor. Vectors shorter than the generators are
+// implicitly padded with zeros. The values are secret, so this runs in constant time.
+func (p *Parameters) VectorCommit(values []*ecc.Scalar, blind *ecc.Scalar) (*ecc.Element, error) {
+	if len(values) > len(p.Generators) {
+		return nil, errVectorLength
+	}
+
+	if err := p.checkScalars(blind); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkScalars(values...); err != nil {
+		return nil, err
+	}
+
+	c := p.H.Copy().Multiply(blind)
+	for i, v := range values {
+		c.Add(p.Generators[i].Copy().Multiply(v))
+	}
+
+	return c, nil
+}
+
+// Opening reveals the committed values at some indices, and proves knowledge of the remaining values and the blinding
+// factor.
+type Opening struct {
+	Proof   *sigma.Proof
+	Indices []int
+	Values  []*ecc.Scalar
+}
+
+// checkIndices returns whether the indices are distinct and within the generators, and a mask of the opened ones.
+func (p *Parameters) checkIndices(indices []int) ([]bool, error) {
+	opened := make([]bool, len(p.Generators))
+
+	for _, i := range indices {
+		if i < 0 || i >= len(opened) || opened[i] {
+			return nil, errIndex
+		}
+
+		opened[i] = true
+	}
+
+	return opened, nil
+}
+
+// relation returns the statement that the commitment minus the opened values is a commitment to the others, and the
+// indices of the latter. The opened values are public, so their contribution is computed in variable time.
+func (p *Parameters) relation(commitment *ecc.Element, opening *Opening, opened []bool) ([]sigma.Relation, []int) {
+	openedGenerators := make([]*ecc.Element, len(opening.Indices))
+	for j, i := range opening.Indices {
+		openedGenerators[j] = p.Generators[i]
+	}
+
+	lhs := commitment.Copy().Subtract(p.Group.MultiScalarMultVartime(opening.Values, openedGenerators))
+	terms := []sigma.Term{{Base: p.H, Index: 0}}
+	hidden := make([]int, 0, len(opened)-len(opening.Indices))
+
+	for i, o := range opened {
+		if !o {
+			hidden = append(hidden, i)
+			terms = append(terms, sigma.Term{Base: p.Generators[i], Index: len(hidden)})
+		}
+	}
+
+	return []sigma.Relation{{LHS: lhs, Terms: terms}}, hidden
+}
+
+// Open returns an opening of the commitment to the values and blinding factor at the given indices, bound to the
+// context. Opening several indices at once yields a single aggregated proof. Values beyond the vector are zero.
+func (p *Parameters) Open(
+	commitment *ecc.Element,
+	values []*ecc.Scalar,
+	blind *ecc.Scalar,
+	indices []int,
+	context []byte,
+) (*Opening, error) {
+	if commitment == nil {
+		return nil, errNilInput
+	}
+
+	if len(values) > len(p.Generators) {
+		return nil, errVectorLength
+	}
+
+	if err := p.checkScalars(blind); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkScalars(values...); err != nil {
+		return nil, err
+	}
+
+	opened, err := p.checkIndices(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func(i int) *ecc.Scalar {
+		if i < len(values) {
+			return values[i]
+		}
+
+		return p.Group.NewScalar()
+	}
+
+	opening := &Opening{
+		Indices: append([]int(nil), indices...),
+		Values:  make([]*ecc.Scalar, len(indices)),
+	}
+
+	for j, i := range indices {
+		opening.Values[j] = value(i).Copy()
+	}
+
+	relations, hidden := p.relation(commitment, opening, opened)
+	witnesses := []*ecc.Scalar{blind}
+
+	for _, i := range hidden {
+		witnesses = append(witnesses, value(i))
+	}
+
+	if opening.Proof, err = sigma.Prove(p.Group, openingDST, relations, witnesses, context); err != nil {
+		return nil, err
+	}
+
+	return opening, nil
+}
+
+// OpenAt returns an opening of the commitment at a single index.
+func (p *Parameters) OpenAt(
+	commitment *ecc.Element,
+	values []*ecc.Scalar,
+	blind *ecc.Scalar,
+	index int,
+	context []byte,
+) (*Opening, error) {
+	return p.Open(commitment, values, blind, []int{index}, context)
+}
+
+// VerifyOpening returns whether the opening proves that the commitment holds its values at its indices.
+func (p *Parameters) VerifyOpening(commitment *ecc.Element, opening *Opening, context []byte) bool {
+	if commitment == nil || commitment.Group() != p.Group || opening == nil || opening.Proof == nil {
+		return false
+	}
+
+	if len(opening.Values) != len(opening.Indices) || p.checkScalars(opening.Values...) != nil {
+		return false
+	}
+
+	opened, err := p.checkIndices(opening.Indices)
+	if err != nil {
+		return false
+	}
+
+	relations, hidden := p.relation(commitment, opening, opened)
+
+	return opening.Proof.Verify(p.Group, openingDST, relations, len(hidden)+1, context)
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package poseidon
+
+import (
+	"math/big"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+const (
+	grainStateSize = 80
+	grainDiscard   = 160
+	fieldTypePrime = 1
+	sboxPow        = 0
+)
+
+// grain is the Grain LFSR of the Poseidon reference implementation, used to generate the round constants and the
+// MDS matrix.
+type grain struct {
+	state []bool
+}
+
+func newGrain(fieldBits, width, fullRounds, partialRounds int) *grain {
+	g := &grain{state: make([]bool, 0, grainStateSize)}
+	g.setBits(2, fieldTypePrime)
+	g.setBits(4, sboxPow)
+	g.setBits(12, fieldBits)
+	g.setBits(12, width)
+	g.setBits(10, fullRounds)
+	g.setBits(10, partialRounds)
+
+	for len(g.state) < grainStateSize {
+		g.state = append(g.state, true)
+	}
+
+	for range grainDiscard {
+		g.nextBit()
+	}
+
+	return g
+}
+
+// setBits appends the value to the initial state, in MSB order.
+func (g *grain) setBits(n, value int) {
+	for i := n - 1; i >= 0; i-- {
+		g.state = append(g.state, (value>>i)&1 == 1)
+	}
+}
+
+func (g *grain) nextBit() bool {
+	s := g.state
+	b := s[62] != s[51] != s[38] != s[23] != s[13] != s[0]
+	copy(s, s[1:])
+	s[grainStateSize-1] = b
+
+	return b
+}
+
+// next returns the next output bit, evaluating the LFSR bits in pairs: if the first bit is 1 the second one is
+// output, otherwise it is discarded.
+func (g *grain) next() bool {
+	for !g.nextBit() {
+		g.nextBit()
+	}
+
+	return g.nextBit()
+}
+
+// nextInt returns the integer of the next n output bits, in MSB order.
+func (g *grain) nextInt(n int) *big.Int {
+	x := new(big.Int)
+	for range n {
+		x.Lsh(x, 1)
+
+		if g.next() {
+			x.SetBit(x, 0, 1)
+		}
+	}
+
+	return x
+}
+
+// nextFieldElement returns the next field element with rejection sampling.
+func (g *grain) nextFieldElement(f *field.Field) *big.Int {
+	n := f.Order().BitLen()
+
+	for {
+		if x := g.nextInt(n); x.Cmp(f.Order()) < 0 {
+			return x
+		}
+	}
+}
+
+// nextFieldElementWithoutRejection returns the next field element, reducing the output modulo the order.
+func (g *grain) nextFieldElementWithoutRejection(f *field.Field) *big.Int {
+	return f.Mod(g.nextInt(f.Order().BitLen()))
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package poseidon implements the Poseidon permutation and sponge over the Pallas and Vesta base fields, with the
+// P128Pow5T3 parameters of Halo2: width 3, rate 2, the x^5 S-box, 8 full rounds and 56 partial rounds. The round
+// constants and the MDS matrix are generated with the Grain LFSR of the Poseidon reference implementation.
+//
+// Field elements are big.Int values in [0, p), where p is the order of the field.
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+const (
+	// Width is the number of field elements in the state.
+	Width = 3
+
+	// Rate is the number of field elements absorbed or squeezed per permutation.
+	Rate = 2
+
+	fullRounds    = 8
+	partialRounds = 56
+	alpha         = 5
+
+	// The Pallas base field is the Vesta scalar field, and vice versa.
+	pallasBaseOrder = "0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001"
+	vestaBaseOrder  = "0x40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"
+)
+
+var (
+	errNotInField = errors.New("input is not a canonical field element")
+	errStateWidth = errors.New("invalid state width")
+
+	pallasOnce, vestaOnce     sync.Once
+	pallasParams, vestaParams *Parameters
+)
+
+// Parameters holds the field and the generated constants of a Poseidon instance.
+type Parameters struct {
+	field          field.Field
+	roundConstants [][Width]*big.Int
+	mds            [Width][Width]*big.Int
+	pow            *big.Int
+}
+
+// Pallas returns the Poseidon parameters over the Pallas base field, as used by Zcash Orchard.
+func Pallas() *Parameters {
+	pallasOnce.Do(func() {
+		pallasParams = newParameters(pallasBaseOrder)
+	})
+
+	return pallasParams
+}
+
+// Vesta returns the Poseidon parameters over the Vesta base field.
+func Vesta() *Parameters {
+	vestaOnce.Do(func() {
+		vestaParams = newParameters(vestaBaseOrder)
+	})
+
+	return vestaParams
+}
+
+func newParameters(order string) *Parameters {
+	o := field.String2Int(order)
+	p := &Parameters{
+		field: field.NewField(&o),
+		pow:   big.NewInt(alpha),
+	}
+
+	g := newGrain(o.BitLen(), Width, fullRounds, partialRounds)
+
+	p.roundConstants = make([][Width]*big.Int, fullRounds+partialRounds)
+	for r := range p.roundConstants {
+		for i := range Width {
+			p.roundConstants[r][i] = g.nextFieldElement(&p.field)
+		}
+	}
+
+	// The Cauchy matrix 1 / (x_i + y_j), with x and y sampled as distinct elements. The first such matrix is secure
+	// for these parameters.
+	var xs, ys []*big.Int
+
+	for {
+		vals := make([]*big.Int, 2*Width)
+		for i := range vals {
+			vals[i] = g.nextFieldElementWithoutRejection(&p.field)
+		}
+
+		if distinct(vals) {
+			xs, ys = vals[:Width], vals[Width:]
+			break
+		}
+	}
+
+	for i := range Width {
+		for j := range Width {
+			p.mds[i][j] = new(big.Int)
+			p.field.Add(p.mds[i][j], xs[i], ys[j])
+			p.field.Inv(p.mds[i][j], p.mds[i][j])
+		}
+	}
+
+	return p
+}
+
+func distinct(vals []*big.Int) bool {
+	for i := range vals {
+		for j := i + 1; j < len(vals); j++ {
+			if vals[i].Cmp(vals[j]) == 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Order returns the order of the field.
+func (p *Parameters) Order() *big.Int {
+	return new(big.Int).Set(p.field.Order())
+}
+
+func (p *Parameters) checkElement(x *big.Int) error {
+	if x == nil || x.Sign() < 0 || x.Cmp(p.field.Order()) >= 0 {
+		return errNotInField
+	}
+
+	return nil
+}
+
+func (p *Parameters) sbox(x *big.Int) {
+	p.field.Exponent(x, x, p.pow)
+}
+
+func (p *Parameters) applyMDS(state []*big.Int) {
+	var out [Width]*big.Int
+
+	tmp := new(big.Int)
+
+	for i := range Width {
+		out[i] = new(big.Int)
+		for j := range Width {
+			p.field.Mul(tmp, p.mds[i][j], state[j])
+			p.field.Add(out[i], out[i], tmp)
+		}
+	}
+
+	copy(state, out[:])
+}
+
+// Permute applies the Poseidon permutation to the state in place.
+func (p *Parameters) Permute(state []*big.Int) error {
+	if len(state) != Width {
+		return errStateWidth
+	}
+
+	for _, x := range state {
+		if err := p.checkElement(x); err != nil {
+			return err
+		}
+	}
+
+	p.permute(state)
+
+	return nil
+}
+
+func (p *Parameters) permute(state []*big.Int) {
+	r := 0
+	round := func(full bool) {
+		for i := range Width {
+			p.field.Add(state[i], state[i], p.roundConstants[r][i])
+		}
+
+		if full {
+			for i := range Width {
+				p.sbox(state[i])
+			}
+		} else {
+			p.sbox(state[0])
+		}
+
+		p.applyMDS(state)
+		r++
+	}
+
+	for range fullRounds / 2 {
+		round(true)
+	}
+
+	for range partialRounds {
+		round(false)
+	}
+
+	for range fullRounds / 2 {
+		round(true)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+)
+
+var errAbsorbAfterSqueeze = errors.New("cannot absorb after squeezing")
+
+// Sponge is a Poseidon duplex sponge, which absorbs and squeezes field elements. It can be used as a Fiat-Shamir
+// transcript that is cheap to verify in a circuit.
+type Sponge struct {
+	params    *Parameters
+	state     [Width]*big.Int
+	buffer    []*big.Int
+	output    []*big.Int
+	squeezing bool
+}
+
+// NewSponge returns a sponge with the given initial capacity element, which separates the domains of use.
+func NewSponge(params *Parameters, capacity *big.Int) (*Sponge, error) {
+	if err := params.checkElement(capacity); err != nil {
+		return nil, err
+	}
+
+	s := &Sponge{params: params}
+	for i := range Rate {
+		s.state[i] = new(big.Int)
+	}
+
+	s.state[Rate] = new(big.Int).Set(capacity)
+
+	return s, nil
+}
+
+// duplex adds the buffered elements to the rate part of the state, applies the permutation, and makes the rate part
+// of the state available for squeezing.
+func (s *Sponge) duplex() {
+	for i, x := range s.buffer {
+		s.params.field.Add(s.state[i], s.state[i], x)
+	}
+
+	s.buffer = s.buffer[:0]
+	s.params.permute(s.state[:])
+
+	s.output = s.output[:0]
+	for i := range Rate {
+		s.output = append(s.output, new(big.Int).Set(s.state[i]))
+	}
+}
+
+// Absorb adds the field elements to the sponge.
+func (s *Sponge) Absorb(elements ...*big.Int) error {
+	if s.squeezing {
+		return errAbsorbAfterSqueeze
+	}
+
+	for _, x := range elements {
+		if err := s.params.checkElement(x); err != nil {
+			return err
+		}
+
+		if len(s.buffer) == Rate {
+			s.duplex()
+		}
+
+		s.buffer = append(s.buffer, new(big.Int).Set(x))
+	}
+
+	return nil
+}
+
+// Squeeze returns the next field element of the sponge's output. No more elements can be absorbed afterwards.
+func (s *Sponge) Squeeze() *big.Int {
+	if !s.squeezing {
+		s.squeezing = true
+		s.duplex()
+	}
+
+	if len(s.output) == 0 {
+		s.duplex()
+	}
+
+	out := s.output[0]
+	s.output = s.output[1:]
+
+	return out
+}
+
+// Hash returns the Poseidon hash of the fixed-length input, as defined by the Halo2 ConstantLength domain: the
+// capacity is initialized to the input length times 2^64, and the input is padded with zeros to a multiple of the
+// rate.
+func Hash(params *Parameters, inputs ...*big.Int) (*big.Int, error) {
+	capacity := new(big.Int).Lsh(big.NewInt(int64(len(inputs))), 64)
+
+	s, err := NewSponge(params, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.Absorb(inputs...); err != nil {
+		return nil, err
+	}
+
+	for range (Rate - len(inputs)%Rate) % Rate {
+		_ = s.Absorb(new(big.Int))
+	}
+
+	return s.Squeeze(), nil
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+const (
+	// tableWindow is the bit width of the scalar digits the tables are indexed by.
+	tableWindow = 4
+
+	// tableWidth is the number of non-zero digit values, i.e. of elements per window.
+	tableWidth = 1<<tableWindow - 1
+)
+
+var (
+	errTableGroup = errors.New("the scalar and the precomputed table are in different groups")
+
+	baseTables [maxGroups - 1]struct {
+		table *PrecomputedTable
+		once  sync.Once
+	}
+)
+
+// PrecomputedTable holds the multiples of a fixed element, for faster variable-time scalar multiplication with it. A
+// table is immutable once built: its methods never modify it, and only return new elements, so it can be shared
+// across goroutines without synchronization.
+type PrecomputedTable struct {
+	point *Element
+
+	// windows[i][j] is (j + 1) * 16^i * point.
+	windows [][tableWidth]*Element
+}
+
+// NewPrecomputedTable returns the table of multiples of the element. It doesn't keep a reference to the element.
+func NewPrecomputedTable(element *Element) *PrecomputedTable {
+	g := element.Group()
+	t := &PrecomputedTable{
+		point:   element.Copy(),
+		windows: make([][tableWidth]*Element, 2*g.ScalarLength()),
+	}
+
+	base := element.Copy()
+	for i := range t.windows {
+		t.windows[i][0] = base.Copy()
+
+		for j := 1; j < tableWidth; j++ {
+			t.windows[i][j] = t.windows[i][j-1].Copy().Add(base)
+		}
+
+		// base = 16^(i+1) * point
+		base.Add(t.windows[i][tableWidth-1])
+	}
+
+	return t
+}
+
+// BaseTable returns the table of the group's generator, which is built on first use and shared by all callers.
+func (g Group) BaseTable() *PrecomputedTable {
+	_ = g.get()
+
+	b := &baseTables[g-1]
+	b.once.Do(func() {
+		b.table = NewPrecomputedTable(g.Base())
+	})
+
+	return b.table
+}
+
+// Group returns the group of the table's element.
+func (t *PrecomputedTable) Group() Group {
+	return t.point.Group()
+}
+
+// Element returns a copy of the table's element.
+func (t *PrecomputedTable) Element() *Element {
+	return t.point.Copy()
+}
+
+// MultiplyVartime returns a new element set to the product of the scalar and the table's element. Its running time
+// depends on the scalar, so it must only be used with public scalars, e.g. in signature verification: use
+// Element.Multiply for secrets.
+func (t *PrecomputedTable) MultiplyVartime(scalar *Scalar) *Element {
+	g := t.Group()
+	res := g.NewElement()
+
+	if scalar == nil {
+		return res
+	}
+
+	if scalar.Group() != g {
+		panic(errTableGroup)
+	}
+
+	recordOperation(g, OpElementMultiply)
+
+	digits := scalarBigEndian(scalar)
+	last := len(digits) - 1
+
+	for i, b := range digits {
+		if lo := b & 0x0f; lo != 0 {
+			res.Add(t.windows[2*(last-i)][lo-1])
+		}
+
+		if hi := b >> tableWindow; hi != 0 {
+			res.Add(t.windows[2*(last-i)+1][hi-1])
+		}
+	}
+
+	return res
+}
+
+// scalarBigEndian returns the big-endian encoding of the scalar, which is little-endian in the groups over Curve25519.
+func scalarBigEndian(s *Scalar) []byte {
+	enc := s.Encode()
+	if s.Group().littleEndianScalars() {
+		slices.Reverse(enc)
+	}
+
+	return enc
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package privacypass
+
+import (
+	"crypto/sha256"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/oprf"
+)
+
+// Client requests and finalizes tokens from an issuer.
+type Client struct {
+	pk        *ecc.Element
+	keyID     []byte
+	tokenType uint16
+}
+
+// State holds the client's secret state between a token request and its response.
+type State struct {
+	blind      *ecc.Scalar
+	blinded    *ecc.Element
+	input      []byte
+	extensions []byte
+	token      Token
+}
+
+// NewClient returns a client requesting tokens of the given type, for the encoded issuer public key.
+func NewClient(tokenType uint16, issuerPublicKey []byte) (*Client, error) {
+	if err := checkTokenType(tokenType, nil); err != nil {
+		return nil, err
+	}
+
+	pk := group.NewElement()
+	if err := pk.Decode(issuerPublicKey); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		pk:        pk,
+		keyID:     TokenKeyID(pk),
+		tokenType: tokenType,
+	}, nil
+}
+
+// Request returns a token request for the encoded token challenge, and the state needed to finalize the response. For
+// public metadata tokens, the extensions are bound to the token.
+func (c *Client) Request(challenge, extensions []byte) (*TokenRequest, *State, error) {
+	if err := checkTokenType(c.tokenType, extensions); err != nil {
+		return nil, nil, err
+	}
+
+	digest := sha256.Sum256(challenge)
+	token := Token{
+		TokenType:       c.tokenType,
+		Nonce:           newNonce(),
+		ChallengeDigest: digest[:],
+		TokenKeyID:      c.keyID,
+	}
+	input := tokenInput(token.TokenType, token.Nonce, token.ChallengeDigest, token.TokenKeyID)
+
+	b, blinded, err := blind(c.tokenType, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &TokenRequest{
+		TokenType:           c.tokenType,
+		TruncatedTokenKeyID: c.keyID[TokenKeyIDLength-1],
+		BlindedMsg:          blinded.Encode(),
+	}
+
+	return req, &State{
+		blind:      b,
+		blinded:    blinded,
+		input:      input,
+		extensions: extensions,
+		token:      token,
+	}, nil
+}
+
+// Finalize verifies the issuer's response, and returns the token.
+func (c *Client) Finalize(state *State, resp *TokenResponse) (*Token, error) {
+	if state == nil || resp == nil {
+		return nil, errNilInput
+	}
+
+	evaluated := group.NewElement()
+	if err := evaluated.Decode(resp.EvaluateMsg); err != nil {
+		return nil, err
+	}
+
+	proof := new(oprf.Proof)
+	if err := proof.Decode(group, resp.EvaluateProof); err != nil {
+		return nil, err
+	}
+
+	var (
+		outputs [][]byte
+		err     error
+	)
+
+	inputs, blinds := [][]byte{state.input}, []*ecc.Scalar{state.blind}
+	batch := []*ecc.Element{evaluated}
+	blinded := []*ecc.Element{state.blinded}
+
+	if c.tokenType == TokenTypeVOPRF {
+		v, _ := oprf.NewVOPRF(group)
+		outputs, err = v.Finalize(inputs, blinds, batch, blinded, c.pk, proof)
+	} else {
+		p, _ := oprf.NewPOPRF(group)
+
+		var tweaked *ecc.Element
+		if tweaked, err = p.TweakKey(c.pk, state.extensions); err != nil {
+			return nil, err
+		}
+
+		outputs, err = p.Finalize(inputs, blinds, batch, blinded, proof, state.extensions, tweaked)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	token := state.token
+	token.Authenticator = outputs[0]
+
+	return &token, nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package privacypass
+
+import (
+	"crypto/subtle"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/oprf"
+)
+
+// Issuer issues and redeems tokens of a given type.
+type Issuer struct {
+	sk        *ecc.Scalar
+	pk        *ecc.Element
+	keyID     []byte
+	tokenType uint16
+}
+
+// NewIssuer returns an issuer of tokens of the given type, with the P384Sha384 private key.
+func NewIssuer(tokenType uint16, sk *ecc.Scalar) (*Issuer, error) {
+	if err := checkTokenType(tokenType, nil); err != nil {
+		return nil, err
+	}
+
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.Group() != group || sk.IsZero() {
+		return nil, errInvalidKey
+	}
+
+	pk := group.Base().Multiply(sk)
+
+	return &Issuer{
+		sk:        sk.Copy(),
+		pk:        pk,
+		keyID:     TokenKeyID(pk),
+		tokenType: tokenType,
+	}, nil
+}
+
+// PublicKey returns the encoding of the issuer public key, to be published in its directory.
+func (i *Issuer) PublicKey() []byte {
+	return i.pk.Encode()
+}
+
+// Issue evaluates the token request, and returns the response. For public metadata tokens, the extensions must be the
+// ones the client used for the request.
+func (i *Issuer) Issue(req *TokenRequest, extensions []byte) (*TokenResponse, error) {
+	if req == nil {
+		return nil, errNilInput
+	}
+
+	if req.TokenType != i.tokenType {
+		return nil, errTokenType
+	}
+
+	if err := checkTokenType(req.TokenType, extensions); err != nil {
+		return nil, err
+	}
+
+	if req.TruncatedTokenKeyID != i.keyID[TokenKeyIDLength-1] {
+		return nil, errTokenKeyID
+	}
+
+	blinded := group.NewElement()
+	if err := blinded.Decode(req.BlindedMsg); err != nil {
+		return nil, err
+	}
+
+	var (
+		evaluated []*ecc.Element
+		proof     *oprf.Proof
+		err       error
+	)
+
+	if i.tokenType == TokenTypeVOPRF {
+		v, _ := oprf.NewVOPRF(group)
+		evaluated, proof, err = v.BlindEvaluate(i.sk, i.pk, blinded)
+	} else {
+		p, _ := oprf.NewPOPRF(group)
+		evaluated, proof, err = p.BlindEvaluate(i.sk, extensions, blinded)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		EvaluateMsg:   evaluated[0].Encode(),
+		EvaluateProof: proof.Encode(),
+	}, nil
+}
+
+// Redeem returns whether the token is valid, i.e. issued by this issuer for the given extensions.
+func (i *Issuer) Redeem(token *Token, extensions []byte) bool {
+	if token == nil || token.TokenType != i.tokenType || checkTokenType(token.TokenType, extensions) != nil {
+		return false
+	}
+
+	if subtle.ConstantTimeCompare(token.TokenKeyID, i.keyID) != 1 {
+		return false
+	}
+
+	input := tokenInput(token.TokenType, token.Nonce, token.ChallengeDigest, token.TokenKeyID)
+
+	if i.tokenType == TokenTypeVOPRF {
+		v, _ := oprf.NewVOPRF(group)
+		return v.VerifyOutput(i.sk, input, token.Authenticator)
+	}
+
+	p, _ := oprf.NewPOPRF(group)
+
+	return p.VerifyOutput(i.sk, input, extensions, token.Authenticator)
+}
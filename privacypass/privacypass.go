@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package privacypass implements the issuance and redemption of Privacy Pass privately verifiable tokens (RFC 9578),
+// based on the P384-SHA384 VOPRF, and their variant with public metadata based on the POPRF, in which extensions
+// agreed upon by the client and the issuer are bound to the token.
+package privacypass
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/oprf"
+)
+
+const (
+	// TokenTypeVOPRF is the RFC 9578 token type of privately verifiable tokens, using the P384-SHA384 VOPRF.
+	TokenTypeVOPRF uint16 = 0x0001
+
+	// TokenTypePOPRF is the token type of privately verifiable tokens with public metadata, using the P384-SHA384
+	// POPRF, as assigned in draft-ietf-privacypass-public-metadata-issuance.
+	TokenTypePOPRF uint16 = 0xDA7A
+
+	// NonceLength is the byte size of the client's token nonce.
+	NonceLength = 32
+
+	// TokenKeyIDLength is the byte size of the issuer's key identifier.
+	TokenKeyIDLength = sha256.Size
+
+	group            = ecc.P384Sha384
+	authenticatorLen = 48
+)
+
+var (
+	errTokenType      = errors.New("unsupported token type")
+	errTokenKeyID     = errors.New("the token key identifier does not match the issuer key")
+	errEncodingLength = errors.New("invalid encoding length")
+	errNilInput       = errors.New("nil input")
+	errInvalidKey     = errors.New("invalid issuer private key")
+	errExtensions     = errors.New("extensions are only supported with public metadata tokens")
+)
+
+func checkTokenType(tokenType uint16, extensions []byte) error {
+	switch tokenType {
+	case TokenTypeVOPRF:
+		if len(extensions) != 0 {
+			return errExtensions
+		}
+
+		return nil
+	case TokenTypePOPRF:
+		return nil
+	default:
+		return errTokenType
+	}
+}
+
+// TokenKeyID returns the identifier of the issuer public key, i.e. the SHA-256 hash of its encoding.
+func TokenKeyID(pk *ecc.Element) []byte {
+	id := sha256.Sum256(pk.Encode())
+	return id[:]
+}
+
+// tokenInput returns the OPRF input of the token.
+func tokenInput(tokenType uint16, nonce, challengeDigest, tokenKeyID []byte) []byte {
+	input := binary.BigEndian.AppendUint16(nil, tokenType)
+	input = append(input, nonce...)
+	input = append(input, challengeDigest...)
+
+	return append(input, tokenKeyID...)
+}
+
+// TokenRequest is the client's request for a token.
+type TokenRequest struct {
+	TokenType           uint16
+	TruncatedTokenKeyID byte
+	BlindedMsg          []byte
+}
+
+// Encode returns the wire encoding of the token request.
+func (r *TokenRequest) Encode() []byte {
+	out := binary.BigEndian.AppendUint16(nil, r.TokenType)
+	out = append(out, r.TruncatedTokenKeyID)
+
+	return append(out, r.BlindedMsg...)
+}
+
+// Decode sets the token request to the decoding of the input, and returns an error on failure.
+func (r *TokenRequest) Decode(data []byte) error {
+	if len(data) != 3+group.ElementLength() {
+		return errEncodingLength
+	}
+
+	r.TokenType = binary.BigEndian.Uint16(data)
+	r.TruncatedTokenKeyID = data[2]
+	r.BlindedMsg = append([]byte(nil), data[3:]...)
+
+	return nil
+}
+
+// TokenResponse is the issuer's response to a token request.
+type TokenResponse struct {
+	EvaluateMsg   []byte
+	EvaluateProof []byte
+}
+
+// Encode returns the wire encoding of the token response.
+func (r *TokenResponse) Encode() []byte {
+	return append(append([]byte(nil), r.EvaluateMsg...), r.EvaluateProof...)
+}
+
+// Decode sets the token response to the decoding of the input, and returns an error on failure.
+func (r *TokenResponse) Decode(data []byte) error {
+	eLen := group.ElementLength()
+	if len(data) != eLen+2*group.ScalarLength() {
+		return errEncodingLength
+	}
+
+	r.EvaluateMsg = append([]byte(nil), data[:eLen]...)
+	r.EvaluateProof = append([]byte(nil), data[eLen:]...)
+
+	return nil
+}
+
+// Token is a finalized token, presented to the issuer or an origin sharing its key for redemption.
+type Token struct {
+	TokenType       uint16
+	Nonce           []byte
+	ChallengeDigest []byte
+	TokenKeyID      []byte
+	Authenticator   []byte
+}
+
+// Encode returns the wire encoding of the token.
+func (t *Token) Encode() []byte {
+	return append(tokenInput(t.TokenType, t.Nonce, t.ChallengeDigest, t.TokenKeyID), t.Authenticator...)
+}
+
+// Decode sets the token to the decoding of the input, and returns an error on failure.
+func (t *Token) Decode(data []byte) error {
+	if len(data) != 2+NonceLength+sha256.Size+TokenKeyIDLength+authenticatorLen {
+		return errEncodingLength
+	}
+
+	t.TokenType = binary.BigEndian.Uint16(data)
+	data = data[2:]
+	t.Nonce = append([]byte(nil), data[:NonceLength]...)
+	data = data[NonceLength:]
+	t.ChallengeDigest = append([]byte(nil), data[:sha256.Size]...)
+	data = data[sha256.Size:]
+	t.TokenKeyID = append([]byte(nil), data[:TokenKeyIDLength]...)
+	t.Authenticator = append([]byte(nil), data[TokenKeyIDLength:]...)
+
+	return nil
+}
+
+func newNonce() []byte {
+	return internal.RandomBytes(NonceLength)
+}
+
+// blind blinds the token input with the OPRF of the token type.
+func blind(tokenType uint16, input []byte) (*ecc.Scalar, *ecc.Element, error) {
+	if tokenType == TokenTypeVOPRF {
+		v, _ := oprf.NewVOPRF(group)
+		return v.Blind(input, nil)
+	}
+
+	p, _ := oprf.NewPOPRF(group)
+
+	return p.Blind(input, nil)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package psi provides the core operations of Diffie-Hellman based private set intersection (ECDH-PSI).
+//
+// Each party hashes its items to group elements, and masks them with its secret key. Since masking commutes, the
+// doubly-masked elements a * b * H(x) match across parties exactly for the items in the intersection, without
+// revealing the others.
+//
+// The protocol requires a prime-order group, like Ristretto255.
+package psi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const hashToGroupDST = "ECC-PSI-V01-HashToGroup"
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errZeroKey       = errors.New("masking key is zero")
+	errIdentity      = errors.New("element is the identity")
+)
+
+// NewKey returns a new random masking key.
+func NewKey(g ecc.Group) *ecc.Scalar {
+	return g.NewScalar().Random()
+}
+
+// HashItem maps the set item to a group element.
+func HashItem(g ecc.Group, item []byte) *ecc.Element {
+	return g.HashToGroup(item, []byte(hashToGroupDST))
+}
+
+func checkKey(key *ecc.Scalar) error {
+	if key == nil {
+		return errNilInput
+	}
+
+	if key.IsZero() {
+		return errZeroKey
+	}
+
+	return nil
+}
+
+// Mask returns the elements multiplied by the key. The elements can be the hashed items of the local party, or the
+// masked elements received from the other party. The inputs are not modified.
+func Mask(key *ecc.Scalar, elements ...*ecc.Element) ([]*ecc.Element, error) {
+	return MaskContext(context.Background(), key, elements...)
+}
+
+// MaskContext is the same as Mask, but checks the context between elements, and returns the context's error if it is
+// done before all the elements are masked.
+func MaskContext(ctx context.Context, key *ecc.Scalar, elements ...*ecc.Element) ([]*ecc.Element, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	g := key.Group()
+	masked := make([]*ecc.Element, len(elements))
+
+	for i, e := range elements {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if e == nil {
+			return nil, errNilInput
+		}
+
+		if e.Group() != g {
+			return nil, errGroupMismatch
+		}
+
+		// A peer could send the identity to test for a zero key, and it never matches an item.
+		if e.IsIdentity() {
+			return nil, errIdentity
+		}
+
+		masked[i] = e.Copy().Multiply(key)
+	}
+
+	return masked, nil
+}
+
+// MaskItems returns the hashed items multiplied by the key.
+func MaskItems(key *ecc.Scalar, items ...[]byte) ([]*ecc.Element, error) {
+	return MaskItemsContext(context.Background(), key, items...)
+}
+
+// MaskItemsContext is the same as MaskItems, but checks the context between items, and returns the context's error if
+// it is done before all the items are masked.
+func MaskItemsContext(ctx context.Context, key *ecc.Scalar, items ...[]byte) ([]*ecc.Element, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	g := key.Group()
+	masked := make([]*ecc.Element, len(items))
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		masked[i] = HashItem(g, item).Multiply(key)
+	}
+
+	return masked, nil
+}
+
+// Intersect returns the indexes of the local doubly-masked elements that are also in the remote ones.
+func Intersect(local, remote []*ecc.Element) []int {
+	set := make(map[string]struct{}, len(remote))
+	for _, e := range remote {
+		set[string(e.Encode())] = struct{}{}
+	}
+
+	var indexes []int
+
+	for i, e := range local {
+		if _, ok := set[string(e.Encode())]; ok {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// Cardinality returns the size of the intersection between the local and remote doubly-masked elements.
+func Cardinality(local, remote []*ecc.Element) int {
+	return len(Intersect(local, remote))
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package psi
+
+import "github.com/bytemare/ecc"
+
+// MaskRemote is the same as Mask, with a masking key held by an external device.
+func MaskRemote(key ecc.RemoteScalar, elements ...*ecc.Element) ([]*ecc.Element, error) {
+	if key == nil {
+		return nil, errNilInput
+	}
+
+	g := key.Group()
+	masked := make([]*ecc.Element, len(elements))
+
+	for i, e := range elements {
+		if e == nil {
+			return nil, errNilInput
+		}
+
+		if e.Group() != g {
+			return nil, errGroupMismatch
+		}
+
+		if e.IsIdentity() {
+			return nil, errIdentity
+		}
+
+		m, err := key.Multiply(e)
+		if err != nil {
+			return nil, err
+		}
+
+		// A device with a zero key, or a faulty one, must not make all the items match.
+		if m == nil || m.Group() != g || m.IsIdentity() {
+			return nil, errZeroKey
+		}
+
+		masked[i] = m
+	}
+
+	return masked, nil
+}
+
+// MaskItemsRemote is the same as MaskItems, with a masking key held by an external device.
+func MaskItemsRemote(key ecc.RemoteScalar, items ...[]byte) ([]*ecc.Element, error) {
+	if key == nil {
+		return nil, errNilInput
+	}
+
+	elements := make([]*ecc.Element, len(items))
+	for i, item := range items {
+		elements[i] = HashItem(key.Group(), item)
+	}
+
+	return MaskRemote(key, elements...)
+}
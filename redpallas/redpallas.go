@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package redpallas implements RedPallas, the instantiation of RedDSA over the Pallas group specified for Zcash Orchard
+// spend authorization and binding signatures, with the re-randomization of spend authorization keys.
+//
+// The signatures follow the RedDSA algorithms of the Zcash protocol specification (section 5.4.7): H* is BLAKE2b-512
+// personalized with "Zcash_RedPallasH" and reduced modulo the group order, keys and R are in the 32-byte pasta_curves
+// encoding, and S is 32 bytes in little-endian. The generators are derived with this module's Pallas hash-to-curve,
+// and the package hasn't been checked against the Zcash test vectors, so interoperability with Orchard isn't claimed.
+package redpallas
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/blake2b"
+)
+
+// Variant identifies a RedPallas signature type, which determines the generator.
+type Variant byte
+
+const (
+	// SpendAuth identifies spend authorization signatures, which support key re-randomization.
+	SpendAuth Variant = iota + 1
+
+	// Binding identifies binding signatures, whose keys are derived from value commitment trapdoors.
+	Binding
+
+	group = ecc.PallasBLAKE2b512
+
+	// nonceEntropy is the length of the random T in RedDSA.Sign, i.e. (512 + 128) / 8 bytes.
+	nonceEntropy = 80
+
+	// encodingLength is the length of the encodings of keys, R, and S.
+	encodingLength = 32
+)
+
+var (
+	errInvalidVariant  = errors.New("invalid RedPallas variant")
+	errNilInput        = errors.New("nil input")
+	errNotPallas       = errors.New("input is not in the Pallas group")
+	errZeroKey         = errors.New("signing key is zero")
+	errNotRandomizable = errors.New("only spend authorization keys can be randomized")
+	errSignatureLength = errors.New("invalid signature encoding length")
+
+	hashPersonalization = [16]byte([]byte("Zcash_RedPallasH"))
+)
+
+// Generator returns the base point of the variant, i.e. GroupHash("z.cash:Orchard", "G") for spend authorization, and
+// GroupHash("z.cash:Orchard-cv", "r") for binding signatures.
+func (v Variant) Generator() *ecc.Element {
+	var domain, msg string
+
+	switch v {
+	case SpendAuth:
+		domain, msg = "z.cash:Orchard", "G"
+	case Binding:
+		domain, msg = "z.cash:Orchard-cv", "r"
+	default:
+		panic(errInvalidVariant)
+	}
+
+	return group.HashToGroup([]byte(msg), []byte(domain+"-"+group.String()))
+}
+
+// Available returns whether the variant is supported.
+func (v Variant) Available() bool {
+	return v == SpendAuth || v == Binding
+}
+
+// hashToScalar is the RedDSA H* function, i.e. the little-endian integer of the personalized BLAKE2b-512 digest of the
+// concatenated inputs, reduced modulo the group order.
+func hashToScalar(input ...[]byte) *ecc.Scalar {
+	digest := blake2b.Sum512Personal(&hashPersonalization, slices.Concat(input...))
+	slices.Reverse(digest[:])
+
+	s := group.NewScalar()
+	if err := s.DecodeReduce(digest[:]); err != nil {
+		panic(err) // unreachable, as the digest is twice the scalar length
+	}
+
+	return s
+}
+
+// encodeElement returns the pasta_curves encoding of the Pallas element.
+func encodeElement(e *ecc.Element) []byte {
+	enc, err := encoding.MarshalPallasZcash(e)
+	if err != nil {
+		panic(err) // unreachable, as elements are checked to be in Pallas
+	}
+
+	return enc
+}
+
+// encodeScalar returns the 32-byte little-endian encoding of the scalar.
+func encodeScalar(s *ecc.Scalar) []byte {
+	enc := s.Encode()
+	slices.Reverse(enc)
+
+	return enc
+}
+
+func checkScalar(s *ecc.Scalar) error {
+	if s == nil {
+		return errNilInput
+	}
+
+	if s.Group() != group {
+		return errNotPallas
+	}
+
+	return nil
+}
+
+func checkElement(e *ecc.Element) error {
+	if e == nil {
+		return errNilInput
+	}
+
+	if e.Group() != group {
+		return errNotPallas
+	}
+
+	return nil
+}
+
+// NewSigningKey returns a new random signing key.
+func NewSigningKey() *ecc.Scalar {
+	return group.NewScalar().Random()
+}
+
+// VerificationKey returns the verification key of the signing key for the variant.
+func (v Variant) VerificationKey(sk *ecc.Scalar) (*ecc.Element, error) {
+	if !v.Available() {
+		return nil, errInvalidVariant
+	}
+
+	if err := checkScalar(sk); err != nil {
+		return nil, err
+	}
+
+	return v.Generator().Multiply(sk), nil
+}
+
+// Signature is a RedPallas signature.
+type Signature struct {
+	R *ecc.Element
+	S *ecc.Scalar
+}
+
+// Sign returns the variant's signature of the message under the signing key.
+func (v Variant) Sign(sk *ecc.Scalar, message []byte) (*Signature, error) {
+	vk, err := v.VerificationKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	vkBytes := encodeElement(vk)
+	r := hashToScalar(internal.RandomBytes(nonceEntropy), vkBytes, message)
+	rBar := v.Generator().Multiply(r)
+	c := hashToScalar(encodeElement(rBar), vkBytes, message)
+
+	return &Signature{
+		R: rBar,
+		S: c.Multiply(sk).Add(r),
+	}, nil
+}
+
+// Verify returns whether the signature of the message is valid under the variant's verification key.
+func (v Variant) Verify(vk *ecc.Element, message []byte, sig *Signature) bool {
+	if !v.Available() || checkElement(vk) != nil || sig == nil ||
+		checkElement(sig.R) != nil || checkScalar(sig.S) != nil {
+		return false
+	}
+
+	c := hashToScalar(encodeElement(sig.R), encodeElement(vk), message)
+
+	// S * G = R + c * vk, as the cofactor of Pallas is 1.
+	return v.Generator().Multiply(sig.S).Equal(vk.Copy().Multiply(c).Add(sig.R))
+}
+
+// NewRandomizer returns a new random randomizer for spend authorization keys.
+func NewRandomizer() *ecc.Scalar {
+	return group.NewScalar().Random()
+}
+
+// RandomizeSigningKey returns the spend authorization signing key re-randomized with alpha, i.e. sk + alpha. The
+// inputs are not modified.
+func (v Variant) RandomizeSigningKey(sk, alpha *ecc.Scalar) (*ecc.Scalar, error) {
+	if v != SpendAuth {
+		return nil, errNotRandomizable
+	}
+
+	if err := checkScalar(sk); err != nil {
+		return nil, err
+	}
+
+	if err := checkScalar(alpha); err != nil {
+		return nil, err
+	}
+
+	return sk.Copy().Add(alpha), nil
+}
+
+// RandomizeVerificationKey returns the spend authorization verification key re-randomized with alpha, i.e.
+// vk + alpha * G, which matches the key returned by RandomizeSigningKey. The inputs are not modified.
+func (v Variant) RandomizeVerificationKey(vk *ecc.Element, alpha *ecc.Scalar) (*ecc.Element, error) {
+	if v != SpendAuth {
+		return nil, errNotRandomizable
+	}
+
+	if err := checkElement(vk); err != nil {
+		return nil, err
+	}
+
+	if err := checkScalar(alpha); err != nil {
+		return nil, err
+	}
+
+	return v.Generator().Multiply(alpha).Add(vk), nil
+}
+
+// EncodeVerificationKey returns the 32-byte encoding of the verification key, which is the one hashed in signatures.
+func EncodeVerificationKey(vk *ecc.Element) ([]byte, error) {
+	if err := checkElement(vk); err != nil {
+		return nil, err
+	}
+
+	return encodeElement(vk), nil
+}
+
+// DecodeVerificationKey returns the verification key decoded from its 32-byte encoding.
+func DecodeVerificationKey(data []byte) (*ecc.Element, error) {
+	return encoding.ParsePallasZcash(data)
+}
+
+// Encode returns the 64-byte encoding of the signature, i.e. the pasta_curves encoding of R followed by the
+// little-endian encoding of S.
+func (s *Signature) Encode() []byte {
+	return append(encodeElement(s.R), encodeScalar(s.S)...)
+}
+
+// Decode sets the signature to the decoding of the input, and returns an error on failure. S must be lower than the
+// group order.
+func (s *Signature) Decode(data []byte) error {
+	if len(data) != 2*encodingLength {
+		return errSignatureLength
+	}
+
+	r, err := encoding.ParsePallasZcash(data[:encodingLength])
+	if err != nil {
+		return err
+	}
+
+	sBytes := slices.Clone(data[encodingLength:])
+	slices.Reverse(sBytes)
+
+	sc := group.NewScalar()
+	if err = sc.DecodeCanonical(sBytes); err != nil {
+		return err
+	}
+
+	s.R, s.S = r, sc
+
+	return nil
+}
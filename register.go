@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"crypto"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// Interfaces to implement for external groups, which are registered with Register.
+type (
+	// ElementImplementation is the interface the elements of a registered group implement, which Element embeds.
+	ElementImplementation = internal.Element
+
+	// ScalarImplementation is the interface the scalars of a registered group implement, which Scalar embeds.
+	ScalarImplementation = internal.Scalar
+)
+
+// GroupImplementation is the interface a group registered with Register implements, for a prime-order group.
+type GroupImplementation interface {
+	// NewScalar returns a new scalar set to 0.
+	NewScalar() ScalarImplementation
+
+	// NewElement returns the identity element (point at infinity).
+	NewElement() ElementImplementation
+
+	// Base returns the group's base point a.k.a. canonical generator.
+	Base() ElementImplementation
+
+	// HashFunc returns the hash function of the group's hash-to-curve suite.
+	HashFunc() crypto.Hash
+
+	// HashToScalar returns a safe mapping of the arbitrary input to a Scalar. The DST is never empty.
+	HashToScalar(input, dst []byte) ScalarImplementation
+
+	// HashToGroup returns a safe mapping of the arbitrary input to an element in the group. The DST is never empty.
+	HashToGroup(input, dst []byte) ElementImplementation
+
+	// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an element in the group. The DST is never
+	// empty.
+	EncodeToGroup(input, dst []byte) ElementImplementation
+
+	// Ciphersuite returns the hash-to-curve ciphersuite identifier, which identifies the group, e.g. in LookupSuite.
+	Ciphersuite() string
+
+	// ScalarLength returns the byte size of an encoded scalar.
+	ScalarLength() int
+
+	// ElementLength returns the byte size of an encoded element.
+	ElementLength() int
+
+	// Order returns the big-endian encoding of the order of the group.
+	Order() []byte
+}
+
+// maxGroups bounds the group identifiers, which fit in a byte.
+const maxGroups = 256
+
+var (
+	errRegisterReserved   = errors.New("group identifier is reserved for the groups of this package")
+	errRegisterNil        = errors.New("nil group constructor")
+	errRegisterDuplicated = errors.New("group identifier is already registered")
+
+	registerMu sync.Mutex
+	registered [maxGroups - 1]atomic.Pointer[func() internal.Group]
+)
+
+// Register makes an external group implementation available under the identifier, so that ecc.Group(id) dispatches
+// to it like the groups of this package, e.g. for out-of-tree or experimental curves. The constructor is called once,
+// on first use of the group, and must always return the same group. The identifiers of the groups of this package, and
+// 0, are reserved, and an identifier can only be registered once. Register is safe for concurrent use, but is
+// typically called from an init function.
+func Register(id byte, constructor func() GroupImplementation) (Group, error) {
+	g := Group(id)
+
+	if g == 0 || g < maxID {
+		return 0, errRegisterReserved
+	}
+
+	if constructor == nil {
+		return 0, errRegisterNil
+	}
+
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	// The method sets are the same, so that the group's optional methods, e.g. FieldOrder, remain visible.
+	get := func() internal.Group {
+		return constructor()
+	}
+
+	if !registered[g-1].CompareAndSwap(nil, &get) {
+		return 0, errRegisterDuplicated
+	}
+
+	resetSuites()
+
+	return g, nil
+}
+
+// isRegistered reports whether the group has been registered with Register.
+func (g Group) isRegistered() bool {
+	return g >= maxID && registered[g-1].Load() != nil
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+// RemoteScalar is a secret scalar held by an external device, e.g. a PKCS #11 token, a TPM, or a KMS, which performs
+// the operations involving it without exporting it. The protocol packages accept it wherever they only need to
+// multiply elements by the secret, and extend it with the signing steps of their schemes.
+//
+// Implementations must check that the elements they receive belong to their group and are not the identity.
+type RemoteScalar interface {
+	// Group returns the group of the scalar.
+	Group() Group
+
+	// Public returns the public key, i.e. the base point multiplied by the scalar.
+	Public() *Element
+
+	// Multiply returns the element multiplied by the scalar. The element must not be modified.
+	Multiply(element *Element) (*Element, error)
+}
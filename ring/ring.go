@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ring implements spontaneous anonymous group (SAG) signatures, which prove that the signer holds the private
+// key of one of the public keys of an ad-hoc ring without revealing which, and their linkable variant (LSAG), whose
+// key image lets verifiers detect that two signatures were made with the same key.
+//
+// Signatures are built on the Element and Scalar API and thus work in all groups, like Ristretto255 or Secp256k1.
+package ring
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	challengeDST = "ECC-Ring-V01-Challenge"
+	keyImageDST  = "ECC-Ring-V01-KeyImage"
+)
+
+var (
+	errNilInput        = errors.New("nil input")
+	errGroupMismatch   = errors.New("inputs belong to different groups")
+	errEmptyRing       = errors.New("empty ring")
+	errIdentityKey     = errors.New("public key is the identity element")
+	errZeroKey         = errors.New("private key is zero")
+	errNotInRing       = errors.New("the public key of the private key is not in the ring")
+	errSignatureLength = errors.New("invalid signature encoding length")
+	errSignatureType   = errors.New("invalid signature type")
+)
+
+// Signature is a ring signature. KeyImage is nil for SAG signatures, and set for linkable ones.
+type Signature struct {
+	Challenge *ecc.Scalar
+	KeyImage  *ecc.Element
+	Responses []*ecc.Scalar
+}
+
+// hashKey returns the hash of the public key to the group, which is the base of its key image.
+func hashKey(pk *ecc.Element) *ecc.Element {
+	return pk.Group().HashToGroup(pk.Encode(), []byte(keyImageDST))
+}
+
+// KeyImage returns the key image of the private key, which is the same in all the linkable signatures it makes.
+func KeyImage(sk *ecc.Scalar) *ecc.Element {
+	return hashKey(sk.Group().Base().Multiply(sk)).Multiply(sk)
+}
+
+func checkRing(ring []*ecc.Element) (ecc.Group, error) {
+	if len(ring) == 0 {
+		return 0, errEmptyRing
+	}
+
+	if ring[0] == nil {
+		return 0, errNilInput
+	}
+
+	g := ring[0].Group()
+
+	for _, pk := range ring {
+		switch {
+		case pk == nil:
+			return 0, errNilInput
+		case pk.Group() != g:
+			return 0, errGroupMismatch
+		case pk.IsIdentity():
+			return 0, errIdentityKey
+		}
+	}
+
+	return g, nil
+}
+
+// challenger derives the challenges of the ring, which all commit to the ring, the key image, and the message.
+type challenger struct {
+	prefix []byte
+	image  *ecc.Element
+	g      ecc.Group
+}
+
+func newChallenger(g ecc.Group, ring []*ecc.Element, image *ecc.Element, message []byte) *challenger {
+	prefix := binary.BigEndian.AppendUint64(nil, uint64(len(ring)))
+	for _, pk := range ring {
+		prefix = append(prefix, pk.Encode()...)
+	}
+
+	if image != nil {
+		prefix = append(prefix, image.Encode()...)
+	}
+
+	prefix = binary.BigEndian.AppendUint64(prefix, uint64(len(message)))
+	prefix = append(prefix, message...)
+
+	return &challenger{prefix: prefix, image: image, g: g}
+}
+
+func (c *challenger) challenge(l, r *ecc.Element) *ecc.Scalar {
+	input := append(append([]byte(nil), c.prefix...), l.Encode()...)
+	if r != nil {
+		input = append(input, r.Encode()...)
+	}
+
+	return c.g.HashToScalar(input, []byte(challengeDST))
+}
+
+// next returns the challenge following the one of the ring member with the given response. Its inputs are public, so
+// it runs in variable time.
+func (c *challenger) next(pk *ecc.Element, response, challenge *ecc.Scalar) *ecc.Scalar {
+	l := c.g.DoubleScalarMultVartime(response, c.g.Base(), challenge, pk)
+
+	var r *ecc.Element
+	if c.image != nil {
+		r = c.g.DoubleScalarMultVartime(response, hashKey(pk), challenge, c.image)
+	}
+
+	return c.challenge(l, r)
+}
+
+// Sign returns a SAG signature of the message by the private key, whose public key must be in the ring.
+func Sign(ring []*ecc.Element, sk *ecc.Scalar, message []byte) (*Signature, error) {
+	return sign(ring, sk, message, false)
+}
+
+// SignLinkable returns an LSAG signature of the message by the private key, whose public key must be in the ring.
+func SignLinkable(ring []*ecc.Element, sk *ecc.Scalar, message []byte) (*Signature, error) {
+	return sign(ring, sk, message, true)
+}
+
+func sign(ring []*ecc.Element, sk *ecc.Scalar, message []byte, linkable bool) (*Signature, error) {
+	g, err := checkRing(ring)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case sk == nil:
+		return nil, errNilInput
+	case sk.Group() != g:
+		return nil, errGroupMismatch
+	case sk.IsZero():
+		return nil, errZeroKey
+	}
+
+	pk := g.Base().Multiply(sk)
+	index := -1
+
+	// The whole ring is scanned, so that the time taken doesn't depend on the signer's position.
+	for i, member := range ring {
+		if member.Equal(pk) && index < 0 {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		return nil, errNotInRing
+	}
+
+	alpha := g.NewScalar().Random()
+
+	var image, r *ecc.Element
+	if linkable {
+		hp := hashKey(pk)
+		image = g.NewElement().Set(hp).Multiply(sk)
+		r = hp.Multiply(alpha)
+	}
+
+	ch := newChallenger(g, ring, image, message)
+	c := ch.challenge(g.Base().Multiply(alpha), r)
+	c0 := c
+	responses := make([]*ecc.Scalar, len(ring))
+
+	for j := 1; j < len(ring); j++ {
+		i := (index + j) % len(ring)
+		if i == 0 {
+			c0 = c
+		}
+
+		responses[i] = g.NewScalar().Random()
+		c = ch.next(ring[i], responses[i], c)
+	}
+
+	if index == 0 {
+		c0 = c
+	}
+
+	responses[index] = alpha.Subtract(c.Copy().Multiply(sk))
+
+	return &Signature{
+		Challenge: c0,
+		KeyImage:  image,
+		Responses: responses,
+	}, nil
+}
+
+func (s *Signature) isValid(g ecc.Group, n int) bool {
+	if s == nil || s.Challenge == nil || s.Challenge.Group() != g || len(s.Responses) != n {
+		return false
+	}
+
+	if s.KeyImage != nil &&
+		(s.KeyImage.Group() != g || s.KeyImage.IsIdentity() || !s.KeyImage.IsTorsionFree()) {
+		return false
+	}
+
+	for _, response := range s.Responses {
+		if response == nil || response.Group() != g {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Verify returns whether the signature is a valid signature of the message by a member of the ring. Linkable
+// signatures are verified against their key image.
+func Verify(ring []*ecc.Element, message []byte, signature *Signature) bool {
+	g, err := checkRing(ring)
+	if err != nil || !signature.isValid(g, len(ring)) {
+		return false
+	}
+
+	ch := newChallenger(g, ring, signature.KeyImage, message)
+	c := signature.Challenge
+
+	for i, pk := range ring {
+		c = ch.next(pk, signature.Responses[i], c)
+	}
+
+	return c.Equal(signature.Challenge)
+}
+
+// Linked returns whether both signatures are linkable and were made with the same private key. Signatures should be
+// verified before being linked.
+func Linked(a, b *Signature) bool {
+	if a == nil || b == nil || a.KeyImage == nil || b.KeyImage == nil || a.KeyImage.Group() != b.KeyImage.Group() {
+		return false
+	}
+
+	return a.KeyImage.Equal(b.KeyImage)
+}
+
+// Encode returns the byte encoding of the signature: a byte set to 1 for linkable signatures and 0 otherwise, the
+// challenge, the key image if any, and the responses.
+func (s *Signature) Encode() []byte {
+	out := []byte{0}
+	out = append(out, s.Challenge.Encode()...)
+
+	if s.KeyImage != nil {
+		out[0] = 1
+		out = append(out, s.KeyImage.Encode()...)
+	}
+
+	for _, response := range s.Responses {
+		out = append(out, response.Encode()...)
+	}
+
+	return out
+}
+
+// Decode sets the signature to the decoding of the input, for the given group, and returns an error on failure. The
+// ring size is derived from the length of the input.
+func (s *Signature) Decode(g ecc.Group, data []byte) error {
+	sLen, eLen := g.ScalarLength(), g.ElementLength()
+	if len(data) == 0 || data[0] > 1 {
+		return errSignatureType
+	}
+
+	linkable := data[0] == 1
+	data = data[1:]
+
+	header := sLen
+	if linkable {
+		header += eLen
+	}
+
+	if len(data) < header+sLen || (len(data)-header)%sLen != 0 {
+		return errSignatureLength
+	}
+
+	challenge := g.NewScalar()
+	if err := challenge.DecodeCanonical(data[:sLen]); err != nil {
+		return err
+	}
+
+	var image *ecc.Element
+
+	if linkable {
+		image = g.NewElement()
+		if err := image.Decode(data[sLen:header]); err != nil {
+			return err
+		}
+	}
+
+	responses, err := decodeScalars(g, data[header:])
+	if err != nil {
+		return err
+	}
+
+	s.Challenge, s.KeyImage, s.Responses = challenge, image, responses
+
+	return nil
+}
+
+func decodeScalars(g ecc.Group, data []byte) ([]*ecc.Scalar, error) {
+	sLen := g.ScalarLength()
+	scalars := make([]*ecc.Scalar, len(data)/sLen)
+
+	for i := range scalars {
+		scalars[i] = g.NewScalar()
+		if err := scalars[i].DecodeCanonical(data[i*sLen : (i+1)*sLen]); err != nil {
+			return nil, err
+		}
+	}
+
+	return scalars, nil
+}
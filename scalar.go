@@ -9,12 +9,24 @@
 package ecc
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"slices"
 	"strings"
 
+	"filippo.io/edwards25519"
+
 	"github.com/bytemare/ecc/internal"
 )
 
+var (
+	errScalarString = errors.New("not a non-negative decimal or 0x-prefixed hexadecimal integer")
+	errClampGroup   = errors.New("scalar clamping is only defined for the groups over Curve25519")
+)
+
 // Scalar represents a scalar in the prime-order group.
 type Scalar struct {
 	_ disallowEqual
@@ -63,7 +75,7 @@ func (s *Scalar) Add(scalar *Scalar) *Scalar {
 
 	s.Scalar.Add(scalar.Scalar)
 
-	return s
+	return debugScalar(s, "Add")
 }
 
 // Subtract subtracts the input from the receiver, and returns the receiver.
@@ -74,7 +86,7 @@ func (s *Scalar) Subtract(scalar *Scalar) *Scalar {
 
 	s.Scalar.Subtract(scalar.Scalar)
 
-	return s
+	return debugScalar(s, "Subtract")
 }
 
 // Multiply multiplies the receiver with the input, and returns the receiver.
@@ -83,9 +95,29 @@ func (s *Scalar) Multiply(scalar *Scalar) *Scalar {
 		return s.Zero()
 	}
 
+	recordOperation(s.Group(), OpScalarMultiply)
 	s.Scalar.Multiply(scalar.Scalar)
 
-	return s
+	return debugScalar(s, "Multiply")
+}
+
+// MulAdd sets the receiver to a * b + c, and returns the receiver. Nil arguments are 0, and the arguments may alias
+// the receiver, e.g. to accumulate a sum of products with s.MulAdd(a, b, s).
+func (s *Scalar) MulAdd(a, b, c *Scalar) *Scalar {
+	if a == nil || b == nil {
+		return s.Set(c)
+	}
+
+	if s == b || s == c {
+		return s.Set(a.Copy().Multiply(b).Add(c))
+	}
+
+	return s.Set(a).Multiply(b).Add(c)
+}
+
+// Square sets the receiver to its square, and returns it.
+func (s *Scalar) Square() *Scalar {
+	return s.Multiply(s)
 }
 
 // Pow sets s to s**scalar modulo the group order, and returns s. If scalar is nil, it returns 1.
@@ -96,13 +128,64 @@ func (s *Scalar) Pow(scalar *Scalar) *Scalar {
 
 	s.Scalar.Pow(scalar.Scalar)
 
-	return s
+	return debugScalar(s, "Pow")
 }
 
 // Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
 func (s *Scalar) Invert() *Scalar {
+	debugInvert(s)
+	recordOperation(s.Group(), OpScalarInvert)
 	s.Scalar.Invert()
-	return s
+
+	return debugScalar(s, "Invert")
+}
+
+// IsOdd returns whether the scalar, as an integer lower than the group order, is odd.
+func (s *Scalar) IsOdd() bool {
+	return s.isOdd() == 1
+}
+
+// IsEven returns whether the scalar, as an integer lower than the group order, is even.
+func (s *Scalar) IsEven() bool {
+	return !s.IsOdd()
+}
+
+// isOdd returns 1 if the scalar, as an integer lower than the group order, is odd, and 0 otherwise, without branching.
+func (s *Scalar) isOdd() int {
+	enc := s.Encode()
+	defer clear(enc)
+
+	if s.Group().littleEndianScalars() {
+		return int(enc[0] & 1)
+	}
+
+	return int(enc[len(enc)-1] & 1)
+}
+
+// ConditionalNegate sets the receiver to its negation if choice is 1, and leaves it unchanged if choice is 0, in
+// constant time with respect to choice and the scalar, e.g. to normalize BIP-340 private keys to an even public key
+// y-coordinate. As in crypto/subtle, and like the results of EqualCT and IsZeroCT, choice must be 0 or 1, and the
+// behavior is undefined otherwise. It returns the receiver.
+func (s *Scalar) ConditionalNegate(choice int) *Scalar {
+	neg := s.Group().NewScalar().Subtract(s)
+	enc, negEnc := s.Encode(), neg.Encode()
+	subtle.ConstantTimeCopy(choice, enc, negEnc)
+
+	if err := s.Scalar.Decode(enc); err != nil {
+		panic(err) // unreachable: both encodings are canonical
+	}
+
+	neg.Wipe()
+	clear(enc)
+	clear(negEnc)
+
+	return debugScalar(s, "ConditionalNegate")
+}
+
+// NegateIfOdd sets the receiver to its negation if it is odd, which makes it even since the group order is odd, in
+// constant time, and returns the receiver.
+func (s *Scalar) NegateIfOdd() *Scalar {
+	return s.ConditionalNegate(s.isOdd())
 }
 
 // Equal returns true if the elements are equivalent, and false otherwise.
@@ -114,6 +197,17 @@ func (s *Scalar) Equal(scalar *Scalar) bool {
 	return s.Scalar.Equal(scalar.Scalar) == 1
 }
 
+// EqualCT returns 1 if the scalars are equal, and 0 otherwise, in constant time in all groups. The result can be
+// directly combined with the crypto/subtle functions, e.g. to select between values without branching on secrets.
+// A nil argument is never equal.
+func (s *Scalar) EqualCT(scalar *Scalar) int {
+	if scalar == nil {
+		return 0
+	}
+
+	return s.Scalar.Equal(scalar.Scalar)
+}
+
 // LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
 func (s *Scalar) LessOrEqual(scalar *Scalar) bool {
 	if scalar == nil {
@@ -128,6 +222,11 @@ func (s *Scalar) IsZero() bool {
 	return s.Scalar.IsZero()
 }
 
+// IsZeroCT returns 1 if the scalar is 0, and 0 otherwise, in constant time in all groups, like EqualCT.
+func (s *Scalar) IsZeroCT() int {
+	return s.Scalar.Equal(s.Scalar.Copy().Zero())
+}
+
 // Set sets the receiver to the value of the argument scalar, and returns the receiver.
 func (s *Scalar) Set(scalar *Scalar) *Scalar {
 	if scalar == nil {
@@ -142,7 +241,7 @@ func (s *Scalar) Set(scalar *Scalar) *Scalar {
 // SetUInt64 sets s to i modulo the field order, and returns an error if one occurs.
 func (s *Scalar) SetUInt64(i uint64) *Scalar {
 	s.Scalar.SetUInt64(i)
-	return s
+	return debugScalar(s, "SetUInt64")
 }
 
 // UInt64 returns the uint64 representation of the scalar,
@@ -166,10 +265,80 @@ func (s *Scalar) Encode() []byte {
 	return s.Scalar.Encode()
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. Encodings of values
+// greater or equal to the group order are rejected, and never silently reduced.
 func (s *Scalar) Decode(data []byte) error {
 	if err := s.Scalar.Decode(data); err != nil {
-		return fmt.Errorf("scalar Decode: %w", err)
+		return newInvalidEncoding(s.Group(), "scalar Decode", err)
+	}
+
+	return nil
+}
+
+// DecodeCanonical sets the receiver to a decoding of the input data, and returns an error if the input is not the
+// canonical encoding of a scalar, i.e. of an integer strictly lower than the group order. Values that would otherwise
+// be reduced modulo the order fail with internal.ErrParamScalarInvalidEncoding, which prevents signature malleability.
+// This holds whatever the group's own decoder, as the decoded scalar must encode back to the input, which is compared
+// in constant time. The receiver is not modified on failure. Use DecodeReduce to accept and reduce such values instead.
+func (s *Scalar) DecodeCanonical(data []byte) error {
+	return s.decodeCanonical(data, "scalar DecodeCanonical", false)
+}
+
+// DecodeChecked sets the receiver to a decoding of the input data, and returns an error if the input is not the
+// canonical encoding of a scalar, as DecodeCanonical, or if it encodes 0, which fails with ErrZeroScalar, e.g. for
+// private keys, nonces, and blinding factors read from untrusted inputs. The receiver is not modified on failure.
+func (s *Scalar) DecodeChecked(data []byte) error {
+	return s.decodeCanonical(data, "scalar DecodeChecked", true)
+}
+
+func (s *Scalar) decodeCanonical(data []byte, op string, nonZero bool) error {
+	d := s.Group().NewScalar()
+	defer d.Wipe()
+
+	if err := d.Scalar.Decode(data); err != nil {
+		return newInvalidEncoding(s.Group(), op, err)
+	}
+
+	enc := d.Encode()
+	defer clear(enc)
+
+	if subtle.ConstantTimeCompare(enc, data) != 1 {
+		return newInvalidEncoding(s.Group(), op, internal.ErrParamScalarInvalidEncoding)
+	}
+
+	if nonZero && d.IsZeroCT() == 1 {
+		return newInvalidEncoding(s.Group(), op, internal.ErrParamZeroScalar)
+	}
+
+	s.Scalar.Set(d.Scalar)
+
+	return nil
+}
+
+// DecodeReduce sets the receiver to the integer encoded in data, in the group's scalar byte order, reduced modulo the
+// group order. Unlike DecodeCanonical, values greater or equal to the order are accepted, and the input can be up to
+// twice the scalar length, e.g. to reduce uniform strings from a wide hash output without bias. It returns an error if
+// data is empty or longer than that, in which case the receiver is not modified.
+func (s *Scalar) DecodeReduce(data []byte) error {
+	length := s.Group().ScalarLength()
+	if len(data) == 0 || len(data) > 2*length {
+		return newInvalidEncoding(s.Group(), "scalar DecodeReduce", internal.ErrParamScalarLength)
+	}
+
+	enc := slices.Clone(data)
+	if s.Group().littleEndianScalars() {
+		slices.Reverse(enc)
+	}
+
+	i := new(big.Int).SetBytes(enc)
+	enc = i.Mod(i, s.Group().OrderBig()).FillBytes(make([]byte, length))
+
+	if s.Group().littleEndianScalars() {
+		slices.Reverse(enc)
+	}
+
+	if err := s.Scalar.Decode(enc); err != nil {
+		return newInvalidEncoding(s.Group(), "scalar DecodeReduce", err)
 	}
 
 	return nil
@@ -180,15 +349,96 @@ func (s *Scalar) Hex() string {
 	return s.Scalar.Hex()
 }
 
+// String returns the group name with the value redacted, e.g. "P-256(scalar redacted)", so that secret scalars don't
+// end up in logs by accident. Use Hex to explicitly get the full encoding.
+func (s *Scalar) String() string {
+	if s == nil || s.Scalar == nil {
+		return "<nil>"
+	}
+
+	return s.Group().Name() + "(scalar redacted)"
+}
+
+// GoString implements fmt.GoStringer, and redacts the value as String does, including for the %#v verb.
+func (s *Scalar) GoString() string {
+	return s.String()
+}
+
 // DecodeHex sets s to the decoding of the hex encoded scalar.
 func (s *Scalar) DecodeHex(h string) error {
 	if err := s.Scalar.DecodeHex(h); err != nil {
-		return fmt.Errorf("scalar DecodeHex: %w", err)
+		return newInvalidEncoding(s.Group(), "scalar DecodeHex", err)
+	}
+
+	return nil
+}
+
+// SetString sets s to the integer written in decimal, or in hexadecimal with a "0x" prefix, e.g. for test fixtures and
+// configuration files, and returns an error if it's not lower than the group order. Unlike DecodeHex, the hexadecimal
+// form is the value of the integer, and not its encoding, which is little-endian in the groups over Curve25519. The
+// receiver is not modified on failure.
+func (s *Scalar) SetString(v string) error {
+	digits, base := v, 10
+	if h, ok := strings.CutPrefix(v, "0x"); ok {
+		digits, base = h, 16
+	}
+
+	i, ok := new(big.Int).SetString(digits, base)
+	if !ok || digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return newInvalidEncoding(s.Group(), "scalar SetString", errScalarString)
+	}
+
+	length := s.Group().ScalarLength()
+	if (i.BitLen()+7)/8 > length {
+		return newInvalidEncoding(s.Group(), "scalar SetString", internal.ErrParamScalarInvalidEncoding)
+	}
+
+	enc := i.FillBytes(make([]byte, length))
+	if s.Group().littleEndianScalars() {
+		slices.Reverse(enc)
+	}
+
+	if err := s.Scalar.Decode(enc); err != nil {
+		return newInvalidEncoding(s.Group(), "scalar SetString", err)
+	}
+
+	return nil
+}
+
+// SetBytesWithClamping sets s to the 32-byte input clamped as X25519 and Ed25519 private keys are, i.e. with the 3
+// least significant bits and the most significant bit cleared, and the second most significant bit set, and reduced
+// modulo the group order. This allows importing keys from these ecosystems, e.g. the first half of the SHA-512 of an
+// Ed25519 seed, in the Ristretto255 and Edwards25519 groups, and returns an error in the other groups. The receiver is
+// not modified on failure.
+func (s *Scalar) SetBytesWithClamping(data []byte) error {
+	if !s.Group().littleEndianScalars() {
+		return errClampGroup
+	}
+
+	clamped, err := edwards25519.NewScalar().SetBytesWithClamping(data)
+	if err != nil {
+		return newInvalidEncoding(s.Group(), "scalar SetBytesWithClamping", err)
+	}
+
+	if err = s.Scalar.Decode(clamped.Bytes()); err != nil {
+		return newInvalidEncoding(s.Group(), "scalar SetBytesWithClamping", err)
 	}
 
 	return nil
 }
 
+// littleEndianScalars reports whether the group encodes scalars in little-endian, as the groups over Curve25519 do.
+func (g Group) littleEndianScalars() bool {
+	return g == Ristretto255Sha512 || g == Edwards25519Sha512
+}
+
+// Wipe overwrites the scalar's internal representation in place and sets it to 0, so that secret values such as
+// private keys and nonces don't linger in memory once they're no longer needed. Copies made before, e.g. with Copy()
+// or Encode(), are not affected and must be wiped separately.
+func (s *Scalar) Wipe() {
+	s.Scalar.Wipe()
+}
+
 // MarshalJSON marshals the scalar into valid JSON.
 func (s *Scalar) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%q", s.Hex())), nil
@@ -208,8 +458,33 @@ func (s *Scalar) MarshalBinary() ([]byte, error) {
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 func (s *Scalar) UnmarshalBinary(data []byte) error {
 	if err := s.Scalar.Decode(data); err != nil {
-		return fmt.Errorf("scalar UnmarshalBinary: %w", err)
+		return newInvalidEncoding(s.Group(), "scalar UnmarshalBinary", err)
 	}
 
 	return nil
 }
+
+// WriteTo implements the io.WriterTo interface, and writes the encoding of the scalar to w, e.g. to stream it into a
+// connection or a hash.
+func (s *Scalar) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(s.Scalar.Encode())
+	return int64(n), err
+}
+
+// ReadFrom implements the io.ReaderFrom interface, and sets s to the decoding of the next ScalarLength bytes of r.
+// Unlike most implementations, it doesn't read until EOF, so that consecutive scalars can be read from the same stream.
+// The receiver is not modified on failure.
+func (s *Scalar) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, s.Group().ScalarLength())
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return int64(n), err
+	}
+
+	if err = s.Scalar.Decode(buf); err != nil {
+		return int64(n), newInvalidEncoding(s.Group(), "scalar ReadFrom", err)
+	}
+
+	return int64(n), nil
+}
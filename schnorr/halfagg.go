@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package schnorr
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const randomizerDST = "ECC-Schnorr-V01-HalfAgg-Randomizer"
+
+var (
+	errLengthMismatch  = errors.New("the number of items and signatures differ")
+	errAggregateLength = errors.New("invalid aggregate signature encoding length")
+	errNothingToAdd    = errors.New("no signature to aggregate")
+)
+
+// Item is a public key and message pair, for which a signature has been aggregated.
+type Item struct {
+	PublicKey *ecc.Element
+	Message   []byte
+}
+
+// AggregateSignature is a half-aggregated Schnorr signature: the commitments of all the signatures are kept, and their
+// responses are combined into a single scalar. Its size is thus about half of the individual signatures.
+type AggregateSignature struct {
+	R []*ecc.Element
+	S *ecc.Scalar
+}
+
+// transcript accumulates the randomizer inputs. The coefficient of the i-th signature commits to all the previous
+// signatures, which allows incremental aggregation.
+type transcript []byte
+
+func (t *transcript) append(r *ecc.Element, item Item) {
+	*t = append(*t, r.Encode()...)
+	*t = append(*t, item.PublicKey.Encode()...)
+	*t = binary.BigEndian.AppendUint64(*t, uint64(len(item.Message)))
+	*t = append(*t, item.Message...)
+}
+
+// randomizer returns the coefficient of the signature at the given index. The first coefficient is 1.
+func (t *transcript) randomizer(g ecc.Group, index int) *ecc.Scalar {
+	if index == 0 {
+		return g.NewScalar().One()
+	}
+
+	return g.HashToScalar(*t, []byte(randomizerDST))
+}
+
+func checkItem(g ecc.Group, item Item) error {
+	if item.PublicKey == nil {
+		return errNilInput
+	}
+
+	if item.PublicKey.Group() != g {
+		return errGroupMismatch
+	}
+
+	return nil
+}
+
+// Aggregate half-aggregates the signatures, each of which must be valid for the item at the same index.
+func Aggregate(items []Item, signatures []*Signature) (*AggregateSignature, error) {
+	return IncAggregate(nil, nil, items, signatures)
+}
+
+// IncAggregate adds the signatures to the aggregate, which was produced over the aggregated items. If aggregate is
+// nil, a new aggregate is created. The input aggregate is not modified.
+func IncAggregate(aggregate *AggregateSignature, aggregated, items []Item, signatures []*Signature) (
+	*AggregateSignature, error,
+) {
+	if len(items) != len(signatures) {
+		return nil, errLengthMismatch
+	}
+
+	if len(items) == 0 {
+		return nil, errNothingToAdd
+	}
+
+	if aggregate == nil {
+		aggregate = &AggregateSignature{}
+	}
+
+	if len(aggregate.R) != len(aggregated) {
+		return nil, errLengthMismatch
+	}
+
+	g := items[0].PublicKey.Group()
+	result := &AggregateSignature{
+		R: make([]*ecc.Element, 0, len(aggregated)+len(items)),
+		S: g.NewScalar(),
+	}
+
+	if aggregate.S != nil {
+		if aggregate.S.Group() != g {
+			return nil, errGroupMismatch
+		}
+
+		result.S.Set(aggregate.S)
+	}
+
+	var t transcript
+
+	for i, item := range aggregated {
+		if err := checkItem(g, item); err != nil {
+			return nil, err
+		}
+
+		t.append(aggregate.R[i], item)
+		result.R = append(result.R, aggregate.R[i].Copy())
+	}
+
+	for i, item := range items {
+		sig := signatures[i]
+		if err := checkItem(g, item); err != nil {
+			return nil, err
+		}
+
+		if sig == nil || sig.R == nil || sig.S == nil {
+			return nil, errNilInput
+		}
+
+		if sig.R.Group() != g || sig.S.Group() != g {
+			return nil, errGroupMismatch
+		}
+
+		t.append(sig.R, item)
+		z := t.randomizer(g, len(result.R))
+		result.S.Add(z.Multiply(sig.S))
+		result.R = append(result.R, sig.R.Copy())
+	}
+
+	return result, nil
+}
+
+// VerifyAggregate returns whether the aggregate signature is valid for all the items.
+func VerifyAggregate(items []Item, aggregate *AggregateSignature) bool {
+	valid, _ := VerifyAggregateContext(context.Background(), items, aggregate)
+	return valid
+}
+
+// VerifyAggregateContext is the same as VerifyAggregate, but checks the context between items, and returns false and
+// the context's error if it is done before verification completes.
+func VerifyAggregateContext(ctx context.Context, items []Item, aggregate *AggregateSignature) (bool, error) {
+	if aggregate == nil || aggregate.S == nil || len(items) == 0 || len(items) != len(aggregate.R) {
+		return false, nil
+	}
+
+	g := aggregate.S.Group()
+	sum := g.NewElement()
+
+	var t transcript
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		r := aggregate.R[i]
+		if checkItem(g, item) != nil || r == nil || r.Group() != g || item.PublicKey.IsIdentity() {
+			return false, nil
+		}
+
+		t.append(r, item)
+		z := t.randomizer(g, i)
+		c := Challenge(r, item.PublicKey, item.Message)
+
+		// z * (R + c * pk)
+		sum.Add(item.PublicKey.Copy().Multiply(c).Add(r).Multiply(z))
+	}
+
+	return g.Base().Multiply(aggregate.S).Equal(sum), nil
+}
+
+// Encode returns the byte encoding of the aggregate signature, i.e. the concatenation of the commitments and s.
+func (a *AggregateSignature) Encode() []byte {
+	g := a.S.Group()
+	out := make([]byte, 0, len(a.R)*g.ElementLength()+g.ScalarLength())
+
+	for _, r := range a.R {
+		out = append(out, r.Encode()...)
+	}
+
+	return append(out, a.S.Encode()...)
+}
+
+// Decode sets the aggregate signature to the decoding of the input, for the given group, and returns an error on
+// failure.
+func (a *AggregateSignature) Decode(g ecc.Group, data []byte) error {
+	eLen, sLen := g.ElementLength(), g.ScalarLength()
+	if len(data) < sLen || (len(data)-sLen)%eLen != 0 {
+		return errAggregateLength
+	}
+
+	n := (len(data) - sLen) / eLen
+	r := make([]*ecc.Element, n)
+
+	for i := range n {
+		r[i] = g.NewElement()
+		if err := r[i].Decode(data[i*eLen : (i+1)*eLen]); err != nil {
+			return err
+		}
+	}
+
+	s := g.NewScalar()
+	if err := s.DecodeCanonical(data[n*eLen:]); err != nil {
+		return err
+	}
+
+	a.R, a.S = r, s
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package schnorr
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	randomizationDST      = "ECC-Schnorr-V01-Randomization"
+	adaptableChallengeDST = "ECC-Schnorr-V01-AdaptableChallenge"
+	adaptableNonceDST     = "ECC-Schnorr-V01-AdaptableNonce"
+)
+
+var errIdentityKey = errors.New("public key is the identity element")
+
+// RandomizePublicKey returns pk + t * G, the public key of the private key randomized with t.
+func RandomizePublicKey(pk *ecc.Element, t *ecc.Scalar) (*ecc.Element, error) {
+	if pk == nil || t == nil {
+		return nil, errNilInput
+	}
+
+	g := pk.Group()
+	if t.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	randomized := g.Base().Multiply(t).Add(pk)
+	if randomized.IsIdentity() {
+		return nil, errIdentityKey
+	}
+
+	return randomized, nil
+}
+
+// RandomizePrivateKey returns sk + t, the private key of the public key randomized with t.
+func RandomizePrivateKey(sk, t *ecc.Scalar) (*ecc.Scalar, error) {
+	if sk == nil || t == nil {
+		return nil, errNilInput
+	}
+
+	if t.Group() != sk.Group() {
+		return nil, errGroupMismatch
+	}
+
+	randomized := sk.Copy().Add(t)
+	if randomized.IsZero() {
+		return nil, errZeroKey
+	}
+
+	return randomized, nil
+}
+
+// SignRandomized returns a signature of the message under the public key randomized with t, i.e. one that verifies
+// against RandomizePublicKey(sk * G, t) and is unlinkable to sk * G for anyone who doesn't know t. Since the challenge
+// binds the public key, an existing signature can't be adapted to the randomized key with t alone: use SignAdaptable
+// and AdaptSignature for that.
+func SignRandomized(sk, t *ecc.Scalar, message []byte) (*Signature, error) {
+	randomized, err := RandomizePrivateKey(sk, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return Sign(randomized, message)
+}
+
+// adaptableChallenge returns the challenge of adaptable signatures, H(R || m), which unlike Challenge doesn't bind the
+// public key, so that it's the same for all the randomizations of a key.
+func adaptableChallenge(r *ecc.Element, message []byte) *ecc.Scalar {
+	input := make([]byte, 0, r.Group().ElementLength()+len(message))
+	input = append(input, r.Encode()...)
+	input = append(input, message...)
+
+	return r.Group().HashToScalar(input, []byte(adaptableChallengeDST))
+}
+
+// SignAdaptable returns an adaptable signature of the message under the private key, which AdaptSignature turns into a
+// signature under any randomization of the public key, without the private key. Its challenge doesn't bind the public
+// key, so a valid signature under pk is also one under pk + t * G for anyone who knows t: verifiers must only rely on
+// it to attest that the signer holds one of the randomizations of a key. Adaptable signatures are domain-separated
+// from those of Sign, and are verified with VerifyAdaptable.
+func SignAdaptable(sk *ecc.Scalar, message []byte) (*Signature, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	g := sk.Group()
+
+	// The nonce input is separated from Sign's, so that both never share a nonce for different challenges.
+	k := nonce(sk, append([]byte(adaptableNonceDST), message...))
+	r := g.Base().Multiply(k)
+
+	return &Signature{
+		R: r,
+		S: adaptableChallenge(r, message).Multiply(sk).Add(k),
+	}, nil
+}
+
+// VerifyAdaptable returns whether the adaptable signature is valid for the message under the public key.
+func VerifyAdaptable(pk *ecc.Element, message []byte, signature *Signature) bool {
+	if pk == nil || signature == nil || signature.R == nil || signature.S == nil {
+		return false
+	}
+
+	g := pk.Group()
+	if signature.R.Group() != g || signature.S.Group() != g || pk.IsIdentity() {
+		return false
+	}
+
+	c := adaptableChallenge(signature.R, message)
+	right := pk.Copy().Multiply(c).Add(signature.R)
+
+	return g.Base().Multiply(signature.S).Equal(right)
+}
+
+// AdaptSignature returns the adaptable signature of the message under pk adapted to RandomizePublicKey(pk, t), i.e.
+// (R, s + c * t) with the challenge c recomputed from R and the message. The input signature is not modified.
+func AdaptSignature(signature *Signature, t *ecc.Scalar, message []byte) (*Signature, error) {
+	if signature == nil || signature.R == nil || signature.S == nil || t == nil {
+		return nil, errNilInput
+	}
+
+	g := t.Group()
+	if signature.R.Group() != g || signature.S.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	c := adaptableChallenge(signature.R, message)
+
+	return &Signature{
+		R: signature.R.Copy(),
+		S: c.Multiply(t).Add(signature.S),
+	}, nil
+}
+
+func randomizationMessage(pk, randomized *ecc.Element, context []byte) []byte {
+	message := make([]byte, 0, len(randomizationDST)+2*pk.Group().ElementLength()+len(context))
+	message = append(message, randomizationDST...)
+	message = append(message, pk.Encode()...)
+	message = append(message, randomized.Encode()...)
+
+	return append(message, context...)
+}
+
+// ProveRandomization returns a proof of knowledge of t such that randomized = pk + t * G, bound to the context. It is
+// a signature under the key randomized - pk, and lets the holder selectively link a randomized key to its origin.
+func ProveRandomization(t *ecc.Scalar, pk, randomized *ecc.Element, context []byte) (*Signature, error) {
+	if t == nil || pk == nil || randomized == nil {
+		return nil, errNilInput
+	}
+
+	g := t.Group()
+	if pk.Group() != g || randomized.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	return Sign(t, randomizationMessage(pk, randomized, context))
+}
+
+// VerifyRandomization returns whether the proof attests that randomized is a randomization of pk.
+func VerifyRandomization(pk, randomized *ecc.Element, context []byte, proof *Signature) bool {
+	if pk == nil || randomized == nil || randomized.Group() != pk.Group() {
+		return false
+	}
+
+	return Verify(randomized.Copy().Subtract(pk), randomizationMessage(pk, randomized, context), proof)
+}
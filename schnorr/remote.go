@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package schnorr
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var errRemoteSignature = errors.New("external device returned an invalid signature")
+
+// RemoteKey is a private key held by an external device, which generates the nonce and computes the response of a
+// signature, so that neither the key nor the nonce leave it.
+type RemoteKey interface {
+	ecc.RemoteScalar
+
+	// Commit returns the commitment R = k * G of a fresh nonce k, which the device keeps for the matching Respond.
+	Commit() (*ecc.Element, error)
+
+	// Respond returns s = k + c * sk, for the nonce k of the commitment R and the challenge c. The device must then
+	// erase k, and never use it again.
+	Respond(commitment *ecc.Element, challenge *ecc.Scalar) (*ecc.Scalar, error)
+}
+
+// SignRemote returns a signature of the message under the private key held by the device. The signature is verified
+// before being returned, so that a faulty device can't produce invalid signatures.
+func SignRemote(key RemoteKey, message []byte) (*Signature, error) {
+	if key == nil {
+		return nil, errNilInput
+	}
+
+	pk := key.Public()
+	if pk == nil || pk.IsIdentity() {
+		return nil, errZeroKey
+	}
+
+	r, err := key.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if r == nil || r.Group() != pk.Group() {
+		return nil, errRemoteSignature
+	}
+
+	s, err := key.Respond(r, Challenge(r, pk, message))
+	if err != nil {
+		return nil, err
+	}
+
+	signature := &Signature{R: r, S: s}
+	if !Verify(pk, message, signature) {
+		return nil, errRemoteSignature
+	}
+
+	return signature, nil
+}
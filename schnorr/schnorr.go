@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package schnorr implements Schnorr signatures over any group of the ecc package, and their half-aggregation.
+//
+// A signature on message m under the public key pk = sk * G is (R, s), where R = k * G, s = k + c * sk, and the
+// challenge c = H(R || pk || m) uses the group's hash-to-scalar function. Adaptable signatures use c = H(R || m)
+// instead, so that they can be adapted to randomizations of the public key.
+package schnorr
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	challengeDST = "ECC-Schnorr-V01-Challenge"
+	nonceDST     = "ECC-Schnorr-V01-Nonce"
+	nonceEntropy = 32
+)
+
+var (
+	errNilInput        = errors.New("nil input")
+	errGroupMismatch   = errors.New("inputs belong to different groups")
+	errZeroKey         = errors.New("private key is zero")
+	errSignatureLength = errors.New("invalid signature encoding length")
+)
+
+// Signature is a Schnorr signature.
+type Signature struct {
+	R *ecc.Element
+	S *ecc.Scalar
+}
+
+// Challenge returns the challenge scalar binding the commitment, the public key, and the message.
+func Challenge(r, pk *ecc.Element, message []byte) *ecc.Scalar {
+	g := pk.Group()
+	input := make([]byte, 0, 2*g.ElementLength()+len(message))
+	input = append(input, r.Encode()...)
+	input = append(input, pk.Encode()...)
+	input = append(input, message...)
+
+	return g.HashToScalar(input, []byte(challengeDST))
+}
+
+// nonce derives a hedged nonce from fresh randomness, the private key, and the message, so that a weak random
+// source alone doesn't leak the key.
+func nonce(sk *ecc.Scalar, message []byte) *ecc.Scalar {
+	g := sk.Group()
+	input := internal.RandomBytes(nonceEntropy)
+	input = append(input, sk.Encode()...)
+	input = append(input, message...)
+
+	return g.HashToScalar(input, []byte(nonceDST))
+}
+
+// Sign returns a signature of the message under the private key.
+func Sign(sk *ecc.Scalar, message []byte) (*Signature, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	g := sk.Group()
+	k := nonce(sk, message)
+	r := g.Base().Multiply(k)
+	c := Challenge(r, g.Base().Multiply(sk), message)
+
+	return &Signature{
+		R: r,
+		S: c.Multiply(sk).Add(k),
+	}, nil
+}
+
+// Verify returns whether the signature is valid for the message under the public key.
+func Verify(pk *ecc.Element, message []byte, signature *Signature) bool {
+	if pk == nil || signature == nil || signature.R == nil || signature.S == nil {
+		return false
+	}
+
+	g := pk.Group()
+	if signature.R.Group() != g || signature.S.Group() != g || pk.IsIdentity() {
+		return false
+	}
+
+	c := Challenge(signature.R, pk, message)
+	right := pk.Copy().Multiply(c).Add(signature.R)
+
+	return g.Base().Multiply(signature.S).Equal(right)
+}
+
+// Encode returns the byte encoding of the signature, i.e. the concatenation of R and s.
+func (s *Signature) Encode() []byte {
+	return append(s.R.Encode(), s.S.Encode()...)
+}
+
+// Decode sets the signature to the decoding of the input, for the given group, and returns an error on failure.
+func (s *Signature) Decode(g ecc.Group, data []byte) error {
+	eLen := g.ElementLength()
+	if len(data) != eLen+g.ScalarLength() {
+		return errSignatureLength
+	}
+
+	r := g.NewElement()
+	if err := r.Decode(data[:eLen]); err != nil {
+		return err
+	}
+
+	sc := g.NewScalar()
+	if err := sc.DecodeCanonical(data[eLen:]); err != nil {
+		return err
+	}
+
+	s.R, s.S = r, sc
+
+	return nil
+}
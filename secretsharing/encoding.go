@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secretsharing
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+// DigestLength is the byte length of a commitment digest.
+const DigestLength = sha256.Size
+
+// shareHeaderLength is the byte length of the group identifier and participant identifier that prefix an encoded
+// share.
+const shareHeaderLength = 1 + 8
+
+var errShareEncoding = errors.New("invalid share encoding")
+
+// Digest returns the SHA-256 digest of the group identifier followed by the encodings of the commitment's elements, to
+// bind shares to the sharing they belong to without carrying the whole commitment.
+func (c Commitment) Digest() []byte {
+	h := sha256.New()
+
+	if len(c) != 0 {
+		h.Write([]byte{byte(c[0].Group())})
+	}
+
+	for _, e := range c {
+		h.Write(e.Encode())
+	}
+
+	return h.Sum(nil)
+}
+
+// Encode returns the encoding of the share, for storage or exchange across implementations:
+//
+//	group identifier (1 byte) || ID (8 bytes, big-endian) || Secret || CommitmentDigest (optional)
+//
+// The digest is included if it is set, and the decoder tells whether it is present from the length of the encoding.
+func (s *Share) Encode() []byte {
+	out := make([]byte, shareHeaderLength, shareHeaderLength+s.Secret.Group().ScalarLength()+len(s.CommitmentDigest))
+	out[0] = byte(s.Secret.Group())
+	binary.BigEndian.PutUint64(out[1:], s.ID)
+	out = append(out, s.Secret.Encode()...)
+
+	return append(out, s.CommitmentDigest...)
+}
+
+// Decode sets the share to the decoding of data, as returned by Encode, and returns an error on failure, in which
+// case the receiver is not modified.
+func (s *Share) Decode(data []byte) error {
+	if len(data) < shareHeaderLength {
+		return errShareEncoding
+	}
+
+	g := ecc.Group(data[0])
+	if !g.Available() {
+		return ecc.ErrInvalidGroup
+	}
+
+	end := shareHeaderLength + g.ScalarLength()
+	if len(data) != end && len(data) != end+DigestLength {
+		return errShareEncoding
+	}
+
+	d := &Share{
+		Secret: g.NewScalar(),
+		ID:     binary.BigEndian.Uint64(data[1:]),
+	}
+
+	if d.ID == 0 {
+		return errZeroIdentifier
+	}
+
+	if err := d.Secret.Decode(data[shareHeaderLength:end]); err != nil {
+		return err
+	}
+
+	if len(data) > end {
+		d.CommitmentDigest = append([]byte(nil), data[end:]...)
+	}
+
+	*s = *d
+
+	return nil
+}
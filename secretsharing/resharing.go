@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secretsharing
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNotEnoughDealers    = errors.New("not enough dealers to reconstruct the secret")
+	errDealerCommitment    = errors.New("a dealer's commitment does not match its public share")
+	errInvalidSubShare     = errors.New("a sub-share does not match its commitment")
+	errSubShareID          = errors.New("sub-shares are for different participants")
+	errCommitmentLength    = errors.New("commitments have different lengths")
+	errNonZeroCommitment   = errors.New("a refresh commitment does not share zero")
+	errInputLengthMismatch = errors.New("the number of shares and commitments differ")
+)
+
+// Reshare is run by a holder of the old sharing to split its share to a new access structure of total participants
+// with the new threshold. Each sub-share is sent to the new participant of the same identifier, and the commitment is
+// broadcast.
+func Reshare(share *Share, threshold, total uint64) ([]*Share, Commitment, error) {
+	if share == nil {
+		return nil, nil, errNilInput
+	}
+
+	return Split(share.Secret, threshold, total)
+}
+
+// checkSubShares verifies that the sub-shares are all for the same participant, and match their commitments.
+func checkSubShares(subShares []*Share, commitments []Commitment) error {
+	if len(subShares) != len(commitments) {
+		return errInputLengthMismatch
+	}
+
+	if len(subShares) == 0 {
+		return errNoParticipants
+	}
+
+	for i, s := range subShares {
+		if s == nil {
+			return errNilInput
+		}
+
+		if s.ID != subShares[0].ID {
+			return errSubShareID
+		}
+
+		if len(commitments[i]) != len(commitments[0]) {
+			return errCommitmentLength
+		}
+
+		if !commitments[i].Verify(s) {
+			return errInvalidSubShare
+		}
+	}
+
+	return nil
+}
+
+// CombineReshares is run by a participant of the new access structure to compute its new share, and the commitment
+// to the new sharing, from the sub-shares and commitments it received from the dealers, identified in the old
+// sharing. Each dealer's commitment is checked against its public share in the old commitment, so the new sharing is
+// guaranteed to be of the same secret.
+func CombineReshares(old Commitment, dealers []uint64, subShares []*Share, commitments []Commitment) (*Share,
+	Commitment, error,
+) {
+	if len(dealers) != len(subShares) {
+		return nil, nil, errInputLengthMismatch
+	}
+
+	if len(old) == 0 || len(dealers) < len(old) {
+		return nil, nil, errNotEnoughDealers
+	}
+
+	if err := checkSubShares(subShares, commitments); err != nil {
+		return nil, nil, err
+	}
+
+	g := old[0].Group()
+	if subShares[0].Secret.Group() != g {
+		return nil, nil, errGroupMismatch
+	}
+
+	share := &Share{Secret: g.NewScalar(), ID: subShares[0].ID}
+	commitment := newIdentityCommitment(g, len(commitments[0]))
+
+	for i, dealer := range dealers {
+		if !commitments[i][0].Equal(old.PublicShare(dealer)) {
+			return nil, nil, errDealerCommitment
+		}
+
+		l, err := LagrangeCoefficient(g, dealer, dealers)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		share.Secret.Add(l.Copy().Multiply(subShares[i].Secret))
+
+		for k, c := range commitments[i] {
+			commitment[k].Add(c.Copy().Multiply(l))
+		}
+	}
+
+	return share, commitment, nil
+}
+
+// NewRefresh returns a sharing of zero with the threshold to total participants, for a proactive refresh round. Each
+// share is sent to the participant of the same identifier, and the commitment is broadcast.
+func NewRefresh(g ecc.Group, threshold, total uint64) ([]*Share, Commitment, error) {
+	return Split(g.NewScalar(), threshold, total)
+}
+
+// Refresh returns the participant's refreshed share and the refreshed commitment, given the shares of zero and
+// commitments received from all participants in the refresh round, including its own. The refreshed shares are of
+// the same secret, but independent of the previous ones.
+func Refresh(share *Share, old Commitment, zeroShares []*Share, commitments []Commitment) (*Share, Commitment,
+	error,
+) {
+	if share == nil || share.Secret == nil {
+		return nil, nil, errNilInput
+	}
+
+	if err := checkSubShares(zeroShares, commitments); err != nil {
+		return nil, nil, err
+	}
+
+	if zeroShares[0].ID != share.ID {
+		return nil, nil, errSubShareID
+	}
+
+	if zeroShares[0].Secret.Group() != share.Secret.Group() {
+		return nil, nil, errGroupMismatch
+	}
+
+	if len(commitments[0]) != len(old) {
+		return nil, nil, errCommitmentLength
+	}
+
+	refreshed := &Share{Secret: share.Secret.Copy(), ID: share.ID}
+	commitment := make(Commitment, len(old))
+
+	for k, c := range old {
+		commitment[k] = c.Copy()
+	}
+
+	for i, c := range commitments {
+		if !c[0].IsIdentity() {
+			return nil, nil, errNonZeroCommitment
+		}
+
+		refreshed.Secret.Add(zeroShares[i].Secret)
+
+		for k, e := range c {
+			commitment[k].Add(e)
+		}
+	}
+
+	return refreshed, commitment, nil
+}
+
+func newIdentityCommitment(g ecc.Group, length int) Commitment {
+	c := make(Commitment, length)
+	for i := range c {
+		c[i] = g.NewElement()
+	}
+
+	return c
+}
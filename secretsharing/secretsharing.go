@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package secretsharing implements Shamir secret sharing over the scalars of a group, with Feldman commitments that
+// allow the share holders to verify their shares against the public polynomial.
+package secretsharing
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNoParticipants      = errors.New("empty participant list")
+	errZeroIdentifier      = errors.New("participant identifier is zero")
+	errDuplicateIdentifier = errors.New("duplicate participant identifier")
+	errNotParticipant      = errors.New("identifier is not in the participant list")
+	errNilInput            = errors.New("nil input")
+	errGroupMismatch       = errors.New("inputs belong to different groups")
+	errThreshold           = errors.New("the threshold must be between 1 and the number of shares")
+)
+
+// Share is the evaluation of the secret polynomial at the participant's identifier. CommitmentDigest optionally binds
+// it to the commitment to the polynomial, and is either empty or of DigestLength bytes, as returned by
+// Commitment.Digest.
+type Share struct {
+	Secret           *ecc.Scalar
+	CommitmentDigest []byte
+	ID               uint64
+}
+
+// Public returns the public share, i.e. Secret * G.
+func (s *Share) Public() *ecc.Element {
+	return s.Secret.Group().Base().Multiply(s.Secret)
+}
+
+// Polynomial is a polynomial over the scalars, with the coefficients in ascending degree order.
+type Polynomial []*ecc.Scalar
+
+// NewPolynomial returns a random polynomial of the given degree with the secret as constant term. The secret is
+// copied.
+func NewPolynomial(secret *ecc.Scalar, degree int) Polynomial {
+	g := secret.Group()
+	p := make(Polynomial, degree+1)
+	p[0] = secret.Copy()
+
+	for i := 1; i <= degree; i++ {
+		p[i] = g.NewScalar().Random()
+	}
+
+	return p
+}
+
+// Evaluate returns the evaluation of the polynomial at x.
+func (p Polynomial) Evaluate(x *ecc.Scalar) *ecc.Scalar {
+	y := x.Group().NewScalar()
+	for i := len(p) - 1; i >= 0; i-- {
+		y.Multiply(x).Add(p[i])
+	}
+
+	return y
+}
+
+// Commit returns the Feldman commitment to the polynomial, i.e. its coefficients multiplied by the base point.
+func (p Polynomial) Commit() Commitment {
+	g := p[0].Group()
+	c := make(Commitment, len(p))
+
+	for i, a := range p {
+		c[i] = g.Base().Multiply(a)
+	}
+
+	return c
+}
+
+// Commitment is the public commitment to a secret polynomial, of which the first element is the public key.
+type Commitment []*ecc.Element
+
+// PublicKey returns the public key of the shared secret.
+func (c Commitment) PublicKey() *ecc.Element {
+	return c[0].Copy()
+}
+
+// PublicShare returns the expected public share of the participant, i.e. the commitment evaluated at its identifier.
+func (c Commitment) PublicShare(id uint64) *ecc.Element {
+	g := c[0].Group()
+	x := g.NewScalar().SetUInt64(id)
+	y := g.NewElement()
+
+	for i := len(c) - 1; i >= 0; i-- {
+		y.Multiply(x).Add(c[i])
+	}
+
+	return y
+}
+
+// Verify returns whether the share is consistent with the commitment, and with its digest if the share has one.
+func (c Commitment) Verify(share *Share) bool {
+	if len(c) == 0 || share == nil || share.Secret == nil || share.ID == 0 {
+		return false
+	}
+
+	g := c[0].Group()
+	if share.Secret.Group() != g {
+		return false
+	}
+
+	for _, e := range c {
+		if e == nil || e.Group() != g {
+			return false
+		}
+	}
+
+	if len(share.CommitmentDigest) != 0 && !bytes.Equal(share.CommitmentDigest, c.Digest()) {
+		return false
+	}
+
+	return c.PublicShare(share.ID).Equal(share.Public())
+}
+
+// Split shares the secret to total participants with identifiers 1 to total, so that any threshold of them can
+// recover it. It returns the shares and the commitment to the polynomial.
+func Split(secret *ecc.Scalar, threshold, total uint64) ([]*Share, Commitment, error) {
+	if secret == nil {
+		return nil, nil, errNilInput
+	}
+
+	if threshold == 0 || threshold > total {
+		return nil, nil, errThreshold
+	}
+
+	p := NewPolynomial(secret, int(threshold-1))
+
+	return p.Shares(total), p.Commit(), nil
+}
+
+// Shares returns the evaluations of the polynomial for the identifiers 1 to total.
+func (p Polynomial) Shares(total uint64) []*Share {
+	g := p[0].Group()
+	shares := make([]*Share, total)
+
+	for i := range total {
+		shares[i] = &Share{
+			Secret: p.Evaluate(g.NewScalar().SetUInt64(i + 1)),
+			ID:     i + 1,
+		}
+	}
+
+	return shares
+}
+
+func checkParticipants(id uint64, participants []uint64) error {
+	if len(participants) == 0 {
+		return errNoParticipants
+	}
+
+	sorted := slices.Clone(participants)
+	slices.Sort(sorted)
+
+	if sorted[0] == 0 {
+		return errZeroIdentifier
+	}
+
+	if len(slices.Compact(sorted)) != len(participants) {
+		return errDuplicateIdentifier
+	}
+
+	if !slices.Contains(participants, id) {
+		return errNotParticipant
+	}
+
+	return nil
+}
+
+// LagrangeCoefficient returns the Lagrange coefficient at 0 of the participant id among the participants, i.e.
+// the product of j / (j - id) for all other participants j.
+func LagrangeCoefficient(g ecc.Group, id uint64, participants []uint64) (*ecc.Scalar, error) {
+	if err := checkParticipants(id, participants); err != nil {
+		return nil, err
+	}
+
+	num := g.NewScalar().One()
+	den := g.NewScalar().One()
+	i := g.NewScalar().SetUInt64(id)
+
+	for _, p := range participants {
+		if p == id {
+			continue
+		}
+
+		j := g.NewScalar().SetUInt64(p)
+		num.Multiply(j)
+		den.Multiply(j.Subtract(i))
+	}
+
+	return num.Multiply(den.Invert()), nil
+}
+
+func identifiers(shares []*Share) ([]uint64, error) {
+	if len(shares) == 0 {
+		return nil, errNoParticipants
+	}
+
+	ids := make([]uint64, len(shares))
+
+	for i, s := range shares {
+		if s == nil || s.Secret == nil {
+			return nil, errNilInput
+		}
+
+		if s.Secret.Group() != shares[0].Secret.Group() {
+			return nil, errGroupMismatch
+		}
+
+		ids[i] = s.ID
+	}
+
+	return ids, nil
+}
+
+// Combine returns the secret interpolated from the shares. It does not verify that the shares are consistent, nor
+// that there are enough of them.
+func Combine(shares []*Share) (*ecc.Scalar, error) {
+	ids, err := identifiers(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	g := shares[0].Secret.Group()
+	secret := g.NewScalar()
+
+	for _, s := range shares {
+		l, err := LagrangeCoefficient(g, s.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		secret.Add(l.Multiply(s.Secret))
+	}
+
+	return secret, nil
+}
+
+// CombineElements returns the interpolation at 0 of the elements in the exponent, indexed by the participants'
+// identifiers, e.g. the public key from public shares.
+func CombineElements(elements map[uint64]*ecc.Element) (*ecc.Element, error) {
+	if len(elements) == 0 {
+		return nil, errNoParticipants
+	}
+
+	ids := make([]uint64, 0, len(elements))
+	for id := range elements {
+		ids = append(ids, id)
+	}
+
+	var sum *ecc.Element
+
+	for id, e := range elements {
+		if e == nil {
+			return nil, errNilInput
+		}
+
+		if sum == nil {
+			sum = e.Group().NewElement()
+		} else if e.Group() != sum.Group() {
+			return nil, errGroupMismatch
+		}
+
+		l, err := LagrangeCoefficient(e.Group(), id, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		sum.Add(e.Copy().Multiply(l))
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	selfTestInput = []byte("input data")
+	selfTestDST   = []byte("domain separation tag")
+)
+
+// selfTestVector holds the known answers of a group's self test.
+type selfTestVector struct {
+	generator    string
+	hashToGroup  string
+	hashToScalar string
+}
+
+var selfTestVectors = [maxID - 1]selfTestVector{
+	Ristretto255Sha512 - 1: {
+		generator:    "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		hashToGroup:  "d0f15a907366d66998784ff0148356bb0de24088680fb29d5fbe1a629d743b10",
+		hashToScalar: "7cf9410111022202c71f9d317d6fcd711a84fee5a406063f8376379bbe8a3f03",
+	},
+	P256Sha256 - 1: {
+		generator:    "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296",
+		hashToGroup:  "03536d17bf54e34ebc3926d425e76502b54bc2c393369fc6df0c729a18df667f4c",
+		hashToScalar: "4b51fd1148439c3a30539e87a2a75c63d72f71b74d108184beeb933d259456b9",
+	},
+	P384Sha384 - 1: {
+		generator: "03aa87ca22be8b05378eb1c71ef320ad746e1d3b628ba79b9859f741e082542a385502f25dbf55296c3a545e38" +
+			"72760ab7",
+		hashToGroup: "02777ff137e17b48ab4984de510461af79cf34609ac27f98eb2a4a553f94dbf31bf97b5cf7bac08f60bb8c7ee4" +
+			"74a26202",
+		hashToScalar: "d22b5352caa675f8a2f385236b95cbc1f84b9e34540b3587d6d55bd5032bf51aeb54ccab701c6f05a489b82e" +
+			"c301012d",
+	},
+	P521Sha512 - 1: {
+		generator: "0200c6858e06b70404e9cd9e3ecb662395b4429c648139053fb521f828af606b4d3dbaa14b5e77efe75928fe1d" +
+			"c127a2ffa8de3348b3c1856a429bf97e7e31c2e5bd66",
+		hashToGroup: "0300d24ae26cefe28681d4cf35cf7bea7de3acd15f38ba0b835303c9cdc641d1912566041cb5f6939ad43f0b" +
+			"21e506cecc4a8124a0517dce94f2f1affa47f052f25bf0",
+		hashToScalar: "01f4e5806586dbebd01e85b17da1eb2df4ac678bc8683b9baa5dd5fba6a0f9d1ff5621ed342a90273150fd0" +
+			"95c7abc07f97d202183ec804d063b9fcc0b95daec0614",
+	},
+	Edwards25519Sha512 - 1: {
+		generator:    "5866666666666666666666666666666666666666666666666666666666666666",
+		hashToGroup:  "a2ca6693cdda5b8d204a506fe873ce1d3e58d5b14d04635e13c10ba9d5637f8f",
+		hashToScalar: "90249f56fa61b29fc09b8787d9954a6beba6ca49e25c80f78560ca5458e5b807",
+	},
+	Secp256k1Sha256 - 1: {
+		generator:    "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		hashToGroup:  "0210dca4244e263298000ff1e9f0dfbf1c28333e1f0a252024e8b20b9921cdf3b2",
+		hashToScalar: "782a63d48eace435ac06468208d9a62e3680e4ddc3977c4345b2c6de08258b69",
+	},
+	PallasBLAKE2b512 - 1: {
+		generator:    "0240000000000000000000000000000000224698fc094cf91b992d30ed00000000",
+		hashToGroup:  "03095cd7e1d37f22813cc032f8627ab8f5fc28e497945558e7589ecfea4db90ac4",
+		hashToScalar: "2db596c25d02e065bc09b4e2481e31c6481b65924de822cde420b3576a83eb12",
+	},
+}
+
+func selfTestFailure(test string) error {
+	return fmt.Errorf("%w: %s", internal.ErrSelfTest, test)
+}
+
+// SelfTest runs known-answer tests on the group, and returns an error matching ErrSelfTest if any fails, e.g. as a
+// power-on self test at startup. It verifies the encoding of the generator, that the generator has the group order,
+// and the hash-to-scalar and hash-to-curve outputs on a fixed input. For registered groups, which have no known
+// answers, only the order of the generator is verified.
+func (g Group) SelfTest() error {
+	if !g.Available() {
+		return ErrInvalidGroup
+	}
+
+	// order * G = (order - 1) * G + G = identity
+	if !g.Base().Multiply(g.NewScalar().MinusOne()).Add(g.Base()).IsIdentity() {
+		return selfTestFailure("generator order")
+	}
+
+	// There are no known answers for registered groups.
+	if g.isRegistered() {
+		return nil
+	}
+
+	v := selfTestVectors[g-1]
+
+	if g.Base().Hex() != v.generator {
+		return selfTestFailure("generator encoding")
+	}
+
+	if g.HashToScalar(selfTestInput, selfTestDST).Hex() != v.hashToScalar {
+		return selfTestFailure("hash-to-scalar")
+	}
+
+	if g.HashToGroup(selfTestInput, selfTestDST).Hex() != v.hashToGroup {
+		return selfTestFailure("hash-to-curve")
+	}
+
+	return nil
+}
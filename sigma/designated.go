@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package sigma
+
+import "github.com/bytemare/ecc"
+
+// DesignatedProof is a non-interactive proof of knowledge of the witnesses satisfying a set of linear relations, or of
+// the secret key of the designated verifier, in the way of Jakobsson, Sako, and Impagliazzo. The prover can only know
+// the former, but the verifier knows the latter, so it could have produced the proof itself, and can't convince anyone
+// else with it.
+//
+// The trapdoor branch is a commitment to the challenge under the verifier's key: TrapdoorResponse * G -
+// TrapdoorChallenge * verifierKey can be opened to any challenge with the verifier's secret key. The proof's challenges
+// must sum to the Fiat-Shamir challenge.
+type DesignatedProof struct {
+	Challenge         *ecc.Scalar
+	TrapdoorChallenge *ecc.Scalar
+	TrapdoorResponse  *ecc.Scalar
+	Responses         []*ecc.Scalar
+}
+
+// trapdoorRelation returns the relation verifierKey = sk * G of the trapdoor branch.
+func trapdoorRelation(verifierKey *ecc.Element) Relation {
+	return Relation{LHS: verifierKey, Terms: []Term{{Base: verifierKey.Group().Base()}}}
+}
+
+// designatedChallenge returns the challenge over the relations and the trapdoor branch, with their commitments.
+func designatedChallenge(g ecc.Group, dst string, relations []Relation, commitments []*ecc.Element,
+	verifierKey, trapdoor *ecc.Element, context []byte,
+) *ecc.Scalar {
+	all := append(append(make([]Relation, 0, len(relations)+1), relations...), trapdoorRelation(verifierKey))
+	return challenge(g, dst, all, append(commitments, trapdoor), context)
+}
+
+func checkVerifierKey(g ecc.Group, verifierKey *ecc.Element) error {
+	if err := checkElement(g, verifierKey); err != nil {
+		return err
+	}
+
+	if verifierKey.IsIdentity() {
+		return errIdentityKey
+	}
+
+	return nil
+}
+
+// ProveDesignated returns a proof that the witnesses satisfy the relations, bound to the context, which only
+// convinces the holder of the secret key of the verifier key.
+func ProveDesignated(g ecc.Group, dst string, relations []Relation, witnesses []*ecc.Scalar,
+	verifierKey *ecc.Element, context []byte,
+) (*DesignatedProof, error) {
+	if err := checkRelations(g, relations, len(witnesses)); err != nil {
+		return nil, err
+	}
+
+	if !checkScalars(g, witnesses...) {
+		return nil, errGroupMismatch
+	}
+
+	if err := checkVerifierKey(g, verifierKey); err != nil {
+		return nil, err
+	}
+
+	// The trapdoor branch is simulated with a random challenge, and the statement's branch gets the rest.
+	nonces := randomScalars(g, len(witnesses))
+	p := &DesignatedProof{
+		TrapdoorChallenge: g.NewScalar().Random(),
+		TrapdoorResponse:  g.NewScalar().Random(),
+	}
+
+	trapdoor := recommit([]Relation{trapdoorRelation(verifierKey)}, p.TrapdoorChallenge,
+		[]*ecc.Scalar{p.TrapdoorResponse})[0]
+	c := designatedChallenge(g, dst, relations, commit(g, relations, nonces), verifierKey, trapdoor, context)
+
+	p.Challenge = c.Subtract(p.TrapdoorChallenge)
+	p.Responses = respond(p.Challenge, witnesses, nonces)
+
+	return p, nil
+}
+
+// SimulateDesignated returns a valid designated proof for the relations, bound to the context, using the designated
+// verifier's secret key instead of the witnesses, for any statement. This is what makes designated proofs
+// non-transferable, and the verifier can use it to deny a proof it received.
+func SimulateDesignated(g ecc.Group, dst string, relations []Relation, nWitnesses int, verifierSecret *ecc.Scalar,
+	context []byte,
+) (*DesignatedProof, error) {
+	if err := checkRelations(g, relations, nWitnesses); err != nil {
+		return nil, err
+	}
+
+	if !checkScalars(g, verifierSecret) {
+		return nil, errGroupMismatch
+	}
+
+	verifierKey := g.Base().Multiply(verifierSecret)
+	if err := checkVerifierKey(g, verifierKey); err != nil {
+		return nil, err
+	}
+
+	// The statement's branch is simulated with a random challenge, and the trapdoor branch gets the rest.
+	p := &DesignatedProof{
+		Challenge: g.NewScalar().Random(),
+		Responses: randomScalars(g, nWitnesses),
+	}
+
+	nonce := g.NewScalar().Random()
+	commitments := recommit(relations, p.Challenge, p.Responses)
+	c := designatedChallenge(g, dst, relations, commitments, verifierKey, g.Base().Multiply(nonce), context)
+
+	p.TrapdoorChallenge = c.Subtract(p.Challenge)
+	p.TrapdoorResponse = respond(p.TrapdoorChallenge, []*ecc.Scalar{verifierSecret}, []*ecc.Scalar{nonce})[0]
+
+	return p, nil
+}
+
+// Verify returns whether the proof attests knowledge of the nWitnesses witnesses satisfying the relations, or of the
+// secret key of the verifier key, bound to the context.
+func (p *DesignatedProof) Verify(g ecc.Group, dst string, relations []Relation, nWitnesses int,
+	verifierKey *ecc.Element, context []byte,
+) bool {
+	if p == nil || len(p.Responses) != nWitnesses ||
+		!checkScalars(g, p.Challenge, p.TrapdoorChallenge, p.TrapdoorResponse) || !checkScalars(g, p.Responses...) ||
+		checkRelations(g, relations, nWitnesses) != nil || checkVerifierKey(g, verifierKey) != nil {
+		return false
+	}
+
+	commitments := recommit(relations, p.Challenge, p.Responses)
+	trapdoor := recommit([]Relation{trapdoorRelation(verifierKey)}, p.TrapdoorChallenge,
+		[]*ecc.Scalar{p.TrapdoorResponse})[0]
+	c := designatedChallenge(g, dst, relations, commitments, verifierKey, trapdoor, context)
+
+	return c.Equal(p.Challenge.Copy().Add(p.TrapdoorChallenge))
+}
+
+// Encode returns the byte encoding of the proof, i.e. the concatenation of the challenge, the trapdoor challenge, the
+// trapdoor response, and the responses.
+func (p *DesignatedProof) Encode() []byte {
+	out := p.Challenge.Encode()
+	out = append(out, p.TrapdoorChallenge.Encode()...)
+	out = append(out, p.TrapdoorResponse.Encode()...)
+
+	for _, s := range p.Responses {
+		out = append(out, s.Encode()...)
+	}
+
+	return out
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *DesignatedProof) Decode(g ecc.Group, data []byte) error {
+	scalars, err := decodeScalars(g, data, 3)
+	if err != nil {
+		return err
+	}
+
+	p.Challenge, p.TrapdoorChallenge, p.TrapdoorResponse, p.Responses = scalars[0], scalars[1], scalars[2], scalars[3:]
+
+	return nil
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package sigma implements non-interactive sigma protocols for the knowledge of secret witnesses satisfying a set of
+// linear relations between group elements, i.e. statements of the form lhs = sum(w[i] * base), made non-interactive
+// with the Fiat-Shamir transform.
+//
+// Proofs are publicly verifiable, or, with the designated-verifier variants, only convincing to the holder of a
+// verifier key: these prove the statement or the knowledge of the verifier's secret key, so the verifier could have
+// produced them itself, and they are not transferable.
+//
+// The challenge binds the relations' elements, but not the witness indices of the terms, so the domain separation tag
+// must identify the shape of the statement, i.e. the protocol.
+package sigma
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNilInput       = errors.New("nil input")
+	errGroupMismatch  = errors.New("inputs belong to different groups")
+	errWitnessIndex   = errors.New("a term's witness index is out of range")
+	errProofLength    = errors.New("invalid proof encoding length")
+	errEmptyStatement = errors.New("the statement has no relations")
+	errIdentityKey    = errors.New("the verifier key is the identity")
+)
+
+// Term is the product of the witness at Index and a public Base element.
+type Term struct {
+	Base  *ecc.Element
+	Index int
+}
+
+// Relation is the statement LHS = sum of the terms, over secret witnesses.
+type Relation struct {
+	LHS   *ecc.Element
+	Terms []Term
+}
+
+// Proof is a non-interactive proof of knowledge of the witnesses satisfying a set of linear relations between
+// elements.
+type Proof struct {
+	Challenge *ecc.Scalar
+	Responses []*ecc.Scalar
+}
+
+// checkRelations returns an error if the relations have nil or foreign elements, or terms on witnesses out of range.
+func checkRelations(g ecc.Group, relations []Relation, nWitnesses int) error {
+	if len(relations) == 0 {
+		return errEmptyStatement
+	}
+
+	for _, rel := range relations {
+		if err := checkElement(g, rel.LHS); err != nil {
+			return err
+		}
+
+		for _, t := range rel.Terms {
+			if err := checkElement(g, t.Base); err != nil {
+				return err
+			}
+
+			if t.Index < 0 || t.Index >= nWitnesses {
+				return errWitnessIndex
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkElement(g ecc.Group, e *ecc.Element) error {
+	if e == nil {
+		return errNilInput
+	}
+
+	if e.Group() != g {
+		return errGroupMismatch
+	}
+
+	return nil
+}
+
+func checkScalars(g ecc.Group, scalars ...*ecc.Scalar) bool {
+	for _, s := range scalars {
+		if s == nil || s.Group() != g {
+			return false
+		}
+	}
+
+	return true
+}
+
+// challenge returns the challenge over the relations, the commitments, and the context.
+func challenge(g ecc.Group, dst string, relations []Relation, commitments []*ecc.Element, context []byte) *ecc.Scalar {
+	input := binary.BigEndian.AppendUint64(nil, uint64(len(context)))
+	input = append(input, context...)
+
+	for i, rel := range relations {
+		input = append(input, rel.LHS.Encode()...)
+		for _, t := range rel.Terms {
+			input = append(input, t.Base.Encode()...)
+		}
+
+		input = append(input, commitments[i].Encode()...)
+	}
+
+	return g.HashToScalar(input, []byte(dst))
+}
+
+// commit returns the commitments of the relations for the nonces, i.e. sum(nonce * base) for each.
+func commit(g ecc.Group, relations []Relation, nonces []*ecc.Scalar) []*ecc.Element {
+	commitments := make([]*ecc.Element, len(relations))
+	for i, rel := range relations {
+		commitments[i] = g.NewElement()
+		for _, t := range rel.Terms {
+			commitments[i].Add(t.Base.Copy().Multiply(nonces[t.Index]))
+		}
+	}
+
+	return commitments
+}
+
+// recommit returns the commitments of the relations recomputed from the challenge and the responses, i.e.
+// sum(s * base) - c * lhs for each.
+func recommit(relations []Relation, c *ecc.Scalar, responses []*ecc.Scalar) []*ecc.Element {
+	commitments := make([]*ecc.Element, len(relations))
+	for i, rel := range relations {
+		commitments[i] = rel.LHS.Copy().Multiply(c).Negate()
+		for _, t := range rel.Terms {
+			commitments[i].Add(t.Base.Copy().Multiply(responses[t.Index]))
+		}
+	}
+
+	return commitments
+}
+
+func randomScalars(g ecc.Group, n int) []*ecc.Scalar {
+	s := make([]*ecc.Scalar, n)
+	for i := range s {
+		s[i] = g.NewScalar().Random()
+	}
+
+	return s
+}
+
+// respond returns the responses nonce + c * witness.
+func respond(c *ecc.Scalar, witnesses, nonces []*ecc.Scalar) []*ecc.Scalar {
+	responses := make([]*ecc.Scalar, len(witnesses))
+	for i, w := range witnesses {
+		responses[i] = c.Copy().Multiply(w).Add(nonces[i])
+	}
+
+	return responses
+}
+
+// Prove returns a proof that the witnesses satisfy the relations, bound to the context.
+func Prove(g ecc.Group, dst string, relations []Relation, witnesses []*ecc.Scalar, context []byte) (*Proof, error) {
+	if err := checkRelations(g, relations, len(witnesses)); err != nil {
+		return nil, err
+	}
+
+	if !checkScalars(g, witnesses...) {
+		return nil, errGroupMismatch
+	}
+
+	nonces := randomScalars(g, len(witnesses))
+	c := challenge(g, dst, relations, commit(g, relations, nonces), context)
+
+	return &Proof{Challenge: c, Responses: respond(c, witnesses, nonces)}, nil
+}
+
+// Verify returns whether the proof attests knowledge of the nWitnesses witnesses satisfying the relations, bound to
+// the context.
+func (p *Proof) Verify(g ecc.Group, dst string, relations []Relation, nWitnesses int, context []byte) bool {
+	if p == nil || len(p.Responses) != nWitnesses || !checkScalars(g, p.Challenge) ||
+		!checkScalars(g, p.Responses...) || checkRelations(g, relations, nWitnesses) != nil {
+		return false
+	}
+
+	commitments := recommit(relations, p.Challenge, p.Responses)
+
+	return challenge(g, dst, relations, commitments, context).Equal(p.Challenge)
+}
+
+// Encode returns the byte encoding of the proof, i.e. the concatenation of the challenge and the responses.
+func (p *Proof) Encode() []byte {
+	out := p.Challenge.Encode()
+	for _, s := range p.Responses {
+		out = append(out, s.Encode()...)
+	}
+
+	return out
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *Proof) Decode(g ecc.Group, data []byte) error {
+	scalars, err := decodeScalars(g, data, 1)
+	if err != nil {
+		return err
+	}
+
+	p.Challenge, p.Responses = scalars[0], scalars[1:]
+
+	return nil
+}
+
+// decodeScalars decodes the concatenation of at least minimum scalars.
+func decodeScalars(g ecc.Group, data []byte, minimum int) ([]*ecc.Scalar, error) {
+	sLen := g.ScalarLength()
+	if len(data) < minimum*sLen || len(data)%sLen != 0 {
+		return nil, errProofLength
+	}
+
+	scalars := make([]*ecc.Scalar, len(data)/sLen)
+	for i := range scalars {
+		scalars[i] = g.NewScalar()
+		if err := scalars[i].Decode(data[i*sLen : (i+1)*sLen]); err != nil {
+			return nil, err
+		}
+	}
+
+	return scalars, nil
+}
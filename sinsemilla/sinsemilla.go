@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package sinsemilla implements the Sinsemilla hash and commitment functions over the Pallas group, as specified for
+// Zcash Orchard (section 5.4.1.9 of the Zcash protocol specification). Messages are bit sequences, processed in 10-bit
+// chunks that each select one of 1024 precomputed generators. As in the specification, the only failure, ⊥, is an
+// exceptional case of the incomplete additions of the hash, and Extract_P maps the identity to 0. The generators come
+// from this module's Pallas hash-to-curve, and the package hasn't been checked against the zcash-test-vectors
+// Sinsemilla and Orchard commitment vectors, so interoperability with Orchard isn't claimed.
+package sinsemilla
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	// K is the number of message bits per chunk.
+	K = 10
+
+	// MaxChunks is the maximum number of chunks in a message.
+	MaxChunks = 253
+
+	group     = ecc.PallasBLAKE2b512
+	domainQ   = "z.cash:SinsemillaQ"
+	domainS   = "z.cash:SinsemillaS"
+	tableSize = 1 << K
+)
+
+var (
+	errMessageTooLong = errors.New("message exceeds the maximum number of chunks")
+	errExceptional    = errors.New("incomplete addition hit an exceptional case")
+	errNilInput       = errors.New("nil input")
+	errNotPallas      = errors.New("input is not in the Pallas group")
+
+	tableOnce sync.Once
+	table     [tableSize]*ecc.Element
+)
+
+// groupHash is the Zcash GroupHash^P function, i.e. hash-to-curve with the domain as the DST prefix.
+func groupHash(domain string, msg []byte) *ecc.Element {
+	return group.HashToGroup(msg, []byte(domain+"-"+group.String()))
+}
+
+func initTable() {
+	for j := range tableSize {
+		table[j] = groupHash(domainS, binary.LittleEndian.AppendUint32(nil, uint32(j)))
+	}
+}
+
+// generator returns S(j).
+func generator(j int) *ecc.Element {
+	tableOnce.Do(initTable)
+	return table[j]
+}
+
+// BytesToBits returns the bits of the input in little-endian order within each byte.
+func BytesToBits(data []byte) []bool {
+	bits := make([]bool, 8*len(data))
+	for i := range bits {
+		bits[i] = data[i/8]>>(i%8)&1 == 1
+	}
+
+	return bits
+}
+
+// incompleteAdd returns a + b, or an error if the inputs are an exceptional case of incomplete addition.
+func incompleteAdd(a, b *ecc.Element) (*ecc.Element, error) {
+	if a.IsIdentity() || b.IsIdentity() || a.Equal(b) || a.Copy().Negate().Equal(b) {
+		return nil, errExceptional
+	}
+
+	return a.Copy().Add(b), nil
+}
+
+// HashToPoint returns the Sinsemilla hash of the message bits to a Pallas element, in the domain.
+func HashToPoint(domain string, message []bool) (*ecc.Element, error) {
+	n := (len(message) + K - 1) / K
+	if n > MaxChunks {
+		return nil, errMessageTooLong
+	}
+
+	acc := groupHash(domainQ, []byte(domain))
+
+	for i := range n {
+		var m int
+
+		for b := range K {
+			if idx := i*K + b; idx < len(message) && message[idx] {
+				m |= 1 << b
+			}
+		}
+
+		t, err := incompleteAdd(acc, generator(m))
+		if err != nil {
+			return nil, err
+		}
+
+		if acc, err = incompleteAdd(t, acc); err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
+}
+
+// Hash returns Extract_P of the Sinsemilla hash of the message in the domain, and an error only if the hash hits an
+// exceptional case of incomplete addition, i.e. ⊥.
+func Hash(domain string, message []bool) ([]byte, error) {
+	p, err := HashToPoint(domain, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExtractP(p), nil
+}
+
+// ExtractP returns the x-coordinate of the Pallas element, and the encoding of 0 for the identity.
+func ExtractP(e *ecc.Element) []byte {
+	if e.IsIdentity() {
+		return make([]byte, group.ElementLength()-1)
+	}
+
+	return e.XCoordinate()
+}
+
+// Commit returns the Sinsemilla commitment to the message in the domain, with the randomness r.
+func Commit(domain string, message []bool, r *ecc.Scalar) (*ecc.Element, error) {
+	if r == nil {
+		return nil, errNilInput
+	}
+
+	if r.Group() != group {
+		return nil, errNotPallas
+	}
+
+	p, err := HashToPoint(domain+"-M", message)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupHash(domain+"-r", nil).Multiply(r).Add(p), nil
+}
+
+// ShortCommit returns Extract_P of the Sinsemilla commitment to the message in the domain, with the randomness r,
+// which is 0 if the commitment is the identity.
+func ShortCommit(domain string, message []bool, r *ecc.Scalar) ([]byte, error) {
+	c, err := Commit(domain, message, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExtractP(c), nil
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// NewScalarStream returns an unbounded sequence of scalars deterministically derived from the seed, e.g. for
+// reproducible simulations and test suites, or derandomized protocol variants. The i-th scalar, starting at 0, is
+// exactly g.HashToScalar(seed || I2OSP(i, 8), dst), i.e. the RFC 9380 hash_to_field of the group's suite over the seed
+// followed by the 8-byte big-endian index, so that the sequence is stable across versions, and each scalar can be
+// recomputed on its own. Scalars are only as unpredictable as the seed, which must be secret and uniform for secret
+// scalars. The DST is checked as in HashToScalar, and the seed is copied. The sequence can be iterated concurrently.
+func (g Group) NewScalarStream(seed, dst []byte) iter.Seq[*Scalar] {
+	checkDST(dst)
+
+	seed = slices.Clone(seed)
+	dst = slices.Clone(dst)
+
+	return func(yield func(*Scalar) bool) {
+		for i := uint64(0); ; i++ {
+			input := make([]byte, len(seed)+8)
+			copy(input, seed)
+			binary.BigEndian.PutUint64(input[len(seed):], i)
+
+			if !yield(g.HashToScalar(input, dst)) {
+				return
+			}
+		}
+	}
+}
+
+// seqPrefixLength is the byte size of the big-endian length prefixing each element encoding in a sequence.
+const seqPrefixLength = 2
+
+// EncodeSeq writes the elements to w as a sequence read by DecodeSeq, in which each encoding is prefixed with its
+// 2-byte big-endian length, and returns the number of bytes written. It stops at the first write error, at the first
+// nil element, with internal.ErrParamNilPoint, or at the first element of another group, with an *ErrWrongGroup.
+func (g Group) EncodeSeq(w io.Writer, elements iter.Seq[*Element]) (int64, error) {
+	var written int64
+
+	for e := range elements {
+		if e == nil {
+			return written, internal.ErrParamNilPoint
+		}
+
+		if err := checkGroup(g, e.Group()); err != nil {
+			return written, err
+		}
+
+		enc := e.Encode()
+		frame := binary.BigEndian.AppendUint16(make([]byte, 0, seqPrefixLength+len(enc)), uint16(len(enc)))
+
+		n, err := w.Write(append(frame, enc...))
+		written += int64(n)
+
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// DecodeSeq returns a sequence of the elements decoded from the reader, as written by EncodeSeq, e.g. in large proof or
+// key files. Each encoding is prefixed with its 2-byte big-endian length, so that the stream is self-delimiting, and
+// each element is checked as by Decode. The sequence ends at the end of the stream, or after yielding the first error,
+// which is io.ErrUnexpectedEOF if the stream ends in the middle of a length or an encoding, and wraps
+// ErrInvalidEncoding if a length is zero or exceeds the group's element length.
+func (g Group) DecodeSeq(r io.Reader) iter.Seq2[*Element, error] {
+	return g.DecodeSeqContext(context.Background(), r)
+}
+
+// DecodeSeqContext is the same as DecodeSeq, but checks the context before each element, and ends the sequence by
+// yielding the context's error if it is done before the end of the stream.
+func (g Group) DecodeSeqContext(ctx context.Context, r io.Reader) iter.Seq2[*Element, error] {
+	return func(yield func(*Element, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			e, err := g.readSeqElement(r)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readSeqElement returns the next element of a sequence written by EncodeSeq, or io.EOF at the end of the stream.
+func (g Group) readSeqElement(r io.Reader) (*Element, error) {
+	var prefix [seqPrefixLength]byte
+
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+
+	length := int(binary.BigEndian.Uint16(prefix[:]))
+	if length == 0 || length > g.ElementLength() {
+		return nil, newInvalidEncoding(g, "DecodeSeq", internal.ErrDecodingInvalidLength)
+	}
+
+	enc := make([]byte, length)
+	if _, err := io.ReadFull(r, enc); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	e := g.NewElement()
+	if err := e.Decode(enc); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HashToCurveMode identifies the hash-to-curve encoding of an RFC 9380 suite.
+type HashToCurveMode byte
+
+const (
+	// RandomOracle identifies the hash_to_curve encoding of "_RO_" suites, as implemented by HashToGroup.
+	RandomOracle HashToCurveMode = 1 + iota
+
+	// NonUniform identifies the encode_to_curve encoding of "_NU_" suites, as implemented by EncodeToGroup.
+	NonUniform
+
+	suiteRO = "_RO_"
+	suiteNU = "_NU_"
+)
+
+// Suite is an RFC 9380 hash-to-curve suite: a group and an encoding mode.
+type Suite struct {
+	Group Group
+	Mode  HashToCurveMode
+}
+
+var (
+	suitesMu  sync.RWMutex
+	suites    map[string]Suite
+	suiteList []Suite
+)
+
+// hasNonUniform reports whether the group has a distinct encode_to_curve suite. Ristretto255 only defines the random
+// oracle encoding, to which its EncodeToGroup falls back, and registered groups might not follow the RFC 9380 naming.
+func (g Group) hasNonUniform() bool {
+	return g != Ristretto255Sha512 && strings.HasSuffix(g.String(), suiteRO)
+}
+
+// loadSuites builds the registry from the ciphersuite strings of the available groups.
+func loadSuites() {
+	suites = make(map[string]Suite)
+	suiteList = nil
+
+	for i := 1; i < maxGroups; i++ {
+		g := Group(i)
+		if !g.Available() {
+			continue
+		}
+
+		modes := []HashToCurveMode{RandomOracle}
+		if g.hasNonUniform() {
+			modes = append(modes, NonUniform)
+		}
+
+		for _, m := range modes {
+			s := Suite{Group: g, Mode: m}
+			suites[s.String()] = s
+			suiteList = append(suiteList, s)
+		}
+	}
+}
+
+// loadedSuites returns the suite registry, which is built on first use, and rebuilt after a group is registered. The
+// returned values are never modified.
+func loadedSuites() (map[string]Suite, []Suite) {
+	suitesMu.RLock()
+	s, l := suites, suiteList
+	suitesMu.RUnlock()
+
+	if s != nil {
+		return s, l
+	}
+
+	suitesMu.Lock()
+	defer suitesMu.Unlock()
+
+	if suites == nil {
+		loadSuites()
+	}
+
+	return suites, suiteList
+}
+
+// resetSuites makes the next lookup rebuild the suite registry.
+func resetSuites() {
+	suitesMu.Lock()
+	suites, suiteList = nil, nil
+	suitesMu.Unlock()
+}
+
+// LookupSuite returns the suite with the given RFC 9380 identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_", and an error
+// wrapping ErrUnknownSuite if no available group implements it.
+func LookupSuite(id string) (Suite, error) {
+	all, _ := loadedSuites()
+
+	s, ok := all[id]
+	if !ok {
+		return Suite{}, fmt.Errorf("%w: %q", ErrUnknownSuite, id)
+	}
+
+	return s, nil
+}
+
+// Suites returns all the suites implemented by the available groups, in group order with the random oracle suite
+// first, e.g. to advertise supported suites during negotiation.
+func Suites() []Suite {
+	_, list := loadedSuites()
+	return append([]Suite(nil), list...)
+}
+
+// String returns the RFC 9380 identifier of the suite.
+func (s Suite) String() string {
+	id := s.Group.String()
+	if s.Mode == NonUniform {
+		return strings.TrimSuffix(id, suiteRO) + suiteNU
+	}
+
+	return id
+}
+
+// Hash maps the input to an element with the encoding of the suite, i.e. HashToGroup for random oracle suites and
+// EncodeToGroup for non-uniform suites.
+func (s Suite) Hash(input, dst []byte) *Element {
+	if s.Mode == NonUniform {
+		return s.Group.EncodeToGroup(input, dst)
+	}
+
+	return s.Group.HashToGroup(input, dst)
+}
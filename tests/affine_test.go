@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestAffineElement(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+		a := e.Affine()
+		s := g.NewScalar().Random()
+
+		if a.Group() != g || !a.EqualElement(e) || !a.Element().Equal(e) || a.IsIdentity() {
+			t.Fatal("unexpected affine element")
+		}
+
+		if !bytes.Equal(a.Encode(), e.Encode()) || a.Hex() != e.Hex() {
+			t.Fatal("unexpected affine element encoding")
+		}
+
+		if !a.Multiply(s).Equal(e.Copy().Multiply(s)) {
+			t.Fatal("unexpected multiplication result")
+		}
+
+		// The mixed addition matches the generic one, including on doubling, inverses, and the identity.
+		for _, p := range []*ecc.Element{g.NewElement(), g.Base(), e.Copy(), e.Copy().Negate()} {
+			if !p.Copy().AddAffine(a).Equal(p.Copy().Add(e)) {
+				t.Fatal("unexpected addition result")
+			}
+		}
+
+		if !e.Copy().AddAffine(nil).Equal(e) || !g.NewElement().Affine().IsIdentity() {
+			t.Fatal("unexpected result with nil or identity arguments")
+		}
+
+		decoded, err := g.NewAffineElement(e.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(a) || decoded.Equal(g.Base().Affine()) || decoded.Equal(nil) || a.EqualElement(nil) {
+			t.Fatal("unexpected affine element equality")
+		}
+
+		if _, err = g.NewAffineElement(nil); err == nil {
+			t.Fatal("expected error on invalid encoding")
+		}
+
+		// The affine element is immutable.
+		enc := a.Encode()
+		enc[0] ^= 0xff
+		a.Element().Add(g.Base())
+
+		if !a.EqualElement(e) {
+			t.Fatal("the affine element was modified")
+		}
+	})
+}
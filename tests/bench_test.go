@@ -11,6 +11,8 @@ package ecc_test
 import (
 	"bytes"
 	"testing"
+
+	"github.com/bytemare/ecc"
 )
 
 func benchAll(b *testing.B, f func(*testing.B, *testGroup)) {
@@ -82,6 +84,40 @@ func BenchmarkScalarMult(b *testing.B) {
 	})
 }
 
+func BenchmarkDoubleScalarMultVartime(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		g := group.group
+		s1, s2 := g.NewScalar().Random(), g.NewScalar().Random()
+		q := g.Base().Multiply(g.NewScalar().Random())
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = g.DoubleScalarMultVartime(s1, g.Base(), s2, q)
+		}
+	})
+}
+
+func BenchmarkMultiScalarMultVartime(b *testing.B) {
+	const n = 64
+
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		g := group.group
+		scalars := make([]*ecc.Scalar, n)
+		elements := make([]*ecc.Element, n)
+
+		for i := range n {
+			scalars[i] = g.NewScalar().Random()
+			elements[i] = g.Base().Multiply(g.NewScalar().Random())
+		}
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = g.MultiScalarMultVartime(scalars, elements)
+		}
+	})
+}
+
 func BenchmarkMarshalUnmarshal(b *testing.B) {
 	benchAll(b, func(b *testing.B, group *testGroup) {
 		pub := group.group.Base().Multiply(group.group.NewScalar().Random())
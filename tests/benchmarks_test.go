@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc/benchmarks"
+)
+
+func TestBenchmarks(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		results := benchmarks.All(group.group, 2, 3)
+		if len(results) != 6 {
+			t.Fatalf("unexpected number of results %d", len(results))
+		}
+
+		for _, res := range results {
+			if res.Group != group.group || res.Iterations != 2 || res.Total <= 0 || res.PerOp() <= 0 {
+				t.Fatalf("unexpected result %v", res)
+			}
+
+			if res.Workload == benchmarks.MSM {
+				if res.Size != 3 || !strings.Contains(res.String(), "MSM/3") {
+					t.Fatalf("unexpected MSM result %v", res)
+				}
+			} else if res.Size != 1 {
+				t.Fatalf("unexpected size for %s", res.Workload)
+			}
+		}
+
+		if len(benchmarks.All(group.group, 1)) != 5+len(benchmarks.DefaultMSMSizes) {
+			t.Fatal("expected the default MSM sizes")
+		}
+	})
+
+	if res := benchmarks.RunKeyGen(testTable[0].group, 0); res.Iterations != 0 || res.PerOp() != 0 {
+		t.Fatal("expected an empty result without iterations")
+	}
+}
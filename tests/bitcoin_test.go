@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bitcoin"
+)
+
+// From the BIP341 wallet test vectors, key-path only output.
+const (
+	taprootPrivateKey  = "6b973d88838f27366ed61c9ad6367663045cb456e28335c109e30717ae0c6baa"
+	taprootInternalKey = "d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d"
+	taprootTweakedKey  = "53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343"
+	taprootTweakedPriv = "2405b971772ad26915c8dcdf10f238753a9b837e5f8e6a86fd7c0cce5b7296d9"
+)
+
+func TestTaproot_TweakPubKey(t *testing.T) {
+	internalKey, _ := hex.DecodeString(taprootInternalKey)
+
+	outputKey, _, err := bitcoin.TaprootTweakPubKey(internalKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(outputKey) != taprootTweakedKey {
+		t.Fatalf("unexpected output key %x", outputKey)
+	}
+
+	if _, _, err = bitcoin.TaprootTweakPubKey(internalKey[1:], nil); err == nil {
+		t.Fatal("expected error on invalid internal key length")
+	}
+}
+
+func TestTaproot_TweakPrivKey(t *testing.T) {
+	sk := decodeScalar(t, ecc.Secp256k1Sha256, taprootPrivateKey)
+
+	if hex.EncodeToString(bitcoin.XOnly(ecc.Secp256k1Sha256.Base().Multiply(sk))) != taprootInternalKey {
+		t.Fatal("unexpected internal key")
+	}
+
+	tweaked, err := bitcoin.TaprootTweakPrivKey(sk, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tweaked.Hex() != taprootTweakedPriv {
+		t.Fatalf("unexpected tweaked private key %s", tweaked.Hex())
+	}
+
+	// The tweaked private key must match the output key for any private key, whatever the parity of its public key.
+	for range 4 {
+		sk = ecc.Secp256k1Sha256.NewScalar().Random()
+		root := bitcoin.TaggedHash("TapBranch", sk.Encode())
+
+		tweaked, err = bitcoin.TaprootTweakPrivKey(sk, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputKey, oddY, err := bitcoin.TaprootTweakPubKey(bitcoin.XOnly(ecc.Secp256k1Sha256.Base().Multiply(sk)), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q := ecc.Secp256k1Sha256.Base().Multiply(tweaked)
+		if hex.EncodeToString(bitcoin.XOnly(q)) != hex.EncodeToString(outputKey) || bitcoin.HasEvenY(q) == oddY {
+			t.Fatal("tweaked private key does not match the output key")
+		}
+	}
+
+	if _, err = bitcoin.TaprootTweakPrivKey(ecc.P256Sha256.NewScalar().Random(), nil); err == nil {
+		t.Fatal("expected error on wrong group")
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	xblake2b "golang.org/x/crypto/blake2b"
+
+	"github.com/bytemare/ecc/internal/blake2b"
+)
+
+// blake2bInput returns n bytes of a fixed pattern.
+func blake2bInput(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+
+	return b
+}
+
+func TestBLAKE2b_RFC7693(t *testing.T) {
+	// RFC 7693, Appendix A: BLAKE2b-512("abc"), which has an all-zero personalization.
+	expected := "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d1" +
+		"7d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"
+
+	digest := blake2b.Sum512Personal(new([16]byte), []byte("abc"))
+	if hex.EncodeToString(digest[:]) != expected {
+		t.Fatalf("unexpected digest %x", digest)
+	}
+
+	// Without personalization, the digests match golang.org/x/crypto/blake2b, including at block boundaries.
+	for _, n := range []int{0, 1, 127, 128, 129, 255, 256, 257, 1000} {
+		input := blake2bInput(n)
+
+		digest = blake2b.Sum512Personal(new([16]byte), input)
+		if digest != xblake2b.Sum512(input) {
+			t.Fatalf("unexpected digest for %d bytes", n)
+		}
+	}
+}
+
+func TestBLAKE2b_Personalization(t *testing.T) {
+	// Digests of hashlib.blake2b(input, digest_size=64, person=b"Zcash_RedPallasH") in Python.
+	vectors := []struct {
+		expected string
+		input    []byte
+	}{
+		{
+			input: nil,
+			expected: "09ea8769e6e3cb8964e328917618b589537c31e587a4b5340e0803cff78d030f" +
+				"e8ab7be7654d1755301446b03f10a6592e7b25e20504321eca54e37b400fe8e1",
+		},
+		{
+			input: []byte("abc"),
+			expected: "cde50bb36c7f5a64bf745925993efff16ac438e26d2a63050eecb27fc1d54591" +
+				"1f8c19d228a0ce057537afa07ef011925ce330993f9c85b8298524ebc7cf9280",
+		},
+		{
+			input: blake2bInput(200),
+			expected: "3a4820c619b3dd3962fa0f60d687a1917db8d1742b2b83c59c8a28fc7fb2d4ba" +
+				"c6ee5d54053c6600471d3b5d808c3c1930a6a1f8f54f46c58908730e9470c794",
+		},
+		{
+			input: blake2bInput(1000),
+			expected: "4057c86cf99c01106709b84bff4c0d066adcbb1586eb67a725cd3e66f8f2cdee" +
+				"f93921ab81a34d27e9617fb3befe6ba7ef4e2581f64576f92ac4ca3d93a10e59",
+		},
+	}
+
+	personal := [16]byte([]byte("Zcash_RedPallasH"))
+
+	for _, v := range vectors {
+		digest := blake2b.Sum512Personal(&personal, v.input)
+		if hex.EncodeToString(digest[:]) != v.expected {
+			t.Fatalf("unexpected digest for %d bytes: %x", len(v.input), digest)
+		}
+	}
+}
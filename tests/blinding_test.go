@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/blinding"
+)
+
+func TestBlinding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+		tweak := blinding.DeriveFactor(pk, []byte("period 42"))
+
+		bsk, err := blinding.BlindScalar(sk, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bpk, err := blinding.BlindElement(pk, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !group.group.Base().Multiply(bsk).Equal(bpk) {
+			t.Fatal("blinded secret and public keys don't match")
+		}
+
+		usk, err := blinding.UnblindScalar(bsk, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !usk.Equal(sk) {
+			t.Fatal("unblinding did not return the original secret key")
+		}
+
+		proof, err := blinding.Prove(pk, bpk, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(blinding.Proof)
+		if err = decoded.Decode(group.group, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Verify(pk, bpk) {
+			t.Fatal("valid proof did not verify")
+		}
+
+		if decoded.Verify(pk, group.group.Base()) {
+			t.Fatal("proof verified for another blinded key")
+		}
+
+		if _, err = blinding.BlindScalar(sk, group.group.NewScalar()); err == nil {
+			t.Fatal("expected error on zero tweak")
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bulletproofs"
+	"github.com/bytemare/ecc/pedersen"
+	"github.com/bytemare/ecc/transcript"
+)
+
+var testBulletproofsDST = []byte("ECC-Bulletproofs-Test")
+
+func TestBulletproofs_InnerProduct(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		gens := bulletproofs.Setup(g, 8, testBulletproofsDST)
+		a, b := make([]*ecc.Scalar, 8), make([]*ecc.Scalar, 8)
+		commitment := g.NewElement()
+		ab := g.NewScalar()
+
+		for i := range a {
+			a[i], b[i] = g.NewScalar().Random(), g.NewScalar().Random()
+			commitment.Add(gens.G[i].Copy().Multiply(a[i])).Add(gens.H[i].Copy().Multiply(b[i]))
+			ab.MulAdd(a[i], b[i], ab)
+		}
+
+		commitment.Add(gens.U.Copy().Multiply(ab))
+
+		proof, err := bulletproofs.ProveInnerProduct(transcript.New(g, "test"), gens.G, gens.H, gens.U, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(bulletproofs.InnerProductProof)
+		if err = decoded.Decode(g, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Verify(transcript.New(g, "test"), gens.G, gens.H, gens.U, commitment) {
+			t.Fatal("valid inner-product proof did not verify")
+		}
+
+		if decoded.Verify(transcript.New(g, "other"), gens.G, gens.H, gens.U, commitment) ||
+			decoded.Verify(transcript.New(g, "test"), gens.G, gens.H, gens.U, commitment.Double()) ||
+			decoded.Verify(transcript.New(g, "test"), gens.G[:4], gens.H[:4], gens.U, commitment) {
+			t.Fatal("inner-product proof verified for wrong inputs")
+		}
+
+		_, err = bulletproofs.ProveInnerProduct(transcript.New(g, "test"), gens.G[:6], gens.H[:6], gens.U, a[:6], b[:6])
+		if err == nil {
+			t.Fatal("expected error on non power of two length")
+		}
+	})
+}
+
+func TestBulletproofs_RangeProof(t *testing.T) {
+	context := []byte("context")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		gens := bulletproofs.Setup(g, 128, testBulletproofsDST)
+
+		for _, test := range []struct {
+			values []uint64
+			bits   int
+		}{
+			{values: []uint64{math.MaxUint64}, bits: 64},
+			{values: []uint64{0, 1<<32 - 1}, bits: 32},
+			{values: []uint64{3, 200, 0, 255}, bits: 8},
+		} {
+			blinds := make([]*ecc.Scalar, len(test.values))
+			for i := range blinds {
+				blinds[i] = g.NewScalar().Random()
+			}
+
+			proof, commitments, err := bulletproofs.Prove(gens, test.values, blinds, test.bits, context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := new(bulletproofs.RangeProof)
+			if err = decoded.Decode(g, proof.Encode()); err != nil {
+				t.Fatal(err)
+			}
+
+			if !decoded.Verify(gens, commitments, test.bits, context) {
+				t.Fatalf("valid %d-bit range proof did not verify", test.bits)
+			}
+
+			if decoded.Verify(gens, commitments, test.bits, []byte("other")) ||
+				decoded.Verify(gens, commitments, 2*test.bits, context) {
+				t.Fatal("range proof verified for wrong inputs")
+			}
+
+			commitments[0] = commitments[0].Copy().Add(gens.B)
+			if decoded.Verify(gens, commitments, test.bits, context) {
+				t.Fatal("range proof verified for another commitment")
+			}
+		}
+
+		// Value commitments are Pedersen commitments.
+		blind := g.NewScalar().Random()
+		pc, _ := pedersen.Setup(g, 1, testBulletproofsDST).VectorCommit([]*ecc.Scalar{g.NewScalar().SetUInt64(42)}, blind)
+
+		if !gens.Commit(42, blind).Equal(pc) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, _, err := bulletproofs.Prove(gens, []uint64{256}, []*ecc.Scalar{blind}, 8, context); err == nil {
+			t.Fatal("expected error on out of range value")
+		}
+
+		if _, _, err := bulletproofs.Prove(gens, []uint64{1, 2, 3}, make([]*ecc.Scalar, 3), 8, context); err == nil {
+			t.Fatal("expected error on non power of two number of values")
+		}
+
+		if _, _, err := bulletproofs.Prove(gens, []uint64{1}, []*ecc.Scalar{blind}, 12, context); err == nil {
+			t.Fatal("expected error on invalid number of bits")
+		}
+
+		if _, _, err := bulletproofs.Prove(gens, []uint64{1, 2, 3, 4}, make([]*ecc.Scalar, 4), 64, context); err == nil {
+			t.Fatal("expected error on insufficient generators")
+		}
+	})
+}
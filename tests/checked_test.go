@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func alternativeGroup(g ecc.Group) ecc.Group {
+	if g == ecc.Edwards25519Sha512 {
+		return ecc.PallasBLAKE2b512
+	}
+
+	return ecc.Edwards25519Sha512
+}
+
+func TestChecked_Element(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		other := alternativeGroup(group.group)
+		e := group.group.Base()
+		checked := e.Checked()
+
+		_, err := checked.Add(other.Base())
+
+		var wrongGroup *ecc.ErrWrongGroup
+		if !errors.As(err, &wrongGroup) || wrongGroup.Expected != group.group || wrongGroup.Got != other {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if _, err = checked.Subtract(other.Base()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if _, err = checked.Multiply(other.NewScalar()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if _, err = checked.Equal(other.Base()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if _, err = checked.Set(other.Base()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if !e.Equal(group.group.Base()) {
+			t.Fatal("the element was modified on error")
+		}
+
+		// Same group operations behave as the unchecked ones.
+		r, err := checked.Add(group.group.Base())
+		if err != nil || r != e || !r.Equal(group.group.Base().Double()) {
+			t.Fatalf("unexpected result: %v", err)
+		}
+	})
+}
+
+func TestChecked_Scalar(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		other := alternativeGroup(group.group).NewScalar().One()
+		s := group.group.NewScalar().One()
+		checked := s.Checked()
+
+		for _, f := range []func(*ecc.Scalar) (*ecc.Scalar, error){
+			checked.Add, checked.Subtract, checked.Multiply, checked.Pow, checked.Set,
+		} {
+			if _, err := f(other); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+				t.Fatalf("expected a wrong group error, got %v", err)
+			}
+		}
+
+		if _, err := checked.Equal(other); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if _, err := checked.LessOrEqual(other); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		if !s.Equal(group.group.NewScalar().One()) {
+			t.Fatal("the scalar was modified on error")
+		}
+
+		r, err := checked.Add(group.group.NewScalar().One())
+		if err != nil || r != s || !r.Equal(group.group.NewScalar().SetUInt64(2)) {
+			t.Fatalf("unexpected result: %v", err)
+		}
+	})
+}
@@ -0,0 +1,369 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+func isNIST(g ecc.Group) bool {
+	return g == ecc.P256Sha256 || g == ecc.P384Sha384 || g == ecc.P521Sha512
+}
+
+func TestCompat_ECDH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		curve, err := compat.ECDHCurve(group.group)
+		if !isNIST(group.group) && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			testCompatX25519(t, curve)
+			return
+		}
+
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		ecdhSK, err := compat.ScalarToECDHPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ecdhPK, err := compat.ElementToECDHPublicKey(pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdhSK.PublicKey().Equal(ecdhPK) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		s, err := compat.ECDHPrivateKeyToScalar(group.group, ecdhSK)
+		if err != nil || !s.Equal(sk) {
+			t.Fatalf("unexpected private key conversion: %v", err)
+		}
+
+		e, err := compat.ECDHPublicKeyToElement(group.group, ecdhPK)
+		if err != nil || !e.Equal(pk) {
+			t.Fatalf("unexpected public key conversion: %v", err)
+		}
+
+		// The shared secrets of both implementations match.
+		peer, err := curve.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shared, err := ecdhSK.ECDH(peer.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		peerElement, err := compat.ECDHPublicKeyToElement(group.group, peer.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(peerElement.Multiply(sk).XCoordinate(), shared) {
+			t.Fatal("unexpected shared secret")
+		}
+
+		other := ecdh.P256()
+		if group.group == ecc.P256Sha256 {
+			other = ecdh.P384()
+		}
+
+		otherKey, _ := other.GenerateKey(rand.Reader)
+		if _, err = compat.ECDHPublicKeyToElement(group.group, otherKey.PublicKey()); err == nil {
+			t.Fatal("expected error on key of another curve")
+		}
+	})
+}
+
+// testCompatX25519 verifies that the public key of an X25519 private key k matches k * B in Edwards25519.
+func testCompatX25519(t *testing.T, curve ecdh.Curve) {
+	sk, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clamped := sk.Bytes()
+	clamped[0] &= 248
+	clamped[31] &= 127
+	clamped[31] |= 64
+
+	slices.Reverse(clamped)
+	k := new(big.Int).SetBytes(clamped)
+	order := slices.Clone(ecc.Edwards25519Sha512.Order())
+	slices.Reverse(order)
+	k.Mod(k, new(big.Int).SetBytes(order))
+
+	encoded := k.FillBytes(make([]byte, 32))
+	slices.Reverse(encoded)
+
+	s := ecc.Edwards25519Sha512.NewScalar()
+	if err = s.Decode(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	pk, err := compat.ElementToECDHPublicKey(ecc.Edwards25519Sha512.Base().Multiply(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pk.Equal(sk.PublicKey()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if _, err = compat.ECDHPublicKeyToElement(ecc.Edwards25519Sha512, pk); err == nil {
+		t.Fatal("expected error on X25519 public key conversion")
+	}
+
+	if _, err = compat.ScalarToECDHPrivateKey(s); err == nil {
+		t.Fatal("expected error on X25519 private key conversion")
+	}
+}
+
+func TestCompat_EllipticCurve(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		curve, err := compat.EllipticCurve(group.group)
+		if group.group == ecc.Ristretto255Sha512 || group.group == ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params := curve.Params()
+		if !curve.IsOnCurve(params.Gx, params.Gy) {
+			t.Fatal("expected the generator to be on the curve")
+		}
+
+		k := group.group.NewScalar().Random()
+		x, y := curve.ScalarBaseMult(k.Encode())
+
+		if e, err := curve.ToElement(x, y); err != nil || !e.Equal(group.group.Base().Multiply(k)) {
+			t.Fatalf("unexpected scalar base multiplication: %v", err)
+		}
+
+		if isNIST(group.group) {
+			sk, err := compat.ScalarToECDHPrivateKey(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			size := (params.BitSize + 7) / 8
+			expected := append([]byte{4}, x.FillBytes(make([]byte, size))...)
+			expected = append(expected, y.FillBytes(make([]byte, size))...)
+
+			if !bytes.Equal(sk.PublicKey().Bytes(), expected) {
+				t.Fatal("unexpected coordinates")
+			}
+		}
+
+		if x2, y2 := curve.ScalarMult(params.Gx, params.Gy, k.Encode()); x2.Cmp(x) != 0 || y2.Cmp(y) != 0 {
+			t.Fatal("unexpected scalar multiplication")
+		}
+
+		two := []byte{2}
+		dx, dy := curve.Double(params.Gx, params.Gy)
+		ax, ay := curve.Add(params.Gx, params.Gy, params.Gx, params.Gy)
+		bx, by := curve.ScalarBaseMult(two)
+
+		if dx.Cmp(ax) != 0 || dy.Cmp(ay) != 0 || dx.Cmp(bx) != 0 || dy.Cmp(by) != 0 {
+			t.Fatal("unexpected doubling")
+		}
+
+		// The point at infinity is (0, 0).
+		if ix, iy := curve.ScalarBaseMult(params.N.Bytes()); ix.Sign() != 0 || iy.Sign() != 0 {
+			t.Fatal("expected the point at infinity")
+		}
+
+		if sx, sy := curve.Add(x, y, new(big.Int), new(big.Int)); sx.Cmp(x) != 0 || sy.Cmp(y) != 0 {
+			t.Fatal("unexpected addition with the point at infinity")
+		}
+
+		offY := new(big.Int).Add(params.Gy, big.NewInt(1))
+		if curve.IsOnCurve(params.Gx, offY) {
+			t.Fatal("expected point not to be on the curve")
+		}
+
+		if err := testPanic("off-curve point", nil, func() {
+			_, _ = curve.Double(params.Gx, offY)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestCompat_Signer(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+
+		signer, err := compat.NewPrivateKey(sk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message := []byte("message")
+
+		if group.group == ecc.Edwards25519Sha512 {
+			sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !ed25519.Verify(signer.Public().(ed25519.PublicKey), message, sig) {
+				t.Fatal("invalid Ed25519 signature")
+			}
+
+			if _, err = signer.Sign(rand.Reader, message, crypto.SHA512); err == nil {
+				t.Fatal("expected error on pre-hashed Ed25519")
+			}
+		} else {
+			h := crypto.SHA256.New()
+			_, _ = h.Write(message)
+			digest := h.Sum(nil)
+
+			sig, err := signer.Sign(nil, digest, crypto.SHA256)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest, sig) {
+				t.Fatal("invalid ECDSA signature")
+			}
+
+			digest[0] ^= 1
+			if ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest, sig) {
+				t.Fatal("unexpected valid ECDSA signature")
+			}
+		}
+
+		if !isNIST(group.group) && group.group != ecc.Edwards25519Sha512 {
+			return
+		}
+
+		template := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if _, err := compat.NewPrivateKey(ecc.P256Sha256.NewScalar()); err == nil {
+		t.Fatal("expected error on zero key")
+	}
+}
+
+func TestEdwardsInterop(t *testing.T) {
+	g := ecc.Edwards25519Sha512
+	s := g.NewScalar().Random()
+	e := g.Base().Multiply(s)
+
+	p, err := compat.ElementToEdwardsPoint(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	es, err := compat.ScalarToEdwardsScalar(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(p.Bytes(), e.Encode()) || !bytes.Equal(es.Bytes(), s.Encode()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Operations on either side match.
+	p.ScalarMult(es, p)
+
+	back, err := compat.EdwardsPointToElement(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !back.Equal(e.Copy().Multiply(s)) || !compat.EdwardsScalarToScalar(es).Equal(s) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The conversions copy their input.
+	p.Set(edwards25519.NewIdentityPoint())
+	if back.IsIdentity() {
+		t.Fatal("expected a copy")
+	}
+
+	// A point of order 8 is rejected.
+	torsion, err := new(edwards25519.Point).SetBytes([]byte{
+		0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0, 0x45, 0xc3, 0xf4, 0x89, 0xf2, 0xef, 0x98, 0xf0,
+		0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6, 0x33, 0x39, 0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x05,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = compat.EdwardsPointToElement(torsion); err == nil {
+		t.Fatal("expected error on torsion point")
+	}
+
+	if _, err = compat.ElementToEdwardsPoint(ecc.P256Sha256.Base()); err == nil {
+		t.Fatal("expected error on non-Edwards element")
+	}
+
+	if _, err = compat.ScalarToEdwardsScalar(ecc.P256Sha256.NewScalar()); err == nil {
+		t.Fatal("expected error on non-Edwards scalar")
+	}
+}
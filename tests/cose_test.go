@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestCOSE_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		public, err := encoding.MarshalCOSEPublicKey(pk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := encoding.ParseCOSEPublicKey(public)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, _, err = encoding.ParseCOSEPrivateKey(public); err == nil {
+			t.Fatal("expected error on public key")
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			if _, err = encoding.MarshalCOSEPrivateKey(sk); err == nil {
+				t.Fatal("expected error on Ed25519 private key")
+			}
+
+			return
+		}
+
+		private, err := encoding.MarshalCOSEPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, p, err := encoding.ParseCOSEPrivateKey(private)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(sk) || !p.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, err = encoding.ParseCOSEPublicKey(append(public, 0)); err == nil {
+			t.Fatal("expected error on trailing data")
+		}
+	})
+}
+
+// TestCOSE_WebAuthn decodes a credential public key as found in WebAuthn attestations, with the ES256 algorithm.
+func TestCOSE_WebAuthn(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, y := key.X.FillBytes(make([]byte, 32)), key.Y.FillBytes(make([]byte, 32))
+
+	// {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	data := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	data = append(data, x...)
+	data = append(data, 0x22, 0x58, 0x20)
+	data = append(data, y...)
+
+	pk, err := encoding.ParseCOSEPublicKey(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := append([]byte{0x02 | byte(key.Y.Bit(0))}, x...)
+	if !bytes.Equal(pk.Encode(), expected) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The same key with a compressed point, {1: 2, -1: 1, -2: x, -3: sign}.
+	compressed := append([]byte{0xa4, 0x01, 0x02, 0x20, 0x01, 0x21, 0x58, 0x20}, x...)
+	compressed = append(compressed, 0x22, 0xf4|byte(key.Y.Bit(0)))
+
+	if pk, err = encoding.ParseCOSEPublicKey(compressed); err != nil || !bytes.Equal(pk.Encode(), expected) {
+		t.Fatalf("unexpected compressed key decoding: %v", err)
+	}
+}
+
+// TestCOSE_Ed25519 uses the Ed25519 key of RFC 8037, appendix A.
+func TestCOSE_Ed25519(t *testing.T) {
+	x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	d, _ := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+
+	// {1: 1, -1: 6, -2: x, -4: d}
+	data := append([]byte{0xa4, 0x01, 0x01, 0x20, 0x06, 0x21, 0x58, 0x20}, x...)
+	data = append(data, 0x23, 0x58, 0x20)
+	data = append(data, d...)
+
+	_, pk, err := encoding.ParseCOSEPrivateKey(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public, err := encoding.MarshalCOSEPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public key is the same map, without d.
+	if public[0] != 0xa3 || !bytes.Equal(public[1:], data[1:len(data)-35]) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// A mismatching public key must be rejected.
+	data[8] ^= 1
+	if _, _, err = encoding.ParseCOSEPrivateKey(data); err == nil {
+		t.Fatal("expected error on mismatching public key")
+	}
+}
+
+func TestCOSE_InvalidCBOR(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{0xbf, 0x01, 0x02, 0xff},             // indefinite length map
+		{0xa2, 0x01, 0x02, 0x01, 0x02},       // duplicate label
+		{0xa1, 0x01},                         // truncated
+		{0x82, 0x01, 0x02},                   // array
+		{0xa2, 0x01, 0x02, 0x20, 0x19},       // truncated argument
+		{0xa2, 0x01, 0x05, 0x20, 0x01},       // unknown key type
+		{0xbb, 0xff, 0xff, 0xff, 0xff, 0xff}, // huge map
+	} {
+		if _, err := encoding.ParseCOSEPublicKey(data); err == nil {
+			t.Fatalf("expected error on %x", data)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytemare/ecc/ctcheck"
+)
+
+func TestCTCheck_DetectsLeak(t *testing.T) {
+	res := ctcheck.Run(2000, 0, func(class int) func() {
+		return func() {
+			if class == 1 {
+				time.Sleep(20 * time.Microsecond)
+			}
+		}
+	})
+
+	if !res.Leaky {
+		t.Fatalf("expected a leak to be detected, got t = %f", res.T)
+	}
+}
+
+func TestCTCheck_Harnesses(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		for name, run := range map[string]func() ctcheck.Result{
+			"Multiply": func() ctcheck.Result { return ctcheck.Multiply(group.group, 100, 0) },
+			"Decode":   func() ctcheck.Result { return ctcheck.Decode(group.group, 100, 0) },
+			"Equal":    func() ctcheck.Result { return ctcheck.Equal(group.group, 100, 0) },
+		} {
+			if res := run(); res.Measurements == 0 {
+				t.Fatalf("%s: no measurements", name)
+			}
+		}
+	})
+
+	if res := ctcheck.Run(1, 0, nil); res.Measurements != 0 || res.Leaky {
+		t.Fatal("expected an empty result with too few measurements")
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestDebugMode(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		// Valid operations never trigger the checks.
+		if hasPanic, err := hasPanic(func() {
+			s := g.NewScalar().Random()
+			e := g.Base().Multiply(s).Add(g.HashToGroup(testHashToGroupInput, testHashToGroupDST))
+			_ = e.Double().Negate().Subtract(g.Base())
+			_ = s.Copy().Invert().Multiply(s).Pow(s).Subtract(s).Add(g.NewScalar().SetUInt64(3))
+		}); hasPanic {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+
+		hasPanic, _ := hasPanic(func() {
+			_ = g.NewScalar().Invert()
+		})
+
+		if hasPanic != ecc.DebugMode {
+			t.Fatalf("inverting zero: expected panic to be %v", ecc.DebugMode)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa2p"
+)
+
+// clearChannel is an insecure stand-in for the Paillier channel, which evaluates a + b * x1 in the clear.
+type clearChannel struct {
+	x1 *ecc.Scalar
+}
+
+func (c *clearChannel) Evaluate(a, b *ecc.Scalar) ([]byte, error) {
+	return b.Copy().Multiply(c.x1).Add(a).Encode(), nil
+}
+
+func (c *clearChannel) Decrypt(ciphertext []byte) (*ecc.Scalar, error) {
+	s := c.x1.Group().NewScalar()
+	if err := s.Decode(ciphertext); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func testContributions(t *testing.T, g ecc.Group, x1, x2 *ecc.Scalar, label string) (c1, c2 *ecdsa2p.Contribution) {
+	ctx1, ctx2 := []byte(label+" party 1"), []byte(label+" party 2")
+
+	c1, err := ecdsa2p.NewContribution(x1, ctx1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment := c1.Commit(ctx1)
+
+	if c2, err = ecdsa2p.NewContribution(x2, ctx2); err != nil {
+		t.Fatal(err)
+	}
+
+	if !c2.Verify(g, ctx2) || !c1.Open(g, commitment, ctx1) {
+		t.Fatal("valid contribution did not verify")
+	}
+
+	if c1.Open(g, commitment, ctx2) || c2.Verify(g, ctx1) || c1.Open(g, c2.Commit(ctx1), ctx1) {
+		t.Fatal("contribution verified for wrong inputs")
+	}
+
+	return c1, c2
+}
+
+func TestECDSA2P(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		x1, x2 := g.NewScalar().Random(), g.NewScalar().Random()
+
+		if _, err := ecdsa2p.NewContribution(x1, nil); err != nil {
+			switch g {
+			case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+				t.Fatal(err)
+			default:
+				return
+			}
+		}
+
+		// Key generation.
+		q1, q2 := testContributions(t, g, x1, x2, "keygen")
+
+		share1, err := ecdsa2p.NewKeyShare(x1, q2.Element)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		share2, err := ecdsa2p.NewKeyShare(x2, q1.Element)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !share1.PublicKey.Equal(share2.PublicKey) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Signing.
+		k1, k2 := g.NewScalar().Random(), g.NewScalar().Random()
+		r1, r2 := testContributions(t, g, k1, k2, "sign")
+		channel := &clearChannel{x1: x1}
+
+		partial, err := ecdsa2p.PartialSign(share2, k2, r1.Element, digest[:], channel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := ecdsa2p.Sign(share1, k1, r2.Element, digest[:], partial, channel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(ecdsa2p.Signature)
+		if err = decoded.Decode(g, sig.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa2p.Verify(share1.PublicKey, digest[:], decoded) ||
+			ecdsa2p.Verify(q1.Element, digest[:], decoded) ||
+			ecdsa2p.Verify(share1.PublicKey, []byte("other"), decoded) {
+			t.Fatal("unexpected verification result")
+		}
+
+		if g == ecc.P256Sha256 {
+			enc, _ := share1.PublicKey.EncodeUncompressed()
+			pk := &ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(enc[1:33]),
+				Y:     new(big.Int).SetBytes(enc[33:]),
+			}
+
+			if !ecdsa.Verify(pk, digest[:], new(big.Int).SetBytes(sig.R.Encode()), new(big.Int).SetBytes(sig.S.Encode())) {
+				t.Fatal("signature rejected by crypto/ecdsa")
+			}
+		}
+
+		// The first party rejects an inconsistent partial signature.
+		bad, _ := ecdsa2p.PartialSign(share2, k2.Copy().Add(g.NewScalar().One()), r1.Element, digest[:], channel)
+		if _, err = ecdsa2p.Sign(share1, k1, r2.Element, digest[:], bad, channel); err == nil {
+			t.Fatal("expected error on inconsistent partial signature")
+		}
+
+		if _, err = ecdsa2p.NewKeyShare(x1, g.NewElement()); err == nil {
+			t.Fatal("expected error on identity peer element")
+		}
+
+		if _, err = ecdsa2p.NewContribution(g.NewScalar(), nil); err == nil {
+			t.Fatal("expected error on zero secret")
+		}
+	})
+}
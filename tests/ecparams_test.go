@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestECParameters(t *testing.T) {
+	expected := map[ecc.Group]string{
+		ecc.P256Sha256:      "06082a8648ce3d030107",
+		ecc.P384Sha384:      "06052b81040022",
+		ecc.P521Sha512:      "06052b81040023",
+		ecc.Secp256k1Sha256: "06052b8104000a",
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		der, err := encoding.MarshalECParameters(group.group)
+
+		params, ok := expected[group.group]
+		if !ok {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hex.EncodeToString(der) != params {
+			t.Fatalf("unexpected parameters %x", der)
+		}
+
+		g, err := encoding.ParseECParameters(der)
+		if err != nil || g != group.group {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, err = encoding.ParseECParameters(append(der, 0)); err == nil {
+			t.Fatal("expected error on trailing data")
+		}
+	})
+
+	// The implicitCurve choice is NULL.
+	if _, err := encoding.ParseECParameters([]byte{0x05, 0x00}); err == nil {
+		t.Fatal("expected error on implicit curve")
+	}
+}
+
+func TestECPoint(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		e := group.group.Base().Multiply(group.group.NewScalar().Random())
+
+		if _, err := encoding.MarshalECParameters(group.group); err != nil {
+			if _, err = encoding.MarshalECPoint(e, false); err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		for _, compressed := range []bool{false, true} {
+			der, err := encoding.MarshalECPoint(e, compressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := encoding.ParseECPoint(group.group, der)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !d.Equal(e) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		// The uncompressed ECPoint is the public key of the SubjectPublicKeyInfo.
+		if isNIST(group.group) {
+			der, _ := encoding.MarshalECPoint(e, false)
+			spki, _ := encoding.MarshalPKIXPublicKey(e)
+
+			if _, err := x509.ParsePKIXPublicKey(spki); err != nil {
+				t.Fatal(err)
+			}
+
+			var point []byte
+			if _, err := asn1.Unmarshal(der, &point); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.HasSuffix(spki, point) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		if _, err := encoding.MarshalECPoint(group.group.NewElement(), true); err == nil {
+			t.Fatal("expected error on identity")
+		}
+	})
+}
@@ -9,12 +9,17 @@
 package ecc_test
 
 import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
 	"testing"
 
 	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
 	"github.com/bytemare/ecc/debug"
 	"github.com/bytemare/ecc/internal"
 )
@@ -107,7 +112,7 @@ func TestElement_WrongInput(t *testing.T) {
 		// The following is arbitrary, and simply aims at confusing identifiers
 		case ecc.Ristretto255Sha512, ecc.Edwards25519Sha512:
 			alternativeGroup = ecc.P256Sha256
-		case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256, ecc.PallasSha256:
+		case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256, ecc.PallasBLAKE2b512:
 			alternativeGroup = ecc.Ristretto255Sha512
 		default:
 			t.Fatalf("Invalid group id %d", group.group)
@@ -189,6 +194,8 @@ func TestElement_Decode_Identity(t *testing.T) {
 			errMessage = "invalid edwards25519 encoding: infinity/identity point"
 		case ecc.Secp256k1Sha256:
 			errMessage = "invalid secp256k1 encoding: invalid point encoding"
+		case ecc.PallasBLAKE2b512:
+			errMessage = "infinity/identity point"
 		}
 
 		decodeErr += errMessage
@@ -206,6 +213,295 @@ func TestElement_Decode_Identity(t *testing.T) {
 	})
 }
 
+func TestElement_Decode_NonCanonical(t *testing.T) {
+	// The order 2 point (0, -1) of Edwards25519, and its encoding with the sign bit of x = 0 set.
+	torsion, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	negZero, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	e := ecc.Edwards25519Sha512.NewElement()
+	if err := e.Decode(torsion); err == nil || !errors.Is(err, internal.ErrParamNotInSubgroup) {
+		t.Fatalf("expected subgroup error, got %v", err)
+	}
+
+	if err := e.Decode(negZero); err == nil || !errors.Is(err, internal.ErrParamNonCanonicalEncoding) {
+		t.Fatalf("expected non-canonical error, got %v", err)
+	}
+
+	// Uncompressed and single byte identity encodings of NIST points.
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e = ecc.P256Sha256.NewElement()
+	if err = e.Decode(key.PublicKey().Bytes()); err == nil ||
+		!errors.Is(err, internal.ErrParamNonCanonicalEncoding) {
+		t.Fatalf("expected non-canonical error, got %v", err)
+	}
+
+	if err = e.Decode([]byte{0}); err == nil || !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("expected identity error, got %v", err)
+	}
+}
+
+func TestElement_DecodeChecked(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		base := group.group.Base()
+		e := group.group.NewElement()
+
+		if err := e.DecodeChecked(base.Encode()); err != nil || !e.Equal(base) {
+			t.Fatalf("unexpected decoding: %v", err)
+		}
+
+		// The identity is rejected, and the receiver is left untouched.
+		if err := e.DecodeChecked(group.group.NewElement().Encode()); !errors.Is(err, ecc.ErrIdentity) {
+			t.Fatalf("expected identity error, got %v", err)
+		}
+
+		if !e.Equal(base) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+
+	// The order 2 point (0, -1) of Edwards25519, and its encoding with the sign bit of x = 0 set.
+	torsion, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	negZero, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	e := ecc.Edwards25519Sha512.NewElement()
+	if err := e.DecodeChecked(torsion); !errors.Is(err, ecc.ErrNotInSubgroup) {
+		t.Fatalf("expected subgroup error, got %v", err)
+	}
+
+	if err := e.DecodeChecked(negZero); !errors.Is(err, ecc.ErrNonCanonicalEncoding) {
+		t.Fatalf("expected non-canonical error, got %v", err)
+	}
+
+	// Uncompressed encodings of NIST points are not canonical.
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e = ecc.P256Sha256.NewElement()
+	if err = e.DecodeChecked(key.PublicKey().Bytes()); !errors.Is(err, ecc.ErrNonCanonicalEncoding) {
+		t.Fatalf("expected non-canonical error, got %v", err)
+	}
+}
+
+func TestElement_DecodeNonIdentity(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		base := group.group.Base()
+
+		e, err := group.group.NewElementFromBytesNonIdentity(base.Encode())
+		if err != nil || !e.Equal(base) {
+			t.Fatalf("unexpected decoding: %v", err)
+		}
+
+		identity := group.group.NewElement().Encode()
+		if _, err = group.group.NewElementFromBytesNonIdentity(identity); !errors.Is(err, ecc.ErrIdentity) {
+			t.Fatalf("expected identity error, got %v", err)
+		}
+
+		if err = e.DecodeNonIdentity(debug.BadElementEncoding(group.group)); err == nil {
+			t.Fatal("expected error on bad encoding")
+		}
+
+		if !e.Equal(base) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestGroup_NewFromHex(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		base := group.group.Base()
+
+		e, err := group.group.NewElementFromHex(base.Hex())
+		if err != nil || !e.Equal(base) {
+			t.Fatalf("unexpected element decoding: %v", err)
+		}
+
+		sk := group.group.NewScalar().Random()
+
+		s, err := group.group.NewScalarFromHex(sk.Hex())
+		if err != nil || !s.Equal(sk) {
+			t.Fatalf("unexpected scalar decoding: %v", err)
+		}
+
+		bad := hex.EncodeToString(debug.BadElementEncoding(group.group))
+		if e, err = group.group.NewElementFromHex(bad); err == nil || e != nil {
+			t.Fatal("expected error on bad element encoding")
+		}
+
+		if s, err = group.group.NewScalarFromHex("not hex"); err == nil || s != nil {
+			t.Fatal("expected error on bad scalar encoding")
+		}
+	})
+}
+
+func TestElement_Uncompressed(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		switch g {
+		case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+		default:
+			if _, err := e.EncodeUncompressed(); err == nil {
+				t.Fatal("expected error in a group without uncompressed encoding")
+			}
+
+			if err := e.DecodeUncompressed(e.Encode()); err == nil || g.UncompressedElementLength() != 0 {
+				t.Fatal("expected no uncompressed encoding")
+			}
+
+			return
+		}
+
+		enc, err := e.EncodeUncompressed()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := compat.MarshalUncompressed(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(enc, expected) || len(enc) != g.UncompressedElementLength() {
+			t.Fatal("unexpected uncompressed encoding")
+		}
+
+		d := g.NewElement()
+		if err = d.DecodeUncompressed(enc); err != nil || !d.Equal(e) {
+			t.Fatalf("unexpected decoding: %v", err)
+		}
+
+		if _, err = g.NewElement().EncodeUncompressed(); !errors.Is(err, ecc.ErrIdentity) {
+			t.Fatalf("expected identity error, got %v", err)
+		}
+
+		// Flipping a bit of y moves the point off the curve.
+		offCurve := bytes.Clone(enc)
+		offCurve[len(offCurve)-1] ^= 1
+
+		for name, bad := range map[string][]byte{
+			"compressed": e.Encode(),
+			"off-curve":  offCurve,
+			"prefix":     append([]byte{0x05}, enc[1:]...),
+			"identity":   {0},
+			"empty":      nil,
+		} {
+			if err = d.DecodeUncompressed(bad); err == nil {
+				t.Fatalf("expected error decoding %s", name)
+			}
+		}
+
+		if !d.Equal(e) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestGroup_DecodeAny(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		encodings := map[string][]byte{"compressed": e.Encode()}
+		if u, err := e.EncodeUncompressed(); err == nil {
+			encodings["uncompressed"] = u
+		}
+
+		for name, enc := range encodings {
+			d, err := g.DecodeAny(enc)
+			if err != nil || !d.Equal(e) {
+				t.Fatalf("unexpected %s decoding: %v", name, err)
+			}
+		}
+
+		for _, identity := range [][]byte{{0}, g.NewElement().Encode()} {
+			d, err := g.DecodeAny(identity)
+			if err != nil || !d.IsIdentity() {
+				t.Fatalf("unexpected identity decoding of %x: %v", identity, err)
+			}
+		}
+
+		for _, bad := range [][]byte{nil, {4}, {1}, debug.BadElementEncoding(g), append(e.Encode(), 0)} {
+			if _, err := g.DecodeAny(bad); err == nil {
+				t.Fatalf("expected error decoding %x", bad)
+			}
+		}
+	})
+}
+
+func TestElement_EqualAny(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		set := []*ecc.Element{nil, g.Base(), other.Base(), g.NewElement()}
+		if e.EqualAny(set) || e.EqualAny(nil) {
+			t.Fatal("unexpected equality")
+		}
+
+		if !e.EqualAny(append(set, e.Copy())) || !g.NewElement().EqualAny(set) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// MultiEqual returns the index of the first occurrence of each element.
+		elements := []*ecc.Element{e, g.Base(), nil, e.Copy(), other.Base(), g.Base(), g.NewElement()}
+		expected := []int{0, 1, -1, 0, -1, 1, 6}
+
+		for i, j := range g.MultiEqual(elements) {
+			if j != expected[i] {
+				t.Fatalf("unexpected index %d for element %d, expected %d", j, i, expected[i])
+			}
+		}
+	})
+}
+
+func TestElement_EqualBytes(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+		enc := e.Encode()
+
+		if !e.EqualBytes(enc) || !g.NewElement().EqualBytes(g.NewElement().Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		bad := bytes.Clone(enc)
+		bad[len(bad)-1] ^= 1
+
+		if e.EqualBytes(bad) || e.EqualBytes(enc[:len(enc)-1]) || e.EqualBytes(nil) || g.Base().EqualBytes(enc) {
+			t.Fatal("unexpected equality")
+		}
+	})
+}
+
+func TestElement_EqualCT(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+		id := g.NewElement()
+
+		if e.EqualCT(e.Copy()) != 1 || id.EqualCT(g.Base().Subtract(g.Base())) != 1 || id.IsIdentityCT() != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if e.EqualCT(g.Base()) != 0 || e.EqualCT(id) != 0 || id.EqualCT(e) != 0 || e.EqualCT(nil) != 0 ||
+			e.IsIdentityCT() != 0 {
+			t.Fatal("unexpected equality")
+		}
+	})
+}
+
 func TestElement_Decode_Bad(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		decodePrefix := "element Decode: "
@@ -224,6 +520,8 @@ func TestElement_Decode_Bad(t *testing.T) {
 			errMessage = "edwards25519: invalid point encoding"
 		case ecc.Secp256k1Sha256:
 			errMessage = "invalid secp256k1 encoding: invalid point encoding"
+		case ecc.PallasBLAKE2b512:
+			errMessage = "invalid point encoding"
 		}
 
 		// off curve
@@ -263,6 +561,74 @@ func TestElement_Decode_Bad(t *testing.T) {
 	})
 }
 
+func TestElement_Wipe(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		e := group.group.Base().Multiply(group.group.NewScalar().Random())
+		c := e.Copy()
+		e.Wipe()
+
+		if !e.IsIdentity() {
+			t.Fatal("expected identity after wipe")
+		}
+
+		if c.IsIdentity() {
+			t.Fatal("wiping an element must not affect its copies")
+		}
+
+		// The element must remain usable.
+		if !e.Add(c).Equal(c) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestElement_Torsion(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		e := group.group.Base().Multiply(group.group.NewScalar().Random())
+		if !e.IsTorsionFree() {
+			t.Fatal("expected torsion free element")
+		}
+
+		cofactor := uint64(1)
+		if group.group == ecc.Edwards25519Sha512 {
+			cofactor = 8
+		}
+
+		expected := e.Copy().Multiply(group.group.NewScalar().SetUInt64(cofactor))
+		if !e.ClearCofactor().Equal(expected) || !e.IsTorsionFree() {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestElement_DecodeFromX(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		e := group.group.Base().Multiply(group.group.NewScalar().Random())
+		d := group.group.NewElement()
+
+		if group.group == ecc.Ristretto255Sha512 || group.group == ecc.Edwards25519Sha512 {
+			if err := d.DecodeFromX(e.XCoordinate(), false); err == nil {
+				t.Fatal("expected error")
+			}
+
+			return
+		}
+
+		odd := e.Encode()[0] == 0x03
+		if err := d.DecodeFromX(e.XCoordinate(), odd); err != nil || !d.Equal(e) {
+			t.Fatalf("unexpected decoding: %v", err)
+		}
+
+		if err := d.DecodeFromX(e.XCoordinate(), !odd); err != nil || !d.Equal(e.Copy().Negate()) {
+			t.Fatalf("unexpected decoding: %v", err)
+		}
+
+		if err := d.DecodeFromX(e.Encode(), odd); err == nil {
+			t.Fatal("expected error on invalid length")
+		}
+	})
+}
+
 func TestElement_XCoordinate(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		baseX := hex.EncodeToString(group.group.Base().XCoordinate())
@@ -565,3 +931,56 @@ func elementTestIdentity(t *testing.T, g ecc.Group) {
 		t.Fatal(errExpectedIdentity)
 	}
 }
+
+func TestElement_String(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		e := group.group.Base()
+		expected := group.group.Name() + "(" + e.Hex()[:10] + "…)"
+
+		if res := fmt.Sprintf("%v", e); res != expected {
+			t.Fatalf("unexpected formatting: %q", res)
+		}
+
+		if res := fmt.Sprintf("%#v", e); res != expected {
+			t.Fatalf("unexpected Go formatting: %q", res)
+		}
+
+		if res := group.group.NewElement().String(); res != group.group.Name()+"(identity)" {
+			t.Fatalf("unexpected identity formatting: %q", res)
+		}
+	})
+}
+
+// shortHexElement is an element implementation with a hexadecimal encoding shorter than the prefix shown by String.
+type shortHexElement struct {
+	relabeledElement
+}
+
+func (e shortHexElement) Hex() string {
+	return e.relabeledElement.Hex()[:6]
+}
+
+type shortHexGroup struct {
+	relabeledGroup
+}
+
+func (g shortHexGroup) Base() ecc.ElementImplementation {
+	return shortHexElement{relabeledElement{g.externalGroup.Base(), g.id}}
+}
+
+func (g shortHexGroup) Ciphersuite() string {
+	return "short-hex_XMD:SHA-256_SSWU_RO_"
+}
+
+func TestElement_String_ShortEncoding(t *testing.T) {
+	g := registerTestGroup(t, registeredID+2, func() ecc.GroupImplementation {
+		return shortHexGroup{relabeledGroup{externalGroup{ecc.P256Sha256}, registeredID + 2}}
+	})
+
+	e := g.Base()
+	expected := e.Group().Name() + "(" + e.Hex() + ")"
+
+	if res := e.String(); res != expected {
+		t.Fatalf("unexpected formatting: %q", res)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -185,6 +186,51 @@ func TestElement_Encoding(t *testing.T) {
 	})
 }
 
+func TestEncoding_Stream(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		var buf bytes.Buffer
+
+		for _, w := range []io.WriterTo{e, s, e} {
+			if _, err := w.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected := append(append(e.Encode(), s.Encode()...), e.Encode()...)
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Fatal("unexpected stream")
+		}
+
+		// Consecutive values are read from the same stream.
+		e1, s1, e2 := g.NewElement(), g.NewScalar(), g.NewElement()
+		for _, r := range []io.ReaderFrom{e1, s1, e2} {
+			if _, err := r.ReadFrom(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if !e1.Equal(e) || !s1.Equal(s) || !e2.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if n, err := e1.ReadFrom(bytes.NewReader(e.Encode()[1:])); err == nil || n != int64(g.ElementLength()-1) {
+			t.Fatal("expected error on short stream")
+		}
+
+		if _, err := s1.ReadFrom(bytes.NewReader(bytes.Repeat([]byte{0xff}, g.ScalarLength()))); err == nil {
+			t.Fatal("expected error on invalid scalar")
+		}
+
+		if !e1.Equal(e) || !s1.Equal(s) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
 func testDecodeEmpty(t *testing.T, s serde) {
 	if err := s.Decode(nil); err == nil {
 		t.Fatal("expected error on Decode() with nil input")
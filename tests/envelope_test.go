@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestEnvelope(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		ee := encoding.EncodeElementEnvelope(e)
+		if ee[0] != encoding.EnvelopeVersion || ecc.Group(ee[1]) != g {
+			t.Fatal("unexpected envelope header")
+		}
+
+		de, err := encoding.DecodeElementEnvelope(ee)
+		if err != nil || !de.Equal(e) {
+			t.Fatalf("unexpected element decoding: %v", err)
+		}
+
+		se := encoding.EncodeScalarEnvelope(s)
+
+		ds, err := encoding.DecodeScalarEnvelope(se)
+		if err != nil || !ds.Equal(s) {
+			t.Fatalf("unexpected scalar decoding: %v", err)
+		}
+
+		// Element and scalar envelopes can't be mistaken for one another.
+		if _, err = encoding.DecodeScalarEnvelope(ee); err == nil {
+			t.Fatal("expected error decoding an element envelope as a scalar")
+		}
+
+		if _, err = encoding.DecodeElementEnvelope(se); err == nil {
+			t.Fatal("expected error decoding a scalar envelope as an element")
+		}
+
+		for name, mutate := range map[string]func([]byte) []byte{
+			"empty":     func([]byte) []byte { return nil },
+			"truncated": func(b []byte) []byte { return b[:len(b)-1] },
+			"trailing":  func(b []byte) []byte { return append(b, 0) },
+			"version":   func(b []byte) []byte { b[0] = encoding.EnvelopeVersion + 1; return b },
+			"group":     func(b []byte) []byte { b[1] = 0; return b },
+			"header":    func(b []byte) []byte { return b[:4] },
+		} {
+			if _, err = encoding.DecodeElementEnvelope(mutate(slices.Clone(ee))); err == nil {
+				t.Fatalf("expected error on %s element envelope", name)
+			}
+
+			if _, err = encoding.DecodeScalarEnvelope(mutate(slices.Clone(se))); err == nil {
+				t.Fatalf("expected error on %s scalar envelope", name)
+			}
+		}
+	})
+}
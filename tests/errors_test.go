@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+func TestErrors_InvalidEncoding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		var target *ecc.ErrInvalidEncoding
+
+		err := group.group.NewElement().Decode(debug.BadElementEncoding(group.group))
+		if !errors.As(err, &target) || target.Group != group.group {
+			t.Fatalf("expected an invalid encoding error for the group, got %v", err)
+		}
+
+		err = group.group.NewElement().Decode(group.group.NewElement().Encode())
+		if !errors.As(err, &target) {
+			t.Fatalf("expected an invalid encoding error on the identity, got %v", err)
+		}
+
+		err = group.group.NewScalar().Decode(debug.BadScalarHigh(group.group))
+		if !errors.Is(err, ecc.ErrInvalidScalarEncoding) || !errors.As(err, &target) || target.Group != group.group {
+			t.Fatalf("expected an invalid scalar encoding error, got %v", err)
+		}
+
+		err = group.group.NewScalar().DecodeHex("00")
+		if !errors.Is(err, ecc.ErrScalarLength) {
+			t.Fatalf("expected a scalar length error, got %v", err)
+		}
+	})
+}
+
+func TestErrors_WrongGroup(t *testing.T) {
+	err := error(&ecc.ErrWrongGroup{Expected: ecc.Ristretto255Sha512, Got: ecc.P256Sha256})
+
+	if !errors.Is(err, &ecc.ErrWrongGroup{}) {
+		t.Fatal("expected the error to match any wrong group error")
+	}
+
+	if err.Error() != "wrong group: expected group 1, got group 3" {
+		t.Fatalf("unexpected error message %q", err)
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ethereum"
+)
+
+func TestEthereum_Address(t *testing.T) {
+	// The address of the private key 1.
+	g := ecc.Secp256k1Sha256
+
+	address, err := ethereum.Address(g.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ethereum.ChecksumAddress(address); got != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Fatalf("unexpected address %s", got)
+	}
+
+	// EIP-55 test vector.
+	raw, _ := hex.DecodeString("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	if got := ethereum.ChecksumAddress(raw); got != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Fatalf("unexpected checksum address %s", got)
+	}
+
+	if _, err = ethereum.Address(ecc.P256Sha256.Base()); err == nil {
+		t.Fatal("expected error on wrong group")
+	}
+}
+
+func TestEthereum_SignRecover(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+	sk := g.NewScalar().Random()
+	pk := g.Base().Multiply(sk)
+	hash := ethereum.Keccak256([]byte("message"))
+
+	for range 16 {
+		sig, err := ethereum.Sign(sk, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(sig) != ethereum.SignatureLength || sig[64] > 1 {
+			t.Fatal("unexpected signature format")
+		}
+
+		s := g.NewScalar()
+		if err = s.Decode(sig[32:64]); err != nil || !ethereum.IsLowS(s) {
+			t.Fatal("expected a low s value")
+		}
+
+		recovered, err := ethereum.Recover(hash, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !recovered.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Legacy recovery identifiers are accepted.
+		legacy := append([]byte{}, sig...)
+		legacy[64] += 27
+
+		if err = ethereum.Verify(pk, hash, legacy); err != nil {
+			t.Fatal(err)
+		}
+
+		// The malleated signature (r, n - s, 1 - v) is rejected, and normalized back.
+		high := append(append([]byte{}, sig[:32]...), g.NewScalar().Subtract(s).Encode()...)
+		high = append(high, sig[64]^1)
+
+		if err = ethereum.Verify(pk, hash, high); err == nil {
+			t.Fatal("expected error on high s value")
+		}
+
+		low, err := ethereum.ToLowS(high)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hex.EncodeToString(low) != hex.EncodeToString(sig) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if err = ethereum.Verify(pk, ethereum.Keccak256([]byte("other")), sig); err == nil {
+			t.Fatal("expected error on wrong message")
+		}
+	}
+
+	if _, err := ethereum.Sign(sk, hash[1:]); err == nil {
+		t.Fatal("expected error on wrong hash length")
+	}
+
+	bad := make([]byte, ethereum.SignatureLength)
+	bad[64] = 2
+
+	if _, err := ethereum.Recover(hash, bad); err == nil {
+		t.Fatal("expected error on invalid recovery identifier")
+	}
+}
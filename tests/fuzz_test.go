@@ -13,6 +13,7 @@ import (
 
 	"github.com/bytemare/ecc"
 	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/fuzz"
 	"github.com/bytemare/ecc/internal"
 )
 
@@ -83,3 +84,11 @@ func FuzzJSONReGetGroup(f *testing.F) {
 		_, _ = encoding.JSONReGetGroup(input)
 	})
 }
+
+func FuzzDecodeElement(f *testing.F) { fuzz.FuzzDecodeElement(f) }
+
+func FuzzDecodeScalar(f *testing.F) { fuzz.FuzzDecodeScalar(f) }
+
+func FuzzDecodeHex(f *testing.F) { fuzz.FuzzDecodeHex(f) }
+
+func FuzzHashToCurve(f *testing.F) { fuzz.FuzzHashToCurve(f) }
@@ -9,10 +9,16 @@
 package ecc_test
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 	"testing"
 
+	"github.com/bytemare/hash2curve"
+
 	"github.com/bytemare/ecc"
 	"github.com/bytemare/ecc/internal"
 )
@@ -43,7 +49,7 @@ func TestNonAvailability(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	oob = ecc.PallasSha256 + 1
+	oob = ecc.PallasBLAKE2b512 + 1
 	if oob.Available() {
 		t.Errorf(consideredAvailableFmt, oob)
 	}
@@ -80,7 +86,7 @@ func TestDST(t *testing.T) {
 		ecc.P521Sha512:         app + "-V01-CS05-",
 		ecc.Edwards25519Sha512: app + "-V01-CS06-",
 		ecc.Secp256k1Sha256:    app + "-V01-CS07-",
-		ecc.PallasSha256:       app + "-V01-CS08-",
+		ecc.PallasBLAKE2b512:   app + "-V01-CS08-",
 	}
 
 	testAllGroups(t, func(group *testGroup) {
@@ -102,6 +108,28 @@ func TestGroup_String(t *testing.T) {
 	})
 }
 
+func TestGroup_Name(t *testing.T) {
+	names := map[ecc.Group]string{
+		ecc.Ristretto255Sha512: "Ristretto255",
+		ecc.P256Sha256:         "P-256",
+		ecc.P384Sha384:         "P-384",
+		ecc.P521Sha512:         "P-521",
+		ecc.Edwards25519Sha512: "Edwards25519",
+		ecc.Secp256k1Sha256:    "secp256k1",
+		ecc.PallasBLAKE2b512:   "Pallas",
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		if res := group.group.Name(); res != names[group.group] {
+			t.Errorf("Wrong name. want %q, got %q", names[group.group], res)
+		}
+	})
+
+	if res := ecc.Group(0).Name(); res != "Group(0)" {
+		t.Errorf("Wrong name for an unavailable group: %q", res)
+	}
+}
+
 func TestGroup_NewScalar(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		s := group.group.NewScalar().Encode()
@@ -124,6 +152,26 @@ func TestGroup_NewElement(t *testing.T) {
 	})
 }
 
+func TestGroup_Identity(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		ref, _ := hex.DecodeString(group.identity)
+
+		if !g.Identity().IsIdentity() || !bytes.Equal(g.Identity().Encode(), ref) {
+			t.Fatal("expected the identity element")
+		}
+
+		if !g.IsIdentityEncoding(ref) || !g.IsIdentityEncoding(g.Base().Subtract(g.Base()).Encode()) {
+			t.Fatal("expected the identity encoding to match")
+		}
+
+		if g.IsIdentityEncoding(g.Base().Encode()) || g.IsIdentityEncoding(ref[:len(ref)-1]) ||
+			g.IsIdentityEncoding(nil) {
+			t.Fatal("unexpected identity encoding match")
+		}
+	})
+}
+
 func TestGroup_ScalarLength(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		if int(group.group.ScalarLength()) != group.scalarLength {
@@ -210,6 +258,79 @@ func TestHashToGroup_NoDST(t *testing.T) {
 	})
 }
 
+func TestHashToGroup_StrictDST(t *testing.T) {
+	data := []byte("input data")
+	shortDST := []byte("short dst")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		if _, err := g.HashToGroupWithOptions(data, shortDST, ecc.WithStrictDST()); !errors.Is(err, ecc.ErrShortDST) {
+			t.Fatalf("expected short DST error, got %v", err)
+		}
+
+		if _, err := g.EncodeToGroupWithOptions(data, shortDST, ecc.WithStrictDST()); !errors.Is(err, ecc.ErrShortDST) {
+			t.Fatalf("expected short DST error, got %v", err)
+		}
+
+		if _, err := g.EncodeToGroupWithOptions(data, nil); err == nil || err.Error() != errZeroLenDST.Error() {
+			t.Fatalf("expected zero-length DST error, got %v", err)
+		}
+
+		if _, err := g.HashToScalarWithOptions(data, shortDST, ecc.WithStrictDST()); !errors.Is(err, ecc.ErrShortDST) {
+			t.Fatalf("expected short DST error, got %v", err)
+		}
+
+		if _, err := g.HashToScalarWithOptions(data, nil); err == nil || err.Error() != errZeroLenDST.Error() {
+			t.Fatalf("expected zero-length DST error, got %v", err)
+		}
+
+		if _, err := g.HashToScalarWithOptions(data, group.hashToCurve.dst, ecc.WithCount(1)); err == nil {
+			t.Fatal("expected error on an option changing the encoding")
+		}
+
+		s, err := g.HashToScalarWithOptions(data, group.hashToCurve.dst, ecc.WithStrictDST())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(g.HashToScalar(data, group.hashToCurve.dst)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Strictness only applies to the call it is given to.
+		e, err := g.HashToGroupWithOptions(data, shortDST)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e.Equal(g.HashToGroup(data, shortDST)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		e, err = g.HashToGroupWithOptions(data, group.hashToCurve.dst, ecc.WithStrictDST())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e.Equal(g.HashToGroup(data, group.hashToCurve.dst)) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+
+	if err := ecc.ValidateDST(shortDST); !errors.Is(err, ecc.ErrShortDST) {
+		t.Fatalf("expected short DST error, got %v", err)
+	}
+
+	if err := ecc.ValidateDST(nil); err == nil || err.Error() != errZeroLenDST.Error() {
+		t.Fatalf("expected zero-length DST error, got %v", err)
+	}
+
+	if err := ecc.ValidateDST([]byte("a sufficiently long dst")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestGroup_Order(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		h := hex.EncodeToString(group.group.Order())
@@ -218,3 +339,186 @@ func TestGroup_Order(t *testing.T) {
 		}
 	})
 }
+
+func TestGroup_OrderAndCofactor(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		n, p, h := g.OrderBig(), g.FieldOrderBig(), g.CofactorBig()
+
+		if !n.ProbablyPrime(20) || !p.ProbablyPrime(20) {
+			t.Fatal("expected prime orders")
+		}
+
+		if !bytes.Equal(p.FillBytes(make([]byte, len(g.FieldOrder()))), g.FieldOrder()) ||
+			!bytes.Equal(h.Bytes(), g.Cofactor()) {
+			t.Fatal("expected the byte and big.Int accessors to match")
+		}
+
+		// Scalars are integers modulo the order.
+		minusOne := g.NewScalar().SetUInt64(1)
+		if err := minusOne.SetString(new(big.Int).Sub(n, big.NewInt(1)).String()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !minusOne.Equal(g.NewScalar().MinusOne()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Hasse's theorem: |h * n - (p + 1)| <= 2 * sqrt(p).
+		d := new(big.Int).Mul(h, n)
+		d.Sub(d, p).Sub(d, big.NewInt(1)).Abs(d)
+
+		if d.Mul(d, d).Cmp(new(big.Int).Lsh(p, 2)) > 0 {
+			t.Fatal("the orders don't satisfy Hasse's theorem")
+		}
+
+		// The accessors return copies.
+		g.OrderBig().SetInt64(0)
+		g.FieldOrderBig().SetInt64(0)
+		g.CofactorBig().SetInt64(0)
+
+		if g.OrderBig().Cmp(n) != 0 || g.FieldOrderBig().Cmp(p) != 0 || g.CofactorBig().Cmp(h) != 0 {
+			t.Fatal("expected copies")
+		}
+	})
+
+	if ecc.Edwards25519Sha512.CofactorBig().Int64() != 8 || ecc.P256Sha256.CofactorBig().Int64() != 1 {
+		t.Fatal("unexpected cofactors")
+	}
+}
+
+func TestGroup_HashToScalarLength(t *testing.T) {
+	levels := map[ecc.Group]int{ecc.P384Sha384: 192, ecc.P521Sha512: 256}
+	input, dst := []byte("input"), []byte(testHashToGroupDST)
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		k, l := g.SecurityLevel(), g.HashToScalarLength()
+
+		if expected, ok := levels[g]; (ok && k != expected) || (!ok && k != 128) {
+			t.Fatalf("unexpected security level %d", k)
+		}
+
+		// L = ceil((log2(order) + k) / 8) of RFC 9380, and 64 for ristretto255.
+		expected := (g.OrderBig().BitLen() + k + 7) / 8
+		if g == ecc.Ristretto255Sha512 {
+			expected = 64
+		}
+
+		if l != expected {
+			t.Fatalf("unexpected expansion length %d, expected %d", l, expected)
+		}
+
+		// HashToScalar reduces an expansion of that length.
+		uniform := hash2curve.ExpandXMD(g.HashFunc(), input, dst, uint(l))
+		if g == ecc.Ristretto255Sha512 {
+			slices.Reverse(uniform)
+		}
+
+		v := new(big.Int).SetBytes(uniform)
+		v.Mod(v, g.OrderBig())
+
+		s := g.NewScalar()
+		if err := s.SetString(v.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(g.HashToScalar(input, dst)) {
+			t.Fatal("expected HashToScalar to reduce an expansion of HashToScalarLength bytes")
+		}
+	})
+}
+
+func TestGroup_DeriveGenerators(t *testing.T) {
+	dst := []byte("DeriveGenerators test")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		gens := g.DeriveGenerators(8, dst)
+
+		if len(gens) != 8 || len(g.DeriveGenerators(0, dst)) != 0 || len(g.DeriveGenerators(-1, dst)) != 0 {
+			t.Fatal("unexpected number of generators")
+		}
+
+		// Generators are distinct, not the identity nor the base point, and deterministic.
+		for i, j := range g.MultiEqual(append(gens, g.Base())) {
+			if i != j || (i < len(gens) && gens[i].IsIdentity()) {
+				t.Fatal("expected distinct non-identity generators")
+			}
+		}
+
+		prefix := g.DeriveGenerators(3, dst)
+		for i, e := range prefix {
+			if !e.Equal(gens[i]) {
+				t.Fatal("expected derivations to share their first generators")
+			}
+		}
+
+		// They are separated from other protocols, and from HashToGroup with the same DST.
+		if g.DeriveGenerators(1, []byte("another protocol"))[0].Equal(gens[0]) ||
+			g.HashToGroup([]byte{0, 0, 0, 0}, dst).Equal(gens[0]) {
+			t.Fatal("expected domain separation")
+		}
+
+		if !g.HashToGroup([]byte{0, 0, 0, 1}, append([]byte("DeriveGenerators-"), dst...)).Equal(gens[1]) {
+			t.Fatal("unexpected derivation")
+		}
+	})
+
+	if ok, _ := hasPanic(func() { ecc.P256Sha256.DeriveGenerators(1, nil) }); !ok {
+		t.Fatal("expected panic on empty DST")
+	}
+}
+
+func TestGroup_DeriveKeyPair(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+	info := []byte("test key")
+
+	// RFC 9497 OPRF mode test vectors.
+	vectors := map[ecc.Group]struct {
+		context, sk string
+	}{
+		ecc.Ristretto255Sha512: {
+			context: "OPRFV1-\x00-ristretto255-SHA512",
+			sk:      "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e",
+		},
+		ecc.P256Sha256: {
+			context: "OPRFV1-\x00-P256-SHA256",
+			sk:      "159749d750713afe245d2d39ccfaae8381c53ce92d098a9375ee70739c7ac0bf",
+		},
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		sk, pk, err := group.group.DeriveKeyPair(seed, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sk.IsZero() || !group.group.Base().Multiply(sk).Equal(pk) {
+			t.Fatal("invalid derived key pair")
+		}
+
+		sk2, _, _ := group.group.DeriveKeyPair(seed, info)
+		if !sk.Equal(sk2) {
+			t.Fatal("key derivation is not deterministic")
+		}
+
+		if _, _, err = group.group.DeriveKeyPair(seed, make([]byte, 1<<16)); !errors.Is(err, internal.ErrParamInfoTooLong) {
+			t.Fatalf("expected error %q, got %q", internal.ErrParamInfoTooLong, err)
+		}
+
+		v, ok := vectors[group.group]
+		if !ok {
+			return
+		}
+
+		sk, _, err = group.group.DeriveKeyPairWithContext(seed, info, []byte(v.context))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sk.Hex() != v.sk {
+			t.Fatalf("unexpected derived key\n\twant: %s\n\tgot : %s", v.sk, sk.Hex())
+		}
+	})
+}
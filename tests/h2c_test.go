@@ -25,6 +25,9 @@ import (
 	"github.com/bytemare/ecc"
 )
 
+// hashToCurveVectorsFileLocation holds the RFC 9380 vectors, and the pallas_XMD:BLAKE2b vectors, which were generated
+// with an independent Python implementation of the pasta_curves hasher rather than taken from a published source: they
+// guard against regressions, but don't establish compatibility with pasta_curves.
 const hashToCurveVectorsFileLocation = "h2c"
 
 type h2cVectors struct {
@@ -139,7 +142,7 @@ func (v *h2cVector) run(t *testing.T) {
 	case ecc.Edwards25519Sha512:
 		p := vectorToEdwards25519(t, v.P.X, v.P.Y)
 		expected = hex.EncodeToString(p.Bytes())
-	case ecc.Secp256k1Sha256:
+	case ecc.Secp256k1Sha256, ecc.PallasBLAKE2b512:
 		expected = hex.EncodeToString(vectorToSecp256k1(v.P.X, v.P.Y))
 	default:
 		t.Fatal("ciphersuite not recognized")
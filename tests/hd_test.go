@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/hd"
+)
+
+func TestHD_BIP32Vector1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	vectors := []struct {
+		path, sk, chainCode, fingerprint, parentFingerprint string
+	}{
+		{
+			path:              "m",
+			sk:                "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			chainCode:         "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+			fingerprint:       "3442193e",
+			parentFingerprint: "00000000",
+		},
+		{
+			path:              "m/0'",
+			sk:                "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+			chainCode:         "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			fingerprint:       "5c1bd648",
+			parentFingerprint: "3442193e",
+		},
+		{
+			path:              "m/0'/1",
+			sk:                "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+			chainCode:         "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+			fingerprint:       "bef5a2f9",
+			parentFingerprint: "5c1bd648",
+		},
+	}
+
+	master, err := hd.NewMaster(ecc.Secp256k1Sha256, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range vectors {
+		path, err := hd.ParsePath(v.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key.PrivateKey.Hex() != v.sk {
+			t.Fatalf("%s: unexpected private key %s", v.path, key.PrivateKey.Hex())
+		}
+
+		if hex.EncodeToString(key.ChainCode) != v.chainCode {
+			t.Fatalf("%s: unexpected chain code %x", v.path, key.ChainCode)
+		}
+
+		if fp := key.Fingerprint(); hex.EncodeToString(fp[:]) != v.fingerprint {
+			t.Fatalf("%s: unexpected fingerprint %x", v.path, fp)
+		}
+
+		if hex.EncodeToString(key.ParentFingerprint[:]) != v.parentFingerprint {
+			t.Fatalf("%s: unexpected parent fingerprint %x", v.path, key.ParentFingerprint)
+		}
+	}
+}
+
+func TestHD_PublicDerivation(t *testing.T) {
+	seed := []byte("a seed of sufficient length for hd derivation")
+
+	testAllGroups(t, func(group *testGroup) {
+		master, err := hd.NewMaster(group.group, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parent, err := master.Child(hd.HardenedOffset + 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		child, err := parent.Child(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicChild, err := parent.Public().Child(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !child.PublicKey.Equal(publicChild.PublicKey) {
+			t.Fatal("public and private derivation yield different public keys")
+		}
+
+		if !group.group.Base().Multiply(child.PrivateKey).Equal(child.PublicKey) {
+			t.Fatal("derived private and public keys don't match")
+		}
+
+		if _, err = parent.Public().Child(hd.HardenedOffset); err == nil {
+			t.Fatal("expected error on hardened derivation from public key")
+		}
+	})
+}
+
+func TestHD_ParsePath(t *testing.T) {
+	path, err := hd.ParsePath("m/44'/0h/1H/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []uint32{hd.HardenedOffset + 44, hd.HardenedOffset, hd.HardenedOffset + 1, 2}
+	for i, p := range path {
+		if p != expected[i] {
+			t.Fatalf("unexpected index %d at position %d", p, i)
+		}
+	}
+
+	for _, bad := range []string{"", "n/1", "m/1''", "m/x", "m/2147483648"} {
+		if _, err = hd.ParsePath(bad); err == nil {
+			t.Fatalf("expected error for path %q", bad)
+		}
+	}
+}
+
+func TestHD_SLIP10Vector1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	vectors := []struct {
+		group                                                ecc.Group
+		sk, chainCode, childSK, childCode, parentFingerprint string
+	}{
+		{
+			group:             ecc.Edwards25519Sha512,
+			sk:                "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			chainCode:         "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			childSK:           "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			childCode:         "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			parentFingerprint: "ddebc675",
+		},
+		{
+			group:             ecc.P256Sha256,
+			sk:                "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			chainCode:         "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			childSK:           "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+			childCode:         "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			parentFingerprint: "be6105b5",
+		},
+	}
+
+	privateKey := func(k *hd.ExtendedKey) string {
+		if k.Group == ecc.Edwards25519Sha512 {
+			return hex.EncodeToString(k.Ed25519PrivateKey().Seed())
+		}
+
+		return k.PrivateKey.Hex()
+	}
+
+	for _, v := range vectors {
+		master, err := hd.NewMasterSLIP10(v.group, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if privateKey(master) != v.sk || hex.EncodeToString(master.ChainCode) != v.chainCode {
+			t.Fatalf("%s: unexpected master key", v.group)
+		}
+
+		child, err := master.Child(hd.HardenedOffset)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if privateKey(child) != v.childSK || hex.EncodeToString(child.ChainCode) != v.childCode {
+			t.Fatalf("%s: unexpected child key", v.group)
+		}
+
+		// The fingerprint of m/0H is the HASH160 of the master's serialized public key.
+		if hex.EncodeToString(child.ParentFingerprint[:]) != v.parentFingerprint {
+			t.Fatalf("%s: unexpected parent fingerprint %x", v.group, child.ParentFingerprint)
+		}
+
+		if fp := master.Public().Fingerprint(); fp != child.ParentFingerprint {
+			t.Fatalf("%s: unexpected public key fingerprint %x", v.group, fp)
+		}
+
+		if v.group == ecc.Edwards25519Sha512 {
+			// The SLIP-0010 vector prefixes the public key with 0x00.
+			if child.PublicKey.Hex() != "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c" {
+				t.Fatal("unexpected SLIP-0010 ed25519 public key")
+			}
+
+			if _, err = child.Child(1); err == nil {
+				t.Fatal("expected error on non-hardened ed25519 derivation")
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestOperationHook(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base()
+		dst := []byte(testHashToGroupDST)
+
+		counter := new(ecc.OperationCounter)
+		ecc.SetOperationHook(counter.Record)
+
+		e.Multiply(s)
+		g.BaseTable().MultiplyVartime(s)
+		s.Multiply(s).Square().Invert()
+		g.HashToGroup([]byte("input"), dst)
+		g.EncodeToGroup([]byte("input"), dst)
+		g.HashToScalar([]byte("input"), dst)
+
+		ecc.SetOperationHook(nil)
+		e.Multiply(s)
+
+		for op, expected := range map[ecc.Operation]uint64{
+			ecc.OpElementMultiply: 2,
+			ecc.OpScalarMultiply:  2,
+			ecc.OpScalarInvert:    1,
+			ecc.OpHashToGroup:     1,
+			ecc.OpEncodeToGroup:   1,
+			ecc.OpHashToScalar:    1,
+		} {
+			if n := counter.Count(g, op); n != expected {
+				t.Fatalf("%s: expected %d operations, got %d", op, expected, n)
+			}
+
+			if n := counter.Snapshot()[g.Name()][op.String()]; n != expected {
+				t.Fatalf("%s: unexpected snapshot count %d", op, n)
+			}
+		}
+
+		if len(counter.Snapshot()) != 1 {
+			t.Fatal("expected counts for a single group")
+		}
+
+		counter.Reset()
+
+		if counter.Count(g, ecc.OpElementMultiply) != 0 || len(counter.Snapshot()) != 0 {
+			t.Fatal("expected no counts after reset")
+		}
+	})
+}
+
+func TestOperationCounter_Publish(t *testing.T) {
+	counter := new(ecc.OperationCounter)
+	counter.Record(ecc.Ristretto255Sha512, ecc.OpScalarInvert)
+	counter.Record(ecc.Ristretto255Sha512, ecc.Operation(255))
+	counter.Publish("ecc_test_operations")
+
+	v := expvar.Get("ecc_test_operations")
+	if v == nil || v.String() != `{"Ristretto255":{"ScalarInvert":1}}` {
+		t.Fatalf("unexpected published value %v", v)
+	}
+
+	if counter.Count(ecc.Ristretto255Sha512, ecc.Operation(255)) != 0 || ecc.Operation(255).String() != "Operation(unknown)" {
+		t.Fatal("expected unknown operations to be ignored")
+	}
+}
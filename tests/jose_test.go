@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+var joseSigningInput = []byte("eyJhbGciOiJFUzI1NiJ9.eyJpc3MiOiJqb2UifQ")
+
+func TestJOSE_SignVerify(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		alg, err := compat.JWSAlgorithm(group.group)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		key, err := compat.NewPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := key.SignJWS(rand.Reader, joseSigningInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(sig) != 2*group.group.ScalarLength() {
+			t.Fatalf("unexpected signature length %d", len(sig))
+		}
+
+		if err = compat.VerifyJWS(pk, alg, joseSigningInput, sig); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = compat.VerifyJWS(pk, "RS256", joseSigningInput, sig); err == nil {
+			t.Fatal("expected error on wrong algorithm")
+		}
+
+		if err = compat.VerifyJWS(pk, alg, append(joseSigningInput, 'x'), sig); err == nil {
+			t.Fatal("expected error on modified input")
+		}
+
+		if err = compat.VerifyJWS(pk, alg, joseSigningInput, sig[1:]); err == nil {
+			t.Fatal("expected error on truncated signature")
+		}
+
+		if err = compat.VerifyJWS(group.group.Base(), alg, joseSigningInput, sig); err == nil {
+			t.Fatal("expected error on wrong key")
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			if !ed25519.Verify(pk.Encode(), joseSigningInput, sig) {
+				t.Fatal("signature doesn't verify with crypto/ed25519")
+			}
+
+			return
+		}
+
+		der, err := compat.JWSToDER(group.group, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jws, err := compat.DERToJWS(group.group, der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(jws, sig) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if isNIST(group.group) {
+			stdPK, err := compat.ToECDSAPublicKey(pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := group.group.HashFunc().New()
+			h.Write(joseSigningInput)
+
+			if !ecdsa.VerifyASN1(stdPK, h.Sum(nil), der) {
+				t.Fatal("signature doesn't verify with crypto/ecdsa")
+			}
+		}
+	})
+}
+
+func TestJOSE_StandardSigner(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if !isNIST(group.group) {
+			return
+		}
+
+		sk := group.group.NewScalar().Random()
+
+		key, err := compat.ToECDSAKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		alg, _ := compat.JWSAlgorithm(group.group)
+		h := group.group.HashFunc().New()
+		h.Write(joseSigningInput)
+
+		der, err := key.Sign(rand.Reader, h.Sum(nil), group.group.HashFunc())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := compat.DERToJWS(group.group, der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = compat.VerifyJWS(group.group.Base().Multiply(sk), alg, joseSigningInput, sig); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = compat.DERToJWS(group.group, append(der, 0)); err == nil {
+			t.Fatal("expected error on trailing data")
+		}
+	})
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestJWK_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		public, err := encoding.PublicJWK(pk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := public.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, _, err = public.PrivateKey(); err == nil {
+			t.Fatal("expected error on public JWK")
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			if _, err = encoding.PrivateJWK(sk); err == nil {
+				t.Fatal("expected error on Ed25519 private key export")
+			}
+
+			return
+		}
+
+		private, err := encoding.PrivateJWK(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := json.Marshal(private)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var parsed encoding.JWK
+		if err = json.Unmarshal(data, &parsed); err != nil {
+			t.Fatal(err)
+		}
+
+		s, p, err := parsed.PrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(sk) || !p.Equal(pk) || *parsed.Public() != *public {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// A private key not matching the public members must be rejected.
+		parsed.X, parsed.Y = public.Y, public.X
+		if _, _, err = parsed.PrivateKey(); err == nil {
+			t.Fatal("expected error on mismatching public key")
+		}
+	})
+}
+
+func TestJWK_ECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwk := &encoding.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		D:   base64.RawURLEncoding.EncodeToString(key.D.FillBytes(make([]byte, 32))),
+	}
+
+	sk, _, err := jwk.PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sk.Encode(), key.D.FillBytes(make([]byte, 32))) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Short coordinates are invalid.
+	jwk.X = base64.RawURLEncoding.EncodeToString(key.X.Bytes()[1:])
+	if _, err = jwk.PublicKey(); err == nil {
+		t.Fatal("expected error on short coordinate")
+	}
+}
+
+// TestJWK_RFC8037 uses the Ed25519 key and thumbprint of RFC 8037, appendix A.
+func TestJWK_RFC8037(t *testing.T) {
+	jwk := &encoding.JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+		D:   "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",
+	}
+
+	_, pk, err := jwk.PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public, err := encoding.PublicJWK(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *public != *jwk.Public() {
+		t.Fatal(errExpectedEquality)
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if base64.RawURLEncoding.EncodeToString(thumbprint) != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+// TestJWK_Thumbprint uses the EC example key of RFC 7517, appendix A.1, whose thumbprint is the SHA-256 of
+// {"crv":"P-256","kty":"EC","x":"MKBC...","y":"4Etl..."}.
+func TestJWK_Thumbprint(t *testing.T) {
+	jwk := &encoding.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+		Y:   "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+	}
+
+	if _, err := jwk.PublicKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if base64.RawURLEncoding.EncodeToString(thumbprint) != "cn-I_WNMClehiVp51i_0VpOENW1upEerA8sEam5hn-s" {
+		t.Fatal(errExpectedEquality)
+	}
+}
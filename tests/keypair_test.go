@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+func TestKeyPair(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		kp := g.GenerateKeyPair()
+		if kp.Group != g || kp.Secret.IsZero() || kp.Validate() != nil {
+			t.Fatal("invalid generated key pair")
+		}
+
+		// Encoding round trip.
+		d := new(ecc.KeyPair)
+		if err := d.Decode(kp.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if d.Group != g || !d.Secret.Equal(kp.Secret) || !d.Public.Equal(kp.Public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Deterministic derivation, and derivation from the secret key.
+		s1, err := g.KeyPairFromSeed([]byte("seed"), []byte("info"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s2, _ := g.KeyPairFromSeed([]byte("seed"), []byte("info"))
+		if !s1.Secret.Equal(s2.Secret) || !s1.Public.Equal(s2.Public) || s1.Validate() != nil {
+			t.Fatal("expected deterministic key pairs")
+		}
+
+		s3, err := ecc.KeyPairFromSecret(s1.Secret)
+		if err != nil || !s3.Public.Equal(s1.Public) || s3.Secret == s1.Secret {
+			t.Fatal("unexpected key pair from secret")
+		}
+
+		s3.Wipe()
+
+		if !s3.Secret.IsZero() || s1.Secret.IsZero() {
+			t.Fatal("expected only the copy to be wiped")
+		}
+	})
+}
+
+func TestKeyPair_Errors(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		kp := g.GenerateKeyPair()
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		for name, k := range map[string]*ecc.KeyPair{
+			"nil secret":   {Group: g, Public: kp.Public},
+			"zero secret":  {Group: g, Secret: g.NewScalar(), Public: g.NewElement()},
+			"mismatch":     {Group: g, Secret: kp.Secret, Public: g.Base()},
+			"group secret": {Group: g, Secret: other.NewScalar().Random(), Public: kp.Public},
+			"group public": {Group: g, Secret: kp.Secret, Public: other.Base()},
+		} {
+			if k.Validate() == nil {
+				t.Fatalf("expected error with %s", name)
+			}
+		}
+
+		wrong := &ecc.KeyPair{Group: g, Secret: kp.Secret, Public: other.Base()}
+		if err := wrong.Validate(); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+			t.Fatalf("expected a group mismatch error, got %v", err)
+		}
+
+		if _, err := ecc.KeyPairFromSecret(nil); err == nil {
+			t.Fatal("expected error on nil secret")
+		}
+
+		if _, err := ecc.KeyPairFromSecret(g.NewScalar()); err == nil {
+			t.Fatal("expected error on zero secret")
+		}
+
+		// Invalid encodings don't modify the receiver.
+		enc := kp.Encode()
+		mismatch := append(append([]byte{byte(g)}, kp.Secret.Encode()...), g.Base().Encode()...)
+		d := g.GenerateKeyPair()
+		backup := d.Encode()
+
+		for name, data := range map[string][]byte{
+			"empty":    nil,
+			"group":    append([]byte{0}, enc[1:]...),
+			"length":   enc[:len(enc)-1],
+			"secret":   append(append([]byte{byte(g)}, debug.BadScalarHigh(g)...), kp.Public.Encode()...),
+			"public":   append(append([]byte{byte(g)}, kp.Secret.Encode()...), debug.BadElementEncoding(g)...),
+			"mismatch": mismatch,
+		} {
+			if err := d.Decode(data); err == nil {
+				t.Fatalf("expected error with %s", name)
+			}
+		}
+
+		if string(d.Encode()) != string(backup) {
+			t.Fatal("expected the receiver to be unmodified")
+		}
+	})
+}
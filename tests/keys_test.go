@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+)
+
+func TestKeys_ECDSA(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+
+		key, err := compat.ToECDSAKey(sk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := compat.FromECDSAKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pk, err := compat.FromECDSAPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(sk) || !pk.Equal(group.group.Base().Multiply(sk)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// A key whose public key doesn't match its private key is rejected.
+		key.D = new(big.Int).Add(key.D, big.NewInt(1))
+		if _, err = compat.FromECDSAKey(key); err == nil {
+			t.Fatal("expected error on mismatching public key")
+		}
+
+		key.D = new(big.Int).SetBytes(group.group.Order())
+		if _, err = compat.FromECDSAKey(key); err == nil {
+			t.Fatal("expected error on out of range key")
+		}
+
+		if _, err = compat.ToECDSAPublicKey(group.group.NewElement()); err == nil {
+			t.Fatal("expected error on identity")
+		}
+	})
+}
+
+func TestKeys_ECDSAStdlib(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, err := compat.FromECDSAKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sk.Group() != ecc.P384Sha384 {
+		t.Fatalf("unexpected group %v", sk.Group())
+	}
+
+	signer, err := compat.NewPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256([]byte("message"))
+
+	signature, err := signer.Sign(rand.Reader, digest[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature) {
+		t.Fatal("expected valid signature")
+	}
+
+	// An off-curve point is rejected.
+	key.PublicKey.X = new(big.Int).Add(key.PublicKey.X, big.NewInt(1))
+	if _, err = compat.FromECDSAPublicKey(&key.PublicKey); err == nil {
+		t.Fatal("expected error on invalid point")
+	}
+
+	if _, err = compat.FromECDSAPublicKey(&ecdsa.PublicKey{Curve: elliptic.P224(), X: big.NewInt(1), Y: big.NewInt(1)}); err == nil {
+		t.Fatal("expected error on unsupported curve")
+	}
+}
+
+func TestKeys_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, pk, err := compat.FromEd25519(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ecc.Edwards25519Sha512.Base().Multiply(sk).Equal(pk) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	e, err := compat.FromEd25519PublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	back, err := compat.ToEd25519PublicKey(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !e.Equal(pk) || !bytes.Equal(back, pub) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Signatures with the derived scalar verify under the original key.
+	signer, err := compat.NewPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := signer.Sign(rand.Reader, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ed25519.Verify(pub, []byte("message"), signature) {
+		t.Fatal("expected valid signature")
+	}
+
+	// A private key embedding another public key is rejected.
+	tampered := bytes.Clone(priv)
+	copy(tampered[ed25519.SeedSize:], ecc.Edwards25519Sha512.Base().Encode())
+
+	if _, _, err = compat.FromEd25519(tampered); err == nil {
+		t.Fatal("expected error on mismatching public key")
+	}
+
+	if _, err = compat.ToEd25519PublicKey(ecc.P256Sha256.Base()); err == nil {
+		t.Fatal("expected error on non-Edwards element")
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/kvac"
+)
+
+func TestKVAC(t *testing.T) {
+	context := []byte("session")
+
+	testAllGroups(t, func(group *testGroup) {
+		sk, err := kvac.KeyGen(group.group, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pp := sk.Public()
+		attributes := []*ecc.Scalar{
+			group.group.NewScalar().Random(),
+			group.group.NewScalar().Random(),
+			group.group.NewScalar().Random(),
+		}
+
+		cred, proof, err := sk.Issue(attributes, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(kvac.Proof)
+		if err = decoded.Decode(group.group, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !pp.VerifyIssuance(attributes, cred, decoded, context) {
+			t.Fatal("valid issuance did not verify")
+		}
+
+		other := []*ecc.Scalar{attributes[1], attributes[0], attributes[2]}
+		if pp.VerifyIssuance(other, cred, proof, context) || pp.VerifyIssuance(attributes, cred, proof, nil) {
+			t.Fatal("issuance verified for wrong inputs")
+		}
+
+		p, err := pp.Present(cred, attributes, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !sk.VerifyPresentation(pp, p, context) {
+			t.Fatal("valid presentation did not verify")
+		}
+
+		if sk.VerifyPresentation(pp, p, []byte("other session")) {
+			t.Fatal("presentation verified for wrong context")
+		}
+
+		// A presentation of a forged credential must fail.
+		forged := &kvac.Credential{U: cred.U, V: cred.V.Copy().Add(group.group.Base())}
+
+		p, err = pp.Present(forged, attributes, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sk.VerifyPresentation(pp, p, context) {
+			t.Fatal("presentation of forged credential verified")
+		}
+
+		if _, _, err = sk.Issue(attributes[:2], context); err == nil {
+			t.Fatal("expected error on wrong number of attributes")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/matrix"
+)
+
+func randomMatrix(t *testing.T, g ecc.Group, rows, cols int) *matrix.Matrix {
+	m, err := matrix.New(g, rows, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range rows {
+		for j := range cols {
+			if err = m.Set(i, j, g.NewScalar().Random()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return m
+}
+
+func TestMatrix(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		a := randomMatrix(t, g, 3, 4)
+		b := randomMatrix(t, g, 4, 2)
+		c := randomMatrix(t, g, 2, 3)
+
+		// (AB)C = A(BC), and (AB)^T = B^T A^T.
+		ab, _ := a.Mul(b)
+		bc, _ := b.Mul(c)
+		abc1, _ := ab.Mul(c)
+		abc2, _ := a.Mul(bc)
+
+		if !abc1.Equal(abc2) {
+			t.Fatal("expected associativity")
+		}
+
+		btat, _ := b.Transpose().Mul(a.Transpose())
+		if !ab.Transpose().Equal(btat) || ab.Rows() != 3 || ab.Cols() != 2 {
+			t.Fatal("unexpected transpose")
+		}
+
+		if _, err := a.Mul(a); err == nil {
+			t.Fatal("expected error on dimension mismatch")
+		}
+
+		// Inverse and Solve.
+		m := randomMatrix(t, g, 4, 4)
+
+		inv, err := m.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id, _ := matrix.Identity(g, 4)
+		if p, _ := m.Mul(inv); !p.Equal(id) {
+			t.Fatal("expected m * m^-1 = I")
+		}
+
+		x := []*ecc.Scalar{g.NewScalar().Random(), g.NewScalar().Random(), g.NewScalar().Random(), g.NewScalar().Random()}
+
+		b2, err := m.MulVec(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sol, err := m.Solve(b2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range x {
+			if !sol[i].Equal(x[i]) {
+				t.Fatal("unexpected solution")
+			}
+		}
+
+		// A matrix with a row that is a combination of others is singular.
+		two := g.NewScalar().SetUInt64(2)
+		for j := range 4 {
+			_ = m.Set(3, j, m.At(0, j).Add(m.At(1, j).Multiply(two)))
+		}
+
+		if m.Rank() != 3 {
+			t.Fatalf("unexpected rank %d", m.Rank())
+		}
+
+		if _, err = m.Inverse(); err == nil {
+			t.Fatal("expected error on singular matrix")
+		}
+
+		if _, err = m.Solve(b2); err == nil {
+			t.Fatal("expected error on singular matrix")
+		}
+
+		// Vandermonde matrices of distinct points are invertible, and evaluate polynomials.
+		points := []*ecc.Scalar{g.NewScalar().SetUInt64(1), g.NewScalar().SetUInt64(2), g.NewScalar().SetUInt64(3)}
+
+		v, err := matrix.Vandermonde(points, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// p(X) = 1 + 2X + 3X^2: p(2) = 17.
+		y, _ := v.MulVec([]*ecc.Scalar{g.NewScalar().SetUInt64(1), two, g.NewScalar().SetUInt64(3)})
+		if !y[1].Equal(g.NewScalar().SetUInt64(17)) {
+			t.Fatal("unexpected polynomial evaluation")
+		}
+
+		if v.Rank() != 3 {
+			t.Fatal("expected an invertible Vandermonde matrix")
+		}
+	})
+}
+
+func TestMatrix_Errors(t *testing.T) {
+	g := ecc.Ristretto255Sha512
+	other := ecc.P256Sha256
+
+	if _, err := matrix.New(g, 0, 1); err == nil {
+		t.Fatal("expected error on empty matrix")
+	}
+
+	if _, err := matrix.FromRows(nil); err == nil {
+		t.Fatal("expected error on empty rows")
+	}
+
+	if _, err := matrix.FromRows([][]*ecc.Scalar{{g.NewScalar()}, {g.NewScalar(), g.NewScalar()}}); err == nil {
+		t.Fatal("expected error on ragged rows")
+	}
+
+	if _, err := matrix.FromRows([][]*ecc.Scalar{{g.NewScalar(), other.NewScalar()}}); err == nil {
+		t.Fatal("expected error on group mismatch")
+	}
+
+	m := randomMatrix(t, g, 2, 2)
+
+	if err := m.Set(0, 0, other.NewScalar()); err == nil {
+		t.Fatal("expected error on group mismatch")
+	}
+
+	if err := m.Set(0, 0, nil); err == nil {
+		t.Fatal("expected error on nil scalar")
+	}
+
+	if _, err := m.Mul(randomMatrix(t, other, 2, 2)); err == nil {
+		t.Fatal("expected error on group mismatch")
+	}
+
+	if _, err := m.MulVec([]*ecc.Scalar{g.NewScalar()}); err == nil {
+		t.Fatal("expected error on dimension mismatch")
+	}
+
+	if _, err := randomMatrix(t, g, 2, 3).Inverse(); err == nil {
+		t.Fatal("expected error on non-square matrix")
+	}
+
+	if m.Equal(nil) || m.Equal(m.Transpose().Transpose().Transpose()) && !m.Equal(m.Transpose()) {
+		t.Fatal("unexpected equality")
+	}
+
+	if !m.Copy().Equal(m) {
+		t.Fatal("expected equal copy")
+	}
+}
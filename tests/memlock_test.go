@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/memlock"
+)
+
+func TestMemlock_Scalar(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		locked := memlock.New(s)
+
+		if locked.Group() != group.group {
+			t.Fatal("unexpected group")
+		}
+
+		if err := locked.Use(func(u *ecc.Scalar) error {
+			if !u.Equal(s) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		locked.Set(s.Add(s))
+
+		if err := locked.Use(func(u *ecc.Scalar) error {
+			if !u.Equal(s) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := testPanic("wrong group", nil, func() {
+			locked.Set(alternativeGroup(group.group).NewScalar())
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		locked.Destroy()
+		locked.Destroy()
+
+		if locked.Locked() {
+			t.Fatal("expected a destroyed scalar not to be locked")
+		}
+
+		if err := locked.Use(func(*ecc.Scalar) error { return nil }); err == nil {
+			t.Fatal("expected an error on a destroyed scalar")
+		}
+	})
+}
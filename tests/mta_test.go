@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/mta"
+)
+
+// shamirShares evaluates a random polynomial of the given degree with constant term secret at 1..n.
+func shamirShares(g ecc.Group, secret *ecc.Scalar, degree, n int) []*ecc.Scalar {
+	coeffs := []*ecc.Scalar{secret}
+	for range degree {
+		coeffs = append(coeffs, g.NewScalar().Random())
+	}
+
+	shares := make([]*ecc.Scalar, n)
+
+	for i := range n {
+		x := g.NewScalar().SetUInt64(uint64(i + 1))
+		y := g.NewScalar()
+
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			y.Multiply(x).Add(coeffs[j])
+		}
+
+		shares[i] = y
+	}
+
+	return shares
+}
+
+func TestMtA_ToAdditive(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+		shares := shamirShares(group.group, secret, 1, 3)
+		participants := []uint64{1, 3}
+
+		sum := group.group.NewScalar()
+		publics := make(map[uint64]*ecc.Element)
+
+		for _, id := range participants {
+			s, err := mta.ToAdditive(shares[id-1], id, participants)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sum.Add(s)
+			publics[id] = group.group.Base().Multiply(shares[id-1])
+		}
+
+		if !sum.Equal(secret) {
+			t.Fatal("additive shares don't sum to the secret")
+		}
+
+		pk, err := mta.CombinePublicShares(publics)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pk.Equal(group.group.Base().Multiply(secret)) {
+			t.Fatal("combined public shares don't match the public key")
+		}
+
+		if _, err = mta.LagrangeCoefficient(group.group, 2, participants); err == nil {
+			t.Fatal("expected error for non-participant")
+		}
+
+		if _, err = mta.LagrangeCoefficient(group.group, 1, []uint64{1, 1}); err == nil {
+			t.Fatal("expected error for duplicate participants")
+		}
+	})
+}
+
+func TestMtA_Commitment(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		a := group.group.NewScalar().Random()
+		b := group.group.NewScalar().Random()
+		beta := group.group.NewScalar().Random()
+		alpha := a.Copy().Multiply(b).Subtract(beta)
+
+		c, err := mta.Commit(b, beta)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !c.Verify(a, alpha) {
+			t.Fatal("valid MtA output did not verify")
+		}
+
+		if c.Verify(a, alpha.Add(group.group.NewScalar().One())) {
+			t.Fatal("invalid MtA output verified")
+		}
+
+		context := []byte("participant 2")
+
+		proof, err := mta.ProveDLog(b, c.B, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !proof.Verify(c.B, context) {
+			t.Fatal("valid proof did not verify")
+		}
+
+		if proof.Verify(c.Beta, context) || proof.Verify(c.B, []byte("participant 3")) {
+			t.Fatal("proof verified for wrong inputs")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestMust(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		base := group.group.MustDecodeElement(ecc.MustHex(group.basePoint))
+		if !base.Equal(group.group.Base()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		s := group.group.NewScalar().Random()
+		if !group.group.MustDecodeScalar(s.Encode()).Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		err := panicError(func() {
+			_ = group.group.MustDecodeElement(make([]byte, group.group.ElementLength()-1))
+		})
+		if err == nil {
+			t.Fatal("expected panic on invalid element")
+		}
+
+		err = panicError(func() {
+			_ = group.group.MustDecodeScalar(bytesFF(group.group.ScalarLength()))
+		})
+		if !errors.Is(err, ecc.ErrInvalidScalarEncoding) {
+			t.Fatalf("expected panic on invalid scalar, got %v", err)
+		}
+	})
+
+	err := panicError(func() { _ = ecc.MustHex("0g") })
+	if !errors.As(err, new(hex.InvalidByteError)) {
+		t.Fatalf("expected panic on invalid hex, got %v", err)
+	}
+}
+
+// panicError runs f and returns the error it panicked with, if any.
+func panicError(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+
+	f()
+
+	return nil
+}
+
+func bytesFF(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = 0xff
+	}
+
+	return b
+}
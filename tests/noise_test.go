@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/noise"
+)
+
+func TestNoise_DH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		dh, err := noise.New(group.group)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if dh.DHName() == "" || dh.DHLen() != group.group.ElementLength() {
+			t.Fatal("unexpected DH parameters")
+		}
+
+		a, err := dh.GenerateKeypair(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := dh.GenerateKeypair(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ab, err := dh.DH(a.Private, b.Public)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ba, err := dh.DH(b.Private, a.Public)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ab) != dh.DHLen() || !bytes.Equal(ab, ba) {
+			t.Fatal("expected equal DH outputs")
+		}
+
+		// The key generation is deterministic given the randomness.
+		seed := bytes.Repeat([]byte{1}, 2*group.group.ScalarLength())
+
+		k1, _ := dh.GenerateKeypair(bytes.NewReader(seed))
+		k2, _ := dh.GenerateKeypair(bytes.NewReader(seed))
+
+		if !bytes.Equal(k1.Private, k2.Private) || !bytes.Equal(k1.Public, k2.Public) {
+			t.Fatal("expected deterministic key generation")
+		}
+
+		if _, err = dh.GenerateKeypair(bytes.NewReader(nil)); err == nil {
+			t.Fatal("expected error on short randomness")
+		}
+
+		if _, err = dh.DH(a.Private, group.group.NewElement().Encode()); !errors.Is(err, ecc.ErrIdentity) {
+			t.Fatalf("expected identity error, got %v", err)
+		}
+
+		if _, err = dh.DH(group.group.Order(), b.Public); err == nil {
+			t.Fatal("expected error on invalid private key")
+		}
+	})
+
+	if _, err := noise.New(ecc.Group(0)); err == nil {
+		t.Fatal("expected error on unsupported group")
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ntt"
+)
+
+func randomScalars(g ecc.Group, n int) []*ecc.Scalar {
+	s := make([]*ecc.Scalar, n)
+	for i := range s {
+		s[i] = g.NewScalar().Random()
+	}
+
+	return s
+}
+
+func copyScalars(s []*ecc.Scalar) []*ecc.Scalar {
+	c := make([]*ecc.Scalar, len(s))
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+
+	return c
+}
+
+// evaluate returns the evaluation of the polynomial with the coefficients at x, with Horner's method.
+func evaluate(g ecc.Group, coefficients []*ecc.Scalar, x *ecc.Scalar) *ecc.Scalar {
+	res := g.NewScalar()
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Multiply(x).Add(coefficients[i])
+	}
+
+	return res
+}
+
+func TestNTT(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		size := 1 << min(3, ntt.TwoAdicity(g))
+
+		d, err := ntt.NewDomain(g, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		one := g.NewScalar().One()
+		omega := d.Generator()
+
+		if d.Size() != size || d.Group() != g || !omega.Copy().Pow(g.NewScalar().SetUInt64(uint64(size))).Equal(one) {
+			t.Fatal("expected a root of unity of the domain size")
+		}
+
+		if size > 1 && omega.Copy().Pow(g.NewScalar().SetUInt64(uint64(size/2))).Equal(one) {
+			t.Fatal("expected a primitive root of unity")
+		}
+
+		// The transform evaluates the polynomial at the points of the domain, and the inverse interpolates it.
+		coefficients := randomScalars(g, size)
+		values := copyScalars(coefficients)
+
+		if err = d.NTT(values); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, v := range values {
+			if !d.Element(i).Equal(omega.Copy().Pow(g.NewScalar().SetUInt64(uint64(i)))) {
+				t.Fatalf("unexpected domain element %d", i)
+			}
+
+			if !v.Equal(evaluate(g, coefficients, d.Element(i))) {
+				t.Fatalf("unexpected evaluation at omega^%d", i)
+			}
+		}
+
+		if err = d.INTT(values); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, v := range values {
+			if !v.Equal(coefficients[i]) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+	})
+}
+
+func TestNTT_PallasMultiplication(t *testing.T) {
+	g := ecc.PallasBLAKE2b512
+
+	if ntt.TwoAdicity(g) != 32 {
+		t.Fatalf("unexpected 2-adicity %d", ntt.TwoAdicity(g))
+	}
+
+	// The product of two polynomials of degree 31 is the inverse transform of the product of their transforms of size
+	// 64.
+	const n = 64
+
+	d, err := ntt.NewDomain(g, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := randomScalars(g, n/2), randomScalars(g, n/2)
+	expected := make([]*ecc.Scalar, n)
+
+	for i := range expected {
+		expected[i] = g.NewScalar()
+	}
+
+	for i, x := range a {
+		for j, y := range b {
+			expected[i+j].MulAdd(x, y, expected[i+j])
+		}
+	}
+
+	fa, fb := copyScalars(a), copyScalars(b)
+	for range n / 2 {
+		fa, fb = append(fa, g.NewScalar()), append(fb, g.NewScalar())
+	}
+
+	_ = d.NTT(fa)
+	_ = d.NTT(fb)
+
+	for i := range fa {
+		fa[i].Multiply(fb[i])
+	}
+
+	_ = d.INTT(fa)
+
+	for i := range fa {
+		if !fa[i].Equal(expected[i]) {
+			t.Fatalf("unexpected coefficient %d", i)
+		}
+	}
+}
+
+func TestNTT_Errors(t *testing.T) {
+	g := ecc.P256Sha256
+
+	for _, size := range []int{0, -2, 3, 12, 1 << (ntt.TwoAdicity(g) + 1)} {
+		if _, err := ntt.NewDomain(g, size); err == nil {
+			t.Fatalf("expected error with size %d", size)
+		}
+	}
+
+	d, err := ntt.NewDomain(g, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, values := range map[string][]*ecc.Scalar{
+		"length": randomScalars(g, 2),
+		"nil":    {g.NewScalar(), nil, g.NewScalar(), g.NewScalar()},
+		"group":  {g.NewScalar(), ecc.Secp256k1Sha256.NewScalar(), g.NewScalar(), g.NewScalar()},
+	} {
+		if d.NTT(values) == nil || d.INTT(values) == nil {
+			t.Fatalf("expected error with %s", name)
+		}
+	}
+
+	if one, _ := ntt.NewDomain(g, 1); !one.Generator().Equal(g.NewScalar().One()) || !d.Element(-1).Equal(d.Element(3)) {
+		t.Fatal("unexpected domain elements")
+	}
+}
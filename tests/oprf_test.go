@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/oprf"
+)
+
+func oprfSupported(g ecc.Group) bool {
+	switch g {
+	case ecc.Ristretto255Sha512, ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestVOPRF(t *testing.T) {
+	inputs := [][]byte{[]byte("input 1"), []byte("input 2")}
+
+	testAllGroups(t, func(group *testGroup) {
+		v, err := oprf.NewVOPRF(group.group)
+		if !oprfSupported(group.group) {
+			if err == nil {
+				t.Fatal("expected error for unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk, pk, err := v.DeriveKeyPair([]byte("seed"), []byte("info"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		blinds := make([]*ecc.Scalar, len(inputs))
+		blinded := make([]*ecc.Element, len(inputs))
+
+		for i, input := range inputs {
+			if blinds[i], blinded[i], err = v.Blind(input, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		evaluated, proof, err := v.BlindEvaluate(sk, pk, blinded...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(oprf.Proof)
+		if err = decoded.Decode(group.group, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		outputs, err := v.Finalize(inputs, blinds, evaluated, blinded, pk, decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, input := range inputs {
+			if !v.VerifyOutput(sk, input, outputs[i]) {
+				t.Fatal("output does not match the server evaluation")
+			}
+		}
+
+		if bytes.Equal(outputs[0], outputs[1]) {
+			t.Fatal("different inputs have the same output")
+		}
+
+		if _, err = v.Finalize(inputs, blinds, evaluated, blinded, group.group.Base(), proof); err == nil {
+			t.Fatal("expected proof verification failure for wrong key")
+		}
+
+		evaluated[0], evaluated[1] = evaluated[1], evaluated[0]
+		if _, err = v.Finalize(inputs, blinds, evaluated, blinded, pk, proof); err == nil {
+			t.Fatal("expected proof verification failure for permuted evaluations")
+		}
+	})
+}
+
+func TestPOPRF(t *testing.T) {
+	input := []byte("input")
+	info := []byte("public info")
+
+	testAllGroups(t, func(group *testGroup) {
+		p, err := oprf.NewPOPRF(group.group)
+		if !oprfSupported(group.group) {
+			if err == nil {
+				t.Fatal("expected error for unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk, pk, err := p.DeriveKeyPair([]byte("seed"), []byte("info"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		blind, blinded, err := p.Blind(input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tweaked, err := p.TweakKey(pk, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evaluated, proof, err := p.BlindEvaluate(sk, info, blinded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inputs, blinds, b := [][]byte{input}, []*ecc.Scalar{blind}, []*ecc.Element{blinded}
+
+		outputs, err := p.Finalize(inputs, blinds, evaluated, b, proof, info, tweaked)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !p.VerifyOutput(sk, input, info, outputs[0]) || p.VerifyOutput(sk, input, nil, outputs[0]) {
+			t.Fatal("unexpected output verification")
+		}
+
+		if tweaked, err = p.TweakKey(pk, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = p.Finalize(inputs, blinds, evaluated, b, proof, nil, tweaked); err == nil {
+			t.Fatal("expected proof verification failure for wrong info")
+		}
+	})
+}
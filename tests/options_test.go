@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto"
+	"math"
+	"testing"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/ecc"
+)
+
+// maxExpandLength is the maximum byte length of the uniform strings of the RFC 9380 expanders.
+const maxExpandLength = 1<<16 - 1
+
+// securityLengths holds the RFC 9380 hash-to-field length L of the groups supporting options, and the uniform string
+// length for Ristretto255.
+var securityLengths = map[ecc.Group]uint{
+	ecc.Ristretto255Sha512: 64,
+	ecc.Edwards25519Sha512: 48,
+	ecc.PallasBLAKE2b512:   64,
+}
+
+func hashWithOptions(t *testing.T, g ecc.Group, encode bool, opts ...ecc.Option) *ecc.Element {
+	t.Helper()
+
+	f := g.HashToGroupWithOptions
+	if encode {
+		f = g.EncodeToGroupWithOptions
+	}
+
+	e, err := f(testHashToGroupInput, testHashToGroupDST, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return e
+}
+
+func TestHashToGroup_DefaultOptions(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if _, ok := securityLengths[g]; !ok {
+			return
+		}
+
+		xmd := ecc.WithExpandXMD(g.HashFunc())
+		l := ecc.WithSecurityLength(securityLengths[g])
+
+		hashCount := ecc.WithCount(2)
+		if g == ecc.Ristretto255Sha512 {
+			hashCount = ecc.WithCount(1)
+		}
+
+		if !hashWithOptions(t, g, false, xmd, l, hashCount).
+			Equal(g.HashToGroup(testHashToGroupInput, testHashToGroupDST)) {
+			t.Fatal("hash-to-group with default options differs")
+		}
+
+		if !hashWithOptions(t, g, true, xmd, l, ecc.WithCount(1)).
+			Equal(g.EncodeToGroup(testHashToGroupInput, testHashToGroupDST)) {
+			t.Fatal("encode-to-group with default options differs")
+		}
+
+		// The group's defaults apply to unset options.
+		if !hashWithOptions(t, g, false).Equal(g.HashToGroup(testHashToGroupInput, testHashToGroupDST)) {
+			t.Fatal("hash-to-group without options differs")
+		}
+
+		if !hashWithOptions(t, g, false, xmd).Equal(g.HashToGroup(testHashToGroupInput, testHashToGroupDST)) {
+			t.Fatal("hash-to-group with the default expander differs")
+		}
+	})
+}
+
+func TestHashToGroup_Options(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if _, ok := securityLengths[g]; !ok {
+			return
+		}
+
+		reference := g.HashToGroup(testHashToGroupInput, testHashToGroupDST)
+
+		other := crypto.SHA512
+		if g.HashFunc() == crypto.SHA512 {
+			other = crypto.SHA256
+		}
+
+		options := map[string]ecc.Option{
+			"hash": ecc.WithExpandXMD(other),
+			"xof":  ecc.WithExpandXOF(hash.SHAKE256),
+		}
+
+		if g != ecc.Ristretto255Sha512 {
+			options["security length"] = ecc.WithSecurityLength(securityLengths[g] + 16)
+			options["count"] = ecc.WithCount(3)
+		} else {
+			options["count"] = ecc.WithCount(2)
+		}
+
+		for name, opt := range options {
+			e := hashWithOptions(t, g, false, opt)
+			if e.IsIdentity() {
+				t.Fatalf("unexpected identity with option %s", name)
+			}
+
+			if e.Equal(reference) {
+				t.Fatalf("option %s doesn't change the output", name)
+			}
+
+			if !e.Equal(hashWithOptions(t, g, false, opt)) {
+				t.Fatalf("option %s is not deterministic", name)
+			}
+		}
+	})
+}
+
+func TestHashToGroup_InvalidOptions(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		if _, ok := securityLengths[g]; !ok {
+			if _, err := g.HashToGroupWithOptions(testHashToGroupInput, testHashToGroupDST, ecc.WithCount(3)); err == nil {
+				t.Fatal("expected error on a group not supporting options")
+			}
+
+			e, err := g.HashToGroupWithOptions(testHashToGroupInput, testHashToGroupDST)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !e.Equal(g.HashToGroup(testHashToGroupInput, testHashToGroupDST)) {
+				t.Fatal("hash-to-group without options differs")
+			}
+
+			return
+		}
+
+		invalid := map[string]ecc.Option{
+			"count":               ecc.WithCount(0),
+			"security length":     ecc.WithSecurityLength(16),
+			"unavailable hash":    ecc.WithExpandXMD(crypto.MD4),
+			"xof is not extended": ecc.WithExpandXOF(hash.SHA256),
+		}
+
+		if g == ecc.Ristretto255Sha512 {
+			invalid["security length"] = ecc.WithSecurityLength(securityLengths[g] + 16)
+		}
+
+		for name, opt := range invalid {
+			if _, err := g.HashToGroupWithOptions(testHashToGroupInput, testHashToGroupDST, opt); err == nil {
+				t.Fatalf("expected error on invalid %s option", name)
+			}
+
+			if _, err := g.EncodeToGroupWithOptions(testHashToGroupInput, testHashToGroupDST, opt); err == nil {
+				t.Fatalf("expected error on invalid %s option", name)
+			}
+		}
+
+		if _, err := g.HashToGroupWithOptions(testHashToGroupInput, nil); err == nil {
+			t.Fatal("expected error on empty DST")
+		}
+	})
+}
+
+func TestHashToGroup_OptionsExpandLength(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		l, ok := securityLengths[g]
+		if !ok {
+			return
+		}
+
+		tooLong := map[string][]ecc.Option{
+			// count * L overflows.
+			"overflow": {ecc.WithExpandXOF(hash.SHAKE256), ecc.WithCount(math.MaxUint)},
+			// count * L exceeds 65535 bytes.
+			"length": {ecc.WithExpandXOF(hash.SHAKE256), ecc.WithCount(maxExpandLength/l + 1)},
+			// ceil(count * L / 32) exceeds 255 SHA-256 outputs.
+			"xmd blocks": {ecc.WithExpandXMD(crypto.SHA256), ecc.WithCount(255*32/l + 1)},
+		}
+
+		for name, opts := range tooLong {
+			if _, err := g.HashToGroupWithOptions(testHashToGroupInput, testHashToGroupDST, opts...); err == nil {
+				t.Fatalf("expected error on the %s limit", name)
+			}
+
+			if _, err := g.EncodeToGroupWithOptions(testHashToGroupInput, testHashToGroupDST, opts...); err == nil {
+				t.Fatalf("expected error on the %s limit", name)
+			}
+		}
+
+		// The longest lengths are accepted.
+		hashWithOptions(t, g, false, ecc.WithExpandXOF(hash.SHAKE256), ecc.WithCount(maxExpandLength/l))
+		hashWithOptions(t, g, false, ecc.WithExpandXMD(crypto.SHA256), ecc.WithCount(255*32/l))
+	})
+}
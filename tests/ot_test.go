@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc/ot"
+)
+
+func TestSimplestOT(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sender := ot.NewSender(group.group)
+
+		for index, choice := range []bool{false, true, true, false} {
+			receiver, err := ot.NewReceiver(uint64(index), sender.A, choice)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k0, k1, err := sender.Keys(uint64(index), receiver.B)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			chosen, other := k0, k1
+			if choice {
+				chosen, other = k1, k0
+			}
+
+			if !bytes.Equal(receiver.Key(), chosen) || bytes.Equal(receiver.Key(), other) {
+				t.Fatalf("unexpected receiver key for choice %v", choice)
+			}
+		}
+
+		if _, _, err := sender.Keys(0, group.group.NewElement()); err == nil {
+			t.Fatal("expected error on identity message")
+		}
+
+		if _, err := ot.NewReceiver(0, group.group.NewElement(), false); err == nil {
+			t.Fatal("expected error on identity message")
+		}
+	})
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+// pastaGenerator is the pasta_curves encoding of the Pallas generator (-1, 2).
+const pastaGenerator = "00000000ed302d991bf94c09fc98462200000000000000000000000000000040"
+
+func TestPallasZcash_Generator(t *testing.T) {
+	g := ecc.PallasBLAKE2b512
+
+	encoded, err := encoding.MarshalPallasZcash(g.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(encoded) != pastaGenerator {
+		t.Fatalf("unexpected generator encoding %x", encoded)
+	}
+
+	// -G has the same x-coordinate and the odd y.
+	if encoded, err = encoding.MarshalPallasZcash(g.Base().Negate()); err != nil {
+		t.Fatal(err)
+	}
+
+	if encoded[31] != 0xc0 {
+		t.Fatalf("unexpected sign bit in %x", encoded)
+	}
+
+	decoded, err := encoding.ParsePallasZcash(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Equal(g.Base().Negate()) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestPallasZcash_RoundTrip(t *testing.T) {
+	g := ecc.PallasBLAKE2b512
+
+	for _, e := range []*ecc.Element{
+		g.NewElement(),
+		g.Base().Multiply(g.NewScalar().Random()),
+		g.HashToGroup([]byte("input"), []byte("domain separation tag")),
+	} {
+		encoded, err := encoding.MarshalPallasZcash(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := encoding.ParsePallasZcash(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestPallasZcash_Invalid(t *testing.T) {
+	if _, err := encoding.MarshalPallasZcash(ecc.P256Sha256.Base()); err == nil {
+		t.Fatal("expected error on non-Pallas element")
+	}
+
+	generator, _ := hex.DecodeString(pastaGenerator)
+
+	// p + 1, a non-canonical encoding of x = 1.
+	nonCanonical := make([]byte, 32)
+	copy(nonCanonical, generator)
+	nonCanonical[0] = 2
+
+	for _, data := range [][]byte{
+		nil,
+		generator[:31],
+		nonCanonical,
+		{31: 0x80}, // the sign bit set on x = 0
+	} {
+		if _, err := encoding.ParsePallasZcash(data); err == nil {
+			t.Fatalf("expected error on %x", data)
+		}
+	}
+}
+
+func TestPallas_DecodeSqrt(t *testing.T) {
+	g := ecc.PallasBLAKE2b512
+	p := g.FieldOrderBig()
+	exp := new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 1)
+
+	// Decoding accepts exactly the x-coordinates for which x^3 + 5 is a square, by Euler's criterion, and returns a
+	// square root with the requested sign.
+	for i := range 200 {
+		x := new(big.Int).SetBytes(internal.RandomBytes(32))
+		x.Mod(x, p)
+
+		y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+		y2.Add(y2, big.NewInt(5)).Mod(y2, p)
+		isSquare := new(big.Int).Exp(y2, exp, p).Cmp(big.NewInt(1)) == 0
+
+		data := append([]byte{0x02 | byte(i&1)}, x.FillBytes(make([]byte, 32))...)
+		e := g.NewElement()
+
+		if err := e.Decode(data); (err == nil) != isSquare {
+			t.Fatalf("unexpected decoding result %v for x = %x", err, x)
+		}
+
+		if isSquare && !bytes.Equal(e.Encode(), data) {
+			t.Fatal("expected the decoded element to re-encode identically")
+		}
+	}
+}
+
+func TestPallas_Encode(t *testing.T) {
+	g := ecc.PallasBLAKE2b512
+
+	// The identity encodes to zeros, including when it results from arithmetic rather than construction.
+	id := g.Base().Subtract(g.Base())
+	if !bytes.Equal(id.Encode(), make([]byte, g.ElementLength())) {
+		t.Fatalf("unexpected identity encoding %x", id.Encode())
+	}
+
+	if !bytes.Equal(id.XCoordinate(), make([]byte, g.ElementLength()-1)) {
+		t.Fatalf("unexpected identity x-coordinate %x", id.XCoordinate())
+	}
+
+	// Both signs of y round-trip, and the x-coordinate is always written at its full length.
+	for _, e := range []*ecc.Element{
+		g.Base(),
+		g.Base().Negate(),
+		g.Base().Multiply(g.NewScalar().Random()),
+	} {
+		for _, p := range []*ecc.Element{e, e.Copy().Negate()} {
+			encoded := p.Encode()
+			if len(encoded) != g.ElementLength() || encoded[0]&^1 != 0x02 {
+				t.Fatalf("unexpected encoding %x", encoded)
+			}
+
+			if !bytes.Equal(encoded[1:], p.XCoordinate()) {
+				t.Fatal("expected the encoding to contain the x-coordinate")
+			}
+
+			decoded := g.NewElement()
+			if err := decoded.Decode(encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if !decoded.Equal(p) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+	}
+}
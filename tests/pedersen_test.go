@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/pedersen"
+)
+
+func TestPedersen_VectorCommit(t *testing.T) {
+	dst := []byte("ECC-Pedersen-Test")
+	context := []byte("context")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		params := pedersen.Setup(g, 8, dst)
+		values := make([]*ecc.Scalar, 6)
+
+		for i := range values {
+			values[i] = g.NewScalar().Random()
+		}
+
+		blind := g.NewScalar().Random()
+
+		commitment, err := params.VectorCommit(values, blind)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Commitments are padded with zeros and hiding.
+		padded, _ := params.VectorCommit(append(values, g.NewScalar()), blind)
+		reblinded, _ := params.VectorCommit(values, g.NewScalar().Random())
+
+		if !padded.Equal(commitment) || reblinded.Equal(commitment) {
+			t.Fatal("unexpected commitment")
+		}
+
+		for _, indices := range [][]int{{0}, {5}, {7}, {1, 3, 4}, {0, 1, 2, 3, 4, 5, 6, 7}, {}} {
+			opening, err := params.Open(commitment, values, blind, indices, context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !params.VerifyOpening(commitment, opening, context) {
+				t.Fatalf("valid opening at %v did not verify", indices)
+			}
+
+			if params.VerifyOpening(commitment, opening, []byte("other")) ||
+				params.VerifyOpening(reblinded, opening, context) {
+				t.Fatal("opening verified for wrong inputs")
+			}
+
+			if len(indices) > 0 {
+				opening.Values[0] = g.NewScalar().Random()
+				if params.VerifyOpening(commitment, opening, context) {
+					t.Fatal("opening with a wrong value verified")
+				}
+			}
+		}
+
+		opening, err := params.OpenAt(commitment, values, blind, 2, context)
+		if err != nil || !opening.Values[0].Equal(values[2]) || !params.VerifyOpening(commitment, opening, context) {
+			t.Fatal("invalid single index opening")
+		}
+
+		opening.Indices[0] = 3
+		if params.VerifyOpening(commitment, opening, context) {
+			t.Fatal("opening at another index verified")
+		}
+
+		if _, err = params.Open(commitment, values, blind, []int{1, 1}, context); err == nil {
+			t.Fatal("expected error on duplicate index")
+		}
+
+		if _, err = params.OpenAt(commitment, values, blind, 8, context); err == nil {
+			t.Fatal("expected error on out of range index")
+		}
+
+		if _, err = params.VectorCommit(make([]*ecc.Scalar, 9), blind); err == nil {
+			t.Fatal("expected error on too long vector")
+		}
+
+		if _, err = params.VectorCommit(values, nil); err == nil {
+			t.Fatal("expected error on nil blind")
+		}
+	})
+}
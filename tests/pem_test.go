@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestPEM_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		pub, err := encoding.EncodePublicKeyPEM(pk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.HasPrefix(pub, []byte("-----BEGIN PUBLIC KEY-----")) {
+			t.Fatalf("unexpected PEM block: %s", pub)
+		}
+
+		decoded, err := encoding.DecodePublicKeyPEM(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, _, err = encoding.DecodePrivateKeyPEM(pub); err == nil {
+			t.Fatal("expected error on public key decoded as private key")
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			return
+		}
+
+		for _, encode := range []func(*ecc.Scalar) ([]byte, error){
+			encoding.EncodePrivateKeyPEM,
+			encoding.EncodeECPrivateKeyPEM,
+		} {
+			priv, err := encode(sk)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, p, err := encoding.DecodePrivateKeyPEM(priv)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !s.Equal(sk) || !p.Equal(pk) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			if _, err = encoding.DecodePublicKeyPEM(priv); err == nil {
+				t.Fatal("expected error on private key decoded as public key")
+			}
+		}
+	})
+}
+
+func TestPEM_X509(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if !isNIST(group.group) {
+			return
+		}
+
+		sk := group.group.NewScalar().Random()
+
+		priv, err := encoding.EncodeECPrivateKeyPEM(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		block, _ := pem.Decode(priv)
+		if block == nil || block.Type != "EC PRIVATE KEY" {
+			t.Fatal("expected an EC PRIVATE KEY block")
+		}
+
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+		encoded, err := encoding.EncodePublicKeyPEM(group.group.Base().Multiply(sk))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(pub, encoded) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		parsed, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !parsed.(*ecdsa.PublicKey).Equal(&key.PublicKey) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestPEM_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, pk, err := encoding.DecodePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(pk.Encode(), pub) || !ecc.Edwards25519Sha512.Base().Multiply(sk).Equal(pk) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if der, err = x509.MarshalPKIXPublicKey(pub); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := encoding.EncodePublicKeyPEM(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(encoded, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestPEM_Invalid(t *testing.T) {
+	if _, err := encoding.DecodePublicKeyPEM([]byte("not a PEM block")); err == nil {
+		t.Fatal("expected error on missing PEM block")
+	}
+
+	if _, _, err := encoding.DecodePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY"})); err == nil {
+		t.Fatal("expected error on unexpected block type")
+	}
+
+	encrypted := pem.EncodeToMemory(&pem.Block{
+		Type:    "EC PRIVATE KEY",
+		Headers: map[string]string{"Proc-Type": "4,ENCRYPTED"},
+	})
+	if _, _, err := encoding.DecodePrivateKeyPEM(encrypted); err == nil {
+		t.Fatal("expected error on encrypted block")
+	}
+}
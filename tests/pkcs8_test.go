@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestPKCS8_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+
+		der, err := encoding.MarshalPKCS8(sk)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, pk, err := encoding.ParsePKCS8(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Equal(sk) || !pk.Equal(group.group.Base().Multiply(sk)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		der, err = encoding.MarshalEC(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s, _, err = encoding.ParseEC(der); err != nil || !s.Equal(sk) {
+			t.Fatalf("unexpected SEC 1 round trip: %v", err)
+		}
+
+		if _, _, err = encoding.ParsePKCS8(der); err == nil {
+			t.Fatal("expected error on SEC 1 key parsed as PKCS #8")
+		}
+	})
+}
+
+func TestPKCS8_X509(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if !isNIST(group.group) {
+			return
+		}
+
+		curve := map[ecc.Group]elliptic.Curve{
+			ecc.P256Sha256: elliptic.P256(),
+			ecc.P384Sha384: elliptic.P384(),
+			ecc.P521Sha512: elliptic.P521(),
+		}[group.group]
+
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sec1, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, parsed := range []func() (*ecc.Scalar, *ecc.Element, error){
+			func() (*ecc.Scalar, *ecc.Element, error) { return encoding.ParsePKCS8(pkcs8) },
+			func() (*ecc.Scalar, *ecc.Element, error) { return encoding.ParseEC(sec1) },
+		} {
+			sk, _, err := parsed()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(sk.Encode(), key.D.FillBytes(make([]byte, group.group.ScalarLength()))) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		sk := group.group.NewScalar().Random()
+
+		der, err := encoding.MarshalPKCS8(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(parsed.(*ecdsa.PrivateKey).D.FillBytes(make([]byte, group.group.ScalarLength())), sk.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if der, err = encoding.MarshalEC(sk); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = x509.ParseECPrivateKey(der); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPKCS8_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, pk, err := encoding.ParsePKCS8(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(pk.Encode(), pub) || !ecc.Edwards25519Sha512.Base().Multiply(sk).Equal(pk) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if _, err = encoding.MarshalPKCS8(sk); err == nil {
+		t.Fatal("expected error on Ed25519 key marshaling")
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc/poseidon"
+)
+
+func TestPoseidon_Permute(t *testing.T) {
+	for _, params := range []*poseidon.Parameters{poseidon.Pallas(), poseidon.Vesta()} {
+		state := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+		if err := params.Permute(state); err != nil {
+			t.Fatal(err)
+		}
+
+		again := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+		if err := params.Permute(again); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range state {
+			if state[i].Cmp(again[i]) != 0 {
+				t.Fatal("permutation is not deterministic")
+			}
+
+			if state[i].Cmp(params.Order()) >= 0 {
+				t.Fatal("permutation output is not reduced")
+			}
+		}
+
+		if err := params.Permute(state[:2]); err == nil {
+			t.Fatal("expected error on invalid state width")
+		}
+
+		if err := params.Permute([]*big.Int{params.Order(), big.NewInt(0), big.NewInt(0)}); err == nil {
+			t.Fatal("expected error on non-canonical element")
+		}
+	}
+}
+
+func TestPoseidon_Hash(t *testing.T) {
+	params := poseidon.Pallas()
+	x, y := big.NewInt(1), big.NewInt(2)
+
+	h, err := poseidon.Hash(params, x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hash is the first squeezed element of a sponge with the ConstantLength capacity.
+	s, err := poseidon.NewSponge(params, new(big.Int).Lsh(big.NewInt(2), 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s.Absorb(x, y); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Squeeze().Cmp(h) != 0 {
+		t.Fatal("hash does not match the sponge output")
+	}
+
+	if err = s.Absorb(x); err == nil {
+		t.Fatal("expected error on absorbing after squeezing")
+	}
+
+	h2, err := poseidon.Hash(params, y, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h3, err := poseidon.Hash(params, x, y, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hv, err := poseidon.Hash(poseidon.Vesta(), x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Cmp(h2) == 0 || h.Cmp(h3) == 0 || h.Cmp(hv) == 0 {
+		t.Fatal("hash collision on different inputs")
+	}
+}
+
+func TestPoseidon_Halo2Vectors(t *testing.T) {
+	// The P128Pow5T3 permutation of [0, 1, 2]. The Pallas outputs are those of Halo2's test_against_reference, which
+	// come from the pasta-hadeshash reference implementation, and confirm the Grain constants and the Cauchy MDS
+	// matrix. The Vesta outputs were generated with this implementation.
+	vectors := []struct {
+		params   *poseidon.Parameters
+		expected [poseidon.Width]string
+	}{
+		{
+			params: poseidon.Pallas(),
+			expected: [poseidon.Width]string{
+				"2a526acd0b64b45394efb364f966240ff7e69a71d0b642a0aeb1bc024aeca456",
+				"13c5d1568b4aa43076ff7dae343d5512dcd42e7fbed9dafe012a3e9628e5b82a",
+				"0a49c868c6976544256fcd597984561af7cfdfe1bda42c7b359029a1d34e9ddd",
+			},
+		},
+		{
+			params: poseidon.Vesta(),
+			expected: [poseidon.Width]string{
+				"315a1f4cdb942f7ceddd74f22f8f2ff74d43d1973dd336c60eb08ea813bebe59",
+				"3be475f2d7642bde642adee0dd13aa48413ee0eb7bbd2198f9f126e61ea165f1",
+				"25ab8aece9537168117fdb2420d8ea605019bfd4e0423fa014d542372a7ba0d9",
+			},
+		},
+	}
+
+	for _, v := range vectors {
+		state := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+		if err := v.params.Permute(state); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, x := range state {
+			if res := fmt.Sprintf("%064x", x); res != v.expected[i] {
+				t.Fatalf("unexpected permutation output %d: %s", i, res)
+			}
+		}
+
+		// As in Halo2's orchard_spec_equivalence, Hash<ConstantLength<2>> is the first element of the permutation of
+		// the message followed by the capacity element 2 * 2^64.
+		m0, m1 := big.NewInt(6), big.NewInt(42)
+
+		h, err := poseidon.Hash(v.params, m0, m1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		state = []*big.Int{m0, m1, new(big.Int).Lsh(big.NewInt(2), 64)}
+		if err = v.params.Permute(state); err != nil {
+			t.Fatal(err)
+		}
+
+		if h.Cmp(state[0]) != 0 {
+			t.Fatal("the hash doesn't match the permutation of the message and the ConstantLength capacity")
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestPrecomputedTable(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		point := g.HashToGroup(testHashToGroupInput, testHashToGroupDST)
+		table := ecc.NewPrecomputedTable(point)
+
+		if table.Group() != g || !table.Element().Equal(point) {
+			t.Fatal("unexpected table group or element")
+		}
+
+		scalars := []*ecc.Scalar{
+			g.NewScalar(),
+			g.NewScalar().One(),
+			g.NewScalar().MinusOne(),
+			g.NewScalar().SetUInt64(0xf0f0),
+		}
+
+		for range 10 {
+			scalars = append(scalars, g.NewScalar().Random())
+		}
+
+		for _, s := range scalars {
+			if !table.MultiplyVartime(s).Equal(point.Copy().Multiply(s)) {
+				t.Fatalf("unexpected multiplication result for %s", s.Hex())
+			}
+		}
+
+		if !table.MultiplyVartime(nil).IsIdentity() {
+			t.Fatal("expected identity for nil scalar")
+		}
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		if hasPanic, _ := hasPanic(func() { _ = table.MultiplyVartime(other.NewScalar().One()) }); !hasPanic {
+			t.Fatal("expected panic on group mismatch")
+		}
+	})
+}
+
+func TestBaseTable_Concurrent(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		expected := g.Base().Multiply(s)
+		tables := make([]*ecc.PrecomputedTable, 8)
+
+		var wg sync.WaitGroup
+
+		for i := range tables {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				tables[i] = g.BaseTable()
+				if !tables[i].MultiplyVartime(s).Equal(expected) {
+					t.Error("unexpected base table multiplication result")
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		for _, table := range tables[1:] {
+			if table != tables[0] {
+				t.Fatal("expected a single shared base table")
+			}
+		}
+
+		if !tables[0].Element().Equal(g.Base()) {
+			t.Fatal("the base table's element is not the generator")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/privacypass"
+)
+
+func testPrivacyPass(t *testing.T, tokenType uint16, extensions []byte) {
+	issuer, err := privacypass.NewIssuer(tokenType, ecc.P384Sha384.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := privacypass.NewClient(tokenType, issuer.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, state, err := client.Request([]byte("token challenge"), extensions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedReq := new(privacypass.TokenRequest)
+	if err = decodedReq.Decode(req.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := issuer.Issue(decodedReq, extensions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedResp := new(privacypass.TokenResponse)
+	if err = decodedResp.Decode(resp.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := client.Finalize(state, decodedResp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedToken := new(privacypass.Token)
+	if err = decodedToken.Decode(token.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !issuer.Redeem(decodedToken, extensions) {
+		t.Fatal("valid token was not redeemed")
+	}
+
+	decodedToken.Nonce[0] ^= 1
+	if issuer.Redeem(decodedToken, extensions) {
+		t.Fatal("modified token was redeemed")
+	}
+
+	other, err := privacypass.NewIssuer(tokenType, ecc.P384Sha384.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other.Redeem(token, extensions) {
+		t.Fatal("token was redeemed by another issuer")
+	}
+}
+
+func TestPrivacyPass_VOPRF(t *testing.T) {
+	testPrivacyPass(t, privacypass.TokenTypeVOPRF, nil)
+
+	issuer, _ := privacypass.NewIssuer(privacypass.TokenTypeVOPRF, ecc.P384Sha384.NewScalar().Random())
+	client, _ := privacypass.NewClient(privacypass.TokenTypeVOPRF, issuer.PublicKey())
+
+	if _, _, err := client.Request(nil, []byte("extensions")); err == nil {
+		t.Fatal("expected error on extensions without public metadata")
+	}
+}
+
+func TestPrivacyPass_PublicMetadata(t *testing.T) {
+	extensions := []byte("public metadata")
+	testPrivacyPass(t, privacypass.TokenTypePOPRF, extensions)
+
+	issuer, _ := privacypass.NewIssuer(privacypass.TokenTypePOPRF, ecc.P384Sha384.NewScalar().Random())
+	client, _ := privacypass.NewClient(privacypass.TokenTypePOPRF, issuer.PublicKey())
+
+	req, state, err := client.Request(nil, extensions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := issuer.Issue(req, []byte("other metadata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.Finalize(state, resp); err == nil {
+		t.Fatal("expected error on mismatching extensions")
+	}
+
+	if _, err = privacypass.NewIssuer(0x0002, ecc.P384Sha384.NewScalar().Random()); err == nil {
+		t.Fatal("expected error on unsupported token type")
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc/psi"
+)
+
+func TestPSI(t *testing.T) {
+	alice := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	bob := [][]byte{[]byte("e"), []byte("d"), []byte("b")}
+
+	testAllGroups(t, func(group *testGroup) {
+		ka, kb := psi.NewKey(group.group), psi.NewKey(group.group)
+
+		// Alice sends her masked items to Bob, who masks them again and sends them back.
+		maskedA, err := psi.MaskItems(ka, alice...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doubleA, err := psi.Mask(kb, maskedA...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Bob sends his masked items to Alice, who masks them again.
+		maskedB, err := psi.MaskItems(kb, bob...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doubleB, err := psi.Mask(ka, maskedB...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(psi.Intersect(doubleA, doubleB), []int{1, 3}) {
+			t.Fatal("unexpected intersection")
+		}
+
+		if psi.Cardinality(doubleB, doubleA) != 2 {
+			t.Fatal("unexpected cardinality")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err = psi.MaskItemsContext(ctx, ka, alice...); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected cancellation error, got %v", err)
+		}
+
+		if _, err = psi.MaskContext(ctx, kb, maskedA...); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected cancellation error, got %v", err)
+		}
+
+		if _, err = psi.Mask(ka, group.group.NewElement()); err == nil {
+			t.Fatal("expected error on identity element")
+		}
+
+		if _, err = psi.MaskItems(group.group.NewScalar(), alice...); err == nil {
+			t.Fatal("expected error on zero key")
+		}
+	})
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/redpallas"
+)
+
+func TestRedPallas(t *testing.T) {
+	message := []byte("sighash")
+
+	for _, v := range []redpallas.Variant{redpallas.SpendAuth, redpallas.Binding} {
+		sk := redpallas.NewSigningKey()
+
+		vk, err := v.VerificationKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := v.Sign(sk, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded := sig.Encode()
+		if len(encoded) != 64 {
+			t.Fatalf("unexpected signature length %d", len(encoded))
+		}
+
+		decoded := new(redpallas.Signature)
+		if err = decoded.Decode(encoded); err != nil {
+			t.Fatal(err)
+		}
+
+		if !v.Verify(vk, message, decoded) {
+			t.Fatal("valid signature did not verify")
+		}
+
+		if v.Verify(vk, []byte("other"), sig) || v.Verify(ecc.PallasBLAKE2b512.Base(), message, sig) {
+			t.Fatal("signature verified for wrong inputs")
+		}
+	}
+
+	if redpallas.SpendAuth.Generator().Equal(redpallas.Binding.Generator()) {
+		t.Fatal("variants share a generator")
+	}
+}
+
+// These signatures use the secret keys 0x1234 and nonces 0xabcd, with S computed outside of this module using
+// Python's hashlib BLAKE2b-512 personalized with "Zcash_RedPallasH" for H*. They check H* and the encodings, but not
+// the generators, as vk and R were computed with this module.
+var redPallasVectors = []struct {
+	vk, sig string
+	variant redpallas.Variant
+}{
+	{
+		variant: redpallas.SpendAuth,
+		vk:      "25425507f071b1780ab51e032a2590be5159aceae9e5480ae4e5ef8407acddb7",
+		sig: "c5f965c098d347460230adcca78815a5958ea3dc2a38d304395d944879f103b6" +
+			"e3c90e82da59356c2c5637ab0764642e078ff0160d4313b283aaba3f9fa1cd0f",
+	},
+	{
+		variant: redpallas.Binding,
+		vk:      "101c882fa27d121030aa93735afbe710372c3ff9090008df2ffec556bddb1c0e",
+		sig: "b8b04d702ec5990b8168c1645a81486c9e59a9e0a20c335f256b7d2edc66f81f" +
+			"d99d231c15ee8e26911b5697aa7c4464675b0f706f7d67bc8e5ddada259e2a07",
+	},
+}
+
+func TestRedPallas_Vectors(t *testing.T) {
+	message := []byte("redpallas test vector")
+
+	for _, v := range redPallasVectors {
+		vkBytes, _ := hex.DecodeString(v.vk)
+		sigBytes, _ := hex.DecodeString(v.sig)
+
+		vk, err := redpallas.DecodeVerificationKey(vkBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk := ecc.PallasBLAKE2b512.NewScalar().SetUInt64(0x1234)
+		if expected, _ := v.variant.VerificationKey(sk); !vk.Equal(expected) {
+			t.Fatal("unexpected verification key")
+		}
+
+		sig := new(redpallas.Signature)
+		if err = sig.Decode(sigBytes); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(sig.Encode(), sigBytes) {
+			t.Fatal("signature encoding does not round trip")
+		}
+
+		if !v.variant.Verify(vk, message, sig) {
+			t.Fatal("valid signature did not verify")
+		}
+
+		// S is little-endian, so the last byte is the most significant one.
+		tampered := slices.Clone(sigBytes)
+		tampered[32]++
+
+		if err = sig.Decode(tampered); err != nil {
+			t.Fatal(err)
+		}
+
+		if v.variant.Verify(vk, message, sig) {
+			t.Fatal("tampered signature verified")
+		}
+
+		tampered[63] = 0xff
+		if err = sig.Decode(tampered); err == nil {
+			t.Fatal("expected error on non-canonical S")
+		}
+	}
+}
+
+func TestRedPallas_Randomize(t *testing.T) {
+	message := []byte("sighash")
+	sk := redpallas.NewSigningKey()
+	vk, _ := redpallas.SpendAuth.VerificationKey(sk)
+	alpha := redpallas.NewRandomizer()
+
+	rsk, err := redpallas.SpendAuth.RandomizeSigningKey(sk, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rvk, err := redpallas.SpendAuth.RandomizeVerificationKey(vk, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := redpallas.SpendAuth.Sign(rsk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !redpallas.SpendAuth.Verify(rvk, message, sig) {
+		t.Fatal("signature with randomized key did not verify")
+	}
+
+	if redpallas.SpendAuth.Verify(vk, message, sig) {
+		t.Fatal("signature with randomized key verified under the original key")
+	}
+
+	if _, err = redpallas.Binding.RandomizeSigningKey(sk, alpha); err == nil {
+		t.Fatal("expected error on binding key randomization")
+	}
+
+	if _, err = redpallas.SpendAuth.Sign(ecc.P256Sha256.NewScalar().One(), message); err == nil {
+		t.Fatal("expected error on non-Pallas key")
+	}
+}
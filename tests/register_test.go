@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	registeredID    = 200
+	registeredSuite = "external_XMD:SHA-256_SSWU_RO_"
+)
+
+// externalGroup is an out-of-package group implementation, which delegates to P-256 under another ciphersuite.
+type externalGroup struct {
+	ecc.Group
+}
+
+func (g externalGroup) NewScalar() ecc.ScalarImplementation {
+	return g.Group.NewScalar().Scalar
+}
+
+func (g externalGroup) NewElement() ecc.ElementImplementation {
+	return g.Group.NewElement().Element
+}
+
+func (g externalGroup) Base() ecc.ElementImplementation {
+	return g.Group.Base().Element
+}
+
+func (g externalGroup) HashFunc() crypto.Hash {
+	return g.Group.HashFunc()
+}
+
+func (g externalGroup) HashToScalar(input, dst []byte) ecc.ScalarImplementation {
+	return g.Group.HashToScalar(input, dst).Scalar
+}
+
+func (g externalGroup) HashToGroup(input, dst []byte) ecc.ElementImplementation {
+	return g.Group.HashToGroup(input, dst).Element
+}
+
+func (g externalGroup) EncodeToGroup(input, dst []byte) ecc.ElementImplementation {
+	return g.Group.EncodeToGroup(input, dst).Element
+}
+
+func (g externalGroup) Ciphersuite() string {
+	return registeredSuite
+}
+
+// lastGroup is registered with the last group identifier.
+type lastGroup struct {
+	externalGroup
+}
+
+func (g lastGroup) Ciphersuite() string {
+	return "last_XMD:SHA-256_SSWU_RO_"
+}
+
+var (
+	registerOnce  sync.Once
+	registerGroup ecc.Group
+	registerErr   error
+)
+
+func registerExternal(t *testing.T) ecc.Group {
+	t.Helper()
+
+	registerOnce.Do(func() {
+		registerGroup, registerErr = ecc.Register(registeredID, func() ecc.GroupImplementation {
+			return externalGroup{ecc.P256Sha256}
+		})
+	})
+
+	if registerErr != nil {
+		t.Fatal(registerErr)
+	}
+
+	return registerGroup
+}
+
+// registerTestGroup registers the group once, so that the tests can run repeatedly.
+func registerTestGroup(t *testing.T, id byte, constructor func() ecc.GroupImplementation) ecc.Group {
+	t.Helper()
+
+	if g := ecc.Group(id); g.Available() {
+		return g
+	}
+
+	g, err := ecc.Register(id, constructor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestRegister(t *testing.T) {
+	g := registerExternal(t)
+
+	if g != ecc.Group(registeredID) || !g.Available() {
+		t.Fatal("expected the registered group to be available")
+	}
+
+	if g.String() != registeredSuite || g.Name() != registeredSuite {
+		t.Fatalf("unexpected identifiers %q and %q", g.String(), g.Name())
+	}
+
+	if !g.Base().Equal(ecc.P256Sha256.Base()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !g.HashToGroup(testHashToGroupInput, testHashToGroupDST).
+		Equal(ecc.P256Sha256.HashToGroup(testHashToGroupInput, testHashToGroupDST)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	s, err := ecc.LookupSuite(registeredSuite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Group != g || s.Mode != ecc.RandomOracle {
+		t.Fatalf("unexpected suite %v", s)
+	}
+
+	if err = g.SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// relabeledElement is a P-256 element implementation that reports the identifier of a registered group. Operands
+// are unwrapped before reaching P-256, and the elements it creates are relabeled.
+type relabeledElement struct {
+	ecc.ElementImplementation
+	id byte
+}
+
+func (e relabeledElement) p256() ecc.ElementImplementation {
+	return e.ElementImplementation
+}
+
+// p256Wrapper is implemented by the test elements wrapping a P-256 element.
+type p256Wrapper interface {
+	p256() ecc.ElementImplementation
+}
+
+func unwrapRelabeled(e ecc.ElementImplementation) ecc.ElementImplementation {
+	if r, ok := e.(p256Wrapper); ok {
+		return r.p256()
+	}
+
+	return e
+}
+
+func (e relabeledElement) Group() byte {
+	return e.id
+}
+
+func (e relabeledElement) Base() ecc.ElementImplementation {
+	return relabeledElement{e.ElementImplementation.Base(), e.id}
+}
+
+func (e relabeledElement) Identity() ecc.ElementImplementation {
+	return relabeledElement{e.ElementImplementation.Identity(), e.id}
+}
+
+func (e relabeledElement) Copy() ecc.ElementImplementation {
+	return relabeledElement{e.ElementImplementation.Copy(), e.id}
+}
+
+func (e relabeledElement) Add(element ecc.ElementImplementation) ecc.ElementImplementation {
+	e.ElementImplementation.Add(unwrapRelabeled(element))
+	return e
+}
+
+func (e relabeledElement) Subtract(element ecc.ElementImplementation) ecc.ElementImplementation {
+	e.ElementImplementation.Subtract(unwrapRelabeled(element))
+	return e
+}
+
+func (e relabeledElement) Set(element ecc.ElementImplementation) ecc.ElementImplementation {
+	e.ElementImplementation.Set(unwrapRelabeled(element))
+	return e
+}
+
+func (e relabeledElement) Equal(element ecc.ElementImplementation) int {
+	return e.ElementImplementation.Equal(unwrapRelabeled(element))
+}
+
+type relabeledGroup struct {
+	externalGroup
+	id byte
+}
+
+func (g relabeledGroup) NewElement() ecc.ElementImplementation {
+	return relabeledElement{g.externalGroup.NewElement(), g.id}
+}
+
+func (g relabeledGroup) Base() ecc.ElementImplementation {
+	return relabeledElement{g.externalGroup.Base(), g.id}
+}
+
+func (g relabeledGroup) HashToGroup(input, dst []byte) ecc.ElementImplementation {
+	return relabeledElement{g.externalGroup.HashToGroup(input, dst), g.id}
+}
+
+func (g relabeledGroup) EncodeToGroup(input, dst []byte) ecc.ElementImplementation {
+	return relabeledElement{g.externalGroup.EncodeToGroup(input, dst), g.id}
+}
+
+func (g relabeledGroup) Ciphersuite() string {
+	return "relabeled_XMD:SHA-256_SSWU_RO_"
+}
+
+func TestRegister_DecodeFromX(t *testing.T) {
+	// The elements of the registered group are encoded as P-256's, but nothing is known of registered encodings.
+	g := registerTestGroup(t, registeredID+3, func() ecc.GroupImplementation {
+		return relabeledGroup{externalGroup{ecc.P256Sha256}, registeredID + 3}
+	})
+
+	if err := g.NewElement().DecodeFromX(ecc.P256Sha256.Base().XCoordinate(), false); err == nil ||
+		!strings.Contains(err.Error(), "x-coordinate decoding is only available") {
+		t.Fatalf("expected an unsupported decoding error, got %v", err)
+	}
+}
+
+func TestRegister_LastIdentifier(t *testing.T) {
+	g := registerTestGroup(t, 255, func() ecc.GroupImplementation {
+		return lastGroup{externalGroup{ecc.P256Sha256}}
+	})
+
+	if g != ecc.Group(255) || !g.Available() {
+		t.Fatal("expected the registered group to be available")
+	}
+
+	for _, s := range ecc.Suites() {
+		if s.Group == g {
+			return
+		}
+	}
+
+	t.Fatal("the group registered with the last identifier is missing from the suites")
+}
+
+func TestRegister_Errors(t *testing.T) {
+	registerExternal(t)
+
+	constructor := func() ecc.GroupImplementation { return externalGroup{ecc.P256Sha256} }
+
+	for _, id := range []byte{0, byte(ecc.P256Sha256), byte(ecc.PallasBLAKE2b512)} {
+		if _, err := ecc.Register(id, constructor); err == nil {
+			t.Fatalf("expected an error registering the reserved identifier %d", id)
+		}
+	}
+
+	if _, err := ecc.Register(registeredID, constructor); err == nil {
+		t.Fatal("expected an error registering an identifier twice")
+	}
+
+	if _, err := ecc.Register(registeredID+1, nil); err == nil {
+		t.Fatal("expected an error on a nil constructor")
+	}
+
+	if ecc.Group(registeredID + 1).Available() {
+		t.Fatal("expected the group to be unavailable")
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/compat"
+	"github.com/bytemare/ecc/psi"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+// device simulates an external device holding a secret scalar.
+type device struct {
+	sk     *ecc.Scalar
+	nonces map[string]*ecc.Scalar
+	faulty bool
+}
+
+func newDevice(g ecc.Group) *device {
+	return &device{sk: g.NewScalar().Random(), nonces: make(map[string]*ecc.Scalar)}
+}
+
+func (d *device) Group() ecc.Group {
+	return d.sk.Group()
+}
+
+func (d *device) Public() *ecc.Element {
+	return d.Group().Base().Multiply(d.sk)
+}
+
+func (d *device) Multiply(e *ecc.Element) (*ecc.Element, error) {
+	if e.Group() != d.Group() || e.IsIdentity() {
+		return nil, errors.New("invalid element")
+	}
+
+	return e.Copy().Multiply(d.sk), nil
+}
+
+func (d *device) Commit() (*ecc.Element, error) {
+	k := d.Group().NewScalar().Random()
+	r := d.Group().Base().Multiply(k)
+	d.nonces[string(r.Encode())] = k
+
+	return r, nil
+}
+
+func (d *device) nonce(commitment *ecc.Element) (*ecc.Scalar, error) {
+	k, ok := d.nonces[string(commitment.Encode())]
+	if !ok {
+		return nil, errors.New("unknown commitment")
+	}
+
+	delete(d.nonces, string(commitment.Encode()))
+
+	if d.faulty {
+		k.Add(k.Group().NewScalar().One())
+	}
+
+	return k, nil
+}
+
+type schnorrDevice struct{ *device }
+
+func (d schnorrDevice) Respond(commitment *ecc.Element, c *ecc.Scalar) (*ecc.Scalar, error) {
+	k, err := d.nonce(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Copy().Multiply(d.sk).Add(k), nil
+}
+
+type ecdsaDevice struct{ *device }
+
+func (d ecdsaDevice) Respond(commitment *ecc.Element, r, e *ecc.Scalar) (*ecc.Scalar, error) {
+	k, err := d.nonce(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Copy().Multiply(d.sk).Add(e).Multiply(k.Invert()), nil
+}
+
+func TestRemote_Schnorr(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		key := schnorrDevice{newDevice(group.group)}
+		message := []byte("message")
+
+		signature, err := schnorr.SignRemote(key, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.Verify(key.Public(), message, signature) {
+			t.Fatal("expected valid signature")
+		}
+
+		key.faulty = true
+		if _, err = schnorr.SignRemote(key, message); err == nil {
+			t.Fatal("expected error on faulty device")
+		}
+	})
+}
+
+func TestRemote_ECDSA(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		key := ecdsaDevice{newDevice(group.group)}
+
+		signer, err := compat.NewRemoteSigner(key)
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		digest := sha256.Sum256([]byte("message"))
+
+		signature, err := signer.Sign(nil, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if isNIST(group.group) && !ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest[:], signature) {
+			t.Fatal("expected valid signature")
+		}
+
+		key.faulty = true
+		if _, err = signer.Sign(nil, digest[:], crypto.SHA256); err == nil {
+			t.Fatal("expected error on faulty device")
+		}
+	})
+}
+
+func TestRemote_ECDH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		alice, bob := newDevice(group.group), newDevice(group.group)
+
+		secret, err := compat.ECDH(alice, bob.Public())
+		if !isNIST(group.group) && group.group != ecc.Secp256k1Sha256 && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		other, err := compat.ECDH(bob, alice.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(secret, other) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, err = compat.ECDH(alice, group.group.NewElement()); err == nil {
+			t.Fatal("expected error on identity")
+		}
+	})
+}
+
+func TestRemote_PSI(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		alice, bob := newDevice(group.group), newDevice(group.group)
+		a := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+		b := [][]byte{[]byte("c"), []byte("d"), []byte("a")}
+
+		maskedA, err := psi.MaskItemsRemote(alice, a...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		maskedB, err := psi.MaskItems(bob.sk, b...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doubleA, err := psi.Mask(bob.sk, maskedA...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doubleB, err := psi.MaskRemote(alice, maskedB...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if psi.Cardinality(doubleA, doubleB) != 2 {
+			t.Fatal("unexpected intersection size")
+		}
+	})
+}
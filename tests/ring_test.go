@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ring"
+)
+
+func testRing(g ecc.Group, n int) ([]*ecc.Element, []*ecc.Scalar) {
+	keys := make([]*ecc.Element, n)
+	secrets := make([]*ecc.Scalar, n)
+
+	for i := range keys {
+		key := g.GenerateKeyPair()
+		keys[i], secrets[i] = key.Public, key.Secret
+	}
+
+	return keys, secrets
+}
+
+func TestRing_Sign(t *testing.T) {
+	message := []byte("message")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		keys, secrets := testRing(g, 4)
+
+		for _, linkable := range []bool{false, true} {
+			for signer := range secrets {
+				sign := ring.Sign
+				if linkable {
+					sign = ring.SignLinkable
+				}
+
+				sig, err := sign(keys, secrets[signer], message)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				decoded := new(ring.Signature)
+				if err = decoded.Decode(g, sig.Encode()); err != nil {
+					t.Fatal(err)
+				}
+
+				if !ring.Verify(keys, message, decoded) {
+					t.Fatalf("valid signature by member %d did not verify", signer)
+				}
+
+				if ring.Verify(keys, []byte("other"), decoded) || ring.Verify(keys[1:], message, decoded) {
+					t.Fatal("signature verified for wrong inputs")
+				}
+
+				if (decoded.KeyImage != nil) != linkable {
+					t.Fatal("unexpected key image")
+				}
+			}
+		}
+
+		// A single key ring is valid.
+		sig, err := ring.Sign(keys[:1], secrets[0], message)
+		if err != nil || !ring.Verify(keys[:1], message, sig) {
+			t.Fatal("single key ring signature did not verify")
+		}
+
+		if _, err = ring.Sign(keys[1:], secrets[0], message); err == nil {
+			t.Fatal("expected error on signer not in the ring")
+		}
+
+		if _, err = ring.Sign(nil, secrets[0], message); err == nil {
+			t.Fatal("expected error on empty ring")
+		}
+
+		if _, err = ring.Sign([]*ecc.Element{keys[0], g.NewElement()}, secrets[0], message); err == nil {
+			t.Fatal("expected error on identity key in the ring")
+		}
+
+		if err = new(ring.Signature).Decode(g, sig.Encode()[:g.ScalarLength()]); err == nil {
+			t.Fatal("expected error on short encoding")
+		}
+	})
+}
+
+func TestRing_Linked(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		keys, secrets := testRing(g, 3)
+
+		a, _ := ring.SignLinkable(keys, secrets[1], []byte("a"))
+		b, _ := ring.SignLinkable(keys[:2], secrets[1], []byte("b"))
+		c, _ := ring.SignLinkable(keys, secrets[2], []byte("a"))
+		d, _ := ring.Sign(keys, secrets[1], []byte("a"))
+
+		if !ring.Linked(a, b) || !a.KeyImage.Equal(ring.KeyImage(secrets[1])) {
+			t.Fatal("signatures of the same key are not linked")
+		}
+
+		if ring.Linked(a, c) || ring.Linked(a, d) {
+			t.Fatal("unexpected link")
+		}
+
+		// Swapping the key image invalidates the signature.
+		a.KeyImage = c.KeyImage
+		if ring.Verify(keys, []byte("a"), a) {
+			t.Fatal("signature with another key image verified")
+		}
+	})
+}
@@ -13,9 +13,11 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/bytemare/ecc"
@@ -55,7 +57,7 @@ func TestScalar_WrongInput(t *testing.T) {
 
 		switch group.group {
 		// The following is arbitrary, and simply aims at confusing identifiers
-		case ecc.Ristretto255Sha512, ecc.Edwards25519Sha512, ecc.Secp256k1Sha256, ecc.PallasSha256:
+		case ecc.Ristretto255Sha512, ecc.Edwards25519Sha512, ecc.Secp256k1Sha256, ecc.PallasBLAKE2b512:
 			wrongGroup = ecc.P256Sha256
 		case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512:
 			wrongGroup = ecc.Ristretto255Sha512
@@ -117,6 +119,22 @@ func TestScalar_Copy(t *testing.T) {
 	})
 }
 
+func TestScalar_EqualCT(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		zero := g.NewScalar()
+
+		if s.EqualCT(s.Copy()) != 1 || zero.EqualCT(s.Copy().Subtract(s)) != 1 || zero.IsZeroCT() != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if s.EqualCT(g.NewScalar().One()) != 0 || s.EqualCT(zero) != 0 || s.EqualCT(nil) != 0 || s.IsZeroCT() != 0 {
+			t.Fatal("unexpected equality")
+		}
+	})
+}
+
 func TestScalar_Set(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		random := group.group.NewScalar().Random()
@@ -255,6 +273,138 @@ func TestScalar_Decode_OutOfBounds(t *testing.T) {
 	})
 }
 
+func TestScalar_DecodeCanonical(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		decoded := group.group.NewScalar()
+
+		if err := decoded.DecodeCanonical(s.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal("expected equality")
+		}
+
+		// A value above the order must be rejected, and not reduced, leaving the receiver untouched.
+		err := decoded.DecodeCanonical(debug.BadScalarHigh(group.group))
+		if err == nil || !errors.Is(err, internal.ErrParamScalarInvalidEncoding) {
+			t.Fatalf("expected error %q, got %v", internal.ErrParamScalarInvalidEncoding, err)
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestScalar_DecodeChecked(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		decoded := group.group.NewScalar()
+
+		if err := decoded.DecodeChecked(s.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal("expected equality")
+		}
+
+		// Zero is rejected, where DecodeCanonical accepts it.
+		zero := group.group.NewScalar().Encode()
+		if err := decoded.DecodeChecked(zero); !errors.Is(err, ecc.ErrZeroScalar) {
+			t.Fatalf("expected error %q, got %v", ecc.ErrZeroScalar, err)
+		}
+
+		if err := group.group.NewScalar().DecodeCanonical(zero); err != nil {
+			t.Fatal(err)
+		}
+
+		// A value above the order is rejected, and not reduced.
+		if err := decoded.DecodeChecked(debug.BadScalarHigh(group.group)); !errors.Is(
+			err, ecc.ErrInvalidScalarEncoding) {
+			t.Fatalf("expected error %q, got %v", ecc.ErrInvalidScalarEncoding, err)
+		}
+
+		// An input of the wrong length is rejected.
+		if err := decoded.DecodeChecked(append(s.Encode(), 0)); err == nil {
+			t.Fatal("expected error on a longer input")
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestScalar_DecodeReduce(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		decoded := group.group.NewScalar()
+
+		if err := decoded.DecodeReduce(s.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal("expected equality")
+		}
+
+		// The order plus one is reduced to one, where DecodeCanonical rejects it.
+		if err := decoded.DecodeReduce(debug.BadScalarHigh(group.group)); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(group.group.NewScalar().One()) {
+			t.Fatal("expected the order plus one to reduce to one")
+		}
+
+		// A wide input is reduced as an integer in the group's scalar byte order.
+		wide := bytes.Repeat([]byte{0xff}, 2*group.group.ScalarLength())
+		if err := decoded.DecodeReduce(wide); err != nil {
+			t.Fatal(err)
+		}
+
+		i := new(big.Int).SetBytes(wide)
+		expected := i.Mod(i, group.group.OrderBig()).FillBytes(make([]byte, group.group.ScalarLength()))
+
+		if group.group == ecc.Ristretto255Sha512 || group.group == ecc.Edwards25519Sha512 {
+			slices.Reverse(expected)
+		}
+
+		if !bytes.Equal(decoded.Encode(), expected) {
+			t.Fatal("unexpected reduction of a wide input")
+		}
+
+		for _, in := range [][]byte{nil, make([]byte, 2*group.group.ScalarLength()+1)} {
+			if err := decoded.DecodeReduce(in); !errors.Is(err, internal.ErrParamScalarLength) {
+				t.Fatalf("expected error %q, got %v", internal.ErrParamScalarLength, err)
+			}
+		}
+
+		if !bytes.Equal(decoded.Encode(), expected) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestScalar_Wipe(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		c := s.Copy()
+		s.Wipe()
+
+		if !s.IsZero() {
+			t.Fatal("expected zero scalar after wipe")
+		}
+
+		if c.IsZero() {
+			t.Fatal("wiping a scalar must not affect its copies")
+		}
+	})
+}
+
 func TestScalar_Arithmetic(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		scalarTestZero(t, group.group)
@@ -268,9 +418,37 @@ func TestScalar_Arithmetic(t *testing.T) {
 		scalarTestMultiply(t, group.group)
 		scalarTestPow(t, group.group)
 		scalarTestInvert(t, group.group)
+		scalarTestMulAdd(t, group.group)
 	})
 }
 
+func scalarTestMulAdd(t *testing.T, g ecc.Group) {
+	a, b, c := g.NewScalar().Random(), g.NewScalar().Random(), g.NewScalar().Random()
+	expected := a.Copy().Multiply(b).Add(c)
+
+	if !g.NewScalar().MulAdd(a, b, c).Equal(expected) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The arguments may alias the receiver.
+	for i, s := range []*ecc.Scalar{a.Copy(), b.Copy(), c.Copy()} {
+		args := []*ecc.Scalar{a, b, c}
+		args[i] = s
+
+		if !s.MulAdd(args[0], args[1], args[2]).Equal(expected) {
+			t.Fatalf("unexpected result when aliasing argument %d", i)
+		}
+	}
+
+	if !g.NewScalar().MulAdd(nil, b, c).Equal(c) || !g.NewScalar().MulAdd(a, b, nil).Equal(a.Copy().Multiply(b)) {
+		t.Fatal("unexpected result with nil argument")
+	}
+
+	if !a.Copy().Square().Equal(a.Copy().Multiply(a)) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
 func scalarTestZero(t *testing.T, g ecc.Group) {
 	zero := g.NewScalar()
 	if !zero.IsZero() {
@@ -560,3 +738,162 @@ func scalarTestInvert(t *testing.T, g ecc.Group) {
 		t.Fatal(errExpectedEquality)
 	}
 }
+
+func TestScalar_String(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		expected := group.group.Name() + "(scalar redacted)"
+
+		for _, f := range []string{"%v", "%s", "%+v", "%#v"} {
+			if res := fmt.Sprintf(f, s); res != expected {
+				t.Fatalf("unexpected formatting with %s: %q", f, res)
+			}
+		}
+
+		if strings.Contains(fmt.Sprint(s), s.Hex()) {
+			t.Fatal("the scalar is not redacted")
+		}
+	})
+
+	var s *ecc.Scalar
+	if s.String() != "<nil>" {
+		t.Fatal("unexpected nil scalar string")
+	}
+}
+
+func TestScalar_SetString(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+
+		be := s.Encode()
+		if g == ecc.Ristretto255Sha512 || g == ecc.Edwards25519Sha512 {
+			slices.Reverse(be)
+		}
+
+		i := new(big.Int).SetBytes(be)
+
+		for _, v := range []string{i.String(), "0x" + i.Text(16), "0x" + hex.EncodeToString(be)} {
+			res := g.NewScalar()
+			if err := res.SetString(v); err != nil {
+				t.Fatal(err)
+			}
+
+			if !res.Equal(s) {
+				t.Fatalf("unexpected scalar for %q", v)
+			}
+		}
+
+		for v, expected := range map[string]uint64{"0": 0, "1": 1, "0x0": 0, "0xff": 255, "0007": 7} {
+			res := g.NewScalar()
+			if err := res.SetString(v); err != nil {
+				t.Fatal(err)
+			}
+
+			if !res.Equal(g.NewScalar().SetUInt64(expected)) {
+				t.Fatalf("unexpected scalar for %q", v)
+			}
+		}
+
+		order := g.Order()
+		if g == ecc.Ristretto255Sha512 || g == ecc.Edwards25519Sha512 {
+			slices.Reverse(order)
+		}
+
+		o := new(big.Int).SetBytes(order)
+		tooBig := new(big.Int).Lsh(big.NewInt(1), uint(8*g.ScalarLength()))
+
+		for _, v := range []string{
+			"", "0x", "-1", "+1", "0x-1", "1.5", "ab", "0X1", " 1", o.String(), "0x" + o.Text(16), tooBig.String(),
+		} {
+			res := g.NewScalar().SetUInt64(3)
+			if err := res.SetString(v); err == nil {
+				t.Fatalf("expected error for %q", v)
+			}
+
+			if !res.Equal(g.NewScalar().SetUInt64(3)) {
+				t.Fatalf("the scalar was modified on error for %q", v)
+			}
+		}
+	})
+}
+
+func TestScalar_SetBytesWithClamping(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		input := internal.RandomBytes(32)
+		s := g.NewScalar()
+
+		if g != ecc.Ristretto255Sha512 && g != ecc.Edwards25519Sha512 {
+			if err := s.SetBytesWithClamping(input); err == nil {
+				t.Fatal("expected error in a group not over Curve25519")
+			}
+
+			return
+		}
+
+		if err := s.SetBytesWithClamping(input); err != nil {
+			t.Fatal(err)
+		}
+
+		clamped := slices.Clone(input)
+		clamped[0] &= 248
+		clamped[31] &= 127
+		clamped[31] |= 64
+		slices.Reverse(clamped)
+
+		if !s.Equal(bigIntExp(t, g, new(big.Int).SetBytes(clamped), big.NewInt(1))) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if err := s.SetBytesWithClamping(input[:31]); err == nil {
+			t.Fatal("expected error on short input")
+		}
+
+		if !s.Equal(bigIntExp(t, g, new(big.Int).SetBytes(clamped), big.NewInt(1))) {
+			t.Fatal("the receiver was modified on failure")
+		}
+	})
+}
+
+func TestScalar_Parity(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for i := range uint64(4) {
+			s := g.NewScalar().SetUInt64(i)
+			if s.IsOdd() != (i%2 == 1) || s.IsEven() == s.IsOdd() {
+				t.Fatalf("unexpected parity of %d", i)
+			}
+
+			// The order is odd, so -i has the other parity, except for 0.
+			if i != 0 && g.NewScalar().Subtract(s).IsOdd() == s.IsOdd() {
+				t.Fatalf("unexpected parity of -%d", i)
+			}
+		}
+
+		if !g.NewScalar().MinusOne().IsEven() {
+			t.Fatal("expected order - 1 to be even")
+		}
+
+		s := g.NewScalar().Random()
+		neg := g.NewScalar().Subtract(s)
+
+		if !s.Copy().ConditionalNegate(1).Equal(neg) || !s.Copy().ConditionalNegate(0).Equal(s) {
+			t.Fatal("unexpected conditional negation")
+		}
+
+		for range 8 {
+			s = g.NewScalar().Random()
+
+			n := s.Copy().NegateIfOdd()
+			if !n.IsEven() {
+				t.Fatal("expected an even scalar")
+			}
+
+			if s.IsOdd() && !n.Equal(g.NewScalar().Subtract(s)) || s.IsEven() && !n.Equal(s) {
+				t.Fatal("unexpected NegateIfOdd result")
+			}
+		}
+	})
+}
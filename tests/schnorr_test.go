@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+func TestSchnorr(t *testing.T) {
+	message := []byte("message")
+
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		sig, err := schnorr.Sign(sk, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(schnorr.Signature)
+		if err = decoded.Decode(group.group, sig.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.Verify(pk, message, decoded) {
+			t.Fatal("valid signature did not verify")
+		}
+
+		if schnorr.Verify(pk, []byte("other message"), decoded) || schnorr.Verify(group.group.Base(), message, decoded) {
+			t.Fatal("signature verified for wrong inputs")
+		}
+	})
+}
+
+func TestSchnorr_HalfAggregation(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		items := make([]schnorr.Item, 5)
+		sigs := make([]*schnorr.Signature, 5)
+
+		for i := range items {
+			sk := group.group.NewScalar().Random()
+			items[i] = schnorr.Item{
+				PublicKey: group.group.Base().Multiply(sk),
+				Message:   []byte(fmt.Sprintf("message %d", i)),
+			}
+
+			var err error
+			if sigs[i], err = schnorr.Sign(sk, items[i].Message); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		agg, err := schnorr.Aggregate(items[:3], sigs[:3])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyAggregate(items[:3], agg) {
+			t.Fatal("valid aggregate did not verify")
+		}
+
+		inc, err := schnorr.IncAggregate(agg, items[:3], items[3:], sigs[3:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		full, err := schnorr.Aggregate(items, sigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !inc.S.Equal(full.S) {
+			t.Fatal("incremental and full aggregation differ")
+		}
+
+		decoded := new(schnorr.AggregateSignature)
+		if err = decoded.Decode(group.group, inc.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyAggregate(items, decoded) {
+			t.Fatal("valid incremental aggregate did not verify")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if valid, err := schnorr.VerifyAggregateContext(ctx, items, decoded); err != nil || !valid {
+			t.Fatalf("valid aggregate did not verify with context: %v", err)
+		}
+
+		cancel()
+
+		if valid, err := schnorr.VerifyAggregateContext(ctx, items, decoded); valid || !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected cancellation error, got %v", err)
+		}
+
+		items[0], items[1] = items[1], items[0]
+		if schnorr.VerifyAggregate(items, decoded) {
+			t.Fatal("aggregate verified with permuted items")
+		}
+	})
+}
+
+func TestSchnorr_Randomization(t *testing.T) {
+	message := []byte("message")
+	context := []byte("context")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		key := g.GenerateKeyPair()
+		r := g.NewScalar().Random()
+
+		randomized, err := schnorr.RandomizePublicKey(key.Public, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		randomizedSecret, err := schnorr.RandomizePrivateKey(key.Secret, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !g.Base().Multiply(randomizedSecret).Equal(randomized) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		sig, err := schnorr.SignRandomized(key.Secret, r, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.Verify(randomized, message, sig) || schnorr.Verify(key.Public, message, sig) {
+			t.Fatal("unexpected verification result for a randomized signature")
+		}
+
+		proof, err := schnorr.ProveRandomization(r, key.Public, randomized, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyRandomization(key.Public, randomized, context, proof) {
+			t.Fatal("valid randomization proof did not verify")
+		}
+
+		other := g.GenerateKeyPair().Public
+		if schnorr.VerifyRandomization(other, randomized, context, proof) ||
+			schnorr.VerifyRandomization(key.Public, randomized, []byte("other"), proof) {
+			t.Fatal("randomization proof verified for wrong inputs")
+		}
+
+		// Randomizing with the negated private key yields the identity.
+		negated := g.NewScalar().Subtract(key.Secret)
+		if _, err = schnorr.RandomizePublicKey(key.Public, negated); err == nil {
+			t.Fatal("expected error on identity randomized key")
+		}
+
+		if _, err = schnorr.SignRandomized(key.Secret, negated, message); err == nil {
+			t.Fatal("expected error on zero randomized key")
+		}
+
+		if _, err = schnorr.RandomizePrivateKey(key.Secret, nil); err == nil {
+			t.Fatal("expected error on nil input")
+		}
+	})
+}
+
+func TestSchnorr_AdaptSignature(t *testing.T) {
+	message := []byte("message")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		key := g.GenerateKeyPair()
+		r := g.NewScalar().Random()
+
+		randomized, err := schnorr.RandomizePublicKey(key.Public, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := schnorr.SignAdaptable(key.Secret, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyAdaptable(key.Public, message, sig) {
+			t.Fatal("valid adaptable signature did not verify")
+		}
+
+		adapted, err := schnorr.AdaptSignature(sig, r, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyAdaptable(randomized, message, adapted) ||
+			schnorr.VerifyAdaptable(key.Public, message, adapted) ||
+			schnorr.VerifyAdaptable(randomized, message, sig) {
+			t.Fatal("unexpected verification result for an adapted signature")
+		}
+
+		if !adapted.R.Equal(sig.R) || adapted.S.Equal(sig.S) {
+			t.Fatal("unexpected adapted signature")
+		}
+
+		// Adapting twice is adapting with the sum, and adaptable signatures are separated from regular ones.
+		twice, err := schnorr.AdaptSignature(adapted, r, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		randomizedTwice, err := schnorr.RandomizePublicKey(key.Public, r.Copy().Add(r))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !schnorr.VerifyAdaptable(randomizedTwice, message, twice) ||
+			schnorr.Verify(key.Public, message, sig) ||
+			schnorr.VerifyAdaptable(randomized, []byte("other"), adapted) {
+			t.Fatal("unexpected verification result")
+		}
+
+		if _, err = schnorr.AdaptSignature(sig, nil, message); err == nil {
+			t.Fatal("expected error on nil input")
+		}
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		if _, err = schnorr.AdaptSignature(sig, other.NewScalar().Random(), message); err == nil {
+			t.Fatal("expected error on group mismatch")
+		}
+	})
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+	"github.com/bytemare/ecc/secretsharing"
+)
+
+func TestSecretSharing(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+
+		shares, commitment, err := secretsharing.Split(secret, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !commitment.PublicKey().Equal(group.group.Base().Multiply(secret)) {
+			t.Fatal("unexpected public key")
+		}
+
+		publics := make(map[uint64]*ecc.Element)
+
+		for _, s := range shares {
+			if !commitment.Verify(s) {
+				t.Fatalf("valid share %d did not verify", s.ID)
+			}
+
+			publics[s.ID] = s.Public()
+		}
+
+		recovered, err := secretsharing.Combine([]*secretsharing.Share{shares[4], shares[0], shares[2]})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !recovered.Equal(secret) {
+			t.Fatal("recovered secret does not match")
+		}
+
+		pk, err := secretsharing.CombineElements(publics)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pk.Equal(commitment.PublicKey()) {
+			t.Fatal("combined public shares do not match the public key")
+		}
+
+		recovered, err = secretsharing.Combine(shares[:2])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if recovered.Equal(secret) {
+			t.Fatal("secret recovered below the threshold")
+		}
+
+		shares[1].Secret.Add(group.group.NewScalar().One())
+		if commitment.Verify(shares[1]) {
+			t.Fatal("invalid share verified")
+		}
+
+		if _, _, err = secretsharing.Split(secret, 6, 5); err == nil {
+			t.Fatal("expected error on threshold above the number of shares")
+		}
+	})
+}
+
+func TestSecretSharing_Reshare(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+
+		shares, old, err := secretsharing.Split(secret, 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Participants 1 and 3 reshare to a 3-out-of-4 structure.
+		dealers := []uint64{1, 3}
+		subShares := make([][]*secretsharing.Share, len(dealers))
+		commitments := make([]secretsharing.Commitment, len(dealers))
+
+		for i, id := range dealers {
+			if subShares[i], commitments[i], err = secretsharing.Reshare(shares[id-1], 3, 4); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		newShares := make([]*secretsharing.Share, 4)
+
+		var newCommitment secretsharing.Commitment
+
+		for j := range newShares {
+			received := []*secretsharing.Share{subShares[0][j], subShares[1][j]}
+
+			newShares[j], newCommitment, err = secretsharing.CombineReshares(old, dealers, received, commitments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !newCommitment.Verify(newShares[j]) {
+				t.Fatal("new share does not match the new commitment")
+			}
+		}
+
+		if !newCommitment.PublicKey().Equal(old.PublicKey()) {
+			t.Fatal("resharing changed the public key")
+		}
+
+		recovered, err := secretsharing.Combine(newShares[1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !recovered.Equal(secret) {
+			t.Fatal("resharing changed the secret")
+		}
+
+		// A dealer resharing a different secret is detected.
+		bogus, bogusCommitment, _ := secretsharing.Split(group.group.NewScalar().Random(), 3, 4)
+		received := []*secretsharing.Share{subShares[0][0], bogus[0]}
+
+		if _, _, err = secretsharing.CombineReshares(old, dealers, received,
+			[]secretsharing.Commitment{commitments[0], bogusCommitment}); err == nil {
+			t.Fatal("expected error on inconsistent dealer commitment")
+		}
+
+		if _, _, err = secretsharing.CombineReshares(old, dealers[:1], received[:1], commitments[:1]); err == nil {
+			t.Fatal("expected error on too few dealers")
+		}
+	})
+}
+
+func TestSecretSharing_Refresh(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+
+		shares, old, err := secretsharing.Split(secret, 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		zeroShares := make([][]*secretsharing.Share, len(shares))
+		commitments := make([]secretsharing.Commitment, len(shares))
+
+		for i := range shares {
+			if zeroShares[i], commitments[i], err = secretsharing.NewRefresh(group.group, 2, 3); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		refreshed := make([]*secretsharing.Share, len(shares))
+
+		var commitment secretsharing.Commitment
+
+		for j, share := range shares {
+			received := []*secretsharing.Share{zeroShares[0][j], zeroShares[1][j], zeroShares[2][j]}
+
+			refreshed[j], commitment, err = secretsharing.Refresh(share, old, received, commitments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !commitment.Verify(refreshed[j]) {
+				t.Fatal("refreshed share does not match the refreshed commitment")
+			}
+
+			if refreshed[j].Secret.Equal(share.Secret) {
+				t.Fatal("share was not refreshed")
+			}
+		}
+
+		recovered, err := secretsharing.Combine(refreshed[:2])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !recovered.Equal(secret) {
+			t.Fatal("refresh changed the secret")
+		}
+
+		nonZero, nonZeroCommitment, _ := secretsharing.Split(group.group.NewScalar().Random(), 2, 3)
+		if _, _, err = secretsharing.Refresh(shares[0], old, []*secretsharing.Share{nonZero[0]},
+			[]secretsharing.Commitment{nonZeroCommitment}); err == nil {
+			t.Fatal("expected error on non-zero refresh commitment")
+		}
+	})
+}
+
+func TestSecretSharing_ShareEncoding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		shares, commitment, err := secretsharing.Split(g.NewScalar().Random(), 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		share := shares[2]
+		encoded := share.Encode()
+
+		if len(encoded) != 9+g.ScalarLength() || ecc.Group(encoded[0]) != g {
+			t.Fatalf("unexpected share encoding %x", encoded)
+		}
+
+		share.CommitmentDigest = commitment.Digest()
+		withDigest := share.Encode()
+
+		if len(withDigest) != len(encoded)+secretsharing.DigestLength || !commitment.Verify(share) {
+			t.Fatal("unexpected share encoding with digest")
+		}
+
+		for _, data := range [][]byte{encoded, withDigest} {
+			decoded := new(secretsharing.Share)
+			if err = decoded.Decode(data); err != nil {
+				t.Fatal(err)
+			}
+
+			if decoded.ID != share.ID || !decoded.Secret.Equal(share.Secret) || !commitment.Verify(decoded) {
+				t.Fatal("unexpected decoded share")
+			}
+		}
+
+		// A share bound to another commitment doesn't verify.
+		_, other, _ := secretsharing.Split(g.NewScalar().Random(), 2, 3)
+		share.CommitmentDigest = other.Digest()
+
+		if commitment.Verify(share) {
+			t.Fatal("share with a different commitment digest verified")
+		}
+
+		zeroID := bytes.Clone(encoded)
+		clear(zeroID[1:9])
+
+		decoded := &secretsharing.Share{ID: 7}
+		for _, data := range [][]byte{
+			nil,
+			encoded[:8],
+			encoded[:len(encoded)-1],
+			append(bytes.Clone(encoded), 1),
+			append([]byte{0}, encoded[1:]...),
+			zeroID,
+			append(encoded[:9:9], debug.BadScalarHigh(g)...),
+		} {
+			if err = decoded.Decode(data); err == nil {
+				t.Fatalf("expected error on %x", data)
+			}
+		}
+
+		if decoded.ID != 7 || decoded.Secret != nil {
+			t.Fatal("the share was modified on failure")
+		}
+	})
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestGroup_SelfTest(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if err := group.group.SelfTest(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestGroup_SelfTest_InvalidGroup(t *testing.T) {
+	if err := ecc.Group(0).SelfTest(); !errors.Is(err, ecc.ErrInvalidGroup) {
+		t.Fatalf("expected invalid group error, got %v", err)
+	}
+}
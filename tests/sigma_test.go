@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/sigma"
+)
+
+const testSigmaDST = "ECC-Sigma-Test-DLEQ"
+
+// testDLEQ returns the statement X = x * G and Y = x * H, and its witness.
+func testDLEQ(g ecc.Group) ([]sigma.Relation, []*ecc.Scalar) {
+	x := g.NewScalar().Random()
+	h := g.HashToGroup(testHashToGroupInput, testHashToGroupDST)
+
+	return []sigma.Relation{
+		{LHS: g.Base().Multiply(x), Terms: []sigma.Term{{Base: g.Base(), Index: 0}}},
+		{LHS: h.Copy().Multiply(x), Terms: []sigma.Term{{Base: h, Index: 0}}},
+	}, []*ecc.Scalar{x}
+}
+
+func TestSigma_Prove(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+		relations, witnesses := testDLEQ(g)
+
+		proof, err := sigma.Prove(g, testSigmaDST, relations, witnesses, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(sigma.Proof)
+		if err = decoded.Decode(g, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Verify(g, testSigmaDST, relations, 1, context) {
+			t.Fatal("valid proof did not verify")
+		}
+
+		if proof.Verify(g, testSigmaDST, relations, 1, []byte("other")) ||
+			proof.Verify(g, "other", relations, 1, context) ||
+			proof.Verify(g, testSigmaDST, relations[:1], 1, context) ||
+			proof.Verify(g, testSigmaDST, relations, 2, context) {
+			t.Fatal("unexpected verification of a wrong statement")
+		}
+
+		// A witness that doesn't satisfy the relations yields an invalid proof.
+		proof, _ = sigma.Prove(g, testSigmaDST, relations, []*ecc.Scalar{g.NewScalar().Random()}, context)
+		if proof.Verify(g, testSigmaDST, relations, 1, context) {
+			t.Fatal("proof with a wrong witness verified")
+		}
+
+		bad := []sigma.Relation{{LHS: g.Base(), Terms: []sigma.Term{{Base: g.Base(), Index: 1}}}}
+		if _, err = sigma.Prove(g, testSigmaDST, bad, witnesses, context); err == nil {
+			t.Fatal("expected error on out of range witness index")
+		}
+
+		if _, err = sigma.Prove(g, testSigmaDST, nil, witnesses, context); err == nil {
+			t.Fatal("expected error on empty statement")
+		}
+	})
+}
+
+func TestSigma_Designated(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+		relations, witnesses := testDLEQ(g)
+		verifier := g.GenerateKeyPair()
+		other := g.GenerateKeyPair()
+
+		proof, err := sigma.ProveDesignated(g, testSigmaDST, relations, witnesses, verifier.Public, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(sigma.DesignatedProof)
+		if err = decoded.Decode(g, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Verify(g, testSigmaDST, relations, 1, verifier.Public, context) {
+			t.Fatal("valid proof did not verify")
+		}
+
+		if proof.Verify(g, testSigmaDST, relations, 1, other.Public, context) ||
+			proof.Verify(g, testSigmaDST, relations, 1, verifier.Public, []byte("other")) {
+			t.Fatal("unexpected verification with another verifier key or context")
+		}
+
+		// The verifier can produce proofs for false statements, so its proofs convince no one else, but no one else can.
+		wrong := []sigma.Relation{{LHS: g.Base(), Terms: []sigma.Term{{Base: g.Base().Double(), Index: 0}}}}
+
+		simulated, err := sigma.SimulateDesignated(g, testSigmaDST, wrong, 1, verifier.Secret, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !simulated.Verify(g, testSigmaDST, wrong, 1, verifier.Public, context) ||
+			simulated.Verify(g, testSigmaDST, wrong, 1, other.Public, context) {
+			t.Fatal("unexpected verification of a simulated proof")
+		}
+
+		if _, err = sigma.ProveDesignated(g, testSigmaDST, relations, witnesses, g.NewElement(), context); err == nil {
+			t.Fatal("expected error on identity verifier key")
+		}
+
+		if err = decoded.Decode(g, proof.Encode()[:2*g.ScalarLength()]); err == nil {
+			t.Fatal("expected error on short encoding")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/sinsemilla"
+)
+
+func TestSinsemilla_Hash(t *testing.T) {
+	domain := "z.cash:test-Sinsemilla"
+	message := sinsemilla.BytesToBits([]byte("message"))
+
+	h1, err := sinsemilla.Hash(domain, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h2, err := sinsemilla.Hash(domain, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(h1, h2) {
+		t.Fatal("hash is not deterministic")
+	}
+
+	h3, err := sinsemilla.Hash("z.cash:other", message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message[0] = !message[0]
+
+	h4, err := sinsemilla.Hash(domain, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(h1, h3) || bytes.Equal(h1, h4) {
+		t.Fatal("hash collision on different inputs")
+	}
+
+	if _, err = sinsemilla.Hash(domain, make([]bool, sinsemilla.K*sinsemilla.MaxChunks+1)); err == nil {
+		t.Fatal("expected error on too long message")
+	}
+}
+
+func TestSinsemilla_Commit(t *testing.T) {
+	domain := "z.cash:test-SinsemillaCommit"
+	message := sinsemilla.BytesToBits([]byte("note"))
+	r := ecc.PallasBLAKE2b512.NewScalar().Random()
+
+	c1, err := sinsemilla.Commit(domain, message, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c2, err := sinsemilla.Commit(domain, message, ecc.PallasBLAKE2b512.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c1.Equal(c2) {
+		t.Fatal("commitments with different randomness are equal")
+	}
+
+	short, err := sinsemilla.ShortCommit(domain, message, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(short, c1.XCoordinate()) {
+		t.Fatal("short commitment is not the x-coordinate of the commitment")
+	}
+
+	if _, err = sinsemilla.Commit(domain, message, ecc.P256Sha256.NewScalar()); err == nil {
+		t.Fatal("expected error on non-Pallas randomness")
+	}
+}
+
+func TestSinsemilla_ExtractP(t *testing.T) {
+	// Extract_P maps the identity to 0, instead of failing.
+	if x := sinsemilla.ExtractP(ecc.PallasBLAKE2b512.NewElement()); !bytes.Equal(x, make([]byte, 32)) {
+		t.Fatalf("unexpected extraction of the identity: %x", x)
+	}
+
+	base := ecc.PallasBLAKE2b512.Base()
+	if !bytes.Equal(sinsemilla.ExtractP(base), base.XCoordinate()) {
+		t.Fatal("unexpected extraction of the base point")
+	}
+}
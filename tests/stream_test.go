@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+	"github.com/bytemare/ecc/internal"
+)
+
+func takeScalars(stream iter.Seq[*ecc.Scalar], n int) []*ecc.Scalar {
+	var res []*ecc.Scalar
+
+	for s := range stream {
+		res = append(res, s)
+		if len(res) == n {
+			break
+		}
+	}
+
+	return res
+}
+
+func TestGroup_NewScalarStream(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		seed := []byte("seed")
+		dst := []byte("scalar stream test")
+
+		stream := g.NewScalarStream(seed, dst)
+		first := takeScalars(stream, 10)
+		seed[0] = 0
+
+		// The stream restarts on each iteration, and doesn't depend on the caller's buffers.
+		if again := takeScalars(stream, 10); len(again) != len(first) {
+			t.Fatal("unexpected stream length")
+		} else {
+			for i := range first {
+				if !first[i].Equal(again[i]) {
+					t.Fatalf("the stream is not deterministic at index %d", i)
+				}
+			}
+		}
+
+		for i, s := range first {
+			expected := g.HashToScalar(binary.BigEndian.AppendUint64([]byte("seed"), uint64(i)), dst)
+			if !s.Equal(expected) {
+				t.Fatalf("unexpected scalar at index %d", i)
+			}
+
+			for _, other := range first[:i] {
+				if s.Equal(other) {
+					t.Fatal("expected distinct scalars")
+				}
+			}
+		}
+
+		if takeScalars(g.NewScalarStream([]byte("seed2"), dst), 1)[0].Equal(first[0]) ||
+			takeScalars(g.NewScalarStream([]byte("seed"), []byte("other stream dst")), 1)[0].Equal(first[0]) {
+			t.Fatal("expected different streams")
+		}
+
+		if panicked, _ := hasPanic(func() { g.NewScalarStream(seed, nil) }); !panicked {
+			t.Fatal("expected panic on empty DST")
+		}
+	})
+}
+
+func TestGroup_NewScalarStream_Concurrent(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		dst := []byte("scalar stream test")
+		stream := g.NewScalarStream([]byte("seed"), dst)
+		expected := takeScalars(stream, 5)
+
+		var wg sync.WaitGroup
+
+		errs := make(chan error, 4)
+
+		for range cap(errs) {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for i, s := range takeScalars(stream, len(expected)) {
+					if !s.Equal(expected[i]) {
+						errs <- fmt.Errorf("unexpected scalar at index %d", i)
+						return
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestGroup_DecodeSeq(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		var buf bytes.Buffer
+
+		var elements []*ecc.Element
+		for range 5 {
+			elements = append(elements, g.Base().Multiply(g.NewScalar().Random()))
+		}
+
+		n, err := g.EncodeSeq(&buf, slices.Values(elements))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != int64(buf.Len()) {
+			t.Fatalf("unexpected number of bytes written: %d", n)
+		}
+
+		data := buf.Bytes()
+		i := 0
+
+		for e, err := range g.DecodeSeq(bytes.NewReader(data)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !e.Equal(elements[i]) {
+				t.Fatalf("unexpected element at index %d", i)
+			}
+
+			i++
+		}
+
+		if i != len(elements) {
+			t.Fatalf("expected %d elements, got %d", len(elements), i)
+		}
+
+		for range g.DecodeSeq(bytes.NewReader(nil)) {
+			t.Fatal("expected an empty sequence")
+		}
+
+		base := g.Base().Encode()
+		frame := func(length int, enc []byte) []byte {
+			return append(binary.BigEndian.AppendUint16(nil, uint16(length)), enc...)
+		}
+
+		// Truncated and invalid streams end with an error.
+		for name, bad := range map[string][]byte{
+			"truncated encoding": data[:len(data)-1],
+			"truncated length":   append(bytes.Clone(data), 0),
+			"invalid":            append(frame(len(base), base), frame(g.ElementLength(), debug.BadElementEncoding(g))...),
+			"zero length":        append(frame(len(base), base), frame(0, nil)...),
+			"long length":        append(frame(len(base), base), frame(g.ElementLength()+1, append(base, 0))...),
+		} {
+			var last error
+
+			n := 0
+			for _, err := range g.DecodeSeq(bytes.NewReader(bad)) {
+				n++
+				last = err
+			}
+
+			if last == nil {
+				t.Fatalf("expected an error on the %s stream", name)
+			}
+
+			switch name {
+			case "truncated encoding":
+				if !errors.Is(last, io.ErrUnexpectedEOF) || n != len(elements) {
+					t.Fatalf("unexpected end of the %s stream: %v", name, last)
+				}
+			case "truncated length":
+				if !errors.Is(last, io.ErrUnexpectedEOF) || n != len(elements)+1 {
+					t.Fatalf("unexpected end of the %s stream: %v", name, last)
+				}
+			default:
+				var invalid *ecc.ErrInvalidEncoding
+				if !errors.As(last, &invalid) || n != 2 {
+					t.Fatalf("unexpected end of the %s stream: %v", name, last)
+				}
+			}
+		}
+
+		// Breaking out of the loop stops the decoding.
+		for range g.DecodeSeq(bytes.NewReader(data)) {
+			break
+		}
+
+		// Elements of another group are not written.
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		var wrong *ecc.ErrWrongGroup
+		if _, err = g.EncodeSeq(io.Discard, slices.Values([]*ecc.Element{other.Base()})); !errors.As(err, &wrong) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+
+		// A nil element returns an error after the preceding ones are written, instead of panicking.
+		var partial bytes.Buffer
+		n, err = g.EncodeSeq(&partial, slices.Values([]*ecc.Element{g.Base(), nil, g.Base()}))
+		if !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("expected a nil element error, got %v", err)
+		}
+
+		if n != int64(2+g.ElementLength()) || n != int64(partial.Len()) {
+			t.Fatalf("unexpected number of bytes written: %d", n)
+		}
+	})
+}
+
+func TestGroup_DecodeSeqContext(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		elements := []*ecc.Element{g.Base(), g.Base().Double(), g.Base().Double().Double()}
+
+		var buf bytes.Buffer
+		if _, err := g.EncodeSeq(&buf, slices.Values(elements)); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		n := 0
+
+		for e, err := range g.DecodeSeqContext(ctx, bytes.NewReader(buf.Bytes())) {
+			if n == 1 {
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("expected context.Canceled, got %v", err)
+				}
+
+				n++
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !e.Equal(elements[n]) {
+				t.Fatalf("unexpected element at index %d", n)
+			}
+
+			// Canceling after the first element ends the sequence with the context's error.
+			cancel()
+			n++
+		}
+
+		if n != 2 {
+			t.Fatalf("unexpected number of iterations: %d", n)
+		}
+	})
+}
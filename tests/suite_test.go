@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestSuite_Lookup(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s, err := ecc.LookupSuite(group.group.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s != (ecc.Suite{Group: group.group, Mode: ecc.RandomOracle}) {
+			t.Fatalf("unexpected suite %v", s)
+		}
+
+		input, dst := []byte(group.hashToCurve.input), []byte(group.hashToCurve.dst)
+		if !s.Hash(input, dst).Equal(group.group.HashToGroup(input, dst)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		nu := ecc.Suite{Group: group.group, Mode: ecc.NonUniform}
+
+		s, err = ecc.LookupSuite(nu.String())
+		if group.group == ecc.Ristretto255Sha512 {
+			if !errors.Is(err, ecc.ErrUnknownSuite) {
+				t.Fatalf("expected ErrUnknownSuite, got %v", err)
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s != nu || !s.Hash(input, dst).Equal(group.group.EncodeToGroup(input, dst)) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestSuite_Identifiers(t *testing.T) {
+	for id, expected := range map[string]ecc.Suite{
+		"P256_XMD:SHA-256_SSWU_RO_":         {Group: ecc.P256Sha256, Mode: ecc.RandomOracle},
+		"P384_XMD:SHA-384_SSWU_NU_":         {Group: ecc.P384Sha384, Mode: ecc.NonUniform},
+		"edwards25519_XMD:SHA-512_ELL2_NU_": {Group: ecc.Edwards25519Sha512, Mode: ecc.NonUniform},
+		"secp256k1_XMD:SHA-256_SSWU_RO_":    {Group: ecc.Secp256k1Sha256, Mode: ecc.RandomOracle},
+	} {
+		s, err := ecc.LookupSuite(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s != expected || s.String() != id {
+			t.Fatalf("unexpected suite for %q: %v", id, s)
+		}
+	}
+
+	for _, id := range []string{"", "P256_XMD:SHA-256_SSWU_RO", "curve448_XOF:SHAKE256_ELL2_RO_"} {
+		if _, err := ecc.LookupSuite(id); !errors.Is(err, ecc.ErrUnknownSuite) {
+			t.Fatalf("expected ErrUnknownSuite for %q, got %v", id, err)
+		}
+	}
+
+	builtin := 0
+	for _, s := range ecc.Suites() {
+		if found, err := ecc.LookupSuite(s.String()); err != nil || found != s {
+			t.Fatalf("suite %v not found: %v", s, err)
+		}
+
+		// Ignore the groups registered by the tests.
+		if s.Group < registeredID {
+			builtin++
+		}
+	}
+
+	// Every group but Ristretto255 has both modes.
+	if builtin != 2*len(testTable)-1 {
+		t.Fatalf("unexpected number of suites: %d", builtin)
+	}
+}
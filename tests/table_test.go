@@ -295,8 +295,8 @@ var testTable = []*testGroup{
 			"",
 		},
 		name:          "Pallas",
-		h2c:           "pallas_XMD:SHA-256_SSWU_RO_",
-		e2c:           "pallas_XMD:SHA-256_SSWU_NU_",
+		h2c:           "pallas_XMD:BLAKE2b_SSWU_RO_",
+		e2c:           "pallas_XMD:BLAKE2b_SSWU_NU_",
 		basePoint:     "0240000000000000000000000000000000224698fc094cf91b992d30ed00000000",
 		basePointX:    "40000000000000000000000000000000224698fc094cf91b992d30ed00000000",
 		identity:      "000000000000000000000000000000000000000000000000000000000000000000",
@@ -307,10 +307,10 @@ var testTable = []*testGroup{
 		hashToCurve: testHashToCurve{
 			input:        testHashToGroupInput,
 			dst:          testHashToGroupDST,
-			hashToScalar: "",
-			hashToGroup:  "",
+			hashToScalar: "2db596c25d02e065bc09b4e2481e31c6481b65924de822cde420b3576a83eb12",
+			hashToGroup:  "03095cd7e1d37f22813cc032f8627ab8f5fc28e497945558e7589ecfea4db90ac4",
 		},
 		group: 8,
-		hash:  crypto.SHA256,
+		hash:  crypto.BLAKE2b_512,
 	},
 }
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/testvectors"
+)
+
+var testVectorsCurves = map[ecc.Group]string{
+	ecc.P256Sha256:       "secp256r1",
+	ecc.P384Sha384:       "secp384r1",
+	ecc.P521Sha512:       "secp521r1",
+	ecc.Secp256k1Sha256:  "secp256k1",
+	ecc.PallasBLAKE2b512: "pallas",
+}
+
+// uncompressed returns the 0x04 || x || y encoding of the element, recovering y from its compressed encoding.
+func uncompressed(group *testGroup, e *ecc.Element) []byte {
+	p, _ := new(big.Int).SetString(group.fieldOrder, 10)
+	enc := e.Encode()
+	x := new(big.Int).SetBytes(enc[1:])
+
+	// y^2 = x^3 + ax + b, with a = -3 for the NIST curves, and a = 0 otherwise.
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+
+	switch group.group {
+	case ecc.Secp256k1Sha256:
+		y2.Add(y2, big.NewInt(7))
+	case ecc.PallasBLAKE2b512:
+		y2.Add(y2, big.NewInt(5))
+	default:
+		var params *elliptic.CurveParams
+
+		switch group.group {
+		case ecc.P256Sha256:
+			params = elliptic.P256().Params()
+		case ecc.P384Sha384:
+			params = elliptic.P384().Params()
+		default:
+			params = elliptic.P521().Params()
+		}
+
+		y2.Sub(y2, new(big.Int).Mul(x, big.NewInt(3)))
+		y2.Add(y2, params.B)
+	}
+
+	y := new(big.Int).ModSqrt(y2.Mod(y2, p), p)
+	if y.Bit(0) != uint(enc[0]&1) {
+		y.Sub(p, y)
+	}
+
+	out := make([]byte, 1, 1+2*len(enc[1:]))
+	out[0] = 0x04
+	out = append(out, enc[1:]...)
+
+	return append(out, y.FillBytes(make([]byte, len(enc[1:])))...)
+}
+
+// signECDSA returns a DER encoded ECDSA signature over the digest.
+func signECDSA(g ecc.Group, sk *ecc.Scalar, digest []byte) []byte {
+	n := new(big.Int).SetBytes(g.Order())
+	e := new(big.Int).SetBytes(digest)
+
+	if excess := 8*len(digest) - n.BitLen(); excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+
+	k := g.NewScalar().Random()
+	r := new(big.Int).SetBytes(g.Base().Multiply(k).XCoordinate())
+	r.Mod(r, n)
+
+	s := new(big.Int).Mul(r, new(big.Int).SetBytes(sk.Encode()))
+	s.Add(s, e)
+	s.Mul(s, new(big.Int).ModInverse(new(big.Int).SetBytes(k.Encode()), n))
+	s.Mod(s, n)
+
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		panic(err)
+	}
+
+	return sig
+}
+
+// testVectorsFile builds a file in the Wycheproof format for the group, with valid and invalid ECDH and ECDSA cases.
+func testVectorsFile(t *testing.T, group *testGroup) *testvectors.File {
+	g := group.group
+	curve := testVectorsCurves[g]
+
+	sk := g.NewScalar().Random()
+	pk := g.Base().Multiply(sk)
+	peer := g.NewScalar().Random()
+	shared := pk.Copy().Multiply(peer).XCoordinate()
+	offCurve := uncompressed(group, pk)
+	offCurve[len(offCurve)-1] ^= 1
+
+	msg := []byte("message")
+	h := crypto.SHA256.New()
+	_, _ = h.Write(msg)
+	sig := signECDSA(g, sk, h.Sum(nil))
+	badSig := signECDSA(g, sk, h.Sum(nil))
+	badSig[len(badSig)-1] ^= 1
+
+	data := fmt.Sprintf(`{
+	"algorithm": "ECDH/ECDSA",
+	"testGroups": [
+		{
+			"type": "EcdhEcpointTest", "curve": %[1]q, "encoding": "ecpoint",
+			"tests": [
+				{"tcId": 1, "comment": "compressed", "public": %[2]q, "private": "00%[3]s", "shared": %[4]q, "result": "valid"},
+				{"tcId": 2, "comment": "uncompressed", "public": %[5]q, "private": %[3]q, "shared": %[4]q, "result": "valid"},
+				{"tcId": 3, "comment": "off curve", "public": %[6]q, "private": %[3]q, "shared": %[4]q, "result": "invalid"},
+				{"tcId": 4, "comment": "wrong shared", "public": %[2]q, "private": %[3]q, "shared": %[7]q, "result": "invalid"}
+			]
+		},
+		{
+			"type": "EcdsaVerify", "sha": "SHA-256",
+			"publicKey": {"curve": %[1]q, "uncompressed": %[8]q},
+			"tests": [
+				{"tcId": 5, "comment": "valid", "msg": %[9]q, "sig": %[10]q, "result": "valid"},
+				{"tcId": 6, "comment": "modified", "msg": %[9]q, "sig": %[11]q, "result": "invalid"},
+				{"tcId": 7, "comment": "BER", "msg": %[9]q, "sig": "3081%[12]s", "result": "invalid"}
+			]
+		},
+		{
+			"type": "EcdhEcpointTest", "curve": "other", "encoding": "ecpoint",
+			"tests": [{"tcId": 8, "public": "", "private": "", "shared": "", "result": "valid"}]
+		}
+	]
+}`,
+		curve,
+		pk.Hex(),
+		peer.Hex(),
+		hex.EncodeToString(shared),
+		hex.EncodeToString(uncompressed(group, pk)),
+		hex.EncodeToString(offCurve),
+		hex.EncodeToString(g.Base().XCoordinate()),
+		hex.EncodeToString(uncompressed(group, pk)),
+		hex.EncodeToString(msg),
+		hex.EncodeToString(sig),
+		hex.EncodeToString(badSig),
+		hex.EncodeToString(sig[1:]),
+	)
+
+	f, err := testvectors.Load(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func TestTestVectors_RunAgainst(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if _, ok := testVectorsCurves[group.group]; !ok {
+			if _, err := testvectors.RunAgainst(group.group); err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		report, err := testVectorsFile(t, group).RunAgainst(group.group)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !report.OK() {
+			for _, failure := range report.Failures {
+				t.Errorf("test case %d (%s): expected %s, got %v", failure.TcID, failure.Comment, failure.Expected,
+					failure.Err)
+			}
+		}
+
+		if report.Total != 8 || report.Passed != 7 || report.Skipped != 1 {
+			t.Fatalf("unexpected report: %d total, %d passed, %d skipped", report.Total, report.Passed, report.Skipped)
+		}
+	})
+}
+
+func TestTestVectors_Load(t *testing.T) {
+	if _, err := testvectors.Load(strings.NewReader("{")); err == nil {
+		t.Fatal("expected error on invalid JSON")
+	}
+
+	if _, err := testvectors.Load(strings.NewReader(`{"testGroups": []}`)); err == nil {
+		t.Fatal("expected error on empty file")
+	}
+
+	if _, err := testvectors.LoadFile("does-not-exist.json"); err == nil {
+		t.Fatal("expected error on missing file")
+	}
+}
+
+func TestTestVectors_Generate(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		v := testvectors.Generate(group.group)
+
+		if v.Generator != group.basePoint || v.Encoding[0].Element != group.basePoint {
+			t.Fatal("unexpected generator")
+		}
+
+		if v.Scalars[0].Sum != testvectors.Generate(group.group).Scalars[0].Sum {
+			t.Fatal("expected deterministic vectors")
+		}
+
+		for _, e := range v.Elements {
+			p := group.group.NewElement()
+			if err := p.DecodeHex(e.P); err != nil {
+				t.Fatal(err)
+			}
+
+			q := group.group.NewElement()
+			if err := q.DecodeHex(e.Q); err != nil {
+				t.Fatal(err)
+			}
+
+			if p.Add(q).Hex() != e.Sum {
+				t.Fatal("unexpected sum")
+			}
+		}
+
+		for _, invalid := range []string{v.Invalid.ElementEncoding, v.Invalid.ElementOffCurve, v.Invalid.Identity} {
+			if err := group.group.NewElement().DecodeHex(invalid); err == nil {
+				t.Fatalf("expected error on %s", invalid)
+			}
+		}
+	})
+}
+
+func TestTestVectors_Export(t *testing.T) {
+	var buf strings.Builder
+	if err := testvectors.Export(&buf, ecc.Edwards25519Sha512, ecc.PallasBLAKE2b512); err != nil {
+		t.Fatal(err)
+	}
+
+	var vectors []testvectors.Vectors
+	if err := json.Unmarshal([]byte(buf.String()), &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vectors) != 2 || vectors[1].Group != ecc.PallasBLAKE2b512 {
+		t.Fatal("unexpected exported vectors")
+	}
+
+	if err := testvectors.Export(&buf, ecc.Group(0)); err == nil {
+		t.Fatal("expected error on invalid group")
+	}
+}
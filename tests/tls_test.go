@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+)
+
+func TestKeyShare_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		pk := group.group.Base().Multiply(group.group.NewScalar().Random())
+
+		entry, err := encoding.NewKeyShareEntry(pk)
+		if !isNIST(group.group) && group.group != ecc.Edwards25519Sha512 {
+			if err == nil {
+				t.Fatal("expected error on unsupported group")
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, rest, err := encoding.ParseKeyShareEntry(append(entry.Marshal(), 0xff))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if parsed.Group != entry.Group || !bytes.Equal(parsed.KeyExchange, entry.KeyExchange) ||
+			!bytes.Equal(rest, []byte{0xff}) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if _, err = parsed.PublicKey(); err != nil {
+			t.Fatal(err)
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			if entry.Group != encoding.X25519 || len(entry.KeyExchange) != 32 {
+				t.Fatalf("unexpected X25519 entry %v", entry)
+			}
+
+			if _, err = parsed.Element(); err == nil {
+				t.Fatal("expected error on X25519 element conversion")
+			}
+
+			return
+		}
+
+		if entry.KeyExchange[0] != 0x04 {
+			t.Fatal("expected an uncompressed point")
+		}
+
+		e, err := parsed.Element()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e.Equal(pk) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestKeyShare_ClientShares(t *testing.T) {
+	x25519, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p256, err := encoding.NewKeyShareEntry(ecc.P256Sha256.Base().Multiply(ecc.P256Sha256.NewScalar().Random()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []*encoding.KeyShareEntry{
+		{Group: encoding.X25519, KeyExchange: x25519.PublicKey().Bytes()},
+		p256,
+		{Group: 0x11ec, KeyExchange: []byte{1, 2, 3}}, // an unsupported group is carried along
+	}
+
+	data, err := encoding.MarshalClientShares(entries...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := encoding.ParseClientShares(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed) != len(entries) {
+		t.Fatalf("unexpected number of entries: %d", len(parsed))
+	}
+
+	for i, e := range parsed {
+		if e.Group != entries[i].Group || !bytes.Equal(e.KeyExchange, entries[i].KeyExchange) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+
+	if _, err = parsed[2].PublicKey(); err == nil {
+		t.Fatal("expected error on unsupported group")
+	}
+
+	key, err := parsed[0].PublicKey()
+	if err != nil || !key.Equal(x25519.PublicKey()) {
+		t.Fatalf("unexpected X25519 key: %v", err)
+	}
+
+	duplicate, _ := encoding.MarshalClientShares(p256, p256)
+	for _, invalid := range [][]byte{
+		nil,
+		data[:len(data)-1],
+		append(data, 0),
+		duplicate,
+		{0, 4, 0, 0x17, 0, 0}, // empty key exchange
+	} {
+		if _, err = encoding.ParseClientShares(invalid); err == nil {
+			t.Fatalf("expected error on %x", invalid)
+		}
+	}
+}
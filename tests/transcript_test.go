@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/transcript"
+)
+
+func TestTranscript(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+		s := g.NewScalar().Random()
+
+		run := func(protocol string, msgs ...string) *transcript.Transcript {
+			tr := transcript.New(g, protocol)
+			tr.AppendElement("element", e)
+			tr.AppendScalar("scalar", s)
+
+			for _, m := range msgs {
+				tr.AppendMessage("message", []byte(m))
+			}
+
+			return tr
+		}
+
+		c := run("test", "a", "b").ChallengeScalar("c")
+		if c.Group() != g || !c.Equal(run("test", "a", "b").ChallengeScalar("c")) {
+			t.Fatal("expected deterministic challenges in the group")
+		}
+
+		for name, other := range map[string]*transcript.Transcript{
+			"protocol": run("other", "a", "b"),
+			"messages": run("test", "ab"),
+			"order":    run("test", "b", "a"),
+			"count":    run("test", "a", "b", ""),
+		} {
+			if other.ChallengeScalar("c").Equal(c) {
+				t.Fatalf("expected different challenges for a different %s", name)
+			}
+		}
+
+		if run("test", "a", "b").ChallengeScalar("d").Equal(c) {
+			t.Fatal("expected different challenges for a different label")
+		}
+
+		// Challenges depend on previous challenges, and clones are independent.
+		tr := run("test", "a", "b")
+		clone := tr.Clone()
+
+		if !tr.ChallengeScalar("c").Equal(c) || tr.ChallengeScalar("c").Equal(c) {
+			t.Fatal("expected chained challenges to differ")
+		}
+
+		if !clone.ChallengeScalar("c").Equal(c) || clone.Group() != g {
+			t.Fatal("expected the clone to be unaffected")
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func testTyped[G ecc.GroupID](t *testing.T, g ecc.Group) {
+	t.Helper()
+
+	if ecc.NewTypedElement[G]().Group() != g || ecc.NewTypedScalar[G]().Group() != g {
+		t.Fatal("unexpected group")
+	}
+
+	sk := ecc.NewTypedScalar[G]().Random()
+	pk := ecc.TypedBase[G]().Multiply(sk)
+
+	if !pk.Element().Equal(g.Base().Multiply(sk.Scalar())) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// (sk + 2) * H - 2 * H = sk * H
+	h := ecc.TypedHashToGroup[G](testHashToGroupInput, testHashToGroupDST)
+	two := ecc.NewTypedScalar[G]().SetUInt64(2)
+	left := h.Copy().Multiply(sk.Copy().Add(two)).Subtract(h.Copy().Multiply(two))
+
+	if !left.Equal(h.Copy().Multiply(sk)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !sk.Copy().Multiply(sk.Copy().Invert()).Equal(ecc.NewTypedScalar[G]().SetUInt64(1)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !h.Copy().Add(nil).Equal(h) || !h.Copy().Multiply(nil).IsIdentity() {
+		t.Fatal("unexpected result with nil argument")
+	}
+
+	decoded := ecc.NewTypedElement[G]()
+	if err := decoded.Decode(pk.Encode()); err != nil || !decoded.Equal(pk) {
+		t.Fatalf("unexpected decoding: %v", err)
+	}
+
+	s := ecc.NewTypedScalar[G]()
+	if err := s.Decode(sk.Encode()); err != nil || !s.Equal(sk) {
+		t.Fatalf("unexpected decoding: %v", err)
+	}
+
+	if _, err := ecc.AsTypedElement[G](g.Base()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ecc.AsTypedScalar[G](g.HashToScalar(testHashToGroupInput, testHashToGroupDST)); err != nil {
+		t.Fatal(err)
+	}
+
+	other := ecc.Ristretto255Sha512
+	if g == other {
+		other = ecc.P256Sha256
+	}
+
+	if _, err := ecc.AsTypedElement[G](other.Base()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+		t.Fatalf("expected wrong group error, got %v", err)
+	}
+
+	if _, err := ecc.AsTypedScalar[G](other.NewScalar()); !errors.Is(err, &ecc.ErrWrongGroup{}) {
+		t.Fatalf("expected wrong group error, got %v", err)
+	}
+}
+
+func TestTyped(t *testing.T) {
+	t.Run("Ristretto255", func(t *testing.T) { testTyped[ecc.Ristretto255](t, ecc.Ristretto255Sha512) })
+	t.Run("P256", func(t *testing.T) { testTyped[ecc.P256](t, ecc.P256Sha256) })
+	t.Run("P384", func(t *testing.T) { testTyped[ecc.P384](t, ecc.P384Sha384) })
+	t.Run("P521", func(t *testing.T) { testTyped[ecc.P521](t, ecc.P521Sha512) })
+	t.Run("Edwards25519", func(t *testing.T) { testTyped[ecc.Edwards25519](t, ecc.Edwards25519Sha512) })
+	t.Run("Secp256k1", func(t *testing.T) { testTyped[ecc.Secp256k1](t, ecc.Secp256k1Sha256) })
+	t.Run("Pallas", func(t *testing.T) { testTyped[ecc.Pallas](t, ecc.PallasBLAKE2b512) })
+}
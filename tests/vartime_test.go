@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestGroup_DoubleScalarMultVartime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		p := g.Base()
+		q := g.HashToGroup(testHashToGroupInput, testHashToGroupDST)
+
+		scalars := []*ecc.Scalar{
+			g.NewScalar(),
+			g.NewScalar().One(),
+			g.NewScalar().MinusOne(),
+			g.NewScalar().SetUInt64(0xf0f0),
+		}
+
+		for range 5 {
+			scalars = append(scalars, g.NewScalar().Random())
+		}
+
+		for _, a := range scalars {
+			for _, b := range scalars {
+				expected := p.Copy().Multiply(a).Add(q.Copy().Multiply(b))
+				if !g.DoubleScalarMultVartime(a, p, b, q).Equal(expected) {
+					t.Fatalf("unexpected result for %s and %s", a.Hex(), b.Hex())
+				}
+			}
+		}
+
+		a := g.NewScalar().Random()
+		if !g.DoubleScalarMultVartime(a, p, nil, q).Equal(p.Copy().Multiply(a)) ||
+			!g.DoubleScalarMultVartime(a, p, a, nil).Equal(p.Copy().Multiply(a)) ||
+			!g.DoubleScalarMultVartime(a, g.NewElement(), a, p.Copy().Negate()).Equal(p.Copy().Multiply(a).Negate()) ||
+			!g.DoubleScalarMultVartime(nil, nil, nil, nil).IsIdentity() {
+			t.Fatal("unexpected result with nil or identity arguments")
+		}
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		if hasPanic, _ := hasPanic(func() { _ = g.DoubleScalarMultVartime(a, p, other.NewScalar().One(), q) }); !hasPanic {
+			t.Fatal("expected panic on group mismatch")
+		}
+	})
+}
+
+func TestGroup_MultiScalarMultVartime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, n := range []int{0, 1, 2, 7, 40} {
+			scalars := make([]*ecc.Scalar, n)
+			elements := make([]*ecc.Element, n)
+			expected := g.NewElement()
+
+			for i := range n {
+				scalars[i] = g.NewScalar().Random()
+				elements[i] = g.Base().Multiply(g.NewScalar().Random())
+
+				switch i {
+				case 1:
+					scalars[i].MinusOne()
+				case 2:
+					scalars[i].Zero()
+				case 3:
+					elements[i] = g.NewElement()
+				}
+
+				expected.Add(elements[i].Copy().Multiply(scalars[i]))
+			}
+
+			if !g.MultiScalarMultVartime(scalars, elements).Equal(expected) {
+				t.Fatalf("unexpected result for %d terms", n)
+			}
+		}
+
+		s := g.NewScalar().Random()
+		if !g.MultiScalarMultVartime([]*ecc.Scalar{s, nil}, []*ecc.Element{g.Base(), g.Base()}).
+			Equal(g.Base().Multiply(s)) {
+			t.Fatal("unexpected result with a nil scalar")
+		}
+
+		if hasPanic, _ := hasPanic(func() {
+			_ = g.MultiScalarMultVartime([]*ecc.Scalar{s}, nil)
+		}); !hasPanic {
+			t.Fatal("expected panic on length mismatch")
+		}
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		if hasPanic, _ := hasPanic(func() {
+			_ = g.MultiScalarMultVartime([]*ecc.Scalar{s}, []*ecc.Element{other.Base()})
+		}); !hasPanic {
+			t.Fatal("expected panic on group mismatch")
+		}
+	})
+}
+
+func TestGroup_MultiScalarMultVartimeContext(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		scalars := []*ecc.Scalar{g.NewScalar().Random(), g.NewScalar().Random()}
+		elements := []*ecc.Element{g.Base(), g.Base().Double()}
+
+		res, err := g.MultiScalarMultVartimeContext(context.Background(), scalars, elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(g.MultiScalarMultVartime(scalars, elements)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err = g.MultiScalarMultVartimeContext(ctx, scalars, elements); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		if _, err = g.MultiScalarMultVartimeContext(context.Background(), scalars, nil); err == nil {
+			t.Fatal("expected error on length mismatch")
+		}
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		var wrong *ecc.ErrWrongGroup
+		if _, err = g.MultiScalarMultVartimeContext(context.Background(), scalars[:1],
+			[]*ecc.Element{other.Base()}); !errors.As(err, &wrong) {
+			t.Fatalf("expected a wrong group error, got %v", err)
+		}
+	})
+}
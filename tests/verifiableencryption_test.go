@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/verifiableencryption"
+)
+
+func TestVerifiableEncryption(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("escrow")
+		kp := g.GenerateKeyPair()
+		x := g.NewScalar().Random()
+		element := g.Base().Multiply(x)
+
+		ct, err := verifiableencryption.Encrypt(kp.Public, x, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decrypted, err := verifiableencryption.Decrypt(kp.Secret, element, ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decrypted.Equal(x) {
+			t.Fatal("unexpected decrypted scalar")
+		}
+
+		decoded := new(verifiableencryption.Ciphertext)
+		if err = decoded.Decode(g, ct.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !verifiableencryption.Verify(kp.Public, element, decoded, context) {
+			t.Fatal("decoded ciphertext did not verify")
+		}
+
+		if err = decoded.Decode(g, ct.Encode()[1:]); err == nil {
+			t.Fatal("expected error on invalid length")
+		}
+
+		// Wrong statements and tampered ciphertexts don't verify. Verification is costly, so only a few are checked.
+		if verifiableencryption.Verify(kp.Public, element, ct, []byte("other")) ||
+			verifiableencryption.Verify(kp.Public, element, nil, context) {
+			t.Fatal("unexpected verification of a wrong statement")
+		}
+
+		ct.Bits[0].C2.Add(g.Base())
+		if verifiableencryption.Verify(kp.Public, element, ct, context) {
+			t.Fatal("tampered ciphertext verified")
+		}
+
+		if _, err = verifiableencryption.Decrypt(g.NewScalar().Random(), element, decoded); err == nil {
+			t.Fatal("expected error on decryption with the wrong key")
+		}
+
+		if _, err = verifiableencryption.Encrypt(g.NewElement(), x, context); err == nil {
+			t.Fatal("expected error on identity public key")
+		}
+	})
+}
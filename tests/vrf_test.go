@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc/secretsharing"
+	"github.com/bytemare/ecc/vrf"
+)
+
+func TestVRF(t *testing.T) {
+	alpha := []byte("alpha")
+
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+		pk := group.group.Base().Multiply(sk)
+
+		proof, err := vrf.Prove(sk, alpha)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(vrf.Proof)
+		if err = decoded.Decode(group.group, proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		beta, err := vrf.Verify(pk, alpha, decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The output is deterministic.
+		proof2, _ := vrf.Prove(sk, alpha)
+		if !bytes.Equal(beta, proof2.Output()) {
+			t.Fatal("output is not deterministic")
+		}
+
+		if _, err = vrf.Verify(pk, []byte("other"), proof); err == nil {
+			t.Fatal("expected error for wrong input")
+		}
+
+		if _, err = vrf.Verify(group.group.Base(), alpha, proof); err == nil {
+			t.Fatal("expected error for wrong key")
+		}
+	})
+}
+
+func TestVRF_Threshold(t *testing.T) {
+	alpha := []byte("round 42")
+
+	testAllGroups(t, func(group *testGroup) {
+		sk := group.group.NewScalar().Random()
+
+		shares, commitment, err := secretsharing.Split(sk, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		partials := make([]*vrf.PartialEvaluation, 0, 3)
+
+		for _, s := range []*secretsharing.Share{shares[1], shares[3], shares[4]} {
+			p, err := vrf.PartialEvaluate(s, commitment.PublicKey(), alpha)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !vrf.VerifyPartial(commitment, alpha, p) {
+				t.Fatal("valid partial evaluation did not verify")
+			}
+
+			partials = append(partials, p)
+		}
+
+		_, beta, err := vrf.Combine(commitment, alpha, partials)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof, _ := vrf.Prove(sk, alpha)
+		if !bytes.Equal(beta, proof.Output()) {
+			t.Fatal("threshold output differs from the output of the group key")
+		}
+
+		if _, _, err = vrf.Combine(commitment, alpha, partials[:2]); err == nil {
+			t.Fatal("expected error on too few partial evaluations")
+		}
+
+		partials[0].ID = shares[0].ID
+		if _, _, err = vrf.Combine(commitment, alpha, partials); err == nil {
+			t.Fatal("expected error on invalid partial evaluation")
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/x3dh"
+)
+
+const x3dhInfo = "x3dh test"
+
+type x3dhResponder struct {
+	identity, signedPrekey, oneTimePrekey *ecc.Scalar
+	bundle                                *x3dh.Bundle
+}
+
+func newX3DHResponder(t *testing.T, g ecc.Group) *x3dhResponder {
+	r := &x3dhResponder{
+		identity:      g.NewScalar().Random(),
+		signedPrekey:  g.NewScalar().Random(),
+		oneTimePrekey: g.NewScalar().Random(),
+	}
+
+	spk := g.Base().Multiply(r.signedPrekey)
+
+	sig, err := x3dh.SignPrekey(r.identity, spk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.bundle = &x3dh.Bundle{
+		IdentityKey:     g.Base().Multiply(r.identity),
+		SignedPrekey:    spk,
+		PrekeySignature: sig,
+		OneTimePrekey:   g.Base().Multiply(r.oneTimePrekey),
+	}
+
+	return r
+}
+
+func TestX3DH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		ika := g.NewScalar().Random()
+		r := newX3DHResponder(t, g)
+
+		sa, ek, err := x3dh.Initiate(ika, r.bundle, x3dhInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sb, err := x3dh.Respond(r.identity, r.signedPrekey, r.oneTimePrekey, g.Base().Multiply(ika), ek, x3dhInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(sa) != x3dh.SecretLength || !bytes.Equal(sa, sb) {
+			t.Fatal("expected equal shared secrets")
+		}
+
+		// Without one-time prekey.
+		r.bundle.OneTimePrekey = nil
+
+		sa2, ek, err := x3dh.Initiate(ika, r.bundle, x3dhInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sb2, err := x3dh.Respond(r.identity, r.signedPrekey, nil, g.Base().Multiply(ika), ek, x3dhInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(sa2, sb2) || bytes.Equal(sa, sa2) {
+			t.Fatal("unexpected shared secrets without one-time prekey")
+		}
+
+		// The secrets depend on the info and on the one-time prekey.
+		sb3, _ := x3dh.Respond(r.identity, r.signedPrekey, nil, g.Base().Multiply(ika), ek, "other")
+		if bytes.Equal(sb3, sa2) {
+			t.Fatal("expected different secrets for different infos")
+		}
+
+		sb4, _ := x3dh.Respond(r.identity, r.signedPrekey, r.oneTimePrekey, g.Base().Multiply(ika), ek, x3dhInfo)
+		if bytes.Equal(sb4, sa2) {
+			t.Fatal("expected different secrets with an unused one-time prekey")
+		}
+
+		ad := x3dh.AssociatedData(g.Base().Multiply(ika), r.bundle.IdentityKey)
+		if len(ad) != 2*g.ElementLength() {
+			t.Fatal("unexpected associated data length")
+		}
+	})
+}
+
+func TestX3DH_Errors(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		ika := g.NewScalar().Random()
+		r := newX3DHResponder(t, g)
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		if _, err := x3dh.SignPrekey(r.identity, other.Base()); err == nil {
+			t.Fatal("expected error on group mismatch")
+		}
+
+		if _, err := x3dh.SignPrekey(nil, r.bundle.SignedPrekey); err == nil {
+			t.Fatal("expected error on nil input")
+		}
+
+		// A prekey signed by another identity is rejected.
+		forged := *r.bundle
+		forged.PrekeySignature, _ = x3dh.SignPrekey(g.NewScalar().Random(), forged.SignedPrekey)
+
+		if _, _, err := x3dh.Initiate(ika, &forged, x3dhInfo); err == nil {
+			t.Fatal("expected error on invalid prekey signature")
+		}
+
+		for name, mutate := range map[string]func(b *x3dh.Bundle){
+			"nil identity key":         func(b *x3dh.Bundle) { b.IdentityKey = nil },
+			"identity signed prekey":   func(b *x3dh.Bundle) { b.SignedPrekey = g.NewElement() },
+			"identity one-time key":    func(b *x3dh.Bundle) { b.OneTimePrekey = g.NewElement() },
+			"wrong group one-time":     func(b *x3dh.Bundle) { b.OneTimePrekey = other.Base() },
+			"wrong group signed key":   func(b *x3dh.Bundle) { b.SignedPrekey = other.Base() },
+			"missing prekey signature": func(b *x3dh.Bundle) { b.PrekeySignature = nil },
+		} {
+			b := *r.bundle
+			mutate(&b)
+
+			if _, _, err := x3dh.Initiate(ika, &b, x3dhInfo); err == nil {
+				t.Fatalf("expected error with %s", name)
+			}
+		}
+
+		if _, _, err := x3dh.Initiate(ika, nil, x3dhInfo); err == nil {
+			t.Fatal("expected error on nil bundle")
+		}
+
+		pka := g.Base().Multiply(ika)
+		if _, err := x3dh.Respond(r.identity, r.signedPrekey, nil, pka, g.NewElement(), x3dhInfo); err == nil {
+			t.Fatal("expected error on identity ephemeral key")
+		}
+
+		if _, err := x3dh.Respond(r.identity, other.NewScalar().Random(), nil, pka, pka, x3dhInfo); err == nil {
+			t.Fatal("expected error on group mismatch")
+		}
+
+		if _, err := x3dh.Respond(r.identity, nil, nil, pka, pka, x3dhInfo); err == nil {
+			t.Fatal("expected error on nil input")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package testvectors
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var errPointOffCurve = errors.New("point is not on the curve")
+
+// curve holds the short Weierstrass equation y^2 = x^3 + ax + b over the prime field of order p, used to validate
+// uncompressed points, which the groups don't decode.
+type curve struct {
+	p, a, b *big.Int
+}
+
+func nistCurve(params *elliptic.CurveParams) *curve {
+	return &curve{
+		p: params.P,
+		a: new(big.Int).Sub(params.P, big.NewInt(3)),
+		b: params.B,
+	}
+}
+
+func hexInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex integer")
+	}
+
+	return i
+}
+
+func curveOf(g ecc.Group) *curve {
+	switch g {
+	case ecc.P256Sha256:
+		return nistCurve(elliptic.P256().Params())
+	case ecc.P384Sha384:
+		return nistCurve(elliptic.P384().Params())
+	case ecc.P521Sha512:
+		return nistCurve(elliptic.P521().Params())
+	case ecc.Secp256k1Sha256:
+		return &curve{
+			p: hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+			a: big.NewInt(0),
+			b: big.NewInt(7),
+		}
+	case ecc.PallasBLAKE2b512:
+		return &curve{
+			p: hexInt("40000000000000000000000000000000224698fc094cf91b992d30ed00000001"),
+			a: big.NewInt(0),
+			b: big.NewInt(5),
+		}
+	default:
+		return nil
+	}
+}
+
+func (c *curve) isOnCurve(x, y *big.Int) bool {
+	if x.Cmp(c.p) >= 0 || y.Cmp(c.p) >= 0 {
+		return false
+	}
+
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Add(rhs, c.a)
+	rhs.Mul(rhs, x)
+	rhs.Add(rhs, c.b)
+	rhs.Mod(rhs, c.p)
+
+	lhs := new(big.Int).Mul(y, y)
+	lhs.Mod(lhs, c.p)
+
+	return lhs.Cmp(rhs) == 0
+}
+
+// decodePoint decodes a compressed or uncompressed SEC1 point encoding into an element of the group.
+func decodePoint(g ecc.Group, data []byte) (*ecc.Element, error) {
+	e := g.NewElement()
+	coordLength := g.ElementLength() - 1
+
+	if len(data) != 1+2*coordLength || data[0] != 0x04 {
+		if err := e.Decode(data); err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	}
+
+	x := data[1 : 1+coordLength]
+	y := new(big.Int).SetBytes(data[1+coordLength:])
+
+	if !curveOf(g).isOnCurve(new(big.Int).SetBytes(x), y) {
+		return nil, errPointOffCurve
+	}
+
+	if err := e.DecodeFromX(x, y.Bit(0) == 1); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package testvectors
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var errSharedSecret = errors.New("shared secret mismatch")
+
+// scalarFromInt decodes the non-negative integer i into a scalar of the group, and fails if it's not reduced.
+func scalarFromInt(g ecc.Group, i *big.Int) (*ecc.Scalar, error) {
+	if i.Sign() < 0 || i.BitLen() > 8*g.ScalarLength() {
+		return nil, ecc.ErrInvalidScalarEncoding
+	}
+
+	s := g.NewScalar()
+	if err := s.DecodeCanonical(i.FillBytes(make([]byte, g.ScalarLength()))); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// runECDH computes the shared secret of an EcdhEcpointTest case, i.e. the x-coordinate of private * public, and
+// returns an error if it fails or doesn't match the expected value.
+func runECDH(g ecc.Group, _ *TestGroup, tc *TestCase) error {
+	public, err := hex.DecodeString(tc.Public)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+
+	private, err := hex.DecodeString(tc.Private)
+	if err != nil {
+		return fmt.Errorf("private key: %w", err)
+	}
+
+	shared, err := hex.DecodeString(tc.Shared)
+	if err != nil {
+		return fmt.Errorf("shared secret: %w", err)
+	}
+
+	q, err := decodePoint(g, public)
+	if err != nil {
+		return err
+	}
+
+	d, err := scalarFromInt(g, new(big.Int).SetBytes(private))
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(q.Multiply(d).XCoordinate(), shared) {
+		return errSharedSecret
+	}
+
+	return nil
+}
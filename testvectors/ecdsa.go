@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package testvectors
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256" // Registers SHA-224 and SHA-256.
+	_ "crypto/sha3"   // Registers the SHA-3 functions.
+	_ "crypto/sha512" // Registers the SHA-512 family.
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+)
+
+var (
+	errNoPublicKey       = errors.New("missing public key")
+	errSignatureEncoding = errors.New("invalid signature encoding")
+	errSignatureRange    = errors.New("signature value out of range")
+	errSignatureIdentity = errors.New("signature verification yields the identity")
+	errSignatureMismatch = errors.New("signature mismatch")
+)
+
+// hashes maps the Wycheproof hash function names to their implementations.
+var hashes = map[string]crypto.Hash{
+	"SHA-224":     crypto.SHA224,
+	"SHA-256":     crypto.SHA256,
+	"SHA-384":     crypto.SHA384,
+	"SHA-512":     crypto.SHA512,
+	"SHA-512/224": crypto.SHA512_224,
+	"SHA-512/256": crypto.SHA512_256,
+	"SHA3-224":    crypto.SHA3_224,
+	"SHA3-256":    crypto.SHA3_256,
+	"SHA3-384":    crypto.SHA3_384,
+	"SHA3-512":    crypto.SHA3_512,
+}
+
+func (tg *TestGroup) hash() (crypto.Hash, bool) {
+	h, ok := hashes[tg.SHA]
+	return h, ok && h.Available()
+}
+
+type derSignature struct {
+	R, S *big.Int
+}
+
+// parseDER parses a DER encoded ECDSA signature, rejecting any other BER encoding.
+func parseDER(sig []byte) (r, s *big.Int, err error) {
+	var v derSignature
+
+	rest, err := asn1.Unmarshal(sig, &v)
+	if err != nil || len(rest) != 0 {
+		return nil, nil, errSignatureEncoding
+	}
+
+	reencoded, err := asn1.Marshal(v)
+	if err != nil || !bytes.Equal(reencoded, sig) {
+		return nil, nil, errSignatureEncoding
+	}
+
+	return v.R, v.S, nil
+}
+
+// parseP1363 parses an IEEE P1363 encoded ECDSA signature, i.e. the fixed-length concatenation of r and s.
+func parseP1363(g ecc.Group, sig []byte) (r, s *big.Int, err error) {
+	if len(sig) != 2*g.ScalarLength() {
+		return nil, nil, errSignatureEncoding
+	}
+
+	return new(big.Int).SetBytes(sig[:g.ScalarLength()]), new(big.Int).SetBytes(sig[g.ScalarLength():]), nil
+}
+
+func runECDSA(g ecc.Group, tg *TestGroup, tc *TestCase) error {
+	return verifyECDSA(g, tg, tc, parseDER)
+}
+
+func runECDSAP1363(g ecc.Group, tg *TestGroup, tc *TestCase) error {
+	return verifyECDSA(g, tg, tc, func(sig []byte) (*big.Int, *big.Int, error) {
+		return parseP1363(g, sig)
+	})
+}
+
+// hashToInt converts a message digest to an integer as specified in SEC 1, i.e. keeping its leftmost bits up to the
+// bit length of the group order, and reduces it.
+func hashToInt(digest []byte, order *big.Int) *big.Int {
+	e := new(big.Int).SetBytes(digest)
+	if excess := 8*len(digest) - order.BitLen(); excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+
+	return e.Mod(e, order)
+}
+
+// verifyECDSA verifies the signature of an EcdsaVerify or EcdsaP1363Verify test case, and returns an error if it
+// is invalid.
+func verifyECDSA(
+	g ecc.Group,
+	tg *TestGroup,
+	tc *TestCase,
+	parse func(sig []byte) (*big.Int, *big.Int, error),
+) error {
+	pk := tg.publicKey()
+	if pk == nil {
+		return errNoPublicKey
+	}
+
+	h, _ := tg.hash()
+
+	public, err := hex.DecodeString(pk.Uncompressed)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+
+	msg, err := hex.DecodeString(tc.Msg)
+	if err != nil {
+		return fmt.Errorf("message: %w", err)
+	}
+
+	sig, err := hex.DecodeString(tc.Sig)
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+
+	q, err := decodePoint(g, public)
+	if err != nil {
+		return err
+	}
+
+	r, s, err := parse(sig)
+	if err != nil {
+		return err
+	}
+
+	order := new(big.Int).SetBytes(g.Order())
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(order) >= 0 || s.Cmp(order) >= 0 {
+		return errSignatureRange
+	}
+
+	digest := h.New()
+	_, _ = digest.Write(msg)
+	e := hashToInt(digest.Sum(nil), order)
+
+	// u1 = e / s and u2 = r / s, the values being in range for the conversions to succeed.
+	w, _ := scalarFromInt(g, new(big.Int).ModInverse(s, order))
+	u1, _ := scalarFromInt(g, e)
+	u2, _ := scalarFromInt(g, r)
+	u1.Multiply(w)
+	u2.Multiply(w)
+
+	p := g.Base().Multiply(u1).Add(q.Multiply(u2))
+	if p.IsIdentity() {
+		return errSignatureIdentity
+	}
+
+	x := new(big.Int).SetBytes(p.XCoordinate())
+	if x.Mod(x, order).Cmp(r) != 0 {
+		return errSignatureMismatch
+	}
+
+	return nil
+}
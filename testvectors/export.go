@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package testvectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+const (
+	exportDST   = "ecc-test-vectors-V01"
+	exportCount = 8
+)
+
+// Vectors holds the deterministic test vectors of a group, with all values hex encoded. They are derived from fixed
+// inputs only, so that generating them again yields the same output, byte for byte.
+type Vectors struct {
+	Ciphersuite string           `json:"ciphersuite"`
+	Generator   string           `json:"generator"`
+	Order       string           `json:"order"`
+	Encoding    []EncodingVector `json:"encoding"`
+	Invalid     InvalidVectors   `json:"invalid"`
+	HashToCurve []HashVector     `json:"hashToCurve"`
+	Scalars     []ScalarVector   `json:"scalarArithmetic"`
+	Elements    []ElementVector  `json:"elementArithmetic"`
+	Group       ecc.Group        `json:"group"`
+}
+
+// EncodingVector holds a scalar k and the encoding of k * G.
+type EncodingVector struct {
+	Scalar  string `json:"scalar"`
+	Element string `json:"element"`
+}
+
+// InvalidVectors holds encodings that must be rejected by decoders.
+type InvalidVectors struct {
+	ScalarAboveOrder string `json:"scalarAboveOrder"`
+	ElementOffCurve  string `json:"elementOffCurve"`
+	ElementEncoding  string `json:"elementEncoding"`
+	Identity         string `json:"identity"`
+}
+
+// HashVector holds the outputs of the hash-to-curve operations on an input.
+type HashVector struct {
+	DST           string `json:"dst"`
+	Input         string `json:"input"`
+	HashToGroup   string `json:"hashToGroup"`
+	EncodeToGroup string `json:"encodeToGroup"`
+	HashToScalar  string `json:"hashToScalar"`
+}
+
+// ScalarVector holds the results of arithmetic operations on two scalars.
+type ScalarVector struct {
+	A          string `json:"a"`
+	B          string `json:"b"`
+	Sum        string `json:"sum"`
+	Difference string `json:"difference"`
+	Product    string `json:"product"`
+	Pow        string `json:"pow"`
+	InverseA   string `json:"inverseA"`
+}
+
+// ElementVector holds the results of arithmetic operations on two elements and a scalar.
+type ElementVector struct {
+	P          string `json:"p"`
+	Q          string `json:"q"`
+	Scalar     string `json:"scalar"`
+	Sum        string `json:"sum"`
+	Difference string `json:"difference"`
+	DoubleP    string `json:"doubleP"`
+	NegateP    string `json:"negateP"`
+	Product    string `json:"product"`
+}
+
+// deterministicScalar derives the i-th scalar with the given label.
+func deterministicScalar(g ecc.Group, label string, i int) *ecc.Scalar {
+	return g.HashToScalar([]byte(fmt.Sprintf("%s-%d", label, i)), []byte(exportDST))
+}
+
+// Generate returns the deterministic test vectors of the group.
+func Generate(g ecc.Group) *Vectors {
+	v := &Vectors{
+		Ciphersuite: g.String(),
+		Generator:   g.Base().Hex(),
+		Order:       hex.EncodeToString(g.Order()),
+		Invalid: InvalidVectors{
+			ScalarAboveOrder: hex.EncodeToString(debug.BadScalarHigh(g)),
+			ElementOffCurve:  hex.EncodeToString(debug.BadElementOffCurve(g)),
+			ElementEncoding:  hex.EncodeToString(debug.BadElementEncoding(g)),
+			Identity:         hex.EncodeToString(g.NewElement().Encode()),
+		},
+		Group: g,
+	}
+
+	k := g.NewScalar()
+	for i := 1; i <= exportCount; i++ {
+		k.SetUInt64(uint64(i))
+		v.Encoding = append(v.Encoding, EncodingVector{Scalar: k.Hex(), Element: g.Base().Multiply(k).Hex()})
+	}
+
+	for i := range exportCount {
+		v.HashToCurve = append(v.HashToCurve, hashVector(g, i))
+		v.Scalars = append(v.Scalars, scalarVector(g, i))
+		v.Elements = append(v.Elements, elementVector(g, i))
+	}
+
+	return v
+}
+
+func hashVector(g ecc.Group, i int) HashVector {
+	dst := []byte(exportDST)
+	input := make([]byte, i*16)
+
+	for j := range input {
+		input[j] = byte(j)
+	}
+
+	return HashVector{
+		DST:           hex.EncodeToString(dst),
+		Input:         hex.EncodeToString(input),
+		HashToGroup:   g.HashToGroup(input, dst).Hex(),
+		EncodeToGroup: g.EncodeToGroup(input, dst).Hex(),
+		HashToScalar:  g.HashToScalar(input, dst).Hex(),
+	}
+}
+
+func scalarVector(g ecc.Group, i int) ScalarVector {
+	a := deterministicScalar(g, "scalar-a", i)
+	b := deterministicScalar(g, "scalar-b", i)
+
+	return ScalarVector{
+		A:          a.Hex(),
+		B:          b.Hex(),
+		Sum:        a.Copy().Add(b).Hex(),
+		Difference: a.Copy().Subtract(b).Hex(),
+		Product:    a.Copy().Multiply(b).Hex(),
+		Pow:        a.Copy().Pow(b).Hex(),
+		InverseA:   a.Copy().Invert().Hex(),
+	}
+}
+
+func elementVector(g ecc.Group, i int) ElementVector {
+	p := g.Base().Multiply(deterministicScalar(g, "element-p", i))
+	q := g.Base().Multiply(deterministicScalar(g, "element-q", i))
+	s := deterministicScalar(g, "element-scalar", i)
+
+	return ElementVector{
+		P:          p.Hex(),
+		Q:          q.Hex(),
+		Scalar:     s.Hex(),
+		Sum:        p.Copy().Add(q).Hex(),
+		Difference: p.Copy().Subtract(q).Hex(),
+		DoubleP:    p.Copy().Double().Hex(),
+		NegateP:    p.Copy().Negate().Hex(),
+		Product:    p.Copy().Multiply(s).Hex(),
+	}
+}
+
+// Export writes the deterministic test vectors of the groups as an indented JSON array, e.g. to check other
+// implementations against this package.
+func Export(w io.Writer, groups ...ecc.Group) error {
+	vectors := make([]*Vectors, 0, len(groups))
+
+	for _, g := range groups {
+		if !g.Available() {
+			return ecc.ErrInvalidGroup
+		}
+
+		vectors = append(vectors, Generate(g))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(vectors); err != nil {
+		return fmt.Errorf("exporting test vectors: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package testvectors validates the groups of the ecc package against Wycheproof test vector files.
+//
+// The ECDH files with the "ecpoint" encoding (e.g. ecdh_secp256r1_ecpoint_test.json) exercise point decoding and
+// scalar multiplication, and the ECDSA files (e.g. ecdsa_secp256r1_sha256_test.json and their P1363 variants)
+// exercise decoding and multi-scalar arithmetic through an ECDSA verifier built on the group's API. The files are
+// not bundled: load them from a Wycheproof checkout with LoadFile, and run them with RunAgainst.
+//
+// The package also generates deterministic test vectors for all groups with Generate and Export, to check other
+// implementations against this one.
+package testvectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bytemare/ecc"
+)
+
+// Expected results of Wycheproof test cases.
+const (
+	ResultValid      = "valid"
+	ResultInvalid    = "invalid"
+	ResultAcceptable = "acceptable"
+)
+
+var (
+	errNoTestGroups     = errors.New("no test groups in file")
+	errUnsupportedGroup = errors.New("group is not covered by Wycheproof vectors")
+)
+
+// curveNames maps the groups to the Wycheproof curve names. Pallas is not covered by Wycheproof, but vectors in the
+// same format can be generated for it.
+var curveNames = map[ecc.Group]string{
+	ecc.P256Sha256:       "secp256r1",
+	ecc.P384Sha384:       "secp384r1",
+	ecc.P521Sha512:       "secp521r1",
+	ecc.Secp256k1Sha256:  "secp256k1",
+	ecc.PallasBLAKE2b512: "pallas",
+}
+
+// File is a Wycheproof test vector file.
+type File struct {
+	Algorithm     string      `json:"algorithm"`
+	Schema        string      `json:"schema"`
+	TestGroups    []TestGroup `json:"testGroups"`
+	NumberOfTests int         `json:"numberOfTests"`
+}
+
+// TestGroup is a group of test cases sharing the same parameters.
+type TestGroup struct {
+	PublicKey *PublicKey `json:"publicKey,omitempty"`
+	Key       *PublicKey `json:"key,omitempty"`
+	Type      string     `json:"type"`
+	Curve     string     `json:"curve"`
+	Encoding  string     `json:"encoding"`
+	SHA       string     `json:"sha"`
+	Tests     []TestCase `json:"tests"`
+}
+
+// PublicKey is the ECDSA public key of a test group.
+type PublicKey struct {
+	Curve        string `json:"curve"`
+	Uncompressed string `json:"uncompressed"`
+}
+
+// TestCase is a single Wycheproof test case. Only the fields relevant to the test group type are set.
+type TestCase struct {
+	Comment string   `json:"comment"`
+	Result  string   `json:"result"`
+	Public  string   `json:"public"`
+	Private string   `json:"private"`
+	Shared  string   `json:"shared"`
+	Msg     string   `json:"msg"`
+	Sig     string   `json:"sig"`
+	Flags   []string `json:"flags"`
+	TcID    int      `json:"tcId"`
+}
+
+// Failure describes a test case whose outcome doesn't match its expected result.
+type Failure struct {
+	Err      error
+	Comment  string
+	Expected string
+	TcID     int
+}
+
+// Report summarizes the run of test vector files against a group.
+type Report struct {
+	Failures []Failure
+	Group    ecc.Group
+	Total    int
+	Passed   int
+	Skipped  int
+}
+
+// OK returns whether no test case failed.
+func (r *Report) OK() bool {
+	return len(r.Failures) == 0
+}
+
+// Load reads a Wycheproof test vector file.
+func Load(r io.Reader) (*File, error) {
+	var f File
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid test vector file: %w", err)
+	}
+
+	if len(f.TestGroups) == 0 {
+		return nil, errNoTestGroups
+	}
+
+	return &f, nil
+}
+
+// LoadFile reads the Wycheproof test vector file at path.
+func LoadFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening test vector file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Load(file)
+}
+
+// RunAgainst runs the test cases of the files that apply to the group, and returns a report of the results. Test
+// groups for other curves, and test group types that are not supported, are counted as skipped.
+func RunAgainst(g ecc.Group, files ...*File) (*Report, error) {
+	curve, ok := curveNames[g]
+	if !ok {
+		return nil, errUnsupportedGroup
+	}
+
+	report := &Report{Group: g}
+
+	for _, f := range files {
+		for i := range f.TestGroups {
+			tg := &f.TestGroups[i]
+			report.Total += len(tg.Tests)
+
+			run := runner(tg)
+			if run == nil || tg.curve() != curve {
+				report.Skipped += len(tg.Tests)
+				continue
+			}
+
+			for _, tc := range tg.Tests {
+				report.record(tc, run(g, tg, &tc))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// RunAgainst runs the test cases of the file that apply to the group.
+func (f *File) RunAgainst(g ecc.Group) (*Report, error) {
+	return RunAgainst(g, f)
+}
+
+type testRunner func(g ecc.Group, tg *TestGroup, tc *TestCase) error
+
+func runner(tg *TestGroup) testRunner {
+	switch tg.Type {
+	case "EcdhEcpointTest":
+		return runECDH
+	case "EcdsaVerify", "EcdsaP1363Verify":
+		if _, ok := tg.hash(); !ok {
+			return nil
+		}
+
+		if tg.Type == "EcdsaVerify" {
+			return runECDSA
+		}
+
+		return runECDSAP1363
+	default:
+		return nil
+	}
+}
+
+func (tg *TestGroup) curve() string {
+	if tg.Curve != "" {
+		return tg.Curve
+	}
+
+	if pk := tg.publicKey(); pk != nil {
+		return pk.Curve
+	}
+
+	return ""
+}
+
+func (tg *TestGroup) publicKey() *PublicKey {
+	if tg.PublicKey != nil {
+		return tg.PublicKey
+	}
+
+	return tg.Key
+}
+
+// record accounts for the outcome of a test case, err being nil if it passed the validation.
+func (r *Report) record(tc TestCase, err error) {
+	switch {
+	case tc.Result == ResultAcceptable,
+		tc.Result == ResultValid && err == nil,
+		tc.Result == ResultInvalid && err != nil:
+		r.Passed++
+	default:
+		r.Failures = append(r.Failures, Failure{
+			Err:      err,
+			Comment:  tc.Comment,
+			Expected: tc.Result,
+			TcID:     tc.TcID,
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package transcript implements Merlin-style transcripts for the Fiat-Shamir transform of interactive protocols:
+// prover and verifier append the same labeled messages, and derive the same challenge scalars, which depend on the
+// whole transcript so far, including previous challenges.
+//
+// Unlike Merlin, the transcript is not built on STROBE, but on the group's HashToScalar, with the protocol name in the
+// domain separation tag. Messages are framed with their label and length, so that distinct sequences of messages never
+// hash the same.
+package transcript
+
+import (
+	"encoding/binary"
+
+	"github.com/bytemare/ecc"
+)
+
+const dstPrefix = "ECC-Transcript-V01-"
+
+// Operations framed in the transcript.
+const (
+	opMessage byte = iota + 1
+	opChallenge
+)
+
+// Transcript accumulates the messages of a protocol run in a group, and derives challenges from them. It is not safe
+// for concurrent use.
+type Transcript struct {
+	dst   []byte
+	state []byte
+	group ecc.Group
+}
+
+// New returns an empty transcript for the protocol in the group. The protocol name separates the challenges of
+// different protocols, and should identify the protocol and its version, e.g. "MyProtocol-V01".
+func New(g ecc.Group, protocol string) *Transcript {
+	return &Transcript{
+		group: g,
+		dst:   []byte(dstPrefix + protocol),
+	}
+}
+
+// Group returns the group of the transcript's challenges.
+func (t *Transcript) Group() ecc.Group {
+	return t.group
+}
+
+// append frames the operation, its label, and its data into the state.
+func (t *Transcript) append(op byte, label string, data []byte) {
+	t.state = append(t.state, op)
+	t.state = binary.BigEndian.AppendUint64(t.state, uint64(len(label)))
+	t.state = append(t.state, label...)
+	t.state = binary.BigEndian.AppendUint64(t.state, uint64(len(data)))
+	t.state = append(t.state, data...)
+}
+
+// AppendMessage appends the labeled message to the transcript.
+func (t *Transcript) AppendMessage(label string, message []byte) {
+	t.append(opMessage, label, message)
+}
+
+// AppendElement appends the labeled encoding of the element to the transcript.
+func (t *Transcript) AppendElement(label string, element *ecc.Element) {
+	t.append(opMessage, label, element.Encode())
+}
+
+// AppendScalar appends the labeled encoding of the scalar to the transcript.
+func (t *Transcript) AppendScalar(label string, scalar *ecc.Scalar) {
+	t.append(opMessage, label, scalar.Encode())
+}
+
+// ChallengeScalar returns the labeled challenge derived from the transcript, and appends it to the transcript, so that
+// subsequent challenges depend on it.
+func (t *Transcript) ChallengeScalar(label string) *ecc.Scalar {
+	t.append(opChallenge, label, nil)
+	c := t.group.HashToScalar(t.state, t.dst)
+	t.append(opMessage, label, c.Encode())
+
+	return c
+}
+
+// Clone returns an independent copy of the transcript, e.g. to derive challenges for alternative branches of a
+// protocol.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{
+		group: t.group,
+		dst:   t.dst,
+		state: append([]byte(nil), t.state...),
+	}
+}
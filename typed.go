@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+// GroupID is implemented by the marker types that parameterize TypedElement and TypedScalar with a group, e.g. P256.
+type GroupID interface {
+	// Group returns the group the marker type stands for.
+	Group() Group
+}
+
+// Marker types of the groups, for TypedElement and TypedScalar.
+type (
+	// Ristretto255 stands for Ristretto255Sha512.
+	Ristretto255 struct{}
+
+	// P256 stands for P256Sha256.
+	P256 struct{}
+
+	// P384 stands for P384Sha384.
+	P384 struct{}
+
+	// P521 stands for P521Sha512.
+	P521 struct{}
+
+	// Edwards25519 stands for Edwards25519Sha512.
+	Edwards25519 struct{}
+
+	// Secp256k1 stands for Secp256k1Sha256.
+	Secp256k1 struct{}
+
+	// Pallas stands for PallasBLAKE2b512.
+	Pallas struct{}
+)
+
+// Group returns Ristretto255Sha512.
+func (Ristretto255) Group() Group { return Ristretto255Sha512 }
+
+// Group returns P256Sha256.
+func (P256) Group() Group { return P256Sha256 }
+
+// Group returns P384Sha384.
+func (P384) Group() Group { return P384Sha384 }
+
+// Group returns P521Sha512.
+func (P521) Group() Group { return P521Sha512 }
+
+// Group returns Edwards25519Sha512.
+func (Edwards25519) Group() Group { return Edwards25519Sha512 }
+
+// Group returns Secp256k1Sha256.
+func (Secp256k1) Group() Group { return Secp256k1Sha256 }
+
+// Group returns PallasBLAKE2b512.
+func (Pallas) Group() Group { return PallasBLAKE2b512 }
+
+func groupOf[G GroupID]() Group {
+	var id G
+	return id.Group()
+}
+
+// TypedElement is an Element whose group is part of its type, so that mixing elements and scalars of different groups
+// is a compile-time error rather than a runtime panic. Its methods behave as those of Element.
+type TypedElement[G GroupID] struct {
+	e *Element
+}
+
+// TypedScalar is a Scalar whose group is part of its type, so that mixing scalars and elements of different groups
+// is a compile-time error rather than a runtime panic. Its methods behave as those of Scalar.
+type TypedScalar[G GroupID] struct {
+	s *Scalar
+}
+
+// NewTypedElement returns the identity element of the group G.
+func NewTypedElement[G GroupID]() *TypedElement[G] {
+	return &TypedElement[G]{e: groupOf[G]().NewElement()}
+}
+
+// TypedBase returns the base point of the group G.
+func TypedBase[G GroupID]() *TypedElement[G] {
+	return &TypedElement[G]{e: groupOf[G]().Base()}
+}
+
+// TypedHashToGroup returns the HashToGroup mapping of the input in the group G.
+func TypedHashToGroup[G GroupID](input, dst []byte) *TypedElement[G] {
+	return &TypedElement[G]{e: groupOf[G]().HashToGroup(input, dst)}
+}
+
+// AsTypedElement returns a typed view of the element, whose operations apply to the element, and an *ErrWrongGroup if
+// the element is not in the group G.
+func AsTypedElement[G GroupID](element *Element) (*TypedElement[G], error) {
+	if err := checkGroup(groupOf[G](), element.Group()); err != nil {
+		return nil, err
+	}
+
+	return &TypedElement[G]{e: element}, nil
+}
+
+// NewTypedScalar returns the scalar 0 of the group G.
+func NewTypedScalar[G GroupID]() *TypedScalar[G] {
+	return &TypedScalar[G]{s: groupOf[G]().NewScalar()}
+}
+
+// TypedHashToScalar returns the HashToScalar mapping of the input in the group G.
+func TypedHashToScalar[G GroupID](input, dst []byte) *TypedScalar[G] {
+	return &TypedScalar[G]{s: groupOf[G]().HashToScalar(input, dst)}
+}
+
+// AsTypedScalar returns a typed view of the scalar, whose operations apply to the scalar, and an *ErrWrongGroup if the
+// scalar is not in the group G.
+func AsTypedScalar[G GroupID](scalar *Scalar) (*TypedScalar[G], error) {
+	if err := checkGroup(groupOf[G](), scalar.Group()); err != nil {
+		return nil, err
+	}
+
+	return &TypedScalar[G]{s: scalar}, nil
+}
+
+// untyped returns the underlying element, or nil if e is nil, which the Element methods handle.
+func (e *TypedElement[G]) untyped() *Element {
+	if e == nil {
+		return nil
+	}
+
+	return e.e
+}
+
+// Group returns the group G.
+func (e *TypedElement[G]) Group() Group {
+	return groupOf[G]()
+}
+
+// Element returns the underlying element.
+func (e *TypedElement[G]) Element() *Element {
+	return e.e
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *TypedElement[G]) Add(element *TypedElement[G]) *TypedElement[G] {
+	e.e.Add(element.untyped())
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *TypedElement[G]) Subtract(element *TypedElement[G]) *TypedElement[G] {
+	e.e.Subtract(element.untyped())
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *TypedElement[G]) Double() *TypedElement[G] {
+	e.e.Double()
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *TypedElement[G]) Negate() *TypedElement[G] {
+	e.e.Negate()
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+func (e *TypedElement[G]) Multiply(scalar *TypedScalar[G]) *TypedElement[G] {
+	e.e.Multiply(scalar.untyped())
+	return e
+}
+
+// Equal returns true if the elements are equivalent, and false otherwise.
+func (e *TypedElement[G]) Equal(element *TypedElement[G]) bool {
+	return e.e.Equal(element.untyped())
+}
+
+// IsIdentity returns whether the element is the point at infinity of the group's underlying curve.
+func (e *TypedElement[G]) IsIdentity() bool {
+	return e.e.IsIdentity()
+}
+
+// Copy returns a copy of the receiver.
+func (e *TypedElement[G]) Copy() *TypedElement[G] {
+	return &TypedElement[G]{e: e.e.Copy()}
+}
+
+// Encode returns the compressed byte encoding of the element.
+func (e *TypedElement[G]) Encode() []byte {
+	return e.e.Encode()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (e *TypedElement[G]) Decode(data []byte) error {
+	return e.e.Decode(data)
+}
+
+// String returns the same as Element.String.
+func (e *TypedElement[G]) String() string {
+	return e.e.String()
+}
+
+// untyped returns the underlying scalar, or nil if s is nil, which the Scalar methods handle.
+func (s *TypedScalar[G]) untyped() *Scalar {
+	if s == nil {
+		return nil
+	}
+
+	return s.s
+}
+
+// Group returns the group G.
+func (s *TypedScalar[G]) Group() Group {
+	return groupOf[G]()
+}
+
+// Scalar returns the underlying scalar.
+func (s *TypedScalar[G]) Scalar() *Scalar {
+	return s.s
+}
+
+// Random sets the scalar to a new non-zero random scalar and returns it.
+func (s *TypedScalar[G]) Random() *TypedScalar[G] {
+	s.s.Random()
+	return s
+}
+
+// SetUInt64 sets the scalar to i, and returns it.
+func (s *TypedScalar[G]) SetUInt64(i uint64) *TypedScalar[G] {
+	s.s.SetUInt64(i)
+	return s
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (s *TypedScalar[G]) Add(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.s.Add(scalar.untyped())
+	return s
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (s *TypedScalar[G]) Subtract(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.s.Subtract(scalar.untyped())
+	return s
+}
+
+// Multiply multiplies the receiver with the input, and returns the receiver.
+func (s *TypedScalar[G]) Multiply(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.s.Multiply(scalar.untyped())
+	return s
+}
+
+// Invert sets the receiver to its modular inverse, and returns it.
+func (s *TypedScalar[G]) Invert() *TypedScalar[G] {
+	s.s.Invert()
+	return s
+}
+
+// Equal returns true if the scalars are equal, and false otherwise.
+func (s *TypedScalar[G]) Equal(scalar *TypedScalar[G]) bool {
+	return s.s.Equal(scalar.untyped())
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *TypedScalar[G]) IsZero() bool {
+	return s.s.IsZero()
+}
+
+// Copy returns a copy of the receiver.
+func (s *TypedScalar[G]) Copy() *TypedScalar[G] {
+	return &TypedScalar[G]{s: s.s.Copy()}
+}
+
+// Encode returns the byte encoding of the scalar.
+func (s *TypedScalar[G]) Encode() []byte {
+	return s.s.Encode()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (s *TypedScalar[G]) Decode(data []byte) error {
+	return s.s.Decode(data)
+}
+
+// String returns the same as Scalar.String, which doesn't reveal the scalar.
+func (s *TypedScalar[G]) String() string {
+	return s.s.String()
+}
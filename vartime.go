@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+const (
+	// nafWidth is the window width of the non-adjacent forms, whose digits are odd and in (-2^(w-1), 2^(w-1)).
+	nafWidth = 5
+
+	// nafTableSize is the number of odd multiples P, 3P, ..., (2^(w-1) - 1)P the digits index.
+	nafTableSize = 1 << (nafWidth - 2)
+
+	// maxMSMWindow bounds the bucket window of MultiScalarMultVartime, which allocates 2^window buckets.
+	maxMSMWindow = 16
+)
+
+var errMSMLength = errors.New("the numbers of scalars and elements differ")
+
+// nafTable holds the odd multiples of an element and their negations, since subtraction is slower than addition in
+// some groups.
+type nafTable struct {
+	pos, neg [nafTableSize]*Element
+}
+
+// DoubleScalarMultVartime returns a new element set to a * p + b * q. The width-5 non-adjacent forms of both scalars
+// are interleaved, so the doublings are shared and few additions are needed, which makes it faster than two
+// multiplications. Its running time depends on all of its inputs, so it must only be used with public values, e.g. in
+// signature verification: use Element.Multiply for secrets. Nil arguments count as zero and the identity, and it
+// panics with an *ErrWrongGroup if an argument is not in g.
+func (g Group) DoubleScalarMultVartime(a *Scalar, p *Element, b *Scalar, q *Element) *Element {
+	for _, s := range []*Scalar{a, b} {
+		if s != nil && s.Group() != g {
+			panic(&ErrWrongGroup{Expected: g, Got: s.Group()})
+		}
+	}
+
+	for _, e := range []*Element{p, q} {
+		if e != nil && e.Group() != g {
+			panic(&ErrWrongGroup{Expected: g, Got: e.Group()})
+		}
+	}
+
+	recordOperation(g, OpElementMultiply)
+
+	nafA, tableA := nafTerm(g, a, p)
+	nafB, tableB := nafTerm(g, b, q)
+	res := g.NewElement()
+
+	for i := max(len(nafA), len(nafB)) - 1; i >= 0; i-- {
+		res.Double()
+		addNAFDigit(res, nafA, tableA, i)
+		addNAFDigit(res, nafB, tableB, i)
+	}
+
+	return res
+}
+
+// nafTerm returns the non-adjacent form of the scalar and the table of the element, or nothing if either is nil. The
+// elements are copied with Set, and only one is negated, since Copy and Negate are slow in some groups.
+func nafTerm(g Group, s *Scalar, e *Element) ([]int8, *nafTable) {
+	if s == nil || e == nil {
+		return nil, nil
+	}
+
+	t := new(nafTable)
+	t.pos[0] = g.NewElement().Set(e)
+	t.neg[0] = g.NewElement().Set(e).Negate()
+	double := g.NewElement().Set(e).Double()
+	negDouble := g.NewElement().Set(t.neg[0]).Double()
+
+	for i := 1; i < nafTableSize; i++ {
+		t.pos[i] = g.NewElement().Set(t.pos[i-1]).Add(double)
+		t.neg[i] = g.NewElement().Set(t.neg[i-1]).Add(negDouble)
+	}
+
+	return wNAF(s), t
+}
+
+// addNAFDigit adds the multiple of the table's element given by the i-th digit of the NAF to res.
+func addNAFDigit(res *Element, naf []int8, t *nafTable, i int) {
+	if i >= len(naf) {
+		return
+	}
+
+	switch d := naf[i]; {
+	case d > 0:
+		res.Add(t.pos[d/2])
+	case d < 0:
+		res.Add(t.neg[-d/2])
+	}
+}
+
+// wNAF returns the width-nafWidth non-adjacent form of the scalar, least significant digit first.
+func wNAF(s *Scalar) []int8 {
+	const (
+		window = 1 << nafWidth
+		mask   = window - 1
+	)
+
+	k := new(big.Int).SetBytes(scalarBigEndian(s))
+	naf := make([]int8, 0, k.BitLen()+1)
+	digit := new(big.Int)
+
+	for k.Sign() > 0 {
+		var d int
+
+		if k.Bit(0) == 1 {
+			d = int(k.Bits()[0] & mask)
+			if d >= window/2 {
+				d -= window
+			}
+
+			k.Sub(k, digit.SetInt64(int64(d)))
+		}
+
+		naf = append(naf, int8(d))
+		k.Rsh(k, 1)
+	}
+
+	return naf
+}
+
+// MultiScalarMultVartime returns a new element set to the sum of scalars[i] * elements[i], with Pippenger's bucket
+// method. The window is picked from the number of terms, by minimizing the number of additions, so that both small and
+// very large inputs are close to optimal. Its running time depends on all of its inputs, so it must only be used with
+// public values, e.g. in batch verification or with commitments to public vectors. Nil entries count as zero and the
+// identity, and it panics if the slices have different lengths, or with an *ErrWrongGroup if an entry is not in g.
+func (g Group) MultiScalarMultVartime(scalars []*Scalar, elements []*Element) *Element {
+	res, err := g.MultiScalarMultVartimeContext(context.Background(), scalars, elements)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// MultiScalarMultVartimeContext is the same as MultiScalarMultVartime, but checks the context between windows, and
+// returns the context's error if it is done before completion, e.g. to bound the time spent on very large inputs. It
+// returns errors instead of panicking on inputs of different lengths or of another group.
+func (g Group) MultiScalarMultVartimeContext(
+	ctx context.Context,
+	scalars []*Scalar,
+	elements []*Element,
+) (*Element, error) {
+	if len(scalars) != len(elements) {
+		return nil, errMSMLength
+	}
+
+	ks := make([]*big.Int, 0, len(scalars))
+	es := make([]*Element, 0, len(elements))
+
+	for i, s := range scalars {
+		e := elements[i]
+
+		if s != nil && s.Group() != g {
+			return nil, &ErrWrongGroup{Expected: g, Got: s.Group()}
+		}
+
+		if e != nil && e.Group() != g {
+			return nil, &ErrWrongGroup{Expected: g, Got: e.Group()}
+		}
+
+		if s == nil || e == nil {
+			continue
+		}
+
+		ks = append(ks, new(big.Int).SetBytes(scalarBigEndian(s)))
+		es = append(es, e)
+	}
+
+	recordOperation(g, OpElementMultiply)
+
+	bits := 8 * g.ScalarLength()
+	window := msmWindow(len(es), bits)
+	buckets := make([]*Element, 1<<window)
+	res := g.NewElement()
+
+	for start := (bits - 1) / window * window; start >= 0; start -= window {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for range window {
+			res.Double()
+		}
+
+		clear(buckets)
+
+		for i, k := range ks {
+			if d := windowDigit(k, start, window); d != 0 {
+				if buckets[d] == nil {
+					buckets[d] = g.NewElement().Set(es[i])
+				} else {
+					buckets[d].Add(es[i])
+				}
+			}
+		}
+
+		// The sum of d * buckets[d] is the sum of the running sums of the buckets, from the highest down.
+		sum, total := g.NewElement(), g.NewElement()
+
+		for d := len(buckets) - 1; d > 0; d-- {
+			sum.Add(buckets[d])
+			total.Add(sum)
+		}
+
+		res.Add(total)
+	}
+
+	return res, nil
+}
+
+// msmWindow returns the bucket window for n terms of scalars of the given bit length, which minimizes the number of
+// additions: each of the windows adds the n terms to the buckets, and then computes two running sums over them.
+func msmWindow(n, bits int) int {
+	best, bestCost := 1, -1
+
+	for window := 1; window <= maxMSMWindow; window++ {
+		windows := (bits + window - 1) / window
+
+		cost := windows * (n + 2<<window)
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = window, cost
+		}
+	}
+
+	return best
+}
+
+// windowDigit returns the window bits of k starting at bit start.
+func windowDigit(k *big.Int, start, window int) int {
+	d := 0
+
+	for i := window - 1; i >= 0; i-- {
+		d = d<<1 | int(k.Bit(start+i))
+	}
+
+	return d
+}
@@ -0,0 +1,379 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package verifiableencryption implements verifiable encryption of discrete logarithms: a scalar x is encrypted under
+// an ElGamal public key, together with a zero-knowledge proof that the ciphertext encrypts the discrete log of the
+// public element X = x * G, e.g. for key escrow or fair exchange, where anyone can check that the holder of the
+// decryption key will be able to recover x.
+//
+// The scalar is encrypted bit by bit with exponential ElGamal, i.e. (r * G, b * G + r * P) for each bit b, so that
+// decryption only needs to tell G from the identity. Each bit ciphertext comes with an OR proof that it encrypts 0 or
+// 1, and the sum of the ciphertexts weighted by the powers of 2 with a proof of equality of discrete logs that it
+// encrypts X. All proofs share the challenge derived from a transcript of the whole statement.
+package verifiableencryption
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/transcript"
+)
+
+const protocol = "VerifiableEncryption-V01"
+
+var (
+	errNilInput         = errors.New("nil input")
+	errGroupMismatch    = errors.New("inputs belong to different groups")
+	errIdentityKey      = errors.New("the public key is the identity")
+	errCiphertextLength = errors.New("invalid ciphertext length")
+	errDecryption       = errors.New("the ciphertext does not decrypt to the discrete log of the element")
+)
+
+// Bit is the encryption of a bit of the scalar, with the proof that it encrypts 0 or 1.
+type Bit struct {
+	C1, C2     *ecc.Element
+	Challenge0 *ecc.Scalar
+	Response0  *ecc.Scalar
+	Response1  *ecc.Scalar
+}
+
+// Ciphertext is the encryption of a scalar, from the least significant bit, with the proof that it encrypts the
+// discrete log of an element.
+type Ciphertext struct {
+	Challenge *ecc.Scalar
+	Response  *ecc.Scalar
+	Bits      []*Bit
+}
+
+// bitLength returns the number of bits of the scalars of the group, i.e. of the group order.
+func bitLength(g ecc.Group) int {
+	return g.OrderBig().BitLen()
+}
+
+// scalarBits returns the bits of x, from the least significant, as scalars, by repeatedly halving it.
+func scalarBits(x *ecc.Scalar, n int) []*ecc.Scalar {
+	g := x.Group()
+	half := g.NewScalar().SetUInt64(2).Invert()
+	x = x.Copy()
+	bits := make([]*ecc.Scalar, n)
+
+	for i := range bits {
+		bits[i] = g.NewScalar()
+		if x.IsOdd() {
+			bits[i].One()
+		}
+
+		x.Subtract(bits[i]).Multiply(half)
+	}
+
+	return bits
+}
+
+// weightedSums returns the sums of the bit ciphertexts weighted by the powers of 2, i.e. the encryption of the scalar.
+func weightedSums(g ecc.Group, bits []*Bit) (c1, c2 *ecc.Element) {
+	c1, c2 = g.NewElement(), g.NewElement()
+
+	for i := len(bits) - 1; i >= 0; i-- {
+		c1.Double().Add(bits[i].C1)
+		c2.Double().Add(bits[i].C2)
+	}
+
+	return c1, c2
+}
+
+func newTranscript(publicKey, element *ecc.Element, context []byte) *transcript.Transcript {
+	t := transcript.New(publicKey.Group(), protocol)
+	t.AppendElement("public key", publicKey)
+	t.AppendElement("element", element)
+	t.AppendMessage("context", context)
+
+	return t
+}
+
+// appendBit appends the bit ciphertext and the commitments of its OR proof to the transcript.
+func appendBit(t *transcript.Transcript, c1, c2, a0, b0, a1, b1 *ecc.Element) {
+	t.AppendElement("c1", c1)
+	t.AppendElement("c2", c2)
+	t.AppendElement("a0", a0)
+	t.AppendElement("b0", b0)
+	t.AppendElement("a1", a1)
+	t.AppendElement("b1", b1)
+}
+
+func checkInputs(publicKey *ecc.Element, elements []*ecc.Element, scalars []*ecc.Scalar) error {
+	if publicKey == nil {
+		return errNilInput
+	}
+
+	g := publicKey.Group()
+
+	for _, e := range elements {
+		if e == nil {
+			return errNilInput
+		}
+
+		if e.Group() != g {
+			return errGroupMismatch
+		}
+	}
+
+	for _, s := range scalars {
+		if s == nil {
+			return errNilInput
+		}
+
+		if s.Group() != g {
+			return errGroupMismatch
+		}
+	}
+
+	if publicKey.IsIdentity() {
+		return errIdentityKey
+	}
+
+	return nil
+}
+
+// Encrypt returns the encryption of x under the public key, with the proof that it encrypts the discrete log of
+// x * G, bound to the context. It runs in constant time with regard to x.
+func Encrypt(publicKey *ecc.Element, x *ecc.Scalar, context []byte) (*Ciphertext, error) {
+	if err := checkInputs(publicKey, nil, []*ecc.Scalar{x}); err != nil {
+		return nil, err
+	}
+
+	g := publicKey.Group()
+	element := g.Base().Multiply(x)
+	t := newTranscript(publicKey, element, context)
+
+	n := bitLength(g)
+	bits := scalarBits(x, n)
+	negBase := g.Base().Negate()
+	randomness := make([]*ecc.Scalar, n)
+	ct := &Ciphertext{Bits: make([]*Bit, n)}
+	c1s := make([]*ecc.Scalar, n)
+
+	for i, b := range bits {
+		r := g.NewScalar().Random()
+		randomness[i] = r
+		bit := &Bit{
+			C1: g.Base().Multiply(r),
+			C2: publicKey.Copy().Multiply(r).Add(g.Base().Multiply(b)),
+		}
+
+		// The branch of the actual bit is the real proof, whose challenge is 0 for now, and the other is simulated,
+		// with a random challenge. Both are computed the same way, so as to not leak the bit.
+		notB := g.NewScalar().One().Subtract(b)
+		bit.Challenge0 = g.NewScalar().Random().Multiply(b)
+		c1s[i] = g.NewScalar().Random().Multiply(notB)
+		bit.Response0 = g.NewScalar().Random()
+		bit.Response1 = g.NewScalar().Random()
+
+		a0, b0 := commitBranch(g, publicKey, bit.C1, bit.C2, bit.Challenge0, bit.Response0)
+		a1, b1 := commitBranch(g, publicKey, bit.C1, bit.C2.Copy().Add(negBase), c1s[i], bit.Response1)
+		appendBit(t, bit.C1, bit.C2, a0, b0, a1, b1)
+
+		ct.Bits[i] = bit
+	}
+
+	// The weighted sum of the ciphertexts encrypts x with randomness R, so (C1, C2 - X) = R * (G, P).
+	sumR := g.NewScalar()
+	for i := n - 1; i >= 0; i-- {
+		sumR.Add(sumR).Add(randomness[i])
+	}
+
+	k := g.NewScalar().Random()
+	t.AppendElement("a", g.Base().Multiply(k))
+	t.AppendElement("b", publicKey.Copy().Multiply(k))
+
+	ct.Challenge = t.ChallengeScalar("challenge")
+	ct.Response = ct.Challenge.Copy().Multiply(sumR).Add(k)
+
+	// The real branch gets the rest of the challenge, and its response is completed with it.
+	for i, bit := range ct.Bits {
+		b := bits[i]
+		notB := g.NewScalar().One().Subtract(b)
+
+		// c0 = b * c0 + (1 - b) * (c - c1), and c1 = (1 - b) * c1 + b * (c - c0)
+		c0 := ct.Challenge.Copy().Subtract(c1s[i]).Multiply(notB).Add(bit.Challenge0)
+		c1 := ct.Challenge.Copy().Subtract(bit.Challenge0).Multiply(b).Add(c1s[i])
+
+		bit.Response0.Add(c0.Copy().Multiply(notB).Multiply(randomness[i]))
+		bit.Response1.Add(c1.Multiply(b).Multiply(randomness[i]))
+		bit.Challenge0 = c0
+	}
+
+	return ct, nil
+}
+
+// commitBranch returns the commitments of a branch of the OR proof that (c1, c2) = r * (G, P), i.e. s * G - c * c1
+// and s * P - c * c2.
+func commitBranch(g ecc.Group, publicKey, c1, c2 *ecc.Element, c, s *ecc.Scalar) (a, b *ecc.Element) {
+	negC := g.NewScalar().Subtract(c)
+	a = g.Base().Multiply(s).Add(c1.Copy().Multiply(negC))
+	b = publicKey.Copy().Multiply(s).Add(c2.Copy().Multiply(negC))
+
+	return a, b
+}
+
+// commitBranchVartime is the same as commitBranch for public values, in variable time.
+func commitBranchVartime(g ecc.Group, publicKey, c1, c2 *ecc.Element, c, s *ecc.Scalar) (a, b *ecc.Element) {
+	negC := g.NewScalar().Subtract(c)
+	a = g.DoubleScalarMultVartime(s, g.Base(), negC, c1)
+	b = g.DoubleScalarMultVartime(s, publicKey, negC, c2)
+
+	return a, b
+}
+
+// Verify returns whether the ciphertext is an encryption under the public key of the discrete log of the element,
+// bound to the context.
+func Verify(publicKey, element *ecc.Element, ct *Ciphertext, context []byte) bool {
+	if ct == nil || checkInputs(publicKey, []*ecc.Element{element}, []*ecc.Scalar{ct.Challenge, ct.Response}) != nil {
+		return false
+	}
+
+	g := publicKey.Group()
+	if len(ct.Bits) != bitLength(g) {
+		return false
+	}
+
+	t := newTranscript(publicKey, element, context)
+	negBase := g.Base().Negate()
+
+	for _, bit := range ct.Bits {
+		if bit == nil || checkInputs(publicKey, []*ecc.Element{bit.C1, bit.C2},
+			[]*ecc.Scalar{bit.Challenge0, bit.Response0, bit.Response1}) != nil {
+			return false
+		}
+
+		c1 := ct.Challenge.Copy().Subtract(bit.Challenge0)
+		a0, b0 := commitBranchVartime(g, publicKey, bit.C1, bit.C2, bit.Challenge0, bit.Response0)
+		a1, b1 := commitBranchVartime(g, publicKey, bit.C1, bit.C2.Copy().Add(negBase), c1, bit.Response1)
+		appendBit(t, bit.C1, bit.C2, a0, b0, a1, b1)
+	}
+
+	sumC1, sumC2 := weightedSums(g, ct.Bits)
+	a, b := commitBranchVartime(g, publicKey, sumC1, sumC2.Subtract(element), ct.Challenge, ct.Response)
+	t.AppendElement("a", a)
+	t.AppendElement("b", b)
+
+	return t.ChallengeScalar("challenge").Equal(ct.Challenge)
+}
+
+// Decrypt returns the scalar encrypted in the ciphertext with the secret key, and an error if it is not the discrete
+// log of the element. The ciphertext should be verified first, since decryption fails on bits that are not 0 or 1.
+func Decrypt(secretKey *ecc.Scalar, element *ecc.Element, ct *Ciphertext) (*ecc.Scalar, error) {
+	if secretKey == nil || element == nil || ct == nil {
+		return nil, errNilInput
+	}
+
+	g := element.Group()
+	if secretKey.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	x := g.NewScalar()
+
+	for i := len(ct.Bits) - 1; i >= 0; i-- {
+		bit := ct.Bits[i]
+		if bit == nil || bit.C1 == nil || bit.C2 == nil || bit.C1.Group() != g || bit.C2.Group() != g {
+			return nil, errDecryption
+		}
+
+		// b * G = C2 - sk * C1
+		m := bit.C2.Copy().Subtract(bit.C1.Copy().Multiply(secretKey))
+		x.Add(x)
+
+		switch {
+		case m.Equal(g.Base()):
+			x.Add(g.NewScalar().One())
+		case !m.IsIdentity():
+			return nil, errDecryption
+		}
+	}
+
+	if !g.Base().Multiply(x).Equal(element) {
+		return nil, errDecryption
+	}
+
+	return x, nil
+}
+
+// Encode returns the byte encoding of the ciphertext, i.e. the challenge and the response, followed by the two
+// elements and three scalars of each bit.
+func (ct *Ciphertext) Encode() []byte {
+	out := append(ct.Challenge.Encode(), ct.Response.Encode()...)
+
+	for _, bit := range ct.Bits {
+		out = append(out, bit.C1.Encode()...)
+		out = append(out, bit.C2.Encode()...)
+		out = append(out, bit.Challenge0.Encode()...)
+		out = append(out, bit.Response0.Encode()...)
+		out = append(out, bit.Response1.Encode()...)
+	}
+
+	return out
+}
+
+// Decode sets the ciphertext to the decoding of the input, for the given group, and returns an error on failure, in
+// which case the receiver is not modified.
+func (ct *Ciphertext) Decode(g ecc.Group, data []byte) error {
+	sLen, eLen := g.ScalarLength(), g.ElementLength()
+	n := bitLength(g)
+
+	if len(data) != 2*sLen+n*(2*eLen+3*sLen) {
+		return errCiphertextLength
+	}
+
+	scalar := func() (*ecc.Scalar, error) {
+		s := g.NewScalar()
+		err := s.Decode(data[:sLen])
+		data = data[sLen:]
+
+		return s, err
+	}
+
+	element := func() (*ecc.Element, error) {
+		e := g.NewElement()
+		err := e.Decode(data[:eLen])
+		data = data[eLen:]
+
+		return e, err
+	}
+
+	var err error
+
+	d := &Ciphertext{Bits: make([]*Bit, n)}
+	if d.Challenge, err = scalar(); err != nil {
+		return err
+	}
+
+	if d.Response, err = scalar(); err != nil {
+		return err
+	}
+
+	for i := range d.Bits {
+		bit := new(Bit)
+		d.Bits[i] = bit
+
+		for _, e := range []**ecc.Element{&bit.C1, &bit.C2} {
+			if *e, err = element(); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range []**ecc.Scalar{&bit.Challenge0, &bit.Response0, &bit.Response1} {
+			if *s, err = scalar(); err != nil {
+				return err
+			}
+		}
+	}
+
+	*ct = *d
+
+	return nil
+}
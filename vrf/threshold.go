@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package vrf
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/secretsharing"
+)
+
+var (
+	errNotEnoughPartials = errors.New("not enough partial evaluations")
+	errDuplicatePartial  = errors.New("duplicate partial evaluation")
+)
+
+// PartialEvaluation is a participant's evaluation of the VRF with its key share. Its proof is relative to the
+// participant's public share, and the input is hashed with the group public key, so that the combination of the
+// partial evaluations yields the output of the group key.
+type PartialEvaluation struct {
+	Proof *Proof
+	ID    uint64
+}
+
+// PartialEvaluate returns the partial evaluation of alpha with the key share, for the group public key.
+func PartialEvaluate(share *secretsharing.Share, groupKey *ecc.Element, alpha []byte) (*PartialEvaluation, error) {
+	if share == nil || share.Secret == nil || groupKey == nil {
+		return nil, errNilInput
+	}
+
+	g := share.Secret.Group()
+	if groupKey.Group() != g {
+		return nil, errGroupMismatch
+	}
+
+	if share.Secret.IsZero() {
+		return nil, errZeroKey
+	}
+
+	return &PartialEvaluation{
+		Proof: prove(share.Secret, share.Public(), hashToGroup(g, groupKey, alpha)),
+		ID:    share.ID,
+	}, nil
+}
+
+// VerifyPartial returns whether the partial evaluation of alpha is valid, given the commitment to the key sharing.
+func VerifyPartial(commitment secretsharing.Commitment, alpha []byte, partial *PartialEvaluation) bool {
+	if len(commitment) == 0 || partial == nil || partial.ID == 0 {
+		return false
+	}
+
+	pk := commitment.PublicKey()
+	publicShare := commitment.PublicShare(partial.ID)
+
+	return !publicShare.IsIdentity() && partial.Proof.verify(publicShare, hashToGroup(pk.Group(), pk, alpha))
+}
+
+// Combine verifies the partial evaluations of alpha against the commitment to the key sharing, and returns Gamma and
+// the VRF output of the group key. At least as many partial evaluations as the threshold are needed, i.e. the length
+// of the commitment.
+func Combine(commitment secretsharing.Commitment, alpha []byte, partials []*PartialEvaluation) (*ecc.Element, []byte,
+	error,
+) {
+	if len(partials) < len(commitment) || len(commitment) == 0 {
+		return nil, nil, errNotEnoughPartials
+	}
+
+	gammas := make(map[uint64]*ecc.Element, len(partials))
+
+	for _, p := range partials {
+		if !VerifyPartial(commitment, alpha, p) {
+			return nil, nil, errVerify
+		}
+
+		if _, ok := gammas[p.ID]; ok {
+			return nil, nil, errDuplicatePartial
+		}
+
+		gammas[p.ID] = p.Proof.Gamma
+	}
+
+	gamma, err := secretsharing.CombineElements(gammas)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gamma, output(gamma), nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package vrf implements a verifiable random function following the ECVRF construction of RFC 9381 over any group of
+// the ecc package, using the group's hash-to-curve, and its threshold variant in which shares of the key produce
+// verifiable partial evaluations that combine into the same output.
+//
+// The outputs are not byte-compatible with the RFC 9381 ciphersuites.
+package vrf
+
+import (
+	"errors"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	hashToGroupDST = "ECC-VRF-V01-HashToGroup"
+	challengeDST   = "ECC-VRF-V01-Challenge"
+	outputDST      = "ECC-VRF-V01-Output"
+)
+
+var (
+	errNilInput      = errors.New("nil input")
+	errGroupMismatch = errors.New("inputs belong to different groups")
+	errZeroKey       = errors.New("private key is zero")
+	errVerify        = errors.New("invalid proof")
+	errProofLength   = errors.New("invalid proof encoding length")
+)
+
+// Proof is a VRF proof: Gamma = sk * H(alpha), and a proof that it has the same discrete logarithm as the public key.
+type Proof struct {
+	Gamma *ecc.Element
+	C     *ecc.Scalar
+	S     *ecc.Scalar
+}
+
+func hashToGroup(g ecc.Group, pk *ecc.Element, alpha []byte) *ecc.Element {
+	return g.HashToGroup(append(pk.Encode(), alpha...), []byte(hashToGroupDST))
+}
+
+func challenge(pk, h, gamma, u, v *ecc.Element) *ecc.Scalar {
+	g := pk.Group()
+	input := make([]byte, 0, 5*g.ElementLength())
+	input = append(input, pk.Encode()...)
+	input = append(input, h.Encode()...)
+	input = append(input, gamma.Encode()...)
+	input = append(input, u.Encode()...)
+	input = append(input, v.Encode()...)
+
+	return g.HashToScalar(input, []byte(challengeDST))
+}
+
+// prove returns a proof for the key pair (sk, pk) over the element h = H(alpha).
+func prove(sk *ecc.Scalar, pk, h *ecc.Element) *Proof {
+	g := sk.Group()
+	k := g.NewScalar().Random()
+	gamma := h.Copy().Multiply(sk)
+	c := challenge(pk, h, gamma, g.Base().Multiply(k), h.Copy().Multiply(k))
+
+	return &Proof{
+		Gamma: gamma,
+		C:     c,
+		S:     c.Copy().Multiply(sk).Add(k),
+	}
+}
+
+// verify returns whether the proof is valid for the public key pk over h = H(alpha).
+func (p *Proof) verify(pk, h *ecc.Element) bool {
+	if p == nil || p.Gamma == nil || p.C == nil || p.S == nil {
+		return false
+	}
+
+	g := pk.Group()
+	if p.Gamma.Group() != g || p.C.Group() != g || p.S.Group() != g || p.Gamma.IsIdentity() {
+		return false
+	}
+
+	// U = s * G - c * pk, V = s * H - c * Gamma
+	u := g.Base().Multiply(p.S).Subtract(pk.Copy().Multiply(p.C))
+	v := h.Copy().Multiply(p.S).Subtract(p.Gamma.Copy().Multiply(p.C))
+
+	return challenge(pk, h, p.Gamma, u, v).Equal(p.C)
+}
+
+// output returns the VRF output beta for Gamma.
+func output(gamma *ecc.Element) []byte {
+	h := gamma.Group().HashFunc().New()
+	h.Write([]byte(outputDST))
+	h.Write(gamma.Encode())
+
+	return h.Sum(nil)
+}
+
+// Prove returns the VRF proof of alpha under the private key.
+func Prove(sk *ecc.Scalar, alpha []byte) (*Proof, error) {
+	if sk == nil {
+		return nil, errNilInput
+	}
+
+	if sk.IsZero() {
+		return nil, errZeroKey
+	}
+
+	g := sk.Group()
+	pk := g.Base().Multiply(sk)
+
+	return prove(sk, pk, hashToGroup(g, pk, alpha)), nil
+}
+
+// Verify verifies the proof of alpha under the public key, and returns the VRF output on success.
+func Verify(pk *ecc.Element, alpha []byte, proof *Proof) ([]byte, error) {
+	if pk == nil {
+		return nil, errNilInput
+	}
+
+	if pk.IsIdentity() || !proof.verify(pk, hashToGroup(pk.Group(), pk, alpha)) {
+		return nil, errVerify
+	}
+
+	return output(proof.Gamma), nil
+}
+
+// Output returns the VRF output of the proof, which must have been verified.
+func (p *Proof) Output() []byte {
+	return output(p.Gamma)
+}
+
+// Encode returns the byte encoding of the proof, i.e. the concatenation of Gamma, c, and s.
+func (p *Proof) Encode() []byte {
+	out := append(p.Gamma.Encode(), p.C.Encode()...)
+	return append(out, p.S.Encode()...)
+}
+
+// Decode sets the proof to the decoding of the input, for the given group, and returns an error on failure.
+func (p *Proof) Decode(g ecc.Group, data []byte) error {
+	eLen, sLen := g.ElementLength(), g.ScalarLength()
+	if len(data) != eLen+2*sLen {
+		return errProofLength
+	}
+
+	gamma := g.NewElement()
+	if err := gamma.Decode(data[:eLen]); err != nil {
+		return err
+	}
+
+	c := g.NewScalar()
+	if err := c.Decode(data[eLen : eLen+sLen]); err != nil {
+		return err
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(data[eLen+sLen:]); err != nil {
+		return err
+	}
+
+	p.Gamma, p.C, p.S = gamma, c, s
+
+	return nil
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package x3dh implements the Extended Triple Diffie-Hellman key agreement
+// (https://signal.org/docs/specifications/x3dh/) over any group of the ecc package, Ristretto255 being recommended.
+//
+// The responder publishes a prekey bundle with its identity key, a signed prekey, and optionally a one-time prekey.
+// The initiator combines them with its own identity key and an ephemeral key into a shared secret, and sends its
+// identity and ephemeral public keys to the responder, who computes the same secret.
+//
+// The DH outputs are the encodings of the shared elements, and the KDF is HKDF with the group's hash function, as in
+// the specification. Since there's no X25519 or X448 in this package, prekeys are signed with the schnorr package in
+// the identity key's group instead of XEdDSA, so this is not interoperable with the Signal implementations.
+package x3dh
+
+import (
+	"bytes"
+	"crypto/hkdf"
+	"errors"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+// SecretLength is the byte size of the shared secrets.
+const SecretLength = 32
+
+var (
+	errNilInput         = errors.New("nil input")
+	errGroupMismatch    = errors.New("inputs belong to different groups")
+	errIdentity         = errors.New("public key is the identity")
+	errInvalidSignature = errors.New("invalid signed prekey signature")
+)
+
+// Bundle is the prekey bundle published by the responder. OneTimePrekey is optional.
+type Bundle struct {
+	IdentityKey     *ecc.Element
+	SignedPrekey    *ecc.Element
+	PrekeySignature *schnorr.Signature
+	OneTimePrekey   *ecc.Element
+}
+
+// SignPrekey returns the signature of the signed prekey under the identity private key, for the bundle.
+func SignPrekey(identity *ecc.Scalar, prekey *ecc.Element) (*schnorr.Signature, error) {
+	if identity == nil || prekey == nil {
+		return nil, errNilInput
+	}
+
+	if prekey.Group() != identity.Group() {
+		return nil, errGroupMismatch
+	}
+
+	return schnorr.Sign(identity, prekey.Encode())
+}
+
+// checkKeys returns an error if a public key is nil, the identity, or not in the group.
+func checkKeys(g ecc.Group, keys ...*ecc.Element) error {
+	for _, k := range keys {
+		if k == nil {
+			return errNilInput
+		}
+
+		if k.Group() != g {
+			return errGroupMismatch
+		}
+
+		if k.IsIdentity() {
+			return errIdentity
+		}
+	}
+
+	return nil
+}
+
+// Initiate verifies the bundle's prekey signature, and returns the shared secret and the ephemeral public key to send
+// to the responder, along with the initiator's identity public key.
+func Initiate(identity *ecc.Scalar, bundle *Bundle, info string) ([]byte, *ecc.Element, error) {
+	if identity == nil || bundle == nil {
+		return nil, nil, errNilInput
+	}
+
+	g := identity.Group()
+	if err := checkKeys(g, bundle.IdentityKey, bundle.SignedPrekey); err != nil {
+		return nil, nil, err
+	}
+
+	if !schnorr.Verify(bundle.IdentityKey, bundle.SignedPrekey.Encode(), bundle.PrekeySignature) {
+		return nil, nil, errInvalidSignature
+	}
+
+	ek := g.NewScalar().Random()
+	defer ek.Wipe()
+
+	dh := [][]byte{
+		bundle.SignedPrekey.Copy().Multiply(identity).Encode(),
+		bundle.IdentityKey.Copy().Multiply(ek).Encode(),
+		bundle.SignedPrekey.Copy().Multiply(ek).Encode(),
+	}
+
+	if bundle.OneTimePrekey != nil {
+		if err := checkKeys(g, bundle.OneTimePrekey); err != nil {
+			return nil, nil, err
+		}
+
+		dh = append(dh, bundle.OneTimePrekey.Copy().Multiply(ek).Encode())
+	}
+
+	secret, err := kdf(g, dh, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secret, g.Base().Multiply(ek), nil
+}
+
+// Respond returns the shared secret from the responder's private keys, and the initiator's identity and ephemeral
+// public keys. oneTimePrekey must be nil if the initiator used a bundle without a one-time prekey, and the responder
+// must then delete it.
+func Respond(
+	identity, signedPrekey, oneTimePrekey *ecc.Scalar,
+	peerIdentity, ephemeral *ecc.Element,
+	info string,
+) ([]byte, error) {
+	if identity == nil || signedPrekey == nil {
+		return nil, errNilInput
+	}
+
+	g := identity.Group()
+	if signedPrekey.Group() != g || (oneTimePrekey != nil && oneTimePrekey.Group() != g) {
+		return nil, errGroupMismatch
+	}
+
+	if err := checkKeys(g, peerIdentity, ephemeral); err != nil {
+		return nil, err
+	}
+
+	dh := [][]byte{
+		peerIdentity.Copy().Multiply(signedPrekey).Encode(),
+		ephemeral.Copy().Multiply(identity).Encode(),
+		ephemeral.Copy().Multiply(signedPrekey).Encode(),
+	}
+
+	if oneTimePrekey != nil {
+		dh = append(dh, ephemeral.Copy().Multiply(oneTimePrekey).Encode())
+	}
+
+	return kdf(g, dh, info)
+}
+
+// AssociatedData returns the associated data both parties bind to the first message, i.e. the encodings of the
+// initiator's and the responder's identity keys.
+func AssociatedData(initiator, responder *ecc.Element) []byte {
+	return append(initiator.Encode(), responder.Encode()...)
+}
+
+// kdf returns HKDF(F || DH1 || DH2 || DH3 [|| DH4]), where F is an element length of 0xFF bytes, the salt is a hash
+// length of zeros, and the info identifies the application.
+func kdf(g ecc.Group, dh [][]byte, info string) ([]byte, error) {
+	h := g.HashFunc()
+
+	ikm := bytes.Repeat([]byte{0xff}, g.ElementLength())
+	for _, d := range dh {
+		ikm = append(ikm, d...)
+	}
+
+	return hkdf.Key(h.New, ikm, make([]byte, h.Size()), info, SecretLength)
+}